@@ -0,0 +1,194 @@
+package goptimization
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Prox Proximal operator of a convex function h: argmin(x) h(x) + rho/2*|x - v|²
+type Prox func(v *mat.VecDense, rho float64) *mat.VecDense
+
+// ADMM Alternating direction method of multipliers for the consensus problem
+// Minimize Σ(1<=i<=N) f_i(x_i) + g(z)
+// Constraints:
+// 1<=i<=N, x_i = z
+// Each function is given by its proximal operator, g is 0 when Regularizer is nil.
+// With a single term it solves min f(x) + g(x), for example the LASSO, see Lasso.
+// The iterations are, with u_i the scaled dual variables:
+// x_i = prox_f_i(z - u_i, rho)
+// z = prox_g(mean(x_i + u_i), N*rho)
+// u_i = u_i + x_i - z
+type ADMM struct {
+	Terms       []Prox
+	Regularizer Prox
+
+	// Rho Penalty of the augmented Lagrangian, 1 by default
+	Rho float64
+	// MaxIter Maximum number of iterations, 1000 by default
+	MaxIter int
+	// AbsoluteTolerance, RelativeTolerance Stop once the primal residual |x_i - z| and the dual residual
+	// rho*|z - z_previous| are below sqrt(n*N)*AbsoluteTolerance + RelativeTolerance times the size of the iterates,
+	// 1e-6 and 1e-4 by default
+	AbsoluteTolerance float64
+	RelativeTolerance float64
+
+	// Iterations, PrimalResidual, DualResidual State of the last Solve
+	Iterations     int
+	PrimalResidual float64
+	DualResidual   float64
+}
+
+// New Initialize the solver with the proximal operators of the terms and of the regularizer, which may be nil
+func (a *ADMM) New(terms []Prox, regularizer Prox) error {
+	if len(terms) == 0 {
+		return errors.New("ADMM needs at least one term")
+	}
+	for i, term := range terms {
+		if term == nil {
+			return errors.Errorf("term %d is nil", i)
+		}
+	}
+	a.Terms = append([]Prox(nil), terms...)
+	a.Regularizer = regularizer
+	a.Rho = 1
+	a.MaxIter = 1000
+	a.AbsoluteTolerance = 1e-6
+	a.RelativeTolerance = 1e-4
+	a.Iterations = 0
+	a.PrimalResidual = 0
+	a.DualResidual = 0
+	return nil
+}
+
+// Solve Iterate from z = 0 in dimension n until the residuals are below the tolerances.
+// It returns the consensus z, with ErrIterationLimit when MaxIter iterations were done first.
+func (a *ADMM) Solve(n int) (*mat.VecDense, error) {
+	if len(a.Terms) == 0 {
+		return nil, errors.New("ADMM is not initialized")
+	}
+	if n <= 0 {
+		return nil, newError(ErrDimensionMismatch, "n must be positive, got %d", n)
+	}
+	if a.Rho <= 0 {
+		return nil, errors.Errorf("rho must be positive, got %g", a.Rho)
+	}
+	N := len(a.Terms)
+	x := make([]*mat.VecDense, N)
+	u := make([]*mat.VecDense, N)
+	for i := range u {
+		u[i] = mat.NewVecDense(n, nil)
+	}
+	z := mat.NewVecDense(n, nil)
+	previous := mat.NewVecDense(n, nil)
+	v := mat.NewVecDense(n, nil)
+	diff := mat.NewVecDense(n, nil)
+	scale := math.Sqrt(float64(n * N))
+
+	for a.Iterations = 0; a.Iterations < a.MaxIter; {
+		a.Iterations++
+		for i, prox := range a.Terms {
+			v.SubVec(z, u[i])
+			x[i] = prox(v, a.Rho)
+			if x[i] == nil || x[i].Len() != n {
+				return nil, newError(ErrDimensionMismatch, "the proximal operator of term %d must return a vector of length %d", i, n)
+			}
+		}
+
+		previous.CopyVec(z)
+		z.Zero()
+		for i := range x {
+			z.AddVec(z, x[i])
+			z.AddVec(z, u[i])
+		}
+		z.ScaleVec(1/float64(N), z)
+		if a.Regularizer != nil {
+			proxZ := a.Regularizer(z, float64(N)*a.Rho)
+			if proxZ == nil || proxZ.Len() != n {
+				return nil, newError(ErrDimensionMismatch, "the proximal operator of the regularizer must return a vector of length %d", n)
+			}
+			z.CopyVec(proxZ)
+		}
+
+		primal, xNorm, uNorm := 0.0, 0.0, 0.0
+		for i := range x {
+			diff.SubVec(x[i], z)
+			u[i].AddVec(u[i], diff)
+			primal += mat.Dot(diff, diff)
+			xNorm += mat.Dot(x[i], x[i])
+			uNorm += mat.Dot(u[i], u[i])
+		}
+		diff.SubVec(z, previous)
+		a.PrimalResidual = math.Sqrt(primal)
+		a.DualResidual = a.Rho * math.Sqrt(float64(N)) * mat.Norm(diff, 2)
+
+		primalTolerance := scale*a.AbsoluteTolerance + a.RelativeTolerance*math.Max(math.Sqrt(xNorm), math.Sqrt(float64(N))*mat.Norm(z, 2))
+		dualTolerance := scale*a.AbsoluteTolerance + a.RelativeTolerance*a.Rho*math.Sqrt(uNorm)
+		if a.PrimalResidual <= primalTolerance && a.DualResidual <= dualTolerance {
+			return z, nil
+		}
+	}
+	return z, ErrIterationLimit
+}
+
+// SoftThreshold Proximal operator of lambda*|x|_1: sign(v_j)*max(|v_j| - lambda/rho, 0)
+func SoftThreshold(lambda float64) Prox {
+	return func(v *mat.VecDense, rho float64) *mat.VecDense {
+		x := mat.NewVecDense(v.Len(), nil)
+		for j := 0; j < v.Len(); j++ {
+			shrunk := math.Max(math.Abs(v.AtVec(j))-lambda/rho, 0)
+			x.SetVec(j, math.Copysign(shrunk, v.AtVec(j)))
+		}
+		return x
+	}
+}
+
+// LeastSquaresProx Proximal operator of 1/2*|Dx - y|², the solution of (DᵀD + rho*I)x = Dᵀy + rho*v.
+// The factorization is kept while rho does not change.
+func LeastSquaresProx(D *mat.Dense, y *mat.VecDense) Prox {
+	_, p := D.Dims()
+	var gram mat.SymDense
+	gram.SymOuterK(1, D.T())
+	dty := mat.NewVecDense(p, nil)
+	dty.MulVec(D.T(), y)
+
+	var chol mat.Cholesky
+	factorized := math.NaN()
+	return func(v *mat.VecDense, rho float64) *mat.VecDense {
+		if rho != factorized {
+			shifted := mat.NewSymDense(p, nil)
+			shifted.CopySym(&gram)
+			for j := 0; j < p; j++ {
+				shifted.SetSym(j, j, shifted.At(j, j)+rho)
+			}
+			chol.Factorize(shifted)
+			factorized = rho
+		}
+		rhs := mat.NewVecDense(p, nil)
+		rhs.AddScaledVec(dty, rho, v)
+		x := mat.NewVecDense(p, nil)
+		err := chol.SolveVecTo(x, rhs)
+		if err != nil {
+			return nil
+		}
+		return x
+	}
+}
+
+// Lasso Minimize 1/2*|Dx - y|² + lambda*|x|_1 with ADMM
+func Lasso(D *mat.Dense, y *mat.VecDense, lambda float64, maxIter int) (*mat.VecDense, error) {
+	rows, p := D.Dims()
+	if y.Len() != rows {
+		return nil, newError(ErrDimensionMismatch, "len(y) = %d must be the number of rows of D, %d", y.Len(), rows)
+	}
+	a := &ADMM{}
+	err := a.New([]Prox{LeastSquaresProx(D, y)}, SoftThreshold(lambda))
+	if err != nil {
+		return nil, err
+	}
+	if maxIter > 0 {
+		a.MaxIter = maxIter
+	}
+	return a.Solve(p)
+}
@@ -0,0 +1,60 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestLasso(t *testing.T) {
+	//With D = I the solution is the soft thresholding of y
+	D := mat.NewDense(3, 3, []float64{1, 0, 0, 0, 1, 0, 0, 0, 1})
+	y := mat.NewVecDense(3, []float64{3, -0.5, -2})
+	x, err := Lasso(D, y, 1, 0)
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{2, 0, -1}, x.RawVector().Data, 0.0001)
+
+	_, err = Lasso(D, mat.NewVecDense(2, nil), 1, 0)
+	assert.Error(t, err)
+}
+
+func TestADMMConsensus(t *testing.T) {
+	//The LASSO split by rows in two blocks has the solution of the whole problem
+	D := mat.NewDense(6, 2, []float64{1, 2, 3, 1, 0, 1, 2, 2, 1, 0, 1, 3})
+	y := mat.NewVecDense(6, []float64{5, 5, 1, 4, 1, 7})
+	whole, err := Lasso(D, y, 0.5, 5000)
+	require.NoError(t, err)
+
+	top := mat.DenseCopyOf(D.Slice(0, 3, 0, 2))
+	bottom := mat.DenseCopyOf(D.Slice(3, 6, 0, 2))
+	a := &ADMM{}
+	require.NoError(t, a.New([]Prox{
+		LeastSquaresProx(top, mat.NewVecDense(3, y.RawVector().Data[:3])),
+		LeastSquaresProx(bottom, mat.NewVecDense(3, y.RawVector().Data[3:])),
+	}, SoftThreshold(0.5)))
+	a.MaxIter = 5000
+	a.AbsoluteTolerance = 0.00000001
+	a.RelativeTolerance = 0.000001
+	z, err := a.Solve(2)
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, whole.RawVector().Data, z.RawVector().Data, 0.001)
+	assert.LessOrEqual(t, a.Iterations, a.MaxIter)
+
+	//Without a regularizer the consensus of Σ 1/2*(x - c_i)² is the mean of the c_i
+	distance := func(c float64) Prox {
+		return func(v *mat.VecDense, rho float64) *mat.VecDense {
+			return mat.NewVecDense(1, []float64{(c + rho*v.AtVec(0)) / (1 + rho)})
+		}
+	}
+	require.NoError(t, a.New([]Prox{distance(1), distance(2), distance(6)}, nil))
+	z, err = a.Solve(1)
+	require.NoError(t, err)
+	assert.InDelta(t, 3, z.AtVec(0), 0.001)
+
+	a.MaxIter = 1
+	_, err = a.Solve(1)
+	assert.Equal(t, ErrIterationLimit, err)
+	assert.Error(t, a.New(nil, nil))
+}
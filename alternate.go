@@ -0,0 +1,145 @@
+package goptimization
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// AlternateOptima Enumerate the optimal vertices of a linear problem in the standard form of Simplex,
+// b can be negative. From the optimal dictionary, a nonbasic variable with a zero reduced cost enters the basis
+// without changing the score, the bases reached this way are explored depth first.
+// An edge of the optimal face which is a ray, where no variable leaves, is skipped.
+// It returns at most maxSolutions distinct vertices as matrices (n+m,1) like Simplex, the optimal score first,
+// the exploration stops after maxIter bases. The options configure the dictionary, e.g. WithTolerance or WithLogger.
+func AlternateOptima(c, A, b *mat.Dense, maxSolutions, maxIter int, opts ...Option) ([]*mat.Dense, float64, error) {
+	cf, score, err := optimalDictionary(c, A, b, maxIter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	vertices := []*mat.Dense{}
+	seenVertices := map[string]bool{}
+	seenBases := map[string]bool{}
+	stack := []*CanonicalForm{cf}
+	for len(stack) > 0 && len(vertices) < maxSolutions && len(seenBases) < maxIter {
+		cf := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		basis := cf.basisKey()
+		if seenBases[basis] {
+			continue
+		}
+		seenBases[basis] = true
+
+		values, _ := cf.values()
+		vertex := vertexKey(values)
+		if !seenVertices[vertex] {
+			seenVertices[vertex] = true
+			vertices = append(vertices, mat.NewDense(len(values), 1, values))
+		}
+
+		y, err := cf.FindY()
+		if err != nil {
+			return nil, 0, err
+		}
+		reduced := cf.reducedCosts(y)
+		for j := 0; j < cf.n; j++ {
+			if math.Abs(reduced.At(0, j)) > cf.tolerance {
+				continue
+			}
+			next := cf.Clone()
+			d, err := next.SolveBd(j)
+			if err != nil {
+				return nil, 0, err
+			}
+			x, leaving, err := next.FindLeavingVariable(d)
+			if err != nil {
+				return nil, 0, err
+			}
+			if leaving == -1 {
+				continue
+			}
+			err = next.pivot(d, y, x, j, leaving, false)
+			if err != nil {
+				return nil, 0, err
+			}
+			stack = append(stack, next)
+		}
+	}
+	return vertices, score, nil
+}
+
+// SecondaryObjective Maximize c2 over the optimal solutions of the linear problem max c*x, Ax <= b, x >= 0.
+// b can be negative. Once the optimal score z* is known, the constraint c*x >= z* is added to the optimal dictionary,
+// which stays feasible, and the primal simplex maximizes c2 from there.
+// It returns a matrix (n+m,1) like Simplex, the score for c and the score for c2. The options are those of AlternateOptima.
+func SecondaryObjective(c, A, b, c2 *mat.Dense, maxIter int, opts ...Option) (*mat.Dense, float64, float64, error) {
+	_, n := c.Dims()
+	if rows, cols := c2.Dims(); rows != 1 || cols != n {
+		return nil, 0, 0, newError(ErrDimensionMismatch, "c2 dims != z dims")
+	}
+	cf, score, err := optimalDictionary(c, A, b, maxIter, opts)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	a := make([]float64, cf.n+cf.m)
+	costs := make([]float64, cf.n+cf.m+1)
+	for j := 0; j < n; j++ {
+		a[j] = -c.At(0, j)
+		costs[j] = c2.At(0, j)
+	}
+	err = cf.AddConstraint(a, -score+cf.tolerance*(1+math.Abs(score)))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	cf.setCosts(costs)
+	_, err = cf.Reoptimize(maxIter)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	values, secondary := cf.values()
+	values = values[:len(values)-1]
+	primary := 0.0
+	for j := 0; j < n; j++ {
+		primary += c.At(0, j) * values[j]
+	}
+	return mat.NewDense(len(values), 1, values), primary, secondary, nil
+}
+
+// optimalDictionary Solve the problem with the two-phase simplex and keep its optimal dictionary
+func optimalDictionary(c, A, b *mat.Dense, maxIter int, opts []Option) (*CanonicalForm, float64, error) {
+	cf := &CanonicalForm{}
+	err := cf.New(mat.DenseCopyOf(c), mat.DenseCopyOf(A), mat.DenseCopyOf(b))
+	if err != nil {
+		return nil, 0, err
+	}
+	cf.configure(newOptions(opts))
+	_, err = cf.twoPhase(maxIter)
+	if err != nil {
+		return nil, 0, err
+	}
+	_, score := cf.values()
+	return cf, score, nil
+}
+
+// basisKey Sorted variables of the basis
+func (cf *CanonicalForm) basisKey() string {
+	basis := append([]int(nil), cf.remap[cf.n:]...)
+	sort.Ints(basis)
+	return fmt.Sprint(basis)
+}
+
+// vertexKey Values rounded to feasibilityTolerance, two bases of a degenerate vertex give the same key.
+// Adding 0 turns -0 into 0.
+func vertexKey(values []float64) string {
+	parts := make([]string, len(values))
+	for j, v := range values {
+		parts[j] = fmt.Sprintf("%.6f", math.Round(v/feasibilityTolerance)*feasibilityTolerance+0)
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,55 @@
+package goptimization
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+// optimalEdge Maximize x + y, x + y <= 4, x <= 3, y <= 3, the optimal face is the edge from (3, 1) to (1, 3)
+func optimalEdge() (*mat.Dense, *mat.Dense, *mat.Dense) {
+	return mat.NewDense(1, 2, []float64{1, 1}),
+		mat.NewDense(3, 2, []float64{1, 1, 1, 0, 0, 1}),
+		mat.NewDense(3, 1, []float64{4, 3, 3})
+}
+
+func TestAlternateOptima(t *testing.T) {
+	c, A, b := optimalEdge()
+	vertices, score, err := AlternateOptima(c, A, b, 10, 100, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, 4.0, score, 0.000001)
+	require.Len(t, vertices, 2)
+	for _, v := range vertices {
+		assert.InDelta(t, 4.0, v.At(0, 0)+v.At(1, 0), 0.000001)
+	}
+	assert.InDelta(t, 4.0, vertices[0].At(0, 0)+vertices[1].At(0, 0), 0.000001)
+	assert.InDelta(t, 2.0, math.Abs(vertices[0].At(0, 0)-vertices[1].At(0, 0)), 0.000001)
+
+	vertices, _, err = AlternateOptima(c, A, b, 1, 100, silent)
+	require.NoError(t, err)
+	assert.Len(t, vertices, 1)
+
+	// Unique optimum (3, 1)
+	vertices, score, err = AlternateOptima(mat.NewDense(1, 2, []float64{2, 1}), A, b, 10, 100, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, 7.0, score, 0.000001)
+	assert.Len(t, vertices, 1)
+}
+
+func TestSecondaryObjective(t *testing.T) {
+	c, A, b := optimalEdge()
+	results, primary, secondary, err := SecondaryObjective(c, A, b, mat.NewDense(1, 2, []float64{0, 1}), 100, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, 4.0, primary, 0.000001)
+	assert.InDelta(t, 3.0, secondary, 0.000001)
+	r, _ := results.Dims()
+	assert.Equal(t, 5, r)
+	assert.InDelta(t, 1.0, results.At(0, 0), 0.000001)
+	assert.InDelta(t, 3.0, results.At(1, 0), 0.000001)
+
+	_, _, _, err = SecondaryObjective(c, A, b, mat.NewDense(1, 3, nil), 100, silent)
+	assert.Error(t, err)
+}
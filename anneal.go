@@ -0,0 +1,66 @@
+package goptimization
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/pkg/errors"
+)
+
+// State Candidate solution of a metaheuristic, of any type chosen by the caller
+type State interface{}
+
+// Neighbor Random state close to s, s must not be modified
+type Neighbor func(s State, rnd *rand.Rand) State
+
+// Energy Value to minimize
+type Energy func(s State) float64
+
+// Schedule Geometric cooling of the annealing from the temperature Initial to Final in Iterations steps.
+// The same Seed gives the same search.
+type Schedule struct {
+	Initial    float64
+	Final      float64
+	Iterations int
+	Seed       int64
+}
+
+// temperature Temperature of the step k, Initial * (Final/Initial)^(k/(Iterations-1))
+func (s Schedule) temperature(k int) float64 {
+	if s.Iterations == 1 {
+		return s.Initial
+	}
+	return s.Initial * math.Pow(s.Final/s.Initial, float64(k)/float64(s.Iterations-1))
+}
+
+// Anneal Minimize the energy with simulated annealing, starting from state.
+// At each step a neighbor replaces the current state if it has a lower energy, or with the probability
+// exp(-Δ/T) otherwise, T following the schedule. It returns the state with the lowest energy found and its energy.
+// The objective can be non-linear or combinatorial, only the neighbor and the energy depend on the problem.
+func Anneal(state State, neighbor Neighbor, energy Energy, schedule Schedule) (State, float64, error) {
+	if neighbor == nil || energy == nil {
+		return nil, 0, errors.New("neighbor and energy must be defined")
+	}
+	if schedule.Initial <= 0 || schedule.Final <= 0 || schedule.Final > schedule.Initial {
+		return nil, 0, errors.New("the temperatures must satisfy 0 < Final <= Initial")
+	}
+	if schedule.Iterations <= 0 {
+		return nil, 0, errors.New("Iterations must be positive")
+	}
+	rnd := rand.New(rand.NewSource(schedule.Seed))
+
+	current, currentEnergy := state, energy(state)
+	best, bestEnergy := current, currentEnergy
+	for k := 0; k < schedule.Iterations; k++ {
+		candidate := neighbor(current, rnd)
+		candidateEnergy := energy(candidate)
+		delta := candidateEnergy - currentEnergy
+		if delta <= 0 || rnd.Float64() < math.Exp(-delta/schedule.temperature(k)) {
+			current, currentEnergy = candidate, candidateEnergy
+		}
+		if currentEnergy < bestEnergy {
+			best, bestEnergy = current, currentEnergy
+		}
+	}
+	return best, bestEnergy, nil
+}
@@ -0,0 +1,58 @@
+package goptimization
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnneal(t *testing.T) {
+	//Shortest tour of 8 cities on a circle, the optimum visits them in order
+	cities := 8
+	distance := func(a, b int) float64 {
+		angle := 2 * math.Pi * float64(a-b) / float64(cities)
+		return math.Sqrt(2 - 2*math.Cos(angle))
+	}
+	length := func(s State) float64 {
+		tour := s.([]int)
+		total := 0.0
+		for k := range tour {
+			total += distance(tour[k], tour[(k+1)%len(tour)])
+		}
+		return total
+	}
+	//Reverse a random segment of the tour
+	reverse := func(s State, rnd *rand.Rand) State {
+		tour := append([]int(nil), s.([]int)...)
+		i, j := rnd.Intn(len(tour)), rnd.Intn(len(tour))
+		if i > j {
+			i, j = j, i
+		}
+		for ; i < j; i, j = i+1, j-1 {
+			tour[i], tour[j] = tour[j], tour[i]
+		}
+		return tour
+	}
+	start := []int{0, 4, 1, 5, 2, 6, 3, 7}
+	schedule := Schedule{Initial: 1, Final: 0.001, Iterations: 5000, Seed: 1}
+
+	best, energy, err := Anneal(start, reverse, length, schedule)
+	require.NoError(t, err)
+	assert.InDelta(t, float64(cities)*distance(0, 1), energy, 0.000001)
+	assert.InDelta(t, energy, length(best), 0.000001)
+	assert.Equal(t, []int{0, 4, 1, 5, 2, 6, 3, 7}, start)
+
+	again, _, err := Anneal(start, reverse, length, schedule)
+	require.NoError(t, err)
+	assert.Equal(t, best, again)
+
+	_, _, err = Anneal(start, reverse, length, Schedule{Initial: 1, Final: 2, Iterations: 10})
+	assert.Error(t, err)
+	_, _, err = Anneal(start, reverse, length, Schedule{Initial: 1, Final: 0.1})
+	assert.Error(t, err)
+	_, _, err = Anneal(start, nil, length, schedule)
+	assert.Error(t, err)
+}
@@ -0,0 +1,123 @@
+package goptimization
+
+import (
+	"sync"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// AsyncStatus State of a search started by SolveAsync
+type AsyncStatus struct {
+	MIPProgress
+	// Done The search has returned, Wait does not block
+	Done bool
+	// Stopped Reason why the search stopped before the tree was empty, see BranchAndBound.Stopped
+	Stopped string
+}
+
+// AsyncSolve Handle of a search started by SolveAsync, its methods are safe for concurrent use
+type AsyncSolve struct {
+	stop chan struct{}
+	once sync.Once
+	done chan struct{}
+
+	mu        sync.Mutex
+	progress  MIPProgress
+	found     int
+	incumbent []float64
+	finished  bool
+	stopped   string
+
+	results *mat.Dense
+	score   float64
+	err     error
+}
+
+// SolveAsync Start MIP in a goroutine and return immediately, the arguments and the options are those of MIP.
+// The handle reports the progress of the search, its best integer solution so far, and stops it.
+// The search stops on Stop or when the context of WithContext is done.
+func SolveAsync(c, A, b *mat.Dense, integer []bool, maxNodes int, opts ...Option) (*AsyncSolve, error) {
+	bb, err := newMIP(c, A, b, integer, opts)
+	if err != nil {
+		return nil, err
+	}
+	return startAsync(bb, maxNodes), nil
+}
+
+// startAsync Solve the search in a goroutine
+func startAsync(bb *BranchAndBound, maxNodes int) *AsyncSolve {
+	s := &AsyncSolve{
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+		progress: bb.Status(),
+	}
+	// The interrupt of the options, e.g. the context, stops the search like Stop
+	if interrupt := bb.Interrupt; interrupt != nil {
+		go func() {
+			select {
+			case <-interrupt:
+				s.Stop()
+			case <-s.done:
+			}
+		}()
+	}
+	bb.Interrupt = s.stop
+	// Progress is called by the goroutine of the search, the incumbent is copied when it improves
+	bb.Progress = func(p MIPProgress) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.progress = p
+		if len(bb.Incumbents) != s.found {
+			s.found = len(bb.Incumbents)
+			s.incumbent = append([]float64(nil), bb.incumbent[:bb.n+bb.m]...)
+		}
+	}
+	go func() {
+		defer close(s.done)
+		results, score, err := bb.Solve(maxNodes)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.progress = bb.Status()
+		s.finished, s.stopped = true, bb.Stopped
+		s.results, s.score, s.err = results, score, err
+		if results != nil {
+			s.incumbent = results.RawMatrix().Data
+		}
+	}()
+	return s
+}
+
+// Status Current progress of the search
+func (s *AsyncSolve) Status() AsyncStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return AsyncStatus{MIPProgress: s.progress, Done: s.finished, Stopped: s.stopped}
+}
+
+// BestSolution Best integer solution found so far, a matrix (n+m,1) like Simplex, and its score.
+// It returns false before the first integer solution.
+func (s *AsyncSolve) BestSolution() (*mat.Dense, float64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.incumbent == nil {
+		return nil, 0, false
+	}
+	return mat.NewDense(len(s.incumbent), 1, append([]float64(nil), s.incumbent...)), s.progress.Incumbent, true
+}
+
+// Stop Interrupt the search after the node being explored, the search keeps its incumbent.
+// It does not wait for the search to return, see Wait.
+func (s *AsyncSolve) Stop() {
+	s.once.Do(func() {
+		close(s.stop)
+	})
+}
+
+// Wait Block until the search returns, then return the results of MIP:
+// the best integer solution and its score, with ErrIterationLimit when the search was stopped before any
+func (s *AsyncSolve) Wait() (*mat.Dense, float64, error) {
+	<-s.done
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.results, s.score, s.err
+}
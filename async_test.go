@@ -0,0 +1,107 @@
+package goptimization
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSolveAsync(t *testing.T) {
+	c, A, b, integer := knapsack(12, 7)
+	_, expected, score, err := MIP(c, A, b, integer, 10000, silent)
+	require.NoError(t, err)
+
+	s, err := SolveAsync(c, A, b, integer, 10000, silent)
+	require.NoError(t, err)
+	results, asyncScore, err := s.Wait()
+	require.NoError(t, err)
+	assert.InDelta(t, score, asyncScore, 0.000001)
+	assert.True(t, mat.EqualApprox(expected, results, 0.000001))
+
+	status := s.Status()
+	assert.True(t, status.Done)
+	assert.Equal(t, "", status.Stopped)
+	assert.InDelta(t, score, status.Incumbent, 0.000001)
+	best, bestScore, ok := s.BestSolution()
+	require.True(t, ok)
+	assert.InDelta(t, score, bestScore, 0.000001)
+	assert.True(t, mat.EqualApprox(expected, best, 0.000001))
+	// Stop after the end does nothing
+	s.Stop()
+	s.Stop()
+
+	_, err = SolveAsync(c, A, b, integer[1:], 10000)
+	assert.Error(t, err)
+}
+
+// slowSearch Maximize Σx_j with 2*Σx_j <= 21: without cuts the search must enumerate the subsets to prove the optimum 10
+func slowSearch(t *testing.T, opts ...Option) *BranchAndBound {
+	n := 21
+	c := mat.NewDense(1, n, nil)
+	A := mat.NewDense(1+n, n, nil)
+	b := mat.NewDense(1+n, 1, nil)
+	integer := make([]bool, n)
+	for j := 0; j < n; j++ {
+		c.Set(0, j, 1)
+		A.Set(0, j, 2)
+		A.Set(1+j, j, 1)
+		b.Set(1+j, 0, 1)
+		integer[j] = true
+	}
+	b.Set(0, 0, float64(n))
+	bb, err := newMIP(c, A, b, integer, append([]Option{silent}, opts...))
+	require.NoError(t, err)
+	bb.CutRounds = 0
+	bb.Heuristics = nil
+	bb.Probing = false
+	return bb
+}
+
+// firstIncumbent Wait for the first integer solution of the search
+func firstIncumbent(t *testing.T, s *AsyncSolve) {
+	_, _, ok := s.BestSolution()
+	for start := time.Now(); !ok && time.Since(start) < 10*time.Second; _, _, ok = s.BestSolution() {
+		time.Sleep(time.Millisecond)
+	}
+	require.True(t, ok)
+}
+
+func TestSolveAsyncStop(t *testing.T) {
+	s := startAsync(slowSearch(t), 10000000)
+	firstIncumbent(t, s)
+	status := s.Status()
+	assert.False(t, status.Done)
+	assert.InDelta(t, 10.0, status.Incumbent, 0.000001)
+	assert.InDelta(t, 10.5, status.BestBound, 0.000001)
+
+	s.Stop()
+	results, score, err := s.Wait()
+	require.NoError(t, err)
+	assert.InDelta(t, 10.0, score, 0.000001)
+	best, _, ok := s.BestSolution()
+	require.True(t, ok)
+	assert.True(t, mat.Equal(results, best))
+	status = s.Status()
+	assert.True(t, status.Done)
+	assert.Equal(t, "interrupted", status.Stopped)
+	assert.Greater(t, status.AbsoluteGap, 0.0)
+}
+
+func TestSolveAsyncContext(t *testing.T) {
+	//The context of the options stops the search like Stop
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := startAsync(slowSearch(t, WithContext(ctx)), 10000000)
+	firstIncumbent(t, s)
+	cancel()
+	_, score, err := s.Wait()
+	require.NoError(t, err)
+	assert.InDelta(t, 10.0, score, 0.000001)
+	status := s.Status()
+	assert.True(t, status.Done)
+	assert.Equal(t, "interrupted", status.Stopped)
+}
@@ -0,0 +1,77 @@
+package goptimization
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// autoInteriorSize Number of variables and constraints from which a sparse problem goes to the interior point method
+	autoInteriorSize = 1000
+	// autoDensity Fraction of nonzero coefficients of A under which a problem is sparse
+	autoDensity = 0.1
+)
+
+// ChooseSolver Method of AutoSolve for p, with the reason of the choice:
+// - MIP when a variable is integer
+// - DualSimplex when the slack basis is dual feasible, c <= 0, but not feasible, some b_i < 0
+// - InteriorPoint when the problem is large and sparse, its iterations do not grow with the size like the pivots
+// - Simplex otherwise
+// A problem whose dimensions do not match goes to Simplex, which reports ErrDimensionMismatch.
+func ChooseSolver(p *Problem) (Solver, string) {
+	if p == nil {
+		return SimplexSolver{}, "simplex: the problem is incomplete"
+	}
+	err := checkDims(p.C, p.A, p.B)
+	if err != nil {
+		return SimplexSolver{}, "simplex: " + err.Error()
+	}
+	integers := 0
+	for _, integer := range p.Integer {
+		if integer {
+			integers++
+		}
+	}
+	if integers > 0 {
+		return MIPSolver{}, fmt.Sprintf("mip: %d integer variables", integers)
+	}
+	m, n := p.A.Dims()
+	positive := 0
+	for j := 0; j < n; j++ {
+		if p.C.At(0, j) > epsilon {
+			positive++
+		}
+	}
+	negative := 0
+	for i := 0; i < m; i++ {
+		if p.B.At(i, 0) < -epsilon {
+			negative++
+		}
+	}
+	if positive == 0 && negative > 0 {
+		return DualSimplexSolver{}, fmt.Sprintf("dual simplex: the slack basis is dual feasible with %d negative right-hand sides", negative)
+	}
+	nonzeros := 0
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			if p.A.At(i, j) != 0 {
+				nonzeros++
+			}
+		}
+	}
+	density := 0.0
+	if m*n > 0 {
+		density = float64(nonzeros) / float64(m*n)
+	}
+	if n+m >= autoInteriorSize && density <= autoDensity {
+		return InteriorPointSolver{}, fmt.Sprintf("interior point: %d variables and %d constraints with a density of %.3g", n, m, density)
+	}
+	return SimplexSolver{}, fmt.Sprintf("simplex: %d variables and %d constraints with a density of %.3g", n, m, density)
+}
+
+// AutoSolve Solve p with the method chosen by ChooseSolver, the reason of the choice is written to the logger of o
+func AutoSolve(ctx context.Context, p *Problem, o *Options) (*Result, error) {
+	solver, reason := ChooseSolver(p)
+	newOptions(o.options(ctx)).logger.Printf("auto: %s\n", reason)
+	return solver.Solve(ctx, p, o)
+}
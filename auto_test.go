@@ -0,0 +1,60 @@
+package goptimization
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestChooseSolver(t *testing.T) {
+	c, A, b, integer := knapsack(12, 7)
+	solver, reason := ChooseSolver(&Problem{C: c, A: A, B: b, Integer: integer})
+	assert.IsType(t, MIPSolver{}, solver)
+	assert.Equal(t, "mip: 12 integer variables", reason)
+
+	solver, _ = ChooseSolver(&Problem{C: c, A: A, B: b})
+	assert.IsType(t, SimplexSolver{}, solver)
+
+	solver, _ = ChooseSolver(&Problem{C: mat.NewDense(1, 2, []float64{-2, -3}), A: mat.NewDense(2, 2, []float64{-1, -1, -1, 0}), B: mat.NewDense(2, 1, []float64{-4, -1})})
+	assert.IsType(t, DualSimplexSolver{}, solver)
+
+	// A diagonal of 600 variables and 600 constraints
+	n := 600
+	diagonal := mat.NewDense(n, n, nil)
+	for j := 0; j < n; j++ {
+		diagonal.Set(j, j, 1)
+	}
+	solver, reason = ChooseSolver(&Problem{C: mat.NewDense(1, n, nil), A: diagonal, B: mat.NewDense(n, 1, nil)})
+	assert.IsType(t, InteriorPointSolver{}, solver)
+	assert.Contains(t, reason, "density of 0.00167")
+
+	solver, _ = ChooseSolver(nil)
+	assert.IsType(t, SimplexSolver{}, solver)
+
+	// The mis-shaped problems are not inspected, Simplex reports them
+	solver, reason = ChooseSolver(&Problem{C: c, A: A, B: mat.NewDense(2, 1, nil), Integer: integer})
+	assert.IsType(t, SimplexSolver{}, solver)
+	assert.Contains(t, reason, "b dims must be")
+	_, err := solver.Solve(context.Background(), &Problem{C: c, A: A, B: mat.NewDense(2, 1, nil)}, nil)
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+	solver, _ = ChooseSolver(&Problem{C: c, A: A})
+	assert.IsType(t, SimplexSolver{}, solver)
+}
+
+func TestAutoSolve(t *testing.T) {
+	buf := &bytes.Buffer{}
+	c, A, b, integer := knapsack(12, 7)
+	o := &Options{Logger: log.New(buf, "", 0)}
+	r, err := AutoSolve(context.Background(), &Problem{C: c, A: A, B: b, Integer: integer}, o)
+	require.NoError(t, err)
+	_, _, expected, err := MIP(c, A, b, integer, 10000, WithLogger(o.Logger))
+	require.NoError(t, err)
+	assert.InDelta(t, expected, r.Score, 0.000001)
+	assert.Contains(t, buf.String(), "auto: mip: 12 integer variables\n")
+}
@@ -0,0 +1,213 @@
+// Package backend Adapters running external solvers behind the goptimization.Solver interface,
+// so that the problems built with the modeling and I/O layers of goptimization are solved by GLPK, HiGHS or lp_solve.
+// The problem is written to a temporary file in the free MPS format, the binary of the solver solves it
+// and its solution is read back: nothing is linked, the binaries only need to be installed.
+package backend
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Command Solver running an external binary, see GLPK, HiGHS and LPSolve.
+// Options.TimeLimit and Options.MIPGap are passed to the binary, the other options are ignored.
+type Command struct {
+	// Name Name of the solver in the errors
+	Name string
+	// Path Binary of the solver, looked up in the PATH when it has no separator
+	Path string
+	// args Arguments solving the model file and writing the solution file
+	args func(model, solution string, o *goptimization.Options) []string
+	// parse Values of the columns by name from the solution file and the output of the binary,
+	// or the error of kind ErrInfeasible, ErrUnbounded or ErrIterationLimit
+	parse func(solution, output []byte, n int) (map[string]float64, error)
+}
+
+// Available Check if the binary of the solver is installed
+func (s *Command) Available() bool {
+	_, err := exec.LookPath(s.Path)
+	return err == nil
+}
+
+// Solve Solve p with the external solver, the problem is written by WriteMPS.
+// The result has no iteration count, its slacks and its score are computed from the values of the columns.
+// An interrupted solve returns the error of the context.
+func (s *Command) Solve(ctx context.Context, p *goptimization.Problem, o *goptimization.Options) (*goptimization.Result, error) {
+	if p == nil {
+		return nil, errors.Wrap(goptimization.ErrDimensionMismatch, "the problem must not be nil")
+	}
+	dir, err := ioutil.TempDir("", "backend")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	model := filepath.Join(dir, "model.mps")
+	solution := filepath.Join(dir, "solution.txt")
+	f, err := os.Create(model)
+	if err != nil {
+		return nil, err
+	}
+	err = WriteMPS(f, p)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	err = f.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	output, runErr := exec.CommandContext(ctx, s.Path, s.args(model, solution, o)...).CombinedOutput()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	// A missing solution file is reported by parse
+	data, _ := ioutil.ReadFile(solution)
+	_, n := p.C.Dims()
+	values, err := s.parse(data, output, n)
+	kind := errors.Cause(err)
+	switch {
+	case err == nil:
+		return result(p, values, nil), nil
+	case kind == goptimization.ErrIterationLimit && values != nil:
+		err = errors.Wrap(err, s.Name)
+		return result(p, values, err), err
+	case runErr != nil && kind != goptimization.ErrInfeasible && kind != goptimization.ErrUnbounded && kind != goptimization.ErrIterationLimit:
+		return nil, errors.Wrapf(runErr, "%s: %s", s.Name, output)
+	}
+	return nil, errors.Wrap(err, s.Name)
+}
+
+// result Solution of the values of the columns x0, x1, ..., a missing column is 0, with the status of err
+func result(p *goptimization.Problem, values map[string]float64, err error) *goptimization.Result {
+	m, n := p.A.Dims()
+	x := make([]float64, n)
+	score := 0.0
+	for j := range x {
+		x[j] = values[column(j)]
+		score += p.C.At(0, j) * x[j]
+	}
+	var ax mat.VecDense
+	ax.MulVec(p.A, mat.NewVecDense(n, x))
+	slacks := make([]float64, m)
+	for i := range slacks {
+		slacks[i] = p.B.At(i, 0) - ax.AtVec(i)
+	}
+	return &goptimization.Result{Solution: goptimization.Solution{X: x, Slacks: slacks, Score: score}, Status: goptimization.StatusOf(err)}
+}
+
+// column Name of the column j in the MPS file
+func column(j int) string {
+	return "x" + strconv.Itoa(j)
+}
+
+// row Name of the row i in the MPS file
+func row(i int) string {
+	return "c" + strconv.Itoa(i)
+}
+
+// WriteMPS Write p in the free MPS format: the minimization of -c, the rows c0, c1, ... Σ a_i_j*x_j <= b_i
+// and the columns x0, x1, ..., the integer columns between markers with the explicit bounds [0, +inf).
+// goptimization.ReadMPS reads it back as the maximization of c.
+func WriteMPS(w io.Writer, p *goptimization.Problem) error {
+	if p == nil || p.C == nil || p.A == nil || p.B == nil {
+		return errors.Wrap(goptimization.ErrDimensionMismatch, "c, A and b must not be nil")
+	}
+	rows, n := p.C.Dims()
+	m, cols := p.A.Dims()
+	if bRows, bCols := p.B.Dims(); rows != 1 || cols != n || bRows != m || bCols != 1 {
+		return errors.Wrapf(goptimization.ErrDimensionMismatch, "c (1,%d), A (%d,%d) and b (%d,1) expected", n, m, n, m)
+	}
+	if p.Integer != nil && len(p.Integer) != n {
+		return errors.Wrapf(goptimization.ErrDimensionMismatch, "len(integer) must be %d, got %d", n, len(p.Integer))
+	}
+	integer := func(j int) bool {
+		return p.Integer != nil && p.Integer[j]
+	}
+	writer := bufio.NewWriter(w)
+	fmt.Fprintln(writer, "NAME PROBLEM")
+	fmt.Fprintln(writer, "ROWS")
+	fmt.Fprintln(writer, " N obj")
+	for i := 0; i < m; i++ {
+		fmt.Fprintf(writer, " L %s\n", row(i))
+	}
+	fmt.Fprintln(writer, "COLUMNS")
+	marked := false
+	for j := 0; j < n; j++ {
+		if integer(j) != marked {
+			marked = integer(j)
+			marker := "'INTEND'"
+			if marked {
+				marker = "'INTORG'"
+			}
+			fmt.Fprintf(writer, " MARKER 'MARKER' %s\n", marker)
+		}
+		// The objective entry keeps the columns without coefficients
+		fmt.Fprintf(writer, " %s obj %s\n", column(j), format(-p.C.At(0, j)))
+		for i := 0; i < m; i++ {
+			if a := p.A.At(i, j); a != 0 {
+				fmt.Fprintf(writer, " %s %s %s\n", column(j), row(i), format(a))
+			}
+		}
+	}
+	if marked {
+		fmt.Fprintln(writer, " MARKER 'MARKER' 'INTEND'")
+	}
+	fmt.Fprintln(writer, "RHS")
+	for i := 0; i < m; i++ {
+		if b := p.B.At(i, 0); b != 0 {
+			fmt.Fprintf(writer, " rhs %s %s\n", row(i), format(b))
+		}
+	}
+	// Some solvers read the integer columns without bounds as binaries
+	fmt.Fprintln(writer, "BOUNDS")
+	for j := 0; j < n; j++ {
+		if integer(j) {
+			fmt.Fprintf(writer, " PL bnd %s\n", column(j))
+		}
+	}
+	fmt.Fprintln(writer, "ENDATA")
+	return writer.Flush()
+}
+
+// format Shortest representation of v which reads back exactly
+func format(v float64) string {
+	if v == 0 {
+		v = 0
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// seconds Time limit in whole seconds, at least 1
+func seconds(o *goptimization.Options) int {
+	return int(math.Max(1, math.Ceil(o.TimeLimit.Seconds())))
+}
+
+// lines Fields of the non-empty lines of data
+func lines(data []byte) [][]string {
+	fields := [][]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if f := bytes.Fields(scanner.Bytes()); len(f) > 0 {
+			line := make([]string, len(f))
+			for k := range f {
+				line[k] = string(f[k])
+			}
+			fields = append(fields, line)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,180 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+var _ goptimization.Solver = (*Command)(nil)
+
+// testProblem Maximize 5x + 4y with 6x + 4y <= 24 and x + 2y <= 6, the relaxation has the optimum x = 3, y = 1.5
+// which is also the one of the problem with x integer
+func testProblem() *goptimization.Problem {
+	return &goptimization.Problem{
+		C: mat.NewDense(1, 2, []float64{5, 4}),
+		A: mat.NewDense(2, 2, []float64{
+			6, 4,
+			1, 2,
+		}),
+		B:       mat.NewDense(2, 1, []float64{24, 6}),
+		Integer: []bool{true, false},
+	}
+}
+
+func TestWriteMPS(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteMPS(buf, testProblem()))
+	assert.Contains(t, buf.String(), " MARKER 'MARKER' 'INTORG'\n x0 obj -5\n x0 c0 6\n x0 c1 1\n MARKER 'MARKER' 'INTEND'\n")
+	assert.Contains(t, buf.String(), " PL bnd x0\n")
+
+	m, err := goptimization.ReadMPS(buf)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x0", "x1"}, m.Names())
+	solution, err := m.Solve(100, goptimization.WithLogger(log.New(ioutil.Discard, "", 0)))
+	require.NoError(t, err)
+	assert.InDelta(t, 21, solution.Score, 0.000001)
+	assert.InDelta(t, 3, solution.Value(0), 0.000001)
+
+	assert.Error(t, WriteMPS(buf, &goptimization.Problem{}))
+	p := testProblem()
+	p.Integer = p.Integer[1:]
+	assert.Error(t, WriteMPS(buf, p))
+}
+
+func TestParseGLPK(t *testing.T) {
+	values, err := parseGLPK([]byte("c Problem: PROBLEM\ns bas 2 2 f f -21\ni 1 b 24 0\nj 1 b 3 0\nj 2 b 1.5 0\ne o f\n"), nil, 2)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"x0": 3, "x1": 1.5}, values)
+	values, err = parseGLPK([]byte("s mip 2 2 f -20\nj 1 4\nj 2 0\n"), nil, 2)
+	assert.Equal(t, goptimization.ErrIterationLimit, errors.Cause(err))
+	assert.Equal(t, map[string]float64{"x0": 4, "x1": 0}, values)
+
+	_, err = parseGLPK([]byte("s bas 2 2 n f 0\n"), nil, 2)
+	assert.Equal(t, goptimization.ErrInfeasible, errors.Cause(err))
+	_, err = parseGLPK([]byte("s bas 2 2 f n 0\n"), nil, 2)
+	assert.Equal(t, goptimization.ErrUnbounded, errors.Cause(err))
+	_, err = parseGLPK([]byte("s mip 2 2 n 0\n"), nil, 2)
+	assert.Equal(t, goptimization.ErrInfeasible, errors.Cause(err))
+	_, err = parseGLPK([]byte("s bas 2 2 f f 0\nj 3 b 1 0\n"), nil, 2)
+	assert.Error(t, err)
+	_, err = parseGLPK(nil, []byte("glpsol: unable to read"), 2)
+	assert.Error(t, err)
+}
+
+func TestParseHiGHS(t *testing.T) {
+	values, err := parseHiGHS([]byte(`Model status
+Optimal
+
+# Primal solution values
+Feasible
+Objective -21
+# Columns 2
+x0 3
+x1 1.5
+# Rows 2
+c0 24
+c1 6
+`), nil, 2)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"x0": 3, "x1": 1.5}, values)
+	_, err = parseHiGHS([]byte("Model status\nInfeasible\n"), nil, 2)
+	assert.Equal(t, goptimization.ErrInfeasible, errors.Cause(err))
+	_, err = parseHiGHS([]byte("Model status\nUnbounded\n"), nil, 2)
+	assert.Equal(t, goptimization.ErrUnbounded, errors.Cause(err))
+	values, err = parseHiGHS([]byte("Model status\nTime limit reached\n# Columns 2\nx0 4\nx1 0\n"), nil, 2)
+	assert.Equal(t, goptimization.ErrIterationLimit, errors.Cause(err))
+	assert.Equal(t, map[string]float64{"x0": 4, "x1": 0}, values)
+	_, err = parseHiGHS([]byte("Model status\nOptimal\n# Columns 3\nx0 4\n"), nil, 2)
+	assert.Error(t, err)
+}
+
+func TestParseLPSolve(t *testing.T) {
+	values, err := parseLPSolve(nil, []byte(`
+Value of objective function: -21.00000000
+
+Actual values of the variables:
+x0                              3
+x1                            1.5
+
+Actual values of the constraints:
+c0                             24
+c1                              6
+`), 2)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"x0": 3, "x1": 1.5}, values)
+	_, err = parseLPSolve(nil, []byte("\nThis problem is infeasible"), 2)
+	assert.Equal(t, goptimization.ErrInfeasible, errors.Cause(err))
+	_, err = parseLPSolve(nil, []byte("\nThis problem is unbounded"), 2)
+	assert.Equal(t, goptimization.ErrUnbounded, errors.Cause(err))
+	_, err = parseLPSolve(nil, []byte("lp_solve: unknown option"), 2)
+	assert.Error(t, err)
+}
+
+func TestCommand(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backend")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	// A glpsol writing the solution given to its -w argument
+	path := filepath.Join(dir, "glpsol")
+	require.NoError(t, ioutil.WriteFile(path, []byte("#!/bin/sh\nprintf 's mip 2 2 o -21\\nj 1 3\\nj 2 1.5\\n' > \"$5\"\n"), 0755))
+	s := GLPK(path)
+	assert.True(t, s.Available())
+	r, err := s.Solve(context.Background(), testProblem(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{3, 1.5}, r.X)
+	assert.Equal(t, []float64{0, 0}, r.Slacks)
+	assert.Equal(t, 21.0, r.Score)
+	assert.Equal(t, goptimization.StatusOptimal, r.Status)
+
+	// The search stopped with a feasible solution
+	require.NoError(t, ioutil.WriteFile(path, []byte("#!/bin/sh\nprintf 's mip 2 2 f -20\\nj 1 4\\nj 2 0\\n' > \"$5\"\n"), 0755))
+	r, err = s.Solve(context.Background(), testProblem(), nil)
+	assert.Equal(t, goptimization.ErrIterationLimit, errors.Cause(err))
+	require.NotNil(t, r)
+	assert.Equal(t, goptimization.StatusIterationLimit, r.Status)
+	assert.Equal(t, []float64{4, 0}, r.X)
+
+	_, err = s.Solve(context.Background(), nil, nil)
+	assert.Equal(t, goptimization.ErrDimensionMismatch, errors.Cause(err))
+	assert.False(t, GLPK(filepath.Join(dir, "missing")).Available())
+	_, err = GLPK(filepath.Join(dir, "missing")).Solve(context.Background(), testProblem(), nil)
+	assert.Error(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = s.Solve(ctx, testProblem(), nil)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestInstalled(t *testing.T) {
+	o := &goptimization.Options{Logger: log.New(ioutil.Discard, "", 0)}
+	expected, err := goptimization.MIPSolver{}.Solve(context.Background(), testProblem(), o)
+	require.NoError(t, err)
+	for _, s := range []*Command{GLPK(""), HiGHS(""), LPSolve("")} {
+		if !s.Available() {
+			t.Logf("%s is not installed", s.Name)
+			continue
+		}
+		r, err := s.Solve(context.Background(), testProblem(), o)
+		require.NoError(t, err, s.Name)
+		assert.InDelta(t, expected.Score, r.Score, 0.000001, s.Name)
+	}
+}
+
+func TestArgs(t *testing.T) {
+	o := &goptimization.Options{TimeLimit: 1500 * time.Millisecond, MIPGap: 0.01}
+	assert.Equal(t, []string{"--freemps", "m", "--min", "-w", "s", "--tmlim", "2", "--mipgap", "0.01"}, glpkArgs("m", "s", o))
+	assert.Equal(t, []string{"-fmps", "m", "-S3", "-timeout", "2", "-gr", "0.01"}, lpSolveArgs("m", "s", o))
+	assert.Equal(t, []string{"--model_file", "m", "--solution_file", "s"}, highsArgs("m", "s", nil))
+}
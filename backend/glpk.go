@@ -0,0 +1,86 @@
+package backend
+
+import (
+	"strconv"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+)
+
+// GLPK Solver running glpsol, the LP with the simplex and the MIP with the branch and cut of GLPK.
+// path is the binary, "glpsol" when it is empty.
+func GLPK(path string) *Command {
+	if path == "" {
+		path = "glpsol"
+	}
+	return &Command{Name: "glpk", Path: path, args: glpkArgs, parse: parseGLPK}
+}
+
+// glpkArgs Minimize the free MPS file and write the solution in the GLPK raw format
+func glpkArgs(model, solution string, o *goptimization.Options) []string {
+	args := []string{"--freemps", model, "--min", "-w", solution}
+	if o != nil && o.TimeLimit > 0 {
+		args = append(args, "--tmlim", strconv.Itoa(seconds(o)))
+	}
+	if o != nil && o.MIPGap > 0 {
+		args = append(args, "--mipgap", format(o.MIPGap))
+	}
+	return args
+}
+
+// parseGLPK Read the solution written by glpsol -w:
+// s bas <rows> <columns> <primal status> <dual status> <objective> for an LP, then j <column> <status> <value> <dual>,
+// s mip <rows> <columns> <status> <objective> for a MIP, then j <column> <value>.
+// The status f is feasible, n has no feasible solution, o is the optimum of a MIP.
+func parseGLPK(solution, output []byte, n int) (map[string]float64, error) {
+	var mip bool
+	var err error
+	values := map[string]float64{}
+	found := false
+	for _, fields := range lines(solution) {
+		switch fields[0] {
+		case "s":
+			if len(fields) < 6 {
+				return nil, errors.Errorf("invalid solution line %v", fields)
+			}
+			found = true
+			mip = fields[1] == "mip"
+			switch {
+			case mip && fields[4] == "o":
+			case mip && fields[4] == "f":
+				err = errors.Wrap(goptimization.ErrIterationLimit, "the search stopped before the optimum")
+			case mip && fields[4] == "n":
+				return nil, errors.Wrap(goptimization.ErrInfeasible, "no integer solution")
+			case mip:
+				return nil, errors.Wrap(goptimization.ErrIterationLimit, "no integer solution found")
+			case fields[4] == "n":
+				return nil, errors.Wrap(goptimization.ErrInfeasible, "no feasible solution")
+			case fields[4] == "f" && fields[5] == "n":
+				return nil, errors.Wrap(goptimization.ErrUnbounded, "no dual feasible solution")
+			case fields[4] != "f" || fields[5] != "f":
+				return nil, errors.Wrapf(goptimization.ErrIterationLimit, "primal status %s, dual status %s", fields[4], fields[5])
+			}
+		case "j":
+			position := 3
+			if mip {
+				position = 2
+			}
+			if len(fields) <= position {
+				return nil, errors.Errorf("invalid column line %v", fields)
+			}
+			j, convErr := strconv.Atoi(fields[1])
+			if convErr != nil || j < 1 || j > n {
+				return nil, errors.Errorf("invalid column %s", fields[1])
+			}
+			v, convErr := strconv.ParseFloat(fields[position], 64)
+			if convErr != nil {
+				return nil, errors.Wrapf(convErr, "column %d", j)
+			}
+			values[column(j-1)] = v
+		}
+	}
+	if !found {
+		return nil, errors.Errorf("no solution: %s", output)
+	}
+	return values, err
+}
@@ -0,0 +1,90 @@
+package backend
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+)
+
+// HiGHS Solver running the highs binary, path is the binary, "highs" when it is empty
+func HiGHS(path string) *Command {
+	if path == "" {
+		path = "highs"
+	}
+	return &Command{Name: "highs", Path: path, args: highsArgs, parse: parseHiGHS}
+}
+
+// highsArgs Solve the MPS file and write the solution file, the gap is set by an options file next to the model
+func highsArgs(model, solution string, o *goptimization.Options) []string {
+	args := []string{"--model_file", model, "--solution_file", solution}
+	if o != nil && o.TimeLimit > 0 {
+		args = append(args, "--time_limit", format(o.TimeLimit.Seconds()))
+	}
+	if o != nil && o.MIPGap > 0 {
+		options := filepath.Join(filepath.Dir(model), "highs.opt")
+		// The solve reports the missing options file
+		if ioutil.WriteFile(options, []byte("mip_rel_gap = "+format(o.MIPGap)+"\n"), 0644) == nil {
+			args = append(args, "--options_file", options)
+		}
+	}
+	return args
+}
+
+// parseHiGHS Read the solution file of highs:
+// Model status
+// Optimal
+// # Primal solution values
+// ...
+// # Columns <n>
+// <name> <value>
+func parseHiGHS(solution, output []byte, n int) (map[string]float64, error) {
+	data := lines(solution)
+	status := ""
+	for k := 0; k+1 < len(data); k++ {
+		if strings.Join(data[k], " ") == "Model status" {
+			status = strings.Join(data[k+1], " ")
+			break
+		}
+	}
+	var err error
+	switch status {
+	case "":
+		return nil, errors.Errorf("no solution: %s", output)
+	case "Optimal":
+	case "Infeasible":
+		return nil, errors.Wrap(goptimization.ErrInfeasible, status)
+	case "Unbounded", "Primal infeasible or unbounded":
+		return nil, errors.Wrap(goptimization.ErrUnbounded, status)
+	default:
+		err = errors.Wrap(goptimization.ErrIterationLimit, status)
+	}
+	for k, fields := range data {
+		if len(fields) != 3 || fields[0] != "#" || fields[1] != "Columns" {
+			continue
+		}
+		count, convErr := strconv.Atoi(fields[2])
+		if convErr != nil || count != n || k+count >= len(data) {
+			return nil, errors.Errorf("invalid columns %v", fields)
+		}
+		values := map[string]float64{}
+		for _, line := range data[k+1 : k+1+count] {
+			if len(line) < 2 {
+				return nil, errors.Errorf("invalid column line %v", line)
+			}
+			v, convErr := strconv.ParseFloat(line[1], 64)
+			if convErr != nil {
+				return nil, errors.Wrapf(convErr, "column %s", line[0])
+			}
+			values[line[0]] = v
+		}
+		return values, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, errors.New("the solution has no columns")
+}
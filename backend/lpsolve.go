@@ -0,0 +1,64 @@
+package backend
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+)
+
+// LPSolve Solver running lp_solve, path is the binary, "lp_solve" when it is empty
+func LPSolve(path string) *Command {
+	if path == "" {
+		path = "lp_solve"
+	}
+	return &Command{Name: "lp_solve", Path: path, args: lpSolveArgs, parse: parseLPSolve}
+}
+
+// lpSolveArgs Solve the free MPS file and print the values of the variables, lp_solve writes no solution file
+func lpSolveArgs(model, solution string, o *goptimization.Options) []string {
+	args := []string{"-fmps", model, "-S3"}
+	if o != nil && o.TimeLimit > 0 {
+		args = append(args, "-timeout", strconv.Itoa(seconds(o)))
+	}
+	if o != nil && o.MIPGap > 0 {
+		args = append(args, "-gr", format(o.MIPGap))
+	}
+	return args
+}
+
+// parseLPSolve Read the output of lp_solve -S3:
+// Value of objective function: <objective>
+// Actual values of the variables:
+// <name> <value>
+// Actual values of the constraints:
+// ...
+func parseLPSolve(solution, output []byte, n int) (map[string]float64, error) {
+	values := map[string]float64{}
+	variables := false
+	found := false
+	for _, fields := range lines(output) {
+		line := strings.Join(fields, " ")
+		switch {
+		case strings.HasPrefix(line, "This problem is infeasible"):
+			return nil, errors.Wrap(goptimization.ErrInfeasible, line)
+		case strings.HasPrefix(line, "This problem is unbounded"):
+			return nil, errors.Wrap(goptimization.ErrUnbounded, line)
+		case strings.HasPrefix(line, "Actual values of the variables"):
+			variables, found = true, true
+		case strings.HasPrefix(line, "Actual values of the constraints"), strings.HasPrefix(line, "Dual value"):
+			variables = false
+		case variables && len(fields) == 2:
+			v, err := strconv.ParseFloat(fields[1], 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "variable %s", fields[0])
+			}
+			values[fields[0]] = v
+		}
+	}
+	if !found {
+		return nil, errors.Errorf("no solution: %s", output)
+	}
+	return values, nil
+}
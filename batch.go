@@ -0,0 +1,64 @@
+package goptimization
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// BatchResult Outcome of a problem of SolveBatch
+type BatchResult struct {
+	// Result Result of the solver, nil when the problem has no solution, e.g. it is infeasible
+	Result *Result
+	// Err Error of the solver, the error of the context when the problem was not solved before ctx was done
+	Err error
+}
+
+// SolveBatch Solve the independent problems with o.Threads workers, the number of CPUs by default, and return their
+// outcomes in the order of the problems, e.g. to score many scenarios of a model.
+// Each problem is solved like AutoSolve, without writing the reason of the choice, and with the other fields of o,
+// TimeLimit and MaxNodes apply to each problem. The workers share o.Threads, so MIP explores each tree sequentially.
+// An error only stops its problem, a problem whose dimensions do not match gets ErrDimensionMismatch
+// and the problems left when ctx is done get the error of the context.
+func SolveBatch(ctx context.Context, problems []Problem, o *Options) []BatchResult {
+	threads := 0
+	shared := Options{}
+	if o != nil {
+		threads = o.Threads
+		shared = *o
+	}
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	shared.Threads = 1
+
+	results := make([]BatchResult, len(problems))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < threads; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := range indexes {
+				if ctx.Err() != nil {
+					results[k].Err = ctx.Err()
+					continue
+				}
+				p := &problems[k]
+				err := checkDims(p.C, p.A, p.B)
+				if err != nil {
+					results[k].Err = err
+					continue
+				}
+				solver, _ := ChooseSolver(p)
+				results[k].Result, results[k].Err = solver.Solve(ctx, p, &shared)
+			}
+		}()
+	}
+	for k := range problems {
+		indexes <- k
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,66 @@
+package goptimization
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSolveBatch(t *testing.T) {
+	// max 5x + 4y st 6x + 4y <= 24 * s, x + 2y <= 6 * s, the optimum scales with s
+	problems := make([]Problem, 50)
+	for k := range problems {
+		s := float64(k + 1)
+		problems[k] = Problem{
+			C: mat.NewDense(1, 2, []float64{5, 4}),
+			A: mat.NewDense(2, 2, []float64{
+				6, 4,
+				1, 2,
+			}),
+			B: mat.NewDense(2, 1, []float64{24 * s, 6 * s}),
+		}
+	}
+	// x + y <= 1 and -x - y <= -2
+	problems[10] = Problem{C: mat.NewDense(1, 2, []float64{1, 1}), A: mat.NewDense(2, 2, []float64{1, 1, -1, -1}), B: mat.NewDense(2, 1, []float64{1, -2})}
+	problems[20].Integer = []bool{true, true}
+	// b has 3 rows for the 2 rows of A
+	problems[30].B = mat.NewDense(3, 1, []float64{1, 2, 3})
+	problems[31] = Problem{C: problems[31].C}
+	o := &Options{Threads: 4, Logger: log.New(ioutil.Discard, "", 0)}
+	results := SolveBatch(context.Background(), problems, o)
+	require.Len(t, results, len(problems))
+	for k, r := range results {
+		switch k {
+		case 10:
+			assert.Nil(t, r.Result)
+			assert.Equal(t, ErrInfeasible, errors.Cause(r.Err))
+		case 30, 31:
+			assert.Nil(t, r.Result)
+			assert.Equal(t, ErrDimensionMismatch, errors.Cause(r.Err), "problem %d", k)
+		case 20:
+			// The relaxation of s = 21 has the optimum 441 and the integer one x = 64, y = 30
+			require.NoError(t, r.Err)
+			assert.InDelta(t, 440, r.Result.Score, 0.000001)
+			assert.InDeltaSlice(t, []float64{64, 30}, r.Result.X, 0.000001)
+		default:
+			require.NoError(t, r.Err, "problem %d", k)
+			assert.InDelta(t, 21*float64(k+1), r.Result.Score, 0.000001, "problem %d", k)
+			assert.InDeltaSlice(t, []float64{3 * float64(k+1), 1.5 * float64(k+1)}, r.Result.X, 0.000001, "problem %d", k)
+		}
+	}
+	assert.Equal(t, 4, o.Threads)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, r := range SolveBatch(ctx, problems[:3], nil) {
+		assert.Nil(t, r.Result)
+		assert.Equal(t, context.Canceled, r.Err)
+	}
+	assert.Empty(t, SolveBatch(context.Background(), nil, o))
+}
@@ -0,0 +1,102 @@
+package goptimization
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime/pprof"
+	"strconv"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// The BenchmarkSimplex_* benchmarks solve problems of GenerateLP with n variables and n constraints, so that
+// the regressions of the iterations are measurable:
+//
+//	go test -run XXX -bench Simplex_ -benchmem -cpuprofile cpu.out -memprofile mem.out
+//	go tool pprof -tagfocus size=200 cpu.out
+//
+// Each solve runs with the pprof labels size and method, the profiles of a run over several sizes are split with -tagfocus.
+// The sizes above benchSize, 200 by default, are skipped: they take minutes with the dense LU factorizations
+// of the iterations, GOPTIMIZATION_BENCH_SIZE=10000 runs them all.
+
+// benchDensity Density of the generated problems
+const benchDensity = 0.1
+
+// benchSize Largest size of the benchmarks, see GOPTIMIZATION_BENCH_SIZE
+func benchSize() int {
+	size, err := strconv.Atoi(os.Getenv("GOPTIMIZATION_BENCH_SIZE"))
+	if err != nil || size <= 0 {
+		return 200
+	}
+	return size
+}
+
+// benchmarkLP Solve the generated problem of size n with method, and report its iterations
+func benchmarkLP(b *testing.B, method string, solve lpMethod, n int) {
+	if n > benchSize() {
+		b.Skipf("size %d above GOPTIMIZATION_BENCH_SIZE=%d", n, benchSize())
+	}
+	lp, err := GenerateLP(n, n, benchDensity, 1)
+	if err != nil {
+		b.Fatal(err)
+	}
+	labels := pprof.Labels("size", strconv.Itoa(n), "method", method)
+	iterations := 0
+	b.ReportAllocs()
+	b.ResetTimer()
+	for k := 0; k < b.N; k++ {
+		pprof.Do(context.Background(), labels, func(context.Context) {
+			var iter int
+			iter, _, _, err = solve(lp.C, mat.DenseCopyOf(lp.A), lp.B, silent)
+			iterations += iter
+		})
+		if err != nil {
+			b.Fatal(fmt.Errorf("%s %dx%d: %v", method, n, n, err))
+		}
+	}
+	b.ReportMetric(float64(iterations)/float64(b.N), "iterations/op")
+}
+
+func BenchmarkSimplex_100x100(b *testing.B) {
+	benchmarkLP(b, "simplex", Simplex, 100)
+}
+
+func BenchmarkSimplex_200x200(b *testing.B) {
+	benchmarkLP(b, "simplex", Simplex, 200)
+}
+
+func BenchmarkSimplex_500x500(b *testing.B) {
+	benchmarkLP(b, "simplex", Simplex, 500)
+}
+
+func BenchmarkSimplex_1000x1000(b *testing.B) {
+	benchmarkLP(b, "simplex", Simplex, 1000)
+}
+
+func BenchmarkSimplex_2000x2000(b *testing.B) {
+	benchmarkLP(b, "simplex", Simplex, 2000)
+}
+
+func BenchmarkSimplex_5000x5000(b *testing.B) {
+	benchmarkLP(b, "simplex", Simplex, 5000)
+}
+
+func BenchmarkSimplex_10000x10000(b *testing.B) {
+	benchmarkLP(b, "simplex", Simplex, 10000)
+}
+
+func BenchmarkDualSimplex_100x100(b *testing.B) {
+	benchmarkLP(b, "dual", DualSimplex, 100)
+}
+
+func BenchmarkInteriorPoint_100x100(b *testing.B) {
+	benchmarkLP(b, "interior", InteriorPoint, 100)
+}
+
+func BenchmarkSimplexProductForm_200x200(b *testing.B) {
+	benchmarkLP(b, "simplex-product-form", func(c mat.Matrix, A *mat.Dense, bb mat.Matrix, opts ...Option) (int, *mat.Dense, float64, error) {
+		return Simplex(c, A, bb, append(opts, WithBasisUpdate(ProductForm))...)
+	}, 200)
+}
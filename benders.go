@@ -0,0 +1,352 @@
+package goptimization
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// BendersSubproblem Linear subproblem max H*y, W*y <= R - T*x, y >= 0 for the master variables x
+type BendersSubproblem struct {
+	T *mat.Dense
+	W *mat.Dense
+	R *mat.Dense
+	H *mat.Dense
+}
+
+// Benders Benders decomposition of
+// Maximize z = c*x + Σ(k) H_k*y_k
+// Constraints:
+// A*x <= b, x_j integer if integer[j]
+// T_k*x + W_k*y_k <= R_k
+// x >= 0, y_k >= 0
+// The master problem is solved with MIP over x and a variable θ_k bounding the value of each subproblem,
+// or a single θ for their sum. At each iteration the subproblems are solved in parallel goroutines,
+// with the duals u of their constraints:
+// - a feasible subproblem gives the optimality cut θ_k <= u*(R_k - T_k*x)
+// - an infeasible subproblem gives the feasibility cut u*T_k*x <= u*R_k from the duals of its phase one
+// The master gives an upper bound and the subproblems a feasible solution, the algorithm stops when they meet.
+// The subproblems must be bounded, with a value within [Lower, Upper] for all the feasible x.
+type Benders struct {
+	c       *mat.Dense
+	A       *mat.Dense
+	b       *mat.Dense
+	integer []bool
+
+	Subproblems []BendersSubproblem
+
+	// MultiCut One θ and one cut per subproblem at each iteration, otherwise the cuts are aggregated
+	MultiCut bool
+	// Lower, Upper Bounds on the value of each subproblem
+	Lower float64
+	Upper float64
+	// MaxIter Maximum number of simplex iterations for a subproblem, MaxNodes maximum number of nodes for the master
+	MaxIter  int
+	MaxNodes int
+	// Tolerance Relative gap between the bounds to stop
+	Tolerance float64
+
+	// Iterations, OptimalityCuts, FeasibilityCuts Number of master problems solved and of cuts added
+	Iterations      int
+	OptimalityCuts  int
+	FeasibilityCuts int
+	// UpperBound Best bound given by the master
+	UpperBound float64
+
+	cuts []Cut
+	rhs  []float64
+	// opts Options of New, followed by the ones of Solve
+	opts []Option
+}
+
+// New Initialize the decomposition, the default bounds on the subproblems are [0, 1e6]
+// The options configure the master and the subproblems of Solve, see Solve.
+func (bd *Benders) New(c, A, b *mat.Dense, integer []bool, subproblems []BendersSubproblem, opts ...Option) error {
+	_, n := c.Dims()
+	m, cols := A.Dims()
+	if cols != n {
+		return newError(ErrDimensionMismatch, "A dims.c != z dims.c")
+	}
+	if rows, _ := b.Dims(); rows != m {
+		return newError(ErrDimensionMismatch, "b dims.r != A dims.r")
+	}
+	if len(integer) != n {
+		return newError(ErrDimensionMismatch, "len(integer) != z dims.c")
+	}
+	for k, sp := range subproblems {
+		rows, tCols := sp.T.Dims()
+		wRows, ny := sp.W.Dims()
+		rRows, _ := sp.R.Dims()
+		_, hCols := sp.H.Dims()
+		if tCols != n || wRows != rows || rRows != rows || hCols != ny {
+			return newError(ErrDimensionMismatch, "subproblem %d has inconsistent dimensions", k)
+		}
+	}
+	bd.c = mat.DenseCopyOf(c)
+	bd.A = mat.DenseCopyOf(A)
+	bd.b = mat.DenseCopyOf(b)
+	bd.integer = append([]bool(nil), integer...)
+	bd.Subproblems = subproblems
+	bd.MultiCut = true
+	bd.Lower = 0
+	bd.Upper = 1e6
+	bd.MaxIter = 1000
+	bd.MaxNodes = 1000
+	bd.Tolerance = 1e-6
+	bd.Iterations = 0
+	bd.OptimalityCuts = 0
+	bd.FeasibilityCuts = 0
+	bd.UpperBound = math.Inf(1)
+	bd.cuts = nil
+	bd.rhs = nil
+	bd.opts = opts
+	return nil
+}
+
+// Solve Iterate between the master and the subproblems at most maxIter times.
+// The options, after the ones of New, are given to MIP for the master and configure the dictionary of each subproblem.
+// MaxIter and MaxNodes replace WithMaxIter, WithTimeLimit and WithContext stop the whole decomposition.
+// It returns the best solution x (n,1), the solution y_k of each subproblem and the score.
+func (bd *Benders) Solve(maxIter int, opts ...Option) (*mat.Dense, []*mat.Dense, float64, error) {
+	if bd.c == nil {
+		return nil, nil, 0, errors.New("benders is not initialized")
+	}
+	if bd.Upper <= bd.Lower {
+		return nil, nil, 0, errors.New("Upper must be greater than Lower")
+	}
+	opts = append(append([]Option(nil), bd.opts...), opts...)
+	o := newOptions(opts)
+	deadline := o.deadline()
+	_, n := bd.c.Dims()
+	thetas := 1
+	if bd.MultiCut {
+		thetas = len(bd.Subproblems)
+	}
+
+	var bestX *mat.Dense
+	var bestY []*mat.Dense
+	best := math.Inf(-1)
+	for bd.Iterations = 0; bd.Iterations < maxIter && !expired(deadline) && !o.cancelled(); {
+		bd.Iterations++
+		x, theta, bound, err := bd.solveMaster(n, thetas, opts, deadline)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		bd.UpperBound = math.Min(bd.UpperBound, bound)
+
+		// θ_k = θ'_k + Lower for each variable of the master
+		added := 0
+		feasible := true
+		score := 0.0
+		for j := 0; j < n; j++ {
+			score += bd.c.At(0, j) * x[j]
+		}
+		results, err := bd.solveSubproblems(x, o, deadline)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		ys := make([]*mat.Dense, len(bd.Subproblems))
+		total := make([]float64, n+1)
+		totalValue := 0.0
+		for k, sp := range bd.Subproblems {
+			y, value, duals, infeasible := results[k].y, results[k].value, results[k].duals, results[k].infeasible
+			// u*T_k*x <= u*R_k, or u*T_k*x + θ'_k <= u*R_k - Lower
+			cut := make([]float64, n+1)
+			rows, _ := sp.T.Dims()
+			for i := 0; i < rows; i++ {
+				for j := 0; j < n; j++ {
+					cut[j] += duals[i] * sp.T.At(i, j)
+				}
+				cut[n] += duals[i] * sp.R.At(i, 0)
+			}
+			if infeasible {
+				feasible = false
+				bd.addCut(cut[:n], -1, cut[n])
+				bd.FeasibilityCuts++
+				added++
+				continue
+			}
+			if value < bd.Lower-feasibilityTolerance || value > bd.Upper+feasibilityTolerance {
+				return nil, nil, 0, errors.Errorf("the value %g of subproblem %d is not within [Lower, Upper]", value, k)
+			}
+			ys[k] = y
+			score += value
+			if bd.MultiCut {
+				if theta[k]+bd.Lower > value+bd.Tolerance*(1+math.Abs(value)) {
+					bd.addCut(cut[:n], k, cut[n]-bd.Lower)
+					bd.OptimalityCuts++
+					added++
+				}
+				continue
+			}
+			for j := range total {
+				total[j] += cut[j]
+			}
+			totalValue += value
+		}
+		if feasible && !bd.MultiCut {
+			lower := float64(len(bd.Subproblems)) * bd.Lower
+			if theta[0]+lower > totalValue+bd.Tolerance*(1+math.Abs(totalValue)) {
+				bd.addCut(total[:n], 0, total[n]-lower)
+				bd.OptimalityCuts++
+				added++
+			}
+		}
+
+		if feasible && score > best {
+			best = score
+			bestX = mat.NewDense(n, 1, append([]float64(nil), x...))
+			bestY = ys
+		}
+		if added == 0 || (feasible && bd.UpperBound-best <= bd.Tolerance*(1+math.Abs(best))) {
+			break
+		}
+	}
+	if bestX == nil {
+		if expired(deadline) {
+			return nil, nil, 0, newError(ErrTimeLimit, "no feasible solution found before the deadline")
+		}
+		return nil, nil, 0, newError(ErrIterationLimit, "no feasible solution found within maxIter")
+	}
+	return bestX, bestY, best, nil
+}
+
+// addCut Store the cut a*x + θ'_k <= rhs, k = -1 for a feasibility cut without θ
+func (bd *Benders) addCut(a []float64, k int, rhs float64) {
+	cut := Cut{A: append([]float64(nil), a...), RHS: rhs}
+	if k >= 0 {
+		cut.A = append(cut.A, make([]float64, k+1)...)
+		cut.A[len(a)+k] = 1
+	}
+	bd.cuts = append(bd.cuts, cut)
+}
+
+// solveMaster Solve max c*x + Σ θ'_k over A*x <= b, θ'_k <= Upper - Lower and the cuts.
+// It returns x, θ' and the bound, which includes the Lower bounds of the θ.
+// The search is configured by opts and stops at the deadline of Solve.
+func (bd *Benders) solveMaster(n, thetas int, opts []Option, deadline time.Time) ([]float64, []float64, float64, error) {
+	m, _ := bd.A.Dims()
+	rows := m + thetas + len(bd.cuts)
+	c := mat.NewDense(1, n+thetas, nil)
+	A := mat.NewDense(rows, n+thetas, nil)
+	b := mat.NewDense(rows, 1, nil)
+	c.Slice(0, 1, 0, n).(*mat.Dense).Copy(bd.c)
+	A.Slice(0, m, 0, n).(*mat.Dense).Copy(bd.A)
+	b.Slice(0, m, 0, 1).(*mat.Dense).Copy(bd.b)
+	width := bd.Upper - bd.Lower
+	if !bd.MultiCut {
+		width *= float64(len(bd.Subproblems))
+	}
+	for k := 0; k < thetas; k++ {
+		c.Set(0, n+k, 1)
+		A.Set(m+k, n+k, 1)
+		b.Set(m+k, 0, width)
+	}
+	for r, cut := range bd.cuts {
+		for j, a := range cut.A {
+			A.Set(m+thetas+r, j, a)
+		}
+		b.Set(m+thetas+r, 0, cut.RHS)
+	}
+	integer := make([]bool, n+thetas)
+	copy(integer, bd.integer)
+
+	bb, err := newMIP(c, A, b, integer, opts)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	bb.Deadline = deadline
+	results, score, err := bb.Solve(bd.MaxNodes)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	x := make([]float64, n)
+	for j := range x {
+		x[j] = results.At(j, 0)
+	}
+	theta := make([]float64, thetas)
+	for k := range theta {
+		theta[k] = results.At(n+k, 0)
+	}
+	return x, theta, score + float64(len(bd.Subproblems))*bd.Lower, nil
+}
+
+// subproblemResult Solution of a subproblem for the current master solution
+type subproblemResult struct {
+	y          *mat.Dense
+	value      float64
+	duals      []float64
+	infeasible bool
+}
+
+// solveSubproblems Solve the subproblems in parallel goroutines, the results are in the order of the subproblems
+func (bd *Benders) solveSubproblems(x []float64, o options, deadline time.Time) ([]subproblemResult, error) {
+	results := make([]subproblemResult, len(bd.Subproblems))
+	errs := make([]error, len(bd.Subproblems))
+	wg := sync.WaitGroup{}
+	for k := range bd.Subproblems {
+		wg.Add(1)
+		go func(k int) {
+			defer wg.Done()
+			r := &results[k]
+			r.y, r.value, r.duals, r.infeasible, errs[k] = bd.solveSubproblem(bd.Subproblems[k], x, o, deadline)
+		}(k)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// solveSubproblem Solve max H*y, W*y <= R - T*x.
+// It returns y, the value and the duals u of the constraints, or the duals of the phase one if it is infeasible.
+// The dictionary is configured by o and stops at the deadline of Solve.
+func (bd *Benders) solveSubproblem(sp BendersSubproblem, x []float64, o options, deadline time.Time) (*mat.Dense, float64, []float64, bool, error) {
+	rows, _ := sp.W.Dims()
+	rhs := mat.NewDense(rows, 1, nil)
+	for i := 0; i < rows; i++ {
+		v := sp.R.At(i, 0)
+		for j, xj := range x {
+			v -= sp.T.At(i, j) * xj
+		}
+		rhs.Set(i, 0, v)
+	}
+	cf := &CanonicalForm{}
+	err := cf.New(mat.DenseCopyOf(sp.H), mat.DenseCopyOf(sp.W), rhs)
+	if err != nil {
+		return nil, 0, nil, false, err
+	}
+	cf.configure(o)
+	cf.deadline = deadline
+	_, err = cf.phaseOne(bd.MaxIter)
+	infeasible := err == ErrInfeasible
+	if err != nil && !infeasible {
+		return nil, 0, nil, false, err
+	}
+	if !infeasible {
+		_, err = cf.Reoptimize(bd.MaxIter)
+		if err != nil {
+			return nil, 0, nil, false, err
+		}
+	}
+	y, err := cf.FindY()
+	if err != nil {
+		return nil, 0, nil, false, err
+	}
+	duals := make([]float64, rows)
+	for i := range duals {
+		duals[i] = y.At(0, i)
+	}
+	if infeasible {
+		return nil, 0, duals, true, nil
+	}
+	values, value := cf.values()
+	_, ny := sp.W.Dims()
+	return mat.NewDense(ny, 1, values[:ny]), value, duals, false, nil
+}
@@ -0,0 +1,105 @@
+package goptimization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+// capacityExpansion Build x <= 5 units of capacity at a cost of 3, two markets then earn 5*y_1 with y_1 <= 2x, y_1 <= 7
+// and 4*y_2 with y_2 <= x, y_2 <= 3
+func capacityExpansion() (*mat.Dense, *mat.Dense, *mat.Dense, []BendersSubproblem) {
+	c := mat.NewDense(1, 1, []float64{-3})
+	A := mat.NewDense(1, 1, []float64{1})
+	b := mat.NewDense(1, 1, []float64{5})
+	subproblems := []BendersSubproblem{
+		{
+			T: mat.NewDense(2, 1, []float64{-2, 0}),
+			W: mat.NewDense(2, 1, []float64{1, 1}),
+			R: mat.NewDense(2, 1, []float64{0, 7}),
+			H: mat.NewDense(1, 1, []float64{5}),
+		},
+		{
+			T: mat.NewDense(2, 1, []float64{-1, 0}),
+			W: mat.NewDense(2, 1, []float64{1, 1}),
+			R: mat.NewDense(2, 1, []float64{0, 3}),
+			H: mat.NewDense(1, 1, []float64{4}),
+		},
+	}
+	return c, A, b, subproblems
+}
+
+func TestBenders(t *testing.T) {
+	c, A, b, subproblems := capacityExpansion()
+
+	//Monolithic problem over (x, y_1, y_2)
+	_, _, expected, err := MIP(mat.NewDense(1, 3, []float64{-3, 5, 4}), mat.NewDense(5, 3, []float64{
+		1, 0, 0,
+		-2, 1, 0,
+		0, 1, 0,
+		-1, 0, 1,
+		0, 0, 1,
+	}), mat.NewDense(5, 1, []float64{5, 0, 7, 0, 3}), []bool{true, false, false}, 100, silent)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 35.0, expected, 0.000001)
+
+	for _, multiCut := range []bool{true, false} {
+		bd := Benders{}
+		require.NoError(t, bd.New(c, A, b, []bool{true}, subproblems, silent))
+		bd.MultiCut = multiCut
+		bd.Upper = 100
+		x, y, score, err := bd.Solve(50)
+		require.NoError(t, err)
+		assert.InEpsilon(t, expected, score, 0.000001)
+		assert.InDelta(t, 4.0, x.At(0, 0), 0.000001)
+		assert.InDelta(t, 7.0, y[0].At(0, 0), 0.000001)
+		assert.InDelta(t, 3.0, y[1].At(0, 0), 0.000001)
+		assert.GreaterOrEqual(t, bd.UpperBound, score-0.000001)
+		assert.Greater(t, bd.OptimalityCuts, 0)
+	}
+}
+
+func TestBendersFeasibilityCuts(t *testing.T) {
+	//Minimize x with a subproblem which must produce y >= 2 with y <= x
+	c := mat.NewDense(1, 1, []float64{-1})
+	A := mat.NewDense(1, 1, []float64{1})
+	b := mat.NewDense(1, 1, []float64{10})
+	subproblems := []BendersSubproblem{{
+		T: mat.NewDense(2, 1, []float64{0, -1}),
+		W: mat.NewDense(2, 1, []float64{-1, 1}),
+		R: mat.NewDense(2, 1, []float64{-2, 0}),
+		H: mat.NewDense(1, 1, []float64{0}),
+	}}
+
+	bd := Benders{}
+	require.NoError(t, bd.New(c, A, b, []bool{false}, subproblems, silent))
+	bd.Upper = 1
+	x, _, score, err := bd.Solve(20)
+	require.NoError(t, err)
+	assert.InDelta(t, -2.0, score, 0.000001)
+	assert.InDelta(t, 2.0, x.At(0, 0), 0.000001)
+	assert.Greater(t, bd.FeasibilityCuts, 0)
+}
+
+func TestBendersErrors(t *testing.T) {
+	c, A, b, subproblems := capacityExpansion()
+	bd := Benders{}
+	_, _, _, err := bd.Solve(10)
+	assert.Error(t, err)
+	assert.Error(t, bd.New(c, A, b, []bool{true, false}, subproblems))
+	subproblems[0].H = mat.NewDense(1, 2, nil)
+	assert.Error(t, bd.New(c, A, b, []bool{true}, subproblems))
+
+	//The context of the options stops the decomposition
+	c, A, b, subproblems = capacityExpansion()
+	require.NoError(t, bd.New(c, A, b, []bool{true}, subproblems, silent))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, _, err = bd.Solve(10, WithContext(ctx))
+	assert.Equal(t, ErrIterationLimit, errors.Cause(err))
+	assert.Equal(t, 0, bd.Iterations)
+}
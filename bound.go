@@ -0,0 +1,147 @@
+package goptimization
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// BoundService Repeated LP relaxation bounds of a fixed problem under variable fixings, for external heuristics
+// The root relaxation is solved once. Each query fixes variables to 0 or 1 and re-optimizes with the dual simplex
+// from the dictionary of the previous query when its fixings are a subset of the new ones, from the root otherwise.
+type BoundService struct {
+	n    int
+	root *CanonicalForm
+
+	last      *CanonicalForm
+	lastFixed []uint64
+	lastValue []uint64
+	cache     map[string]float64
+
+	// MaxIter Maximum number of simplex iterations for each relaxation
+	MaxIter int
+	// CacheSize Maximum number of bounds kept in the cache, 0 disables the cache
+	CacheSize int
+
+	// Queries, CacheHits, WarmStarts Number of calls to Bound, of bounds read from the cache
+	// and of relaxations started from the dictionary of the previous query
+	Queries    int
+	CacheHits  int
+	WarmStarts int
+}
+
+// New Solve the root relaxation of the problem in the standard form of Simplex.
+// The options configure the root dictionary and, through Clone, the relaxations of Bound,
+// WithTimeLimit only limits the root relaxation.
+func (bs *BoundService) New(c, A, b *mat.Dense, maxIter int, opts ...Option) error {
+	o := newOptions(opts)
+	cf := &CanonicalForm{}
+	err := cf.New(mat.DenseCopyOf(c), mat.DenseCopyOf(A), mat.DenseCopyOf(b))
+	if err != nil {
+		return err
+	}
+	cf.configure(o)
+	cf.deadline = o.deadline()
+	_, err = cf.Reoptimize(maxIter)
+	if err != nil {
+		return err
+	}
+	bs.n = cf.n
+	bs.root = cf
+	bs.last = nil
+	bs.lastFixed = nil
+	bs.lastValue = nil
+	bs.cache = map[string]float64{}
+	bs.MaxIter = maxIter
+	bs.CacheSize = 1024
+	bs.Queries = 0
+	bs.CacheHits = 0
+	bs.WarmStarts = 0
+	return nil
+}
+
+// Bound LP relaxation bound with the variable j fixed to bit j of value when bit j of fixed is set.
+// Bit j is bit j%64 of the word j/64. It returns -Inf when the fixings are infeasible.
+func (bs *BoundService) Bound(fixed, value []uint64) (float64, error) {
+	if bs.root == nil {
+		return 0, errors.New("bound service is not initialized")
+	}
+	words := (bs.n + 63) / 64
+	if len(fixed) > words || len(value) > len(fixed) {
+		return 0, errors.New("the bitmasks are longer than the number of variables")
+	}
+	fixed = append(append([]uint64(nil), fixed...), make([]uint64, words-len(fixed))...)
+	value = append(append([]uint64(nil), value...), make([]uint64, words-len(value))...)
+	if bs.n%64 != 0 && fixed[words-1]>>uint(bs.n%64) != 0 {
+		return 0, errors.New("the bitmasks are longer than the number of variables")
+	}
+	for w := range value {
+		value[w] &= fixed[w]
+	}
+	bs.Queries++
+
+	key := fmt.Sprint(fixed, value)
+	if bound, ok := bs.cache[key]; ok {
+		bs.CacheHits++
+		return bound, nil
+	}
+
+	// Only add the fixings which are not in the dictionary of the previous query
+	cf := bs.root.Clone()
+	previous := make([]uint64, words)
+	if bs.last != nil && bs.extends(fixed, value) {
+		cf = bs.last.Clone()
+		previous = bs.lastFixed
+		bs.WarmStarts++
+	}
+	for j := 0; j < bs.n; j++ {
+		mask := uint64(1) << uint(j%64)
+		if fixed[j/64]&mask == 0 || previous[j/64]&mask != 0 {
+			continue
+		}
+		rows := [][2]float64{{1, 0}}
+		if value[j/64]&mask != 0 {
+			rows = [][2]float64{{1, 1}, {-1, -1}}
+		}
+		for _, row := range rows {
+			a := make([]float64, cf.n+cf.m)
+			a[j] = row[0]
+			err := cf.AddConstraint(a, row[1])
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	bound := math.Inf(-1)
+	_, err := cf.Reoptimize(bs.MaxIter)
+	if err != nil && err != ErrInfeasible {
+		return 0, err
+	}
+	if err == nil {
+		_, bound = cf.values()
+		bs.last = cf
+		bs.lastFixed = fixed
+		bs.lastValue = value
+	}
+
+	if bs.CacheSize > 0 {
+		if len(bs.cache) >= bs.CacheSize {
+			bs.cache = map[string]float64{}
+		}
+		bs.cache[key] = bound
+	}
+	return bound, nil
+}
+
+// extends Check if the fixings of the previous query are a subset of fixed and value
+func (bs *BoundService) extends(fixed, value []uint64) bool {
+	for w := range fixed {
+		if bs.lastFixed[w]&^fixed[w] != 0 || (bs.lastValue[w]^value[w])&bs.lastFixed[w] != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+package goptimization
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestBoundService(t *testing.T) {
+	c := mat.NewDense(1, 4, []float64{8, 11, 6, 4})
+	A := mat.NewDense(5, 4, []float64{
+		5, 7, 4, 3,
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1,
+	})
+	b := mat.NewDense(5, 1, []float64{14, 1, 1, 1, 1})
+
+	bs := BoundService{}
+	_, err := bs.Bound(nil, nil)
+	assert.Error(t, err)
+	require.NoError(t, bs.New(c, A, b, 100, silent))
+
+	bound, err := bs.Bound(nil, nil)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 22.0, bound, 0.000001)
+
+	//x_1 = 1
+	bound, err = bs.Bound([]uint64{0x2}, []uint64{0x2})
+	require.NoError(t, err)
+	assert.InEpsilon(t, 22.0, bound, 0.000001)
+
+	//x_1 = 1, x_2 = 0 starts from the previous dictionary
+	bound, err = bs.Bound([]uint64{0x6}, []uint64{0x2})
+	require.NoError(t, err)
+	assert.InEpsilon(t, 65.0/3, bound, 0.000001)
+	assert.Equal(t, 2, bs.WarmStarts)
+
+	//x_0 = x_1 = x_2 = 1 exceeds the capacity
+	bound, err = bs.Bound([]uint64{0x7}, []uint64{0x7})
+	require.NoError(t, err)
+	assert.True(t, math.IsInf(bound, -1))
+
+	//x_0 = 0 starts from the root, the same fixings come from the cache
+	for i := 0; i < 2; i++ {
+		bound, err = bs.Bound([]uint64{0x1}, []uint64{0x10})
+		require.NoError(t, err)
+		assert.InEpsilon(t, 21.0, bound, 0.000001)
+	}
+	assert.Equal(t, 6, bs.Queries)
+	assert.Equal(t, 1, bs.CacheHits)
+	assert.Equal(t, 2, bs.WarmStarts)
+
+	_, err = bs.Bound([]uint64{0x10}, nil)
+	assert.Error(t, err)
+	_, err = bs.Bound([]uint64{0x1, 0x1}, nil)
+	assert.Error(t, err)
+
+	//The options configure the root relaxation
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, ErrIterationLimit, bs.New(c, A, b, 100, WithContext(ctx), silent))
+}
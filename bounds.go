@@ -0,0 +1,145 @@
+package goptimization
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/mat"
+)
+
+// SimplexRanged Solve the linear problem of Simplex with ranged constraints b_i - r_i <= Σ a_i_j*x_j <= b_i,
+// a single row each instead of a <= row and a negated >= row. ranges holds r_i >= 0, +Inf for a one-sided
+// constraint, and nil means no ranged constraint.
+// The slack variable of a ranged constraint is bounded, 0 <= s_i <= r_i: when it reaches r_i, it is substituted
+// by r_i - s_i (its column and its cost change sign), so that the nonbasic variables stay at 0 in the dictionary.
+// The primal ratio test also stops the basic slacks at their bound and an entering slack limited by its own bound
+// flips to it without a pivot, the dual iterations substitute the slacks above their bound before the ratio test
+// and the dual ratio test flips the bounded nonbasic variables it passes, see DualIter.
+// The results follow the layout of Simplex: the slacks are b_i - Σ a_i_j*x_j, between 0 and r_i.
+// The options and errors are those of Simplex.
+func SimplexRanged(c mat.Matrix, A *mat.Dense, b mat.Matrix, ranges []float64, opts ...Option) (int, *mat.Dense, float64, error) {
+	o := newOptions(opts)
+	row, err := asRow(c, "c")
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	column, err := asColumn(b, "b")
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	err = checkDims(row, A, column)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	m, n := A.Dims()
+	if ranges != nil && len(ranges) != m {
+		return 0, nil, 0, newError(ErrDimensionMismatch, "len(ranges) must be %d, got %d", m, len(ranges))
+	}
+	upper := make([]float64, n+m)
+	for j := range upper {
+		upper[j] = math.Inf(1)
+	}
+	for i, r := range ranges {
+		if r < 0 || math.IsNaN(r) {
+			return 0, nil, 0, newError(ErrDimensionMismatch, "the range of the constraint %d must be >= 0, got %v", i, r)
+		}
+		upper[n+i] = r
+	}
+	cf := CanonicalForm{}
+	err = cf.New(row, A, column)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	cf.configure(o)
+	cf.setUpper(upper)
+	return cf.run(o)
+}
+
+// setUpper Bound each variable by upper, indexed by variable
+func (cf *CanonicalForm) setUpper(upper []float64) {
+	cf.upper = upper
+	cf.reflected = make([]bool, len(upper))
+	// The substitutions change b, which is shared with the caller of New
+	cf.b = mat.DenseCopyOf(cf.b)
+}
+
+// upperOf Upper bound of the variable v
+func (cf *CanonicalForm) upperOf(v int) float64 {
+	if cf.upper == nil {
+		return math.Inf(1)
+	}
+	return cf.upper[v]
+}
+
+// growBounds Add an unbounded variable, see AddColumn and AddConstraint
+func (cf *CanonicalForm) growBounds() {
+	if cf.upper != nil {
+		cf.upper = append(cf.upper, math.Inf(1))
+		cf.reflected = append(cf.reflected, false)
+	}
+}
+
+// reflect Substitute U - x to the variable x at the position p, with its upper bound U.
+// Its column and its cost change sign and b loses U times the column, the objective gains c_p*U.
+// d is B^-1 times the column of a nonbasic variable, whose value goes from 0 to U in the basic variables,
+// a basic variable takes the value U - x.
+func (cf *CanonicalForm) reflect(p int, d *mat.Dense) {
+	v := cf.remap[p]
+	u := cf.upperOf(v)
+	blas64.Axpy(-u, colVector(cf.A, p), colVector(cf.b, 0))
+	if p >= cf.n {
+		cf.xBStar.Set(p-cf.n, 0, u-cf.xBStar.At(p-cf.n, 0))
+		cf.invalidate()
+	} else {
+		blas64.Axpy(-u, colVector(d, 0), colVector(cf.xBStar, 0))
+	}
+	blas64.Scal(-1, colVector(cf.A, p))
+	cf.offset += cf.c.At(0, p) * u
+	cf.c.Set(0, p, -cf.c.At(0, p))
+	cf.reflected[v] = !cf.reflected[v]
+}
+
+// reflectAbove Substitute the basic variables above their upper bound, they become negative
+func (cf *CanonicalForm) reflectAbove() {
+	if cf.upper == nil {
+		return
+	}
+	for k := 0; k < cf.m; k++ {
+		if cf.xBStar.At(k, 0) > cf.upperOf(cf.remap[cf.n+k])+cf.tolerance {
+			cf.reflect(cf.n+k, nil)
+		}
+	}
+}
+
+// boundedRatio Complete the ratio test x, leavingVarIndex of FindLeavingVariable with the upper bounds:
+// a basic variable decreasing with d_i < 0 reaches its bound U_i after (x_i - U_i)/d_i, it is substituted
+// so that it leaves the basis at 0, and the entering variable cannot exceed its own bound.
+// It returns true when the entering variable flips to its bound instead of a pivot.
+func (cf *CanonicalForm) boundedRatio(d *mat.Dense, enteringVarIndex int, x float64, leavingVarIndex int) (bool, float64, int) {
+	if cf.upper == nil {
+		return false, x, leavingVarIndex
+	}
+	if leavingVarIndex == -1 {
+		x = math.Inf(1)
+	}
+	above := -1
+	for i := 0; i < cf.m; i++ {
+		u := cf.upperOf(cf.remap[cf.n+i])
+		di := d.At(i, 0)
+		if math.IsInf(u, 1) || di >= -cf.tolerance {
+			continue
+		}
+		if t := (cf.xBStar.At(i, 0) - u) / di; t < x {
+			x, leavingVarIndex, above = t, i, i
+		}
+	}
+	if u := cf.upperOf(cf.remap[enteringVarIndex]); u < x {
+		cf.reflect(enteringVarIndex, d)
+		return true, u, -1
+	}
+	if above != -1 {
+		cf.reflect(cf.n+above, nil)
+		d.Set(above, 0, -d.At(above, 0))
+	}
+	return false, x, leavingVarIndex
+}
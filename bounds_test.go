@@ -0,0 +1,247 @@
+package goptimization
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+// expandRanges Two rows Σ a_i_j*x_j <= b_i and -Σ a_i_j*x_j <= r_i - b_i for each ranged row, appended after A
+func expandRanges(A, b *mat.Dense, ranges []float64) (*mat.Dense, *mat.Dense) {
+	m, n := A.Dims()
+	rows := [][]float64{}
+	rhs := []float64{}
+	for i := 0; i < m; i++ {
+		rows = append(rows, mat.Row(nil, i, A))
+		rhs = append(rhs, b.At(i, 0))
+	}
+	for i, r := range ranges {
+		if math.IsInf(r, 1) {
+			continue
+		}
+		row := mat.Row(nil, i, A)
+		for j := range row {
+			row[j] = -row[j]
+		}
+		rows = append(rows, row)
+		rhs = append(rhs, r-b.At(i, 0))
+	}
+	expanded := mat.NewDense(len(rows), n, nil)
+	for i, row := range rows {
+		expanded.SetRow(i, row)
+	}
+	return expanded, mat.NewDense(len(rhs), 1, rhs)
+}
+
+func TestSimplexRanged(t *testing.T) {
+	for seed := int64(1); seed <= 8; seed++ {
+		lp, err := GenerateLP(15, 10, 0.4, seed)
+		require.NoError(t, err)
+		rnd := rand.New(rand.NewSource(seed))
+		ranges := make([]float64, 10)
+		for i := range ranges {
+			ranges[i] = math.Inf(1)
+			if rnd.Intn(2) == 0 {
+				// Some ranges exclude the slack basis, b_i - r_i > 0, and need the phase one
+				ranges[i] = rnd.Float64() * math.Abs(lp.B.At(i, 0)) * 1.5
+			}
+		}
+		expandedA, expandedB := expandRanges(lp.A, lp.B, ranges)
+		_, _, expectedScore, expectedErr := Simplex(lp.C, expandedA, expandedB, silent)
+
+		A := mat.DenseCopyOf(lp.A)
+		_, results, score, err := SimplexRanged(lp.C, A, lp.B, ranges, silent)
+		assert.Equal(t, errors.Cause(expectedErr), errors.Cause(err), "seed %d", seed)
+		if expectedErr != nil {
+			continue
+		}
+		assert.InDelta(t, expectedScore, score, 1e-6, "seed %d", seed)
+		assert.True(t, feasible(lp.A, lp.B, mat.Col(nil, 0, results)[:15]), "seed %d", seed)
+		for i, r := range ranges {
+			slack := results.At(15+i, 0)
+			assert.True(t, slack >= -1e-7 && slack <= r+1e-7, "seed %d: slack %d = %v outside [0, %v]", seed, i, slack, r)
+		}
+		assert.Equal(t, mat.Row(nil, 0, lp.A), mat.Row(nil, 0, A), "A is not modified")
+	}
+}
+
+func TestBoundFlip(t *testing.T) {
+	flips := 0
+	for seed := int64(1); seed <= 8; seed++ {
+		lp, err := GenerateLP(10, 10, 0.5, seed)
+		require.NoError(t, err)
+		cf := CanonicalForm{}
+		require.NoError(t, cf.New(mat.DenseCopyOf(lp.C), mat.DenseCopyOf(lp.A), lp.B))
+		cf.configure(newOptions([]Option{silent}))
+		upper := make([]float64, 20)
+		for j := range upper {
+			upper[j] = math.Inf(1)
+			if j >= 10 {
+				upper[j] = 5
+			}
+		}
+		cf.setUpper(upper)
+		_, err = cf.phaseOne(0)
+		if errors.Cause(err) == ErrInfeasible {
+			continue
+		}
+		require.NoError(t, err)
+		for k := 0; k < 1000; k++ {
+			remap := append([]int(nil), cf.remap...)
+			reflected := append([]bool(nil), cf.reflected...)
+			end, err := cf.Iter(0)
+			require.NoError(t, err)
+			if end {
+				break
+			}
+			if assert.ObjectsAreEqual(remap, cf.remap) && !assert.ObjectsAreEqual(reflected, cf.reflected) {
+				flips++
+			}
+			// The basic variables stay within their bounds
+			require.True(t, cf.primalFeasible(), "seed %d", seed)
+		}
+		values, score := cf.values()
+		total := 0.0
+		for j := 0; j < 10; j++ {
+			total += lp.C.At(0, j) * values[j]
+		}
+		assert.InDelta(t, total, score, 1e-7, "offset of the reflected variables")
+		for j, u := range upper {
+			assert.True(t, values[j] >= -1e-7 && values[j] <= u+1e-7, "seed %d: variable %d = %v outside [0, %v]", seed, j, values[j], u)
+		}
+	}
+	assert.NotZero(t, flips)
+}
+
+func TestDualBoundFlip(t *testing.T) {
+	c := mat.NewDense(1, 3, []float64{-1, -2, -3})
+	A := mat.NewDense(1, 3, []float64{-1, -1, -1})
+	b := mat.NewDense(1, 1, []float64{-2.5})
+
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(c, A, b))
+	cf.configure(newOptions([]Option{silent}))
+	cf.setUpper([]float64{1, 1, 1, math.Inf(1)})
+
+	//x_1 + x_2 + x_3 >= 2.5 with x_j <= 1: the breakpoints of x_1 and x_2 are passed, both flip to 1,
+	//and a single dual pivot brings x_3 in at 0.5
+	end, err := cf.DualIter()
+	require.NoError(t, err)
+	assert.False(t, end)
+	assert.Equal(t, []bool{true, true, false, false}, cf.reflected)
+	end, err = cf.DualIter()
+	require.NoError(t, err)
+	assert.True(t, end)
+	values, score := cf.values()
+	assert.InDeltaSlice(t, []float64{1, 1, 0.5, 0}, values, 1e-9)
+	assert.InDelta(t, -4.5, score, 1e-9)
+}
+
+func TestSimplexRangedBounds(t *testing.T) {
+	// Maximize x1 + x2 with 2 <= x1 <= 3, 1 <= x2 <= 4 and x1 + x2 <= 6:
+	// x1 leaves its row at the upper bound of its slack and x2 stops at the slack bound of its row
+	c := mat.NewDense(1, 2, []float64{1, 2})
+	A := mat.NewDense(3, 2, []float64{
+		1, 0,
+		0, 1,
+		1, 1,
+	})
+	b := mat.NewDense(3, 1, []float64{3, 4, 6})
+	_, results, score, err := SimplexRanged(c, A, b, []float64{1, 3, math.Inf(1)}, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, 10, score, 1e-9)
+	assert.InDelta(t, 2, results.At(0, 0), 1e-9)
+	assert.InDelta(t, 4, results.At(1, 0), 1e-9)
+	assert.InDelta(t, 1, results.At(2, 0), 1e-9)
+	assert.InDelta(t, 0, results.At(3, 0), 1e-9)
+
+	A = mat.NewDense(1, 1, []float64{1})
+	b = mat.NewDense(1, 1, []float64{1})
+	c = mat.NewDense(1, 1, []float64{1})
+	// A negative range is refused, -4 <= x1 <= -3 is infeasible
+	_, _, _, err = SimplexRanged(c, A, b, []float64{-2}, silent)
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+	_, _, _, err = SimplexRanged(c, A, mat.NewDense(1, 1, []float64{-3}), []float64{1}, silent)
+	assert.Equal(t, ErrInfeasible, errors.Cause(err))
+	_, _, _, err = SimplexRanged(c, A, b, []float64{1, 2}, silent)
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+}
+
+func TestRangedReoptimize(t *testing.T) {
+	lp, err := GenerateLP(12, 8, 0.5, 5)
+	require.NoError(t, err)
+	ranges := []float64{math.Inf(1), 2, math.Inf(1), 4, 1, math.Inf(1), 3, math.Inf(1)}
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(mat.DenseCopyOf(lp.C), mat.DenseCopyOf(lp.A), lp.B))
+	cf.configure(newOptions([]Option{silent}))
+	upper := make([]float64, 20)
+	for j := range upper {
+		upper[j] = math.Inf(1)
+		if j >= 12 {
+			upper[j] = ranges[j-12]
+		}
+	}
+	cf.setUpper(upper)
+	_, _, _, err = cf.run(newOptions([]Option{silent}))
+	require.NoError(t, err)
+
+	// Cut the optimum with Σ x_j <= 0.95 of its sum, the dual iterations restore the feasibility
+	values, _ := cf.values()
+	a := make([]float64, 20)
+	sum := 0.0
+	for j := 0; j < 12; j++ {
+		a[j] = 1
+		sum += values[j]
+	}
+	require.NoError(t, cf.AddConstraint(a, 0.95*sum))
+	_, err = cf.Reoptimize(0)
+	require.NoError(t, err)
+	_, score := cf.GetResults()
+
+	expandedA, expandedB := expandRanges(lp.A, lp.B, ranges)
+	rows, _ := expandedA.Dims()
+	cut := mat.NewDense(rows+1, 12, nil)
+	cut.Slice(0, rows, 0, 12).(*mat.Dense).Copy(expandedA)
+	cutB := mat.NewDense(rows+1, 1, nil)
+	cutB.Slice(0, rows, 0, 1).(*mat.Dense).Copy(expandedB)
+	for j := 0; j < 12; j++ {
+		cut.Set(rows, j, 1)
+	}
+	cutB.Set(rows, 0, 0.95*sum)
+	_, _, expected, err := Simplex(lp.C, cut, cutB, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, expected, score, 1e-6)
+}
+
+func TestModelAddRange(t *testing.T) {
+	m := &Model{}
+	x := m.AddVariable("x", false)
+	y := m.AddVariable("y", false)
+	m.Maximize(Expr{Terms: []Term{{x, 1}, {y, 1}}})
+	require.NoError(t, m.AddRange("total", Expr{Terms: []Term{{x, 1}, {y, 1}}, Constant: 1}, 3, 5))
+	require.NoError(t, m.AddNamedRow("x", x.Expr(), LessEq, 3))
+	require.NoError(t, m.AddRange("y", y.Expr(), math.Inf(-1), 1))
+	assert.Error(t, m.AddRange("empty", y.Expr(), 2, 1))
+
+	_, A, b, ranges, _ := m.Ranged()
+	rows, _ := A.Dims()
+	assert.Equal(t, 3, rows)
+	assert.Equal(t, []float64{2, math.Inf(1), math.Inf(1)}, ranges)
+	assert.Equal(t, 4.0, b.At(0, 0))
+	_, A, b, _ = m.Standard()
+	rows, _ = A.Dims()
+	assert.Equal(t, 4, rows)
+	assert.Equal(t, []float64{-2, 4, 3, 1}, mat.Col(nil, 0, b))
+	assert.Equal(t, []string{"total.ge", "total.le", "x", "y"}, m.RowNames())
+
+	solution, err := m.Solve(100, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, 4, solution.Score, 1e-9)
+	assert.InDelta(t, 3, solution.Value(x), 1e-9)
+	assert.InDelta(t, 1, solution.Value(y), 1e-9)
+}
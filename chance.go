@@ -0,0 +1,204 @@
+package goptimization
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// chanceRounds Maximum number of rounds of tangent cuts of ChanceCuts
+const chanceRounds = 100
+
+// ChanceConstraint Individual chance constraint P(Σ a_j*x_j <= RHS) >= 1 - Epsilon where the coefficients a
+// follow a normal distribution of mean Mean and covariance Covariance. It is the second-order cone constraint
+// Σ μ_j*x_j + z*sqrt(x^T*Σ*x) <= RHS with z the 1-Epsilon quantile of the standard normal distribution.
+type ChanceConstraint struct {
+	Mean       []float64
+	Covariance *mat.SymDense
+	RHS        float64
+	// Epsilon Probability of violation in (0, 0.5], the cone is then convex
+	Epsilon float64
+}
+
+// ChanceApproximation Form of the cone of the chance constraints in ChanceConstrained
+type ChanceApproximation int
+
+const (
+	// ChanceCuts Outer approximation by the tangent planes of the cone, added as cuts at the solution of the previous
+	// round and re-optimized with the dual simplex, until the cone holds: the solution is the one of the cone
+	ChanceCuts ChanceApproximation = iota
+	// ChanceSafe Inner approximation by a single row: sqrt(x^T*Σ*x) <= Σ σ_j*x_j for x >= 0, with σ_j the
+	// standard deviation of a_j, so the row Σ (μ_j + z*σ_j)*x_j <= RHS is conservative
+	ChanceSafe
+	// ChanceCone No approximation, the cones are solved by SecondOrderCone with Σ = Q*Λ*Q^T:
+	// ||z*Λ^1/2*Q^T*x|| <= RHS - Σ μ_j*x_j. The solution is then in the interior of the optimal face.
+	ChanceCone
+)
+
+// ChanceConstrained Solve the linear problem of Simplex with the chance constraints in addition to A*x <= b,
+// with the cone of each chance constraint, or its linear approximation, chosen by approximation.
+// The results follow the layout of Simplex with the rows of A and then the chance constraints: the n values of x,
+// the m slacks of A*x <= b and the slack RHS - Σ μ_j*x_j - z*sqrt(x^T*Σ*x) of each chance constraint, without the cuts.
+// ChanceCuts returns the last solution with ErrIterationLimit when the cone still does not hold after chanceRounds rounds.
+// The options and the other errors are those of Simplex, and of SecondOrderCone for ChanceCone.
+func ChanceConstrained(c mat.Matrix, A *mat.Dense, b mat.Matrix, chance []ChanceConstraint, approximation ChanceApproximation, opts ...Option) (int, *mat.Dense, float64, error) {
+	o := newOptions(opts)
+	row, err := asRow(c, "c")
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	column, err := asColumn(b, "b")
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	err = checkDims(row, A, column)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	m, n := A.Dims()
+	quantiles := make([]float64, len(chance))
+	for k, cc := range chance {
+		if len(cc.Mean) != n {
+			return 0, nil, 0, newError(ErrDimensionMismatch, "chance constraint %d: len(Mean) must be %d, got %d", k, n, len(cc.Mean))
+		}
+		if cc.Covariance != nil && cc.Covariance.Symmetric() != n {
+			return 0, nil, 0, newError(ErrDimensionMismatch, "chance constraint %d: Covariance must be (%d,%d)", k, n, n)
+		}
+		if !(cc.Epsilon > 0 && cc.Epsilon <= 0.5) {
+			return 0, nil, 0, errors.Errorf("chance constraint %d: Epsilon must be in (0, 0.5], got %v", k, cc.Epsilon)
+		}
+		quantiles[k] = distuv.UnitNormal.Quantile(1 - cc.Epsilon)
+	}
+	if approximation == ChanceCone {
+		cones, err := chanceCones(chance, quantiles)
+		if err != nil {
+			return 0, nil, 0, err
+		}
+		return SecondOrderCone(row, A, column, cones, opts...)
+	}
+
+	// The rows of A, then a row per chance constraint: μ*x <= RHS for the cuts, μ + z*σ for the safe row
+	chanceA := mat.NewDense(m+len(chance), n, nil)
+	chanceA.Slice(0, m, 0, n).(*mat.Dense).Copy(A)
+	chanceB := mat.NewDense(m+len(chance), 1, nil)
+	chanceB.Slice(0, m, 0, 1).(*mat.Dense).Copy(column)
+	for k, cc := range chance {
+		for j, mu := range cc.Mean {
+			if approximation == ChanceSafe && cc.Covariance != nil {
+				mu += quantiles[k] * math.Sqrt(math.Max(cc.Covariance.At(j, j), 0))
+			}
+			chanceA.Set(m+k, j, mu)
+		}
+		chanceB.Set(m+k, 0, cc.RHS)
+	}
+	cf := CanonicalForm{}
+	err = cf.New(row, chanceA, chanceB)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	cf.configure(o)
+	totalIter, _, _, err := cf.run(o)
+	if err != nil {
+		return totalIter, nil, 0, err
+	}
+
+	converged := approximation == ChanceSafe
+	for round := 0; !converged && round < chanceRounds; round++ {
+		x := cf.Solution().X
+		converged = true
+		for k, cc := range chance {
+			spread := chanceSpread(cc, x)
+			if spread <= 0 || chanceSlack(cc, quantiles[k], x) >= -feasibilityTolerance*(1+math.Abs(cc.RHS)) {
+				continue
+			}
+			// Tangent plane of μ*x + z*sqrt(x^T*Σ*x) at x, the gradient is μ + z*Σ*x/sqrt(x^T*Σ*x)
+			converged = false
+			var sx mat.VecDense
+			sx.MulVec(cc.Covariance, mat.NewVecDense(n, x))
+			cut := make([]float64, cf.n+cf.m)
+			for j := 0; j < n; j++ {
+				cut[j] = cc.Mean[j] + quantiles[k]*sx.AtVec(j)/spread
+			}
+			err = cf.AddConstraint(cut, cc.RHS)
+			if err != nil {
+				return totalIter, nil, 0, err
+			}
+		}
+		if converged {
+			break
+		}
+		iter, err := cf.Reoptimize(o.maxIter)
+		totalIter += iter
+		if err != nil {
+			return totalIter, nil, 0, err
+		}
+	}
+
+	solution := cf.Solution()
+	results := mat.NewDense(n+m+len(chance), 1, nil)
+	for j, v := range solution.X {
+		results.Set(j, 0, v)
+	}
+	for i := 0; i < m; i++ {
+		results.Set(n+i, 0, solution.Slacks[i])
+	}
+	for k, cc := range chance {
+		results.Set(n+m+k, 0, chanceSlack(cc, quantiles[k], solution.X))
+	}
+	if !converged {
+		return totalIter, results, solution.Score, ErrIterationLimit
+	}
+	return totalIter, results, solution.Score, nil
+}
+
+// chanceSpread Standard deviation sqrt(x^T*Σ*x) of Σ a_j*x_j, 0 without covariance
+func chanceSpread(cc ChanceConstraint, x []float64) float64 {
+	if cc.Covariance == nil {
+		return 0
+	}
+	v := mat.NewVecDense(len(x), x)
+	return math.Sqrt(math.Max(mat.Inner(v, cc.Covariance, v), 0))
+}
+
+// chanceSlack Slack RHS - Σ μ_j*x_j - z*sqrt(x^T*Σ*x) of the chance constraint with the quantile z
+func chanceSlack(cc ChanceConstraint, quantile float64, x []float64) float64 {
+	slack := cc.RHS - quantile*chanceSpread(cc, x)
+	for j, mu := range cc.Mean {
+		slack -= mu * x[j]
+	}
+	return slack
+}
+
+// chanceCones Second-order cone of each chance constraint with the quantile z, see ChanceCone
+func chanceCones(chance []ChanceConstraint, quantiles []float64) ([]Cone, error) {
+	cones := make([]Cone, len(chance))
+	for k, cc := range chance {
+		h := make([]float64, len(cc.Mean))
+		for j, mu := range cc.Mean {
+			h[j] = -mu
+		}
+		cones[k] = Cone{H: h, D: cc.RHS}
+		if cc.Covariance == nil {
+			continue
+		}
+		var eigen mat.EigenSym
+		if !eigen.Factorize(cc.Covariance, true) {
+			return nil, errors.Errorf("chance constraint %d: the eigen decomposition of Covariance failed", k)
+		}
+		values := eigen.Values(nil)
+		var vectors mat.Dense
+		eigen.VectorsTo(&vectors)
+		F := mat.NewDense(len(values), len(h), nil)
+		for i, value := range values {
+			scale := quantiles[k] * math.Sqrt(math.Max(value, 0))
+			for j := range h {
+				F.Set(i, j, scale*vectors.At(j, i))
+			}
+		}
+		cones[k].F = F
+		cones[k].G = make([]float64, len(values))
+	}
+	return cones, nil
+}
@@ -0,0 +1,84 @@
+package goptimization
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+func TestChanceConstrained(t *testing.T) {
+	for seed := int64(1); seed <= 5; seed++ {
+		lp, err := GenerateLP(8, 6, 0.5, seed)
+		require.NoError(t, err)
+		// Coefficients of mean 1 with a correlated covariance
+		mean := make([]float64, 8)
+		covariance := mat.NewSymDense(8, nil)
+		for j := range mean {
+			mean[j] = 1
+			for k := j; k < 8; k++ {
+				covariance.SetSym(j, k, 0.05/float64(1+k-j))
+			}
+		}
+		chance := []ChanceConstraint{{Mean: mean, Covariance: covariance, RHS: 5, Epsilon: 0.05}}
+
+		_, cuts, cutsScore, err := ChanceConstrained(lp.C, lp.A, lp.B, chance, ChanceCuts, silent)
+		require.NoError(t, err, "seed %d", seed)
+		_, safe, safeScore, err := ChanceConstrained(lp.C, lp.A, lp.B, chance, ChanceSafe, silent)
+		require.NoError(t, err, "seed %d", seed)
+		_, cone, coneScore, err := ChanceConstrained(lp.C, lp.A, lp.B, chance, ChanceCone, silent)
+		require.NoError(t, err, "seed %d", seed)
+		assert.InDelta(t, cutsScore, coneScore, 1e-5*(1+math.Abs(cutsScore)), "seed %d", seed)
+		_, _, linear, err := Simplex(lp.C, mat.DenseCopyOf(lp.A), lp.B, silent)
+		require.NoError(t, err)
+
+		rows, _ := cuts.Dims()
+		assert.Equal(t, 8+6+1, rows)
+		z := distuv.UnitNormal.Quantile(0.95)
+		for _, results := range []*mat.Dense{cuts, safe, cone} {
+			x := mat.Col(nil, 0, results)[:8]
+			assert.True(t, feasible(lp.A, lp.B, x), "seed %d", seed)
+			v := mat.NewVecDense(8, x)
+			slack := 5 - floats.Dot(mean, x) - z*math.Sqrt(mat.Inner(v, covariance, v))
+			assert.InDelta(t, slack, results.At(14, 0), 1e-9)
+			assert.True(t, slack >= -1e-5, "seed %d: slack %v", seed, slack)
+		}
+		// The safe row is an inner approximation and the chance constraint restricts the linear problem
+		assert.True(t, safeScore <= cutsScore+1e-6, "seed %d", seed)
+		assert.True(t, cutsScore <= linear+1e-6, "seed %d", seed)
+	}
+}
+
+func TestChanceConstrainedDeterministic(t *testing.T) {
+	// Without covariance the chance constraint is the row x1 + x2 <= 3
+	c := mat.NewDense(1, 2, []float64{1, 2})
+	A := mat.NewDense(1, 2, []float64{1, 0})
+	b := mat.NewDense(1, 1, []float64{2})
+	chance := []ChanceConstraint{{Mean: []float64{1, 1}, RHS: 3, Epsilon: 0.1}}
+	for _, approximation := range []ChanceApproximation{ChanceCuts, ChanceSafe, ChanceCone} {
+		_, results, score, err := ChanceConstrained(c, A, b, chance, approximation, silent)
+		require.NoError(t, err)
+		assert.InDelta(t, 6, score, 1e-6)
+		assert.InDeltaSlice(t, []float64{0, 3, 2, 0}, mat.Col(nil, 0, results), 1e-6)
+	}
+}
+
+func TestChanceConstrainedErrors(t *testing.T) {
+	c := mat.NewDense(1, 2, []float64{1, 1})
+	A := mat.NewDense(1, 2, []float64{1, 1})
+	b := mat.NewDense(1, 1, []float64{4})
+
+	_, _, _, err := ChanceConstrained(c, A, b, []ChanceConstraint{{Mean: []float64{1}, RHS: 1, Epsilon: 0.1}}, ChanceCuts, silent)
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+	_, _, _, err = ChanceConstrained(c, A, b, []ChanceConstraint{{Mean: []float64{1, 1}, Covariance: mat.NewSymDense(3, nil), RHS: 1, Epsilon: 0.1}}, ChanceCuts, silent)
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+	for _, eps := range []float64{0, 0.6, -1} {
+		_, _, _, err = ChanceConstrained(c, A, b, []ChanceConstraint{{Mean: []float64{1, 1}, RHS: 1, Epsilon: eps}}, ChanceCuts, silent)
+		assert.Error(t, err)
+	}
+}
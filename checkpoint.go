@@ -0,0 +1,351 @@
+package goptimization
+
+import (
+	"encoding/gob"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// checkpointVersion Version of the format of the checkpoints, a checkpoint of another version is refused
+const checkpointVersion = 1
+
+// dictionaryState Exported copy of a CanonicalForm for gob, the views on the basic and nonbasic variables
+// are rebuilt by slice. The pivot gate and the logger are not saved.
+type dictionaryState struct {
+	N, M               int
+	A, X, C, B, XBStar *mat.Dense
+	Remap              []int
+	Slack              []bool
+	Upper              []float64
+	Reflected          []bool
+	Offset             float64
+	Degenerate         int
+	RecordHistory      bool
+	History            []Pivot
+	CheckInvariants    bool
+	Tolerance          float64
+	Rule               PivotRule
+	Pricing            PricingRule
+	BasisUpdate        BasisUpdate
+	PriceStart         int
+	Candidates         []int
+	SinceCondition     int
+	Refactorizations   int
+	Repairs            int
+	Repaired           bool
+}
+
+// state Copy of the dictionary
+func (cf *CanonicalForm) state() dictionaryState {
+	return dictionaryState{
+		N:                cf.n,
+		M:                cf.m,
+		A:                cf.A,
+		X:                cf.x,
+		C:                cf.c,
+		B:                cf.b,
+		XBStar:           cf.xBStar,
+		Remap:            cf.remap,
+		Slack:            cf.slack,
+		Upper:            cf.upper,
+		Reflected:        cf.reflected,
+		Offset:           cf.offset,
+		Degenerate:       cf.degenerate,
+		RecordHistory:    cf.recordHistory,
+		History:          cf.history,
+		CheckInvariants:  cf.checkInvariants,
+		Tolerance:        cf.tolerance,
+		Rule:             cf.rule,
+		Pricing:          cf.pricing,
+		BasisUpdate:      cf.basisUpdate,
+		PriceStart:       cf.priceStart,
+		Candidates:       cf.candidates,
+		SinceCondition:   cf.sinceCondition,
+		Refactorizations: cf.refactorizations,
+		Repairs:          cf.repairs,
+		Repaired:         cf.repaired,
+	}
+}
+
+// restore Replace the dictionary by the state, the trace is written to the standard output
+func (cf *CanonicalForm) restore(s dictionaryState) error {
+	if s.A == nil || s.X == nil || s.C == nil || s.B == nil || s.XBStar == nil {
+		return errors.New("the checkpoint has no dictionary")
+	}
+	if r, c := s.A.Dims(); r != s.M || c != s.N+s.M || len(s.Remap) != s.N+s.M || len(s.Slack) != s.N+s.M ||
+		len(s.Upper) != len(s.Reflected) || (s.Upper != nil && len(s.Upper) != s.N+s.M) {
+		return newError(ErrDimensionMismatch, "the dictionary of the checkpoint has inconsistent dimensions")
+	}
+	*cf = CanonicalForm{
+		n:                s.N,
+		m:                s.M,
+		A:                s.A,
+		x:                s.X,
+		c:                s.C,
+		b:                s.B,
+		xBStar:           s.XBStar,
+		remap:            s.Remap,
+		slack:            s.Slack,
+		upper:            s.Upper,
+		reflected:        s.Reflected,
+		offset:           s.Offset,
+		degenerate:       s.Degenerate,
+		recordHistory:    s.RecordHistory,
+		history:          s.History,
+		checkInvariants:  s.CheckInvariants,
+		tolerance:        s.Tolerance,
+		rule:             s.Rule,
+		logger:           stdoutLogger{},
+		pricing:          s.Pricing,
+		basisUpdate:      s.BasisUpdate,
+		priceStart:       s.PriceStart,
+		candidates:       s.Candidates,
+		sinceCondition:   s.SinceCondition,
+		refactorizations: s.Refactorizations,
+		repairs:          s.Repairs,
+		repaired:         s.Repaired,
+	}
+	cf.slice()
+	return nil
+}
+
+// dictionaryCheckpoint Checkpoint of a CanonicalForm
+type dictionaryCheckpoint struct {
+	Version    int
+	Dictionary dictionaryState
+}
+
+// WriteCheckpoint Write the current dictionary, its basis and its configuration, so that ReadCheckpoint resumes
+// the iterations, for example with Reoptimize, in another process. The pivot gate and the logger are not saved.
+func (cf *CanonicalForm) WriteCheckpoint(w io.Writer) error {
+	return gob.NewEncoder(w).Encode(dictionaryCheckpoint{Version: checkpointVersion, Dictionary: cf.state()})
+}
+
+// ReadCheckpoint Replace the dictionary by the one written by WriteCheckpoint, the trace goes to the standard output
+func (cf *CanonicalForm) ReadCheckpoint(r io.Reader) error {
+	checkpoint := dictionaryCheckpoint{}
+	err := gob.NewDecoder(r).Decode(&checkpoint)
+	if err != nil {
+		return errors.Wrap(err, "read checkpoint")
+	}
+	if checkpoint.Version != checkpointVersion {
+		return errors.Errorf("checkpoint version %d, expected %d", checkpoint.Version, checkpointVersion)
+	}
+	return cf.restore(checkpoint.Dictionary)
+}
+
+// nodeState Exported copy of a node
+type nodeState struct {
+	Dictionary dictionaryState
+	Integer    []bool
+	Depth      int
+	Bound      float64
+}
+
+// semiContinuousState Exported copy of a semi-continuous variable
+type semiContinuousState struct {
+	J    int
+	L, U float64
+}
+
+// searchCheckpoint Problem, configuration and open nodes of a BranchAndBound
+type searchCheckpoint struct {
+	Version int
+	// Original problem and the conflicts found by the probing
+	C, A, B   *mat.Dense
+	Integer   []bool
+	Conflicts map[int]map[int]bool
+	Semi      []semiContinuousState
+	Sets      []SOS
+
+	MaxIter            int
+	CutRounds          int
+	MaxCuts            int
+	HeuristicFrequency int
+	Gap                float64
+	MaxBytes           uint64
+	Threads            int
+	Probing            bool
+
+	Root    nodeState
+	Open    []nodeState
+	Started bool
+
+	BestBound    float64
+	Fixed        int
+	Implications int
+	Nodes        int
+	Cuts         int
+	Incumbents   []Incumbent
+	Incumbent    []float64
+	Score        float64
+}
+
+// WriteCheckpoint Write the problem, the configuration and the open nodes of the search, so that ReadCheckpoint
+// and Resume continue it after a crash or on another machine. It is called between two calls to Solve or Resume,
+// for example after a limit stopped the search, use CheckpointFile to write checkpoints while the search runs.
+// The separators, the lazy constraints, the heuristics, the callbacks and the limits are not saved.
+func (bb *BranchAndBound) WriteCheckpoint(w io.Writer) error {
+	if bb.root == nil {
+		return errors.New("branch and bound is not initialized")
+	}
+	checkpoint := searchCheckpoint{
+		Version:            checkpointVersion,
+		C:                  bb.c,
+		A:                  bb.A,
+		B:                  bb.b,
+		Integer:            bb.integer,
+		Conflicts:          bb.conflicts.edges,
+		Sets:               bb.sets,
+		MaxIter:            bb.MaxIter,
+		CutRounds:          bb.CutRounds,
+		MaxCuts:            bb.MaxCuts,
+		HeuristicFrequency: bb.HeuristicFrequency,
+		Gap:                bb.Gap,
+		MaxBytes:           bb.MaxBytes,
+		Threads:            bb.Threads,
+		Probing:            bb.Probing,
+		Root:               bb.root.state(),
+		Started:            bb.started,
+		BestBound:          bb.BestBound,
+		Fixed:              bb.Fixed,
+		Implications:       bb.Implications,
+		Nodes:              bb.Nodes,
+		Cuts:               bb.Cuts,
+		Incumbents:         bb.Incumbents,
+		Incumbent:          bb.incumbent,
+		Score:              bb.score,
+	}
+	for _, semi := range bb.semi {
+		checkpoint.Semi = append(checkpoint.Semi, semiContinuousState{J: semi.j, L: semi.l, U: semi.u})
+	}
+	for _, nd := range bb.open {
+		checkpoint.Open = append(checkpoint.Open, nd.state())
+	}
+	return gob.NewEncoder(w).Encode(checkpoint)
+}
+
+// ReadCheckpoint Initialize the search from a checkpoint written by WriteCheckpoint, it replaces New.
+// The separators and the heuristics are the defaults of New
+// and the other fields which are not saved must be set again before Resume.
+func (bb *BranchAndBound) ReadCheckpoint(r io.Reader) error {
+	checkpoint := searchCheckpoint{}
+	err := gob.NewDecoder(r).Decode(&checkpoint)
+	if err != nil {
+		return errors.Wrap(err, "read checkpoint")
+	}
+	if checkpoint.Version != checkpointVersion {
+		return errors.Errorf("checkpoint version %d, expected %d", checkpoint.Version, checkpointVersion)
+	}
+	if checkpoint.C == nil || checkpoint.A == nil || checkpoint.B == nil {
+		return errors.New("the checkpoint has no problem")
+	}
+	err = bb.New(checkpoint.C, checkpoint.A, checkpoint.B, checkpoint.Integer)
+	if err != nil {
+		return err
+	}
+	root, err := checkpoint.Root.node()
+	if err != nil {
+		return err
+	}
+	open := make([]*node, len(checkpoint.Open))
+	for k, s := range checkpoint.Open {
+		open[k], err = s.node()
+		if err != nil {
+			return err
+		}
+	}
+	if checkpoint.Conflicts != nil {
+		bb.conflicts.edges = checkpoint.Conflicts
+	}
+	for _, semi := range checkpoint.Semi {
+		bb.semi = append(bb.semi, semiContinuous{j: semi.J, l: semi.L, u: semi.U})
+	}
+	bb.sets = checkpoint.Sets
+	bb.MaxIter = checkpoint.MaxIter
+	bb.CutRounds = checkpoint.CutRounds
+	bb.MaxCuts = checkpoint.MaxCuts
+	bb.HeuristicFrequency = checkpoint.HeuristicFrequency
+	bb.Gap = checkpoint.Gap
+	bb.MaxBytes = checkpoint.MaxBytes
+	bb.Threads = checkpoint.Threads
+	bb.Probing = checkpoint.Probing
+	bb.root = root
+	bb.open = open
+	bb.started = checkpoint.Started
+	bb.BestBound = checkpoint.BestBound
+	bb.Fixed = checkpoint.Fixed
+	bb.Implications = checkpoint.Implications
+	bb.Nodes = checkpoint.Nodes
+	bb.checkpointed = checkpoint.Nodes
+	bb.Cuts = checkpoint.Cuts
+	bb.Incumbents = checkpoint.Incumbents
+	bb.incumbent = checkpoint.Incumbent
+	bb.score = checkpoint.Score
+	return nil
+}
+
+// Resume Continue the search from the open nodes left by Solve, Resume or ReadCheckpoint,
+// or run Solve if the root is not solved yet. maxNodes includes the nodes explored before.
+func (bb *BranchAndBound) Resume(maxNodes int) (*mat.Dense, float64, error) {
+	if !bb.started {
+		return bb.Solve(maxNodes)
+	}
+	bb.Stopped = ""
+	return bb.search(bb.open, maxNodes)
+}
+
+// state Copy of the node
+func (nd *node) state() nodeState {
+	return nodeState{Dictionary: nd.cf.state(), Integer: nd.integer, Depth: nd.depth, Bound: nd.bound}
+}
+
+// node Node of the state
+func (s nodeState) node() (*node, error) {
+	cf := &CanonicalForm{}
+	err := cf.restore(s.Dictionary)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.Integer) != cf.n+cf.m {
+		return nil, newError(ErrDimensionMismatch, "the node of the checkpoint has %d integrality flags for %d variables", len(s.Integer), cf.n+cf.m)
+	}
+	return &node{cf: cf, integer: s.Integer, depth: s.Depth, bound: s.Bound}, nil
+}
+
+// periodicCheckpoint Write a checkpoint of the open nodes if CheckpointFrequency nodes were explored since the last one
+func (bb *BranchAndBound) periodicCheckpoint(open []*node) error {
+	if bb.CheckpointFile == "" || bb.Nodes-bb.checkpointed < bb.CheckpointFrequency {
+		return nil
+	}
+	bb.open = open
+	return bb.checkpoint()
+}
+
+// checkpoint Write the checkpoint to a temporary file renamed to CheckpointFile,
+// so that a crash while writing keeps the previous checkpoint
+func (bb *BranchAndBound) checkpoint() error {
+	tmp := bb.CheckpointFile + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return errors.Wrap(err, "checkpoint")
+	}
+	err = bb.WriteCheckpoint(f)
+	if err != nil {
+		f.Close()
+		return errors.Wrap(err, "checkpoint")
+	}
+	err = f.Close()
+	if err != nil {
+		return errors.Wrap(err, "checkpoint")
+	}
+	err = os.Rename(tmp, bb.CheckpointFile)
+	if err != nil {
+		return errors.Wrap(err, "checkpoint")
+	}
+	bb.checkpointed = bb.Nodes
+	return nil
+}
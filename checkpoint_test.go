@@ -0,0 +1,133 @@
+package goptimization
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestCanonicalFormCheckpoint(t *testing.T) {
+	c, A, b, _ := knapsack(12, 7)
+	cf := &CanonicalForm{}
+	require.NoError(t, cf.New(c, A, b))
+	cf.logger = log.New(ioutil.Discard, "", 0)
+	_, err := cf.Reoptimize(3)
+	assert.Error(t, err)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, cf.WriteCheckpoint(buf))
+	restored := &CanonicalForm{}
+	require.NoError(t, restored.ReadCheckpoint(buf))
+	restored.logger = cf.logger
+	assert.Equal(t, cf.remap, restored.remap)
+	assert.True(t, mat.Equal(cf.xBStar, restored.xBStar))
+
+	expected, err := cf.Reoptimize(1000)
+	require.NoError(t, err)
+	iterations, err := restored.Reoptimize(1000)
+	require.NoError(t, err)
+	assert.Equal(t, expected, iterations)
+	results, score := cf.GetResults()
+	restoredResults, restoredScore := restored.GetResults()
+	assert.Equal(t, score, restoredScore)
+	assert.True(t, mat.Equal(results, restoredResults))
+
+	assert.Error(t, restored.ReadCheckpoint(bytes.NewReader([]byte("not a checkpoint"))))
+}
+
+func TestBranchAndBoundCheckpoint(t *testing.T) {
+	c, A, b, integer := knapsack(12, 7)
+	newSearch := func() *BranchAndBound {
+		bb := &BranchAndBound{}
+		require.NoError(t, bb.New(c, A, b, integer))
+		bb.root.cf.logger = log.New(ioutil.Discard, "", 0)
+		bb.CutRounds = 0
+		bb.Heuristics = nil
+		return bb
+	}
+	complete := newSearch()
+	expected, score, err := complete.Solve(10000)
+	require.NoError(t, err)
+	require.Greater(t, complete.Nodes, 10)
+
+	// The search stopped after 10 nodes continues in another BranchAndBound as if it had not stopped
+	stopped := newSearch()
+	_, _, _ = stopped.Solve(10)
+	assert.Equal(t, "nodes", stopped.Stopped)
+	buf := &bytes.Buffer{}
+	require.NoError(t, stopped.WriteCheckpoint(buf))
+
+	resumed := &BranchAndBound{}
+	require.NoError(t, resumed.ReadCheckpoint(buf))
+	assert.Equal(t, 10, resumed.Nodes)
+	assert.Equal(t, 0, resumed.CutRounds)
+	resumed.Heuristics = nil
+	results, resumedScore, err := resumed.Resume(10000)
+	require.NoError(t, err)
+	assert.Equal(t, "", resumed.Stopped)
+	assert.InDelta(t, score, resumedScore, 0.000001)
+	assert.True(t, mat.EqualApprox(expected, results, 0.000001))
+	assert.Equal(t, complete.Nodes, resumed.Nodes)
+	assert.Equal(t, complete.Incumbents, resumed.Incumbents)
+
+	// The stopped search also resumes in place
+	results, _, err = stopped.Resume(10000)
+	require.NoError(t, err)
+	assert.True(t, mat.EqualApprox(expected, results, 0.000001))
+	assert.Equal(t, complete.Nodes, stopped.Nodes)
+
+	// Before Solve the checkpoint holds the problem only
+	fresh := newSearch()
+	buf.Reset()
+	require.NoError(t, fresh.WriteCheckpoint(buf))
+	resumed = &BranchAndBound{}
+	require.NoError(t, resumed.ReadCheckpoint(buf))
+	resumed.CutRounds = 0
+	resumed.Heuristics = nil
+	_, resumedScore, err = resumed.Resume(10000)
+	require.NoError(t, err)
+	assert.InDelta(t, score, resumedScore, 0.000001)
+
+	assert.Error(t, (&BranchAndBound{}).WriteCheckpoint(buf))
+	assert.Error(t, resumed.ReadCheckpoint(bytes.NewReader([]byte("not a checkpoint"))))
+}
+
+func TestCheckpointFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checkpoint")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "search.gob")
+
+	c, A, b, integer := knapsack(12, 7)
+	bb := &BranchAndBound{}
+	require.NoError(t, bb.New(c, A, b, integer))
+	bb.root.cf.logger = log.New(ioutil.Discard, "", 0)
+	bb.CutRounds = 0
+	bb.Heuristics = nil
+	bb.CheckpointFile = path
+	bb.CheckpointFrequency = 4
+	_, _, _ = bb.Solve(6)
+	assert.Equal(t, "nodes", bb.Stopped)
+	_, err = os.Stat(path + ".tmp")
+	assert.True(t, os.IsNotExist(err))
+
+	// The checkpoint written when the limit stopped the search
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	resumed := &BranchAndBound{}
+	require.NoError(t, resumed.ReadCheckpoint(f))
+	assert.Equal(t, 6, resumed.Nodes)
+	assert.Equal(t, len(bb.open), len(resumed.open))
+
+	bb.CheckpointFile = filepath.Join(dir, "missing", "search.gob")
+	_, _, err = bb.Resume(8)
+	assert.Error(t, err)
+}
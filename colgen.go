@@ -0,0 +1,131 @@
+package goptimization
+
+import (
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Column Variable of the master problem, with its coefficient in each constraint and its cost
+type Column struct {
+	A    []float64
+	Cost float64
+}
+
+// Pricing Find columns with a positive reduced cost Cost - Σ y_i*A_i from the duals y of the master constraints.
+// The master is a maximization: for a minimization, negate the costs, a positive reduced cost is then
+// a negative reduced cost of the original problem. Returning no column ends the column generation.
+type Pricing func(y []float64) ([]Column, error)
+
+// ColumnGeneration Restricted master problem solved with columns generated on demand
+// Maximize z = Σ(columns) Cost_j*x_j
+// Constraints:
+// 1<=i<=m,  Σ(columns) A_i_j*x_j <= b_i
+// The master starts from the columns of the problem and is re-optimized from its current basis
+// after each round of pricing.
+type ColumnGeneration struct {
+	// Number of original columns and constraints
+	n int
+	m int
+
+	master *CanonicalForm
+
+	// MaxIter Maximum number of simplex iterations to re-optimize the master
+	MaxIter int
+	// Columns Columns added by the pricing, in the order they were added
+	Columns []Column
+	// Rounds Number of pricing rounds
+	Rounds int
+	// Converged is true when the pricing did not find any improving column
+	Converged bool
+}
+
+// New Initialize the master problem with the original columns, in the standard form of Simplex.
+// The options configure the dictionary of the master, e.g. WithTolerance or WithLogger.
+func (cg *ColumnGeneration) New(c, A, b *mat.Dense, opts ...Option) error {
+	master := &CanonicalForm{}
+	err := master.New(mat.DenseCopyOf(c), mat.DenseCopyOf(A), mat.DenseCopyOf(b))
+	if err != nil {
+		return err
+	}
+	master.configure(newOptions(opts))
+	cg.n = master.n
+	cg.m = master.m
+	cg.master = master
+	cg.MaxIter = 1000
+	cg.Columns = nil
+	cg.Rounds = 0
+	cg.Converged = false
+	return nil
+}
+
+// Solve Alternate between the master and the pricing until no improving column is found or after maxRounds rounds.
+// It returns a matrix (n+k+m,1): the values of the n original columns, of the k generated columns
+// and the leftover for each constraint, and the score of the master.
+func (cg *ColumnGeneration) Solve(pricing Pricing, maxRounds int) (*mat.Dense, float64, error) {
+	if cg.master == nil {
+		return nil, 0, errors.New("column generation is not initialized")
+	}
+	for ; cg.Rounds < maxRounds; cg.Rounds++ {
+		_, err := cg.master.Reoptimize(cg.MaxIter)
+		if err != nil {
+			return nil, 0, err
+		}
+		duals, err := cg.master.FindY()
+		if err != nil {
+			return nil, 0, err
+		}
+		y := make([]float64, cg.m)
+		for i := range y {
+			y[i] = duals.At(0, i)
+		}
+
+		columns, err := pricing(y)
+		if err != nil {
+			return nil, 0, err
+		}
+		added := 0
+		for _, column := range columns {
+			if len(column.A) != cg.m {
+				return nil, 0, newError(ErrDimensionMismatch, "len(column.A) != number of constraints")
+			}
+			reduced := column.Cost
+			for i, a := range column.A {
+				reduced -= y[i] * a
+			}
+			if reduced <= cg.master.tolerance {
+				continue
+			}
+			err = cg.master.AddColumn(column.A, column.Cost)
+			if err != nil {
+				return nil, 0, err
+			}
+			cg.Columns = append(cg.Columns, Column{A: append([]float64(nil), column.A...), Cost: column.Cost})
+			added++
+		}
+		if added == 0 {
+			cg.Converged = true
+			break
+		}
+	}
+	if !cg.Converged {
+		_, err := cg.master.Reoptimize(cg.MaxIter)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	// The generated columns have the indexes after the slack variables
+	values, score := cg.master.values()
+	k := len(cg.Columns)
+	results := mat.NewDense(cg.n+k+cg.m, 1, nil)
+	for j := 0; j < cg.n; j++ {
+		results.Set(j, 0, values[j])
+	}
+	for j := 0; j < k; j++ {
+		results.Set(cg.n+j, 0, values[cg.n+cg.m+j])
+	}
+	for i := 0; i < cg.m; i++ {
+		results.Set(cg.n+k+i, 0, values[cg.n+i])
+	}
+	return results, score, nil
+}
@@ -0,0 +1,117 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+// cuttingPatterns All the ways to cut pieces of the widths from a roll of the width
+func cuttingPatterns(width float64, widths []float64) [][]float64 {
+	patterns := [][]float64{}
+	var enumerate func(k int, left float64, pattern []float64)
+	enumerate = func(k int, left float64, pattern []float64) {
+		if k == len(widths) {
+			patterns = append(patterns, append([]float64(nil), pattern...))
+			return
+		}
+		for count := 0.0; count*widths[k] <= left; count++ {
+			pattern[k] = count
+			enumerate(k+1, left-count*widths[k], pattern)
+		}
+		pattern[k] = 0
+	}
+	enumerate(0, width, make([]float64, len(widths)))
+	return patterns[1:]
+}
+
+func TestColumnGeneration(t *testing.T) {
+	//Cut at most 3 rolls of width 10 into pieces of widths (3, 4, 5) with demands (4, 3, 2) and values (3, 4.5, 6)
+	widths := []float64{3, 4, 5}
+	demands := []float64{4, 3, 2}
+	values := []float64{3, 4.5, 6}
+	patterns := cuttingPatterns(10, widths)
+	column := func(pattern []float64) Column {
+		col := Column{A: append([]float64{1}, pattern...)}
+		for k, count := range pattern {
+			col.Cost += values[k] * count
+		}
+		return col
+	}
+	b := mat.NewDense(4, 1, append([]float64{3}, demands...))
+
+	//Full problem with all the patterns
+	full := mat.NewDense(4, len(patterns), nil)
+	fullC := mat.NewDense(1, len(patterns), nil)
+	for j, pattern := range patterns {
+		col := column(pattern)
+		fullC.Set(0, j, col.Cost)
+		for i, a := range col.A {
+			full.Set(i, j, a)
+		}
+	}
+	_, _, fullScore, err := Simplex(fullC, full, b, WithMaxIter(1000))
+	require.NoError(t, err)
+
+	//The master starts with one pattern per width and prices the best pattern by enumeration
+	initialA := mat.NewDense(4, 3, []float64{
+		1, 1, 1,
+		3, 0, 0,
+		0, 2, 0,
+		0, 0, 2,
+	})
+	initialC := mat.NewDense(1, 3, []float64{9, 9, 12})
+	cg := ColumnGeneration{}
+	require.NoError(t, cg.New(initialC, initialA, b))
+	results, score, err := cg.Solve(func(y []float64) ([]Column, error) {
+		best := Column{}
+		bestReduced := 0.0
+		for _, pattern := range patterns {
+			col := column(pattern)
+			reduced := col.Cost
+			for i, a := range col.A {
+				reduced -= y[i] * a
+			}
+			if reduced > bestReduced {
+				best = col
+				bestReduced = reduced
+			}
+		}
+		if best.A == nil {
+			return nil, nil
+		}
+		return []Column{best}, nil
+	}, 100)
+	require.NoError(t, err)
+	assert.True(t, cg.Converged)
+	assert.InEpsilon(t, fullScore, score, 0.000001)
+	assert.Less(t, len(cg.Columns), len(patterns))
+
+	//The solution satisfies the master constraints
+	k := len(cg.Columns)
+	rows, _ := results.Dims()
+	assert.Equal(t, 3+k+4, rows)
+	columns := []Column{column([]float64{3, 0, 0}), column([]float64{0, 2, 0}), column([]float64{0, 0, 2})}
+	columns = append(columns, cg.Columns...)
+	for i := 0; i < 4; i++ {
+		activity := 0.0
+		for j, col := range columns {
+			activity += col.A[i] * results.At(j, 0)
+		}
+		assert.InDelta(t, b.At(i, 0), activity+results.At(3+k+i, 0), 0.000001)
+	}
+}
+
+func TestColumnGenerationErrors(t *testing.T) {
+	cg := ColumnGeneration{}
+	_, _, err := cg.Solve(nil, 10)
+	assert.Error(t, err)
+
+	require.NoError(t, cg.New(mat.NewDense(1, 1, []float64{1}), mat.NewDense(1, 1, []float64{1}), mat.NewDense(1, 1, []float64{1})))
+	_, _, err = cg.Solve(func(y []float64) ([]Column, error) {
+		return []Column{{A: []float64{1, 1}, Cost: 5}}, nil
+	}, 10)
+	assert.Error(t, err)
+}
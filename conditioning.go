@@ -0,0 +1,170 @@
+package goptimization
+
+import (
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+const (
+	// conditionInterval Number of pivots between two estimates of the condition number of B
+	conditionInterval = 10
+	// conditionThreshold Condition number of B above which xBStar is recomputed from b instead of updated
+	conditionThreshold = 1e8
+	// repairThreshold Condition number of B above which the dependent basic columns are replaced by slack columns
+	repairThreshold = 1e12
+	// repairTolerance Relative norm under which a basic column is dependent on the previous ones
+	repairTolerance = 1e-7
+)
+
+// condition Estimate the condition number of B in the 1-norm, +Inf when B is singular
+func (cf *CanonicalForm) condition() float64 {
+	lu := &cf.workspace().cond
+	lu.Factorize(cf.B)
+	return lu.Cond()
+}
+
+// monitorCondition Estimate the condition number of B every conditionInterval pivots.
+// Above conditionThreshold the incremental updates of xBStar have lost accuracy, it is refactorized from b.
+// Above repairThreshold the basis is repaired first.
+func (cf *CanonicalForm) monitorCondition() error {
+	cf.sinceCondition++
+	if cf.sinceCondition < conditionInterval {
+		return nil
+	}
+	cf.sinceCondition = 0
+	cond := cf.condition()
+	if cond <= conditionThreshold {
+		return nil
+	}
+	if cond > repairThreshold {
+		cf.repair()
+	}
+	return cf.refactorize()
+}
+
+// refactorize Recompute xBStar by solving B*xBStar = b
+func (cf *CanonicalForm) refactorize() error {
+	var xBStar mat.Dense
+	err := xBStar.Solve(cf.B, cf.b)
+	if err != nil {
+		return newError(ErrSingularBasis, "%v", err)
+	}
+	cf.xBStar.Copy(&xBStar)
+	cf.refactorizations++
+	return nil
+}
+
+// repair Replace the basic columns that depend on the previous ones by nonbasic slack columns,
+// the slack columns of all the constraints span the space so the repaired basis is nonsingular.
+// The columns are orthogonalized in the order of the basis with the modified Gram-Schmidt process,
+// a column is dependent when less than repairTolerance of its norm is left.
+// The basic solution of the repaired basis can be infeasible, restoreFeasibility runs phaseOne from it.
+func (cf *CanonicalForm) repair() {
+	var kept []*mat.VecDense
+	independent := func(col mat.Vector) bool {
+		v := mat.VecDenseCopyOf(col)
+		norm := mat.Norm(v, 2)
+		if norm == 0 {
+			return false
+		}
+		for _, q := range kept {
+			v.AddScaledVec(v, -mat.Dot(q, v), q)
+		}
+		residual := mat.Norm(v, 2)
+		if residual <= repairTolerance*norm {
+			return false
+		}
+		v.ScaleVec(1/residual, v)
+		kept = append(kept, v)
+		return true
+	}
+
+	var dependent []int
+	for k := 0; k < cf.m; k++ {
+		if !independent(cf.B.ColView(k)) {
+			dependent = append(dependent, k)
+		}
+	}
+	if len(dependent) == 0 {
+		return
+	}
+	for j := 0; j < cf.n && len(dependent) > 0; j++ {
+		if !cf.slack[cf.remap[j]] || !independent(cf.AN.ColView(j)) {
+			continue
+		}
+		cf.swap(j, dependent[0])
+		dependent = dependent[1:]
+	}
+	cf.degenerate = 0
+	cf.repaired = true
+	cf.repairs++
+}
+
+// recoverSingular Repair the basis after a solve with B failed with err, the primal iteration then
+// returns without a pivot and the caller runs restoreFeasibility.
+// err is returned when it is not ErrSingularBasis or when no basic column is dependent.
+func (cf *CanonicalForm) recoverSingular(err error) error {
+	if errors.Cause(err) != ErrSingularBasis {
+		return err
+	}
+	repairs := cf.repairs
+	cf.repair()
+	if cf.repairs == repairs {
+		return err
+	}
+	return cf.refactorize()
+}
+
+// swap Exchange the nonbasic column at position j and the basic column at position k without a pivot
+func (cf *CanonicalForm) swap(j, k int) {
+	for i := 0; i < cf.m; i++ {
+		tmp := cf.B.At(i, k)
+		cf.B.Set(i, k, cf.AN.At(i, j))
+		cf.AN.Set(i, j, tmp)
+	}
+	tmp := cf.cB.At(0, k)
+	cf.cB.Set(0, k, cf.cN.At(0, j))
+	cf.cN.Set(0, j, tmp)
+	cf.remap[j], cf.remap[cf.n+k] = cf.remap[cf.n+k], cf.remap[j]
+	cf.invalidate()
+}
+
+// restoreFeasibility Run phaseOne after a repair made the basic solution infeasible,
+// with at most maxIter iterations. It returns the number of iterations.
+func (cf *CanonicalForm) restoreFeasibility(maxIter int) (int, error) {
+	if !cf.repaired {
+		return 0, nil
+	}
+	cf.repaired = false
+	if cf.primalFeasible() {
+		return 0, nil
+	}
+	if maxIter <= 0 {
+		return 0, ErrIterationLimit
+	}
+	return cf.phaseOne(maxIter)
+}
+
+// phaseOneColumn Column of the artificial variable of phaseOne, -B*1 so that B^-1 times it is -1
+// and x0 lifts every basic variable by its value, -1 in the slack basis.
+// With upper bounds, lifting the other basic variables could push them above their bound: the column is B*w instead,
+// with w_i = xB_i/|min| for the negative basic variables and 0 for the others, x0 enters at |min| and lifts them to 0.
+func (cf *CanonicalForm) phaseOneColumn(min float64) []float64 {
+	w := make([]float64, cf.m)
+	for k := range w {
+		w[k] = -1
+		if cf.upper != nil {
+			w[k] = 0
+			if xB := cf.xBStar.At(k, 0); xB < -cf.tolerance {
+				w[k] = xB / -min
+			}
+		}
+	}
+	column := make([]float64, cf.m)
+	for i := range column {
+		for k := 0; k < cf.m; k++ {
+			column[i] += cf.B.At(i, k) * w[k]
+		}
+	}
+	return column
+}
@@ -0,0 +1,93 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+// nearlySingular Dictionary of max x_1 + x_2, x_1 + x_2 <= b1, x_1 + (1+1e-13)*x_2 <= 2 with x_1 and x_2 basic
+func nearlySingular(t *testing.T, b1 float64) *CanonicalForm {
+	cf := &CanonicalForm{}
+	require.NoError(t, cf.New(mat.NewDense(1, 2, []float64{1, 1}), mat.NewDense(2, 2, []float64{
+		1, 1,
+		1, 1 + 1e-13,
+	}), mat.NewDense(2, 1, []float64{b1, 2})))
+	cf.swap(0, 0)
+	cf.swap(1, 1)
+	return cf
+}
+
+func TestRepair(t *testing.T) {
+	cf := nearlySingular(t, 2)
+	assert.Greater(t, cf.condition(), repairThreshold)
+
+	//Every conditionInterval pivots
+	cf.sinceCondition = conditionInterval - 1
+	require.NoError(t, cf.monitorCondition())
+	assert.Equal(t, 1, cf.repairs)
+	assert.Equal(t, 1, cf.refactorizations)
+	assert.Less(t, cf.condition(), conditionThreshold)
+	//x_2 is replaced by the slack variable of the first constraint
+	assert.ElementsMatch(t, []int{0, 2}, cf.remap[cf.n:])
+	var activity mat.Dense
+	activity.Mul(cf.B, cf.xBStar)
+	assert.True(t, mat.EqualApprox(cf.b, &activity, 0.000001))
+
+	n, err := cf.restoreFeasibility(10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.False(t, cf.repaired)
+}
+
+func TestRestoreFeasibility(t *testing.T) {
+	//The slack variable of the first constraint is -1 in the repaired basis
+	cf := nearlySingular(t, 1)
+	cf.repair()
+	require.NoError(t, cf.refactorize())
+	assert.False(t, cf.primalFeasible())
+
+	_, err := cf.restoreFeasibility(10)
+	require.NoError(t, err)
+	assert.True(t, cf.primalFeasible())
+	_, err = cf.Reoptimize(10)
+	require.NoError(t, err)
+	_, score := cf.values()
+	assert.InDelta(t, 1.0, score, 0.000001)
+
+	cf = nearlySingular(t, 1)
+	cf.repair()
+	require.NoError(t, cf.refactorize())
+	_, err = cf.restoreFeasibility(0)
+	assert.Equal(t, ErrIterationLimit, err)
+}
+
+func TestRecoverSingular(t *testing.T) {
+	//max x_1 + x_2, x_1 + x_2 <= 2, x_1 + x_2 <= 3 with x_1 and x_2 basic
+	cf := &CanonicalForm{}
+	require.NoError(t, cf.New(mat.NewDense(1, 2, []float64{1, 1}), mat.NewDense(2, 2, []float64{
+		1, 1,
+		1, 1,
+	}), mat.NewDense(2, 1, []float64{2, 3})))
+	cf.swap(0, 0)
+	cf.swap(1, 1)
+	_, err := cf.FindY()
+	assert.Equal(t, ErrSingularBasis, errors.Cause(err))
+
+	end, err := cf.Iter(0)
+	require.NoError(t, err)
+	assert.False(t, end)
+	assert.Equal(t, 1, cf.repairs)
+	_, err = cf.restoreFeasibility(10)
+	require.NoError(t, err)
+	_, err = cf.Reoptimize(10)
+	require.NoError(t, err)
+	_, score := cf.values()
+	assert.InDelta(t, 2.0, score, 0.000001)
+
+	//Other errors are returned as is
+	assert.Equal(t, ErrUnbounded, cf.recoverSingular(ErrUnbounded))
+}
@@ -0,0 +1,228 @@
+package goptimization
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Cone Second-order cone constraint ||F*x + G|| <= H*x + D of SecondOrderCone, a nil F (and G) is the linear
+// constraint H*x + D >= 0
+type Cone struct {
+	F *mat.Dense
+	G []float64
+	H []float64
+	D float64
+}
+
+// SecondOrderCone Solve the second-order cone program:
+// Maximize Σ(1<=j<=n) c_j*x_j
+// Constraints:
+// 1<=i<=m,  Σ(1<=j<=n) a_i_j*x_j <= b_i
+// 1<=k<=K,  ||F_k*x + g_k|| <= h_k*x + d_k
+// 1<=j<=n x_j >= 0
+// with the interior point method of InteriorPoint. Each cone adds the variables (t, v) = (h*x + d, F*x + g) with
+// t >= ||v||, and the central path z∘w = μe of the algebra of the cone is followed with the scaling of Nesterov and Todd,
+// which keeps the normal equations symmetric.
+// The robust counterpart of a row with ellipsoidal uncertainty, a in {ā + P*u, ||u|| <= 1}, is the cone
+// ||P^T*x|| <= b - ā*x, and so are the constraints on Euclidean norms.
+// The results follow the layout of Simplex with the slack h_k*x + d_k - ||F_k*x + g_k|| of each cone after the m slacks.
+// The options and the errors are those of InteriorPoint.
+func SecondOrderCone(c mat.Matrix, A *mat.Dense, b mat.Matrix, cones []Cone, opts ...Option) (int, *mat.Dense, float64, error) {
+	o := newOptions(opts)
+	row, err := asRow(c, "c")
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	column, err := asColumn(b, "b")
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	err = checkDims(row, A, column)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	m, n := A.Dims()
+	rows, size := m, n+m
+	for k, cone := range cones {
+		if len(cone.H) != n {
+			return 0, nil, 0, newError(ErrDimensionMismatch, "cone %d: len(H) must be %d, got %d", k, n, len(cone.H))
+		}
+		p := cone.dim()
+		if cone.F != nil {
+			if _, cols := cone.F.Dims(); cols != n {
+				return 0, nil, 0, newError(ErrDimensionMismatch, "cone %d: F dims.c must be %d, got %d", k, n, cols)
+			}
+		}
+		if len(cone.G) != p {
+			return 0, nil, 0, newError(ErrDimensionMismatch, "cone %d: len(G) must be %d, got %d", k, p, len(cone.G))
+		}
+		rows += 1 + p
+		size += 1 + p
+	}
+
+	// min -c*x subject to A*x + s = b, t_k - h_k*x = d_k and v_k - F_k*x = g_k
+	cost := make([]float64, size)
+	for j := 0; j < n; j++ {
+		cost[j] = -row.At(0, j)
+	}
+	M := mat.NewDense(rows, size, nil)
+	M.Slice(0, m, 0, n).(*mat.Dense).Copy(A)
+	rhs := make([]float64, rows)
+	sizes := make([]int, 0, n+m+len(cones))
+	for i := 0; i < m; i++ {
+		M.Set(i, n+i, 1)
+		rhs[i] = column.At(i, 0)
+	}
+	for j := 0; j < n+m; j++ {
+		sizes = append(sizes, 1)
+	}
+	r := m
+	for _, cone := range cones {
+		for j, h := range cone.H {
+			M.Set(r, j, -h)
+		}
+		M.Set(r, n+r, 1)
+		rhs[r] = cone.D
+		for i := 0; i < cone.dim(); i++ {
+			for j := 0; j < n; j++ {
+				M.Set(r+1+i, j, -cone.F.At(i, j))
+			}
+			M.Set(r+1+i, n+r+1+i, 1)
+			rhs[r+1+i] = cone.G[i]
+		}
+		sizes = append(sizes, 1+cone.dim())
+		r += 1 + cone.dim()
+	}
+
+	iter, z, err := conicInteriorPoint(cost, M, rhs, sizes, o, iterationLimit(o.maxIter, n, rows))
+	if z == nil {
+		return iter, nil, 0, err
+	}
+	results := mat.NewDense(n+m+len(cones), 1, nil)
+	score := 0.0
+	for j := 0; j < n+m; j++ {
+		results.Set(j, 0, z[j])
+	}
+	for j := 0; j < n; j++ {
+		score += row.At(0, j) * z[j]
+	}
+	r = n + m
+	for k, cone := range cones {
+		results.Set(n+m+k, 0, z[r]-floats.Norm(z[r+1:r+1+cone.dim()], 2))
+		r += 1 + cone.dim()
+	}
+	return iter, results, score, err
+}
+
+// dim Dimension of the norm of the cone
+func (cone Cone) dim() int {
+	if cone.F == nil {
+		return 0
+	}
+	p, _ := cone.F.Dims()
+	return p
+}
+
+// coneDet Determinant u_0^2 - ||u_1||^2 of u in the algebra of the second-order cone
+func coneDet(u []float64) float64 {
+	det := u[0] * u[0]
+	for _, v := range u[1:] {
+		det -= v * v
+	}
+	return det
+}
+
+// coneScaling Scaling W of Nesterov and Todd of a block z, w of the cone, W*z = W^-1*w. It is
+// W^-1 = β(2vv^T - J) with J = diag(1, -I), and W = (2Jvv^TJ - J)/β.
+type coneScaling struct {
+	beta float64
+	v    []float64
+}
+
+// unitCone Scaling point of the cones of size 1, it is not modified
+var unitCone = []float64{1}
+
+// newConeScaling Scaling of the primal block z and the dual block w in the interior of the cone
+func newConeScaling(z, w []float64) coneScaling {
+	if len(z) == 1 {
+		return coneScaling{beta: math.Sqrt(z[0] / w[0]), v: unitCone}
+	}
+	zNorm, wNorm := math.Sqrt(coneDet(z)), math.Sqrt(coneDet(w))
+	// Scaling point of the normalized blocks, (z/zNorm + J*w/wNorm)/2γ
+	gamma := math.Sqrt((1 + floats.Dot(z, w)/(zNorm*wNorm)) / 2)
+	v := make([]float64, len(z))
+	v[0] = (z[0]/zNorm + w[0]/wNorm) / (2 * gamma)
+	for i := 1; i < len(z); i++ {
+		v[i] = (z[i]/zNorm - w[i]/wNorm) / (2 * gamma)
+	}
+	scale := math.Sqrt(2 * (v[0] + 1))
+	v[0]++
+	floats.Scale(1/scale, v)
+	return coneScaling{beta: math.Sqrt(zNorm / wNorm), v: v}
+}
+
+// apply dst = W*u
+func (s coneScaling) apply(dst, u []float64) {
+	// Jv*u with the first entry of Jv = v_0 and the others -v_i
+	jv := s.v[0] * u[0]
+	for i := 1; i < len(u); i++ {
+		jv -= s.v[i] * u[i]
+	}
+	dst[0] = (2*s.v[0]*jv - u[0]) / s.beta
+	for i := 1; i < len(u); i++ {
+		dst[i] = (-2*s.v[i]*jv + u[i]) / s.beta
+	}
+}
+
+// applyInverse dst = W^-1*u
+func (s coneScaling) applyInverse(dst, u []float64) {
+	vu := floats.Dot(s.v, u)
+	dst[0] = s.beta * (2*s.v[0]*vu - u[0])
+	for i := 1; i < len(u); i++ {
+		dst[i] = s.beta * (2*s.v[i]*vu + u[i])
+	}
+}
+
+// coneProduct dst = u∘v = (u*v, u_0*v_1 + v_0*u_1)
+func coneProduct(dst, u, v []float64) {
+	dst[0] = floats.Dot(u, v)
+	for i := 1; i < len(u); i++ {
+		dst[i] = u[0]*v[i] + v[0]*u[i]
+	}
+}
+
+// coneDivide dst = λ^-1∘r, the solution of λ∘dst = r, λ in the interior of the cone
+func coneDivide(dst, lambda, r []float64) {
+	dst[0] = lambda[0] * r[0]
+	for i := 1; i < len(r); i++ {
+		dst[0] -= lambda[i] * r[i]
+	}
+	dst[0] /= coneDet(lambda)
+	for i := 1; i < len(r); i++ {
+		dst[i] = (r[i] - dst[0]*lambda[i]) / lambda[0]
+	}
+}
+
+// coneStep Fraction of the step to the boundary of the cone of u + alpha*du, at most 1, see stepLength
+func coneStep(u, du []float64) float64 {
+	if len(u) == 1 {
+		return stepLength(u, du)
+	}
+	// The smallest positive root of det(u + alpha*du) = a*alpha^2 + 2b*alpha + c with c > 0
+	a, c := coneDet(du), coneDet(u)
+	b := u[0] * du[0]
+	for i := 1; i < len(u); i++ {
+		b -= u[i] * du[i]
+	}
+	disc := b*b - a*c
+	boundary := math.Inf(1)
+	switch {
+	case b < 0 && disc >= 0:
+		boundary = c / (math.Sqrt(disc) - b)
+	case a < 0:
+		boundary = (-b - math.Sqrt(disc)) / a
+	}
+	return math.Min(1, stepFraction*boundary)
+}
@@ -0,0 +1,129 @@
+package goptimization
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestConeScaling(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	interior := func(size int) []float64 {
+		u := make([]float64, size)
+		for i := 1; i < size; i++ {
+			u[i] = rnd.NormFloat64()
+		}
+		u[0] = floats.Norm(u[1:], 2) + rnd.Float64() + 0.1
+		return u
+	}
+	for _, size := range []int{1, 2, 5} {
+		z, w := interior(size), interior(size)
+		s := newConeScaling(z, w)
+		wz, winvw := make([]float64, size), make([]float64, size)
+		s.apply(wz, z)
+		s.applyInverse(winvw, w)
+		assert.InDeltaSlice(t, wz, winvw, 1e-12, "size %d", size)
+		// W^-1*W = I
+		u, back := interior(size), make([]float64, size)
+		s.apply(back, u)
+		s.applyInverse(back, back)
+		assert.InDeltaSlice(t, u, back, 1e-12, "size %d", size)
+		// λ∘(λ^-1∘r) = r
+		r, rho := interior(size), make([]float64, size)
+		coneDivide(rho, wz, r)
+		coneProduct(back, wz, rho)
+		assert.InDeltaSlice(t, r, back, 1e-12, "size %d", size)
+		// The step stops at the boundary
+		du := make([]float64, size)
+		for i := range du {
+			du[i] = rnd.NormFloat64()
+		}
+		du[0] = -floats.Norm(du, 2) - 1
+		alpha := coneStep(z, du) / stepFraction
+		boundary := append([]float64(nil), z...)
+		floats.AddScaled(boundary, alpha, du)
+		assert.InDelta(t, 0, coneDet(boundary), 1e-9, "size %d", size)
+	}
+}
+
+func TestSecondOrderCone(t *testing.T) {
+	// Maximize x1 + x2 with ||x|| <= 1 and x1 <= 0.5
+	c := mat.NewDense(1, 2, []float64{1, 1})
+	A := mat.NewDense(1, 2, []float64{1, 0})
+	b := mat.NewDense(1, 1, []float64{0.5})
+	cones := []Cone{{F: mat.NewDense(2, 2, []float64{1, 0, 0, 1}), G: []float64{0, 0}, H: []float64{0, 0}, D: 1}}
+	_, results, score, err := SecondOrderCone(c, A, b, cones, silent)
+	require.NoError(t, err)
+	x2 := math.Sqrt(0.75)
+	assert.InDelta(t, 0.5+x2, score, 1e-6)
+	assert.InDeltaSlice(t, []float64{0.5, x2, 0, 0}, mat.Col(nil, 0, results), 1e-6)
+
+	// Without cones it is InteriorPoint
+	cc, AA, bb, _ := knapsack(30, 3)
+	_, _, expected, err := Simplex(cc, mat.DenseCopyOf(AA), bb, silent)
+	require.NoError(t, err)
+	_, _, score, err = SecondOrderCone(cc, AA, bb, nil, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, expected, score, 1e-6*expected)
+}
+
+func TestSecondOrderConeRobust(t *testing.T) {
+	// Each row a_i in {ā_i + P*u, ||u|| <= 1}: ā_i*x + ||P^T*x|| <= b_i
+	for seed := int64(1); seed <= 3; seed++ {
+		lp, err := GenerateLP(6, 4, 0.6, seed)
+		require.NoError(t, err)
+		m, n := lp.A.Dims()
+		P := mat.NewDense(n, n, nil)
+		for j := 0; j < n; j++ {
+			P.Set(j, j, 0.1)
+			if j > 0 {
+				P.Set(j, j-1, 0.05)
+			}
+		}
+		cones := make([]Cone, m)
+		for i := range cones {
+			h := make([]float64, n)
+			for j := range h {
+				h[j] = -lp.A.At(i, j)
+			}
+			cones[i] = Cone{F: mat.DenseCopyOf(P.T()), G: make([]float64, n), H: h, D: lp.B.At(i, 0)}
+		}
+		_, results, score, err := SecondOrderCone(lp.C, lp.A, lp.B, cones, silent)
+		require.NoError(t, err, "seed %d", seed)
+		_, _, nominal, err := Simplex(lp.C, mat.DenseCopyOf(lp.A), lp.B, silent)
+		require.NoError(t, err)
+		assert.True(t, score <= nominal+1e-6, "seed %d", seed)
+		x := mat.NewVecDense(n, mat.Col(nil, 0, results)[:n])
+		var px mat.VecDense
+		px.MulVec(P.T(), x)
+		for i := 0; i < m; i++ {
+			assert.True(t, mat.Dot(lp.A.RowView(i), x)+mat.Norm(&px, 2) <= lp.B.At(i, 0)+1e-6, "seed %d row %d", seed, i)
+			assert.True(t, results.At(n+m+i, 0) >= -1e-6)
+		}
+	}
+}
+
+func TestSecondOrderConeErrors(t *testing.T) {
+	c := mat.NewDense(1, 2, []float64{1, 1})
+	A := mat.NewDense(1, 2, []float64{1, 1})
+	b := mat.NewDense(1, 1, []float64{4})
+	identity := mat.NewDense(2, 2, []float64{1, 0, 0, 1})
+	_, _, _, err := SecondOrderCone(c, A, b, []Cone{{F: identity, G: []float64{0, 0}, H: []float64{0}}}, silent)
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+	_, _, _, err = SecondOrderCone(c, A, b, []Cone{{F: identity, G: []float64{0}, H: []float64{0, 0}}}, silent)
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+	_, _, _, err = SecondOrderCone(c, A, b, []Cone{{F: mat.NewDense(2, 3, nil), G: []float64{0, 0}, H: []float64{0, 0}}}, silent)
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+	// ||x - (10, 10)|| <= 1 is out of x1 + x2 <= 4
+	_, _, _, err = SecondOrderCone(c, A, b, []Cone{{F: identity, G: []float64{-10, -10}, H: []float64{0, 0}, D: 1}}, silent)
+	assert.Equal(t, ErrInfeasible, errors.Cause(err))
+	// ||x|| <= x1 + x2 leaves x2 free
+	_, _, _, err = SecondOrderCone(c, mat.NewDense(1, 2, []float64{1, 0}), mat.NewDense(1, 1, []float64{1}), []Cone{{F: identity, G: []float64{0, 0}, H: []float64{1, 1}}}, silent)
+	assert.Equal(t, ErrUnbounded, errors.Cause(err))
+}
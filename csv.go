@@ -0,0 +1,126 @@
+package goptimization
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ReadCSV Read a linear problem from a CSV table into a Model, one column per variable followed by a sense column and
+// a right-hand side column:
+// - an optional header row names the variables, its sense cell is neither max nor min, e.g. "sense";
+// the variables are named x0, x1, ... otherwise
+// - the objective row, whose sense is max or min, empty for max, and whose right-hand side is the constant of the
+// objective, 0 when empty
+// - a row per constraint with the sense <=, >= or = (also written L, G, E, ≤ or ≥) and the right-hand side
+// Empty coefficients are 0, the cells are trimmed and the lines starting with # are comments.
+// Like ReadMPS, a minimization is read as the maximization of the opposite objective.
+func ReadCSV(r io.Reader) (*Model, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "csv")
+	}
+	if len(records) == 0 {
+		return nil, errors.New("csv: no objective row")
+	}
+	width := len(records[0])
+	n := width - 2
+	if n < 1 {
+		return nil, errors.Errorf("csv: %d columns, at least one variable with the sense and the right-hand side", width)
+	}
+
+	m := &Model{}
+	// header Number of rows before the objective, for the errors
+	header := 0
+	sense := func(record []string) string {
+		return strings.ToLower(strings.TrimSpace(record[n]))
+	}
+	if s := sense(records[0]); s != "" && s != "max" && s != "min" {
+		for j := 0; j < n; j++ {
+			m.AddVariable(strings.TrimSpace(records[0][j]), false)
+		}
+		records = records[1:]
+		header = 1
+		if len(records) == 0 {
+			return nil, errors.New("csv: no objective row")
+		}
+	} else {
+		for j := 0; j < n; j++ {
+			m.AddVariable("x"+strconv.Itoa(j), false)
+		}
+	}
+
+	// expr Terms of the coefficients and value of the right-hand side of the record k
+	expr := func(k int, record []string, sign float64) (Expr, float64, error) {
+		row := k + header + 1
+		e := Expr{}
+		for j := 0; j < n; j++ {
+			v, err := csvNumber(record[j])
+			if err != nil {
+				return Expr{}, 0, errors.Errorf("csv: row %d, column %d: %v", row, j+1, err)
+			}
+			if v != 0 {
+				e.Terms = append(e.Terms, Term{Var: Var(j), Coef: sign * v})
+			}
+		}
+		rhs, err := csvNumber(record[n+1])
+		if err != nil {
+			return Expr{}, 0, errors.Errorf("csv: row %d, right-hand side: %v", row, err)
+		}
+		return e, rhs, nil
+	}
+
+	sign := 1.0
+	switch sense(records[0]) {
+	case "", "max":
+	case "min":
+		sign = -1
+	default:
+		return nil, errors.Errorf("csv: the sense of the objective must be max or min, got %s", records[0][n])
+	}
+	objective, constant, err := expr(0, records[0], sign)
+	if err != nil {
+		return nil, err
+	}
+	objective.Constant = sign * constant
+	m.Maximize(objective)
+
+	for k := 1; k < len(records); k++ {
+		record := records[k]
+		e, rhs, err := expr(k, record, 1)
+		if err != nil {
+			return nil, err
+		}
+		var s Sense
+		switch sense(record) {
+		case "<=", "≤", "l", "le":
+			s = LessEq
+		case ">=", "≥", "g", "ge":
+			s = GreaterEq
+		case "=", "==", "e", "eq":
+			s = Equal
+		default:
+			return nil, errors.Errorf("csv: row %d: unknown sense %s", k+header+1, record[n])
+		}
+		err = m.AddRow(e, s, rhs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// csvNumber Value of a cell, 0 when it is empty
+func csvNumber(cell string) (float64, error) {
+	cell = strings.TrimSpace(cell)
+	if cell == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(cell, 64)
+}
@@ -0,0 +1,57 @@
+package goptimization
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestReadCSV(t *testing.T) {
+	m, err := ReadCSV(strings.NewReader(`# Maximize 5x + 4y + 3z
+x, y, z, sense, rhs
+5, 4, 3, max,
+2, 3, 1, <=, 5
+4, 1, 2, <=, 11
+3, 4, 2, <=, 8
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x", "y", "z"}, m.Names())
+	c, A, b, _ := m.Standard()
+	assert.True(t, mat.Equal(mat.NewDense(1, 3, []float64{5, 4, 3}), c))
+	assert.True(t, mat.Equal(mat.NewDense(3, 3, []float64{2, 3, 1, 4, 1, 2, 3, 4, 2}), A))
+	assert.True(t, mat.Equal(mat.NewDense(3, 1, []float64{5, 11, 8}), b))
+	solution, err := m.Solve(100)
+	require.NoError(t, err)
+	assert.InDelta(t, 13, solution.Score, 1e-9)
+
+	// Minimize 2x0 + 3x1 + 1 with x0 + x1 >= 4, x0 = 1 and empty coefficients
+	m, err = ReadCSV(strings.NewReader(`2,3,MIN,1
+1,1,>=,4
+1,,E,1
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x0", "x1"}, m.Names())
+	solution, err = m.Solve(100)
+	require.NoError(t, err)
+	assert.InDelta(t, -12, solution.Score, 1e-9)
+	assert.InDelta(t, 1, solution.Value(0), 1e-9)
+	assert.InDelta(t, 3, solution.Value(1), 1e-9)
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	for text, message := range map[string]string{
+		"":                           "csv: no objective row",
+		"x,sense,rhs\n":              "csv: no objective row",
+		"1,max\n":                    "csv: 2 columns, at least one variable with the sense and the right-hand side",
+		"1,max,\n1,<=\n":             "csv: record on line 2: wrong number of fields",
+		"1,max,\none,<=,1\n":         "csv: row 2, column 1: strconv.ParseFloat: parsing \"one\": invalid syntax",
+		"x,sense,rhs\n1,max,\n1,<,1": "csv: row 3: unknown sense <",
+		"1,max,\n1,<=,r\n":           "csv: row 2, right-hand side: strconv.ParseFloat: parsing \"r\": invalid syntax",
+	} {
+		_, err := ReadCSV(strings.NewReader(text))
+		assert.EqualError(t, err, message, text)
+	}
+}
@@ -0,0 +1,337 @@
+package goptimization
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// violationTolerance Minimum violation of a cut by the relaxation before it is added
+const violationTolerance = 1e-6
+
+// Cut Constraint Σ a_j*x_j <= RHS tightening the relaxation of a node
+// A is indexed by variable (the n original variables followed by the slack variables), it can be shorter
+// than the number of variables of the node, the missing coefficients are zero.
+type Cut struct {
+	A   []float64
+	RHS float64
+}
+
+// Separation Information available to the cut separators at a node of the search tree
+type Separation struct {
+	// X Value of each variable in the relaxation of the node, indexed by variable
+	X []float64
+	// Integer Integrality of each variable of the node
+	Integer []bool
+	// Binary Original variables restricted to {0, 1}
+	Binary []bool
+	// A, B Original constraints Σ a_i_j*x_j <= b_i
+	A *mat.Dense
+	B *mat.Dense
+	// Depth Depth of the node in the search tree
+	Depth int
+	// MaxCuts Maximum number of cuts a separator should return
+	MaxCuts int
+
+	cf        *CanonicalForm
+	conflicts *conflictGraph
+}
+
+// Conflict Check if the binary variables j and k cannot be equal to 1 at the same time
+func (s *Separation) Conflict(j, k int) bool {
+	return s.conflicts.has(j, k)
+}
+
+// CutSeparator Generate cuts violated by the solution of the relaxation of a node
+// The cuts which are not violated by Separation.X are discarded.
+type CutSeparator interface {
+	Separate(s *Separation) ([]Cut, error)
+}
+
+// CutCallback User defined separator
+type CutCallback func(s *Separation) ([]Cut, error)
+
+// Separate Call the user function
+func (f CutCallback) Separate(s *Separation) ([]Cut, error) {
+	return f(s)
+}
+
+// GomorySeparator Gomory mixed-integer cuts from the rows of the dictionary whose basic variable is integer
+// with a fractional value. The most fractional rows are used first.
+type GomorySeparator struct{}
+
+// Separate Generate the Gomory mixed-integer cuts of the node
+func (GomorySeparator) Separate(s *Separation) ([]Cut, error) {
+	cf := s.cf
+	rows := []int{}
+	for i := 0; i < cf.m; i++ {
+		f0 := cf.xBStar.At(i, 0) - math.Floor(cf.xBStar.At(i, 0))
+		// Cuts from almost integral rows are numerically unsafe
+		if s.Integer[cf.remap[cf.n+i]] && f0 > 0.005 && f0 < 0.995 {
+			rows = append(rows, i)
+		}
+	}
+	fractionality := func(i int) float64 {
+		return math.Abs(cf.xBStar.At(i, 0) - math.Floor(cf.xBStar.At(i, 0)) - 0.5)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return fractionality(rows[i]) < fractionality(rows[j])
+	})
+	if len(rows) > s.MaxCuts {
+		rows = rows[:s.MaxCuts]
+	}
+
+	cuts := []Cut{}
+	for _, r := range rows {
+		a, ok, err := gomoryCut(cf, s.Integer, r)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			cuts = append(cuts, Cut{A: a, RHS: -1})
+		}
+	}
+	return cuts, nil
+}
+
+// gomoryCut Build the Gomory mixed-integer cut from the r-th row of the dictionary
+// x_Br + Σ(j nonbasic) alpha_j*x_j = xBStar_r, with f0 the fractional part of xBStar_r and f_j the one of alpha_j:
+// Σ(j integer, f_j<=f0) f_j/f0*x_j + Σ(j integer, f_j>f0) (1-f_j)/(1-f0)*x_j
+// + Σ(j continuous, alpha_j>0) alpha_j/f0*x_j - Σ(j continuous, alpha_j<0) alpha_j/(1-f0)*x_j >= 1
+// The cut is returned as the coefficients, indexed by variable, of the constraint -Σ g_j*x_j <= -1.
+func gomoryCut(cf *CanonicalForm, integer []bool, r int) ([]float64, bool, error) {
+	row, err := cf.tableauRow(r)
+	if err != nil {
+		return nil, false, err
+	}
+	f0 := cf.xBStar.At(r, 0) - math.Floor(cf.xBStar.At(r, 0))
+
+	a := make([]float64, cf.n+cf.m)
+	nonZero := false
+	for j := 0; j < cf.n; j++ {
+		alpha := row.At(0, j)
+		if math.Abs(alpha) > 1e6 {
+			return nil, false, nil
+		}
+		g := 0.0
+		if integer[cf.remap[j]] {
+			fj := alpha - math.Floor(alpha)
+			if fj <= f0 {
+				g = fj / f0
+			} else {
+				g = (1 - fj) / (1 - f0)
+			}
+		} else if alpha > 0 {
+			g = alpha / f0
+		} else {
+			g = -alpha / (1 - f0)
+		}
+		if math.Abs(g) <= epsilon {
+			continue
+		}
+		a[cf.remap[j]] = -g
+		nonZero = true
+	}
+	return a, nonZero, nil
+}
+
+// CoverSeparator Extended cover cuts from the knapsack constraints Σ a_i_j*x_j <= b_i
+// with non-negative coefficients over binary variables.
+// A cover C is a set of variables with Σ(j in C) a_i_j > b_i, so Σ(j in E(C)) x_j <= |C|-1
+// where E(C) extends C with the variables whose coefficient is at least max(j in C) a_i_j.
+type CoverSeparator struct{}
+
+// Separate Greedily build a cover for each knapsack constraint, picking first the variables closest to 1
+func (CoverSeparator) Separate(s *Separation) ([]Cut, error) {
+	m, n := s.A.Dims()
+	cuts := []Cut{}
+	for i := 0; i < m && len(cuts) < s.MaxCuts; i++ {
+		items := []int{}
+		knapsack := true
+		for j := 0; j < n; j++ {
+			a := s.A.At(i, j)
+			if a == 0 {
+				continue
+			}
+			if a < 0 || !s.Binary[j] {
+				knapsack = false
+				break
+			}
+			items = append(items, j)
+		}
+		if !knapsack || len(items) < 2 {
+			continue
+		}
+		sort.SliceStable(items, func(k, l int) bool {
+			return (1-s.X[items[k]])/s.A.At(i, items[k]) < (1-s.X[items[l]])/s.A.At(i, items[l])
+		})
+
+		weight := 0.0
+		maxWeight := 0.0
+		cover := map[int]bool{}
+		for _, j := range items {
+			if weight > s.B.At(i, 0) {
+				break
+			}
+			weight += s.A.At(i, j)
+			maxWeight = math.Max(maxWeight, s.A.At(i, j))
+			cover[j] = true
+		}
+		if weight <= s.B.At(i, 0) {
+			continue
+		}
+
+		cut := Cut{A: make([]float64, n), RHS: float64(len(cover) - 1)}
+		for _, j := range items {
+			if cover[j] || s.A.At(i, j) >= maxWeight {
+				cut.A[j] = 1
+			}
+		}
+		cuts = append(cuts, cut)
+	}
+	return cuts, nil
+}
+
+// CliqueSeparator Clique cuts Σ(j in K) x_j <= 1 from the conflict graph of the binary variables
+// Two binary variables are in conflict when a constraint with non-negative coefficients
+// cannot be satisfied with both of them equal to 1.
+type CliqueSeparator struct{}
+
+// Separate Greedily grow a clique from each fractional binary variable, picking first the variables closest to 1
+func (CliqueSeparator) Separate(s *Separation) ([]Cut, error) {
+	candidates := []int{}
+	for j, binary := range s.Binary {
+		if binary && s.X[j] > violationTolerance {
+			candidates = append(candidates, j)
+		}
+	}
+	sort.SliceStable(candidates, func(k, l int) bool {
+		return s.X[candidates[k]] > s.X[candidates[l]]
+	})
+
+	cuts := []Cut{}
+	seen := map[string]bool{}
+	for _, start := range candidates {
+		if len(cuts) >= s.MaxCuts {
+			break
+		}
+		if isIntegral(s.X[start]) {
+			continue
+		}
+		clique := []int{start}
+		total := s.X[start]
+		for _, j := range candidates {
+			if j == start {
+				continue
+			}
+			inClique := true
+			for _, k := range clique {
+				if !s.conflicts.has(j, k) {
+					inClique = false
+					break
+				}
+			}
+			if inClique {
+				clique = append(clique, j)
+				total += s.X[j]
+			}
+		}
+		if len(clique) < 2 || total <= 1+violationTolerance {
+			continue
+		}
+
+		sort.Ints(clique)
+		key := fmt.Sprint(clique)
+		cut := Cut{A: make([]float64, len(s.Binary)), RHS: 1}
+		for _, j := range clique {
+			cut.A[j] = 1
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		cuts = append(cuts, cut)
+	}
+	return cuts, nil
+}
+
+// conflictGraph Pairs of binary variables which cannot be equal to 1 at the same time
+type conflictGraph struct {
+	edges map[int]map[int]bool
+}
+
+// newConflictGraph Find the conflicts in the constraints with non-negative coefficients:
+// j and k are in conflict when a_i_j + a_i_k > b_i
+func newConflictGraph(A, b *mat.Dense, binary []bool) *conflictGraph {
+	g := &conflictGraph{edges: map[int]map[int]bool{}}
+	m, n := A.Dims()
+	for i := 0; i < m; i++ {
+		nonNegative := true
+		for j := 0; j < n; j++ {
+			if A.At(i, j) < 0 {
+				nonNegative = false
+				break
+			}
+		}
+		if !nonNegative {
+			continue
+		}
+		for j := 0; j < n; j++ {
+			if !binary[j] || A.At(i, j) == 0 {
+				continue
+			}
+			for k := j + 1; k < n; k++ {
+				if binary[k] && A.At(i, j)+A.At(i, k) > b.At(i, 0)+epsilon {
+					g.add(j, k)
+				}
+			}
+		}
+	}
+	return g
+}
+
+// add Add the conflict between j and k
+func (g *conflictGraph) add(j, k int) {
+	if g.edges[j] == nil {
+		g.edges[j] = map[int]bool{}
+	}
+	if g.edges[k] == nil {
+		g.edges[k] = map[int]bool{}
+	}
+	g.edges[j][k] = true
+	g.edges[k][j] = true
+}
+
+// has Check the conflict between j and k
+func (g *conflictGraph) has(j, k int) bool {
+	if g == nil {
+		return false
+	}
+	return g.edges[j][k]
+}
+
+// binaries Find the integer variables with an upper bound a_i_j*x_j <= b_i such that x_j <= 1
+func binaries(A, b *mat.Dense, integer []bool) []bool {
+	m, n := A.Dims()
+	binary := make([]bool, n)
+	for i := 0; i < m; i++ {
+		j := -1
+		single := true
+		for k := 0; k < n; k++ {
+			if A.At(i, k) == 0 {
+				continue
+			}
+			if j != -1 {
+				single = false
+				break
+			}
+			j = k
+		}
+		if single && j != -1 && integer[j] && A.At(i, j) > 0 && b.At(i, 0)/A.At(i, j) < 2 {
+			binary[j] = true
+		}
+	}
+	return binary
+}
@@ -0,0 +1,101 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestCoverSeparator(t *testing.T) {
+	A := mat.NewDense(1, 4, []float64{5, 7, 4, 3})
+	b := mat.NewDense(1, 1, []float64{14})
+	s := &Separation{
+		X:       []float64{1, 1, 0.5, 0, 0},
+		Binary:  []bool{true, true, true, true},
+		A:       A,
+		B:       b,
+		MaxCuts: 10,
+	}
+
+	cuts, err := CoverSeparator{}.Separate(s)
+	require.NoError(t, err)
+	require.Len(t, cuts, 1)
+	//x_1 + x_2 + x_3 <= 2, x_4 is lighter than the cover
+	assert.Equal(t, []float64{1, 1, 1, 0}, cuts[0].A)
+	assert.Equal(t, 2.0, cuts[0].RHS)
+
+	//The row is not a knapsack constraint when a variable is not binary
+	s.Binary[3] = false
+	cuts, err = CoverSeparator{}.Separate(s)
+	require.NoError(t, err)
+	assert.Len(t, cuts, 0)
+}
+
+func TestCliqueSeparator(t *testing.T) {
+	A := mat.NewDense(3, 3, []float64{
+		1, 1, 0,
+		0, 1, 1,
+		1, 0, 1,
+	})
+	b := mat.NewDense(3, 1, []float64{1, 1, 1})
+	binary := []bool{true, true, true}
+	s := &Separation{
+		X:         []float64{0.5, 0.5, 0.5},
+		Binary:    binary,
+		A:         A,
+		B:         b,
+		MaxCuts:   10,
+		conflicts: newConflictGraph(A, b, binary),
+	}
+	assert.True(t, s.Conflict(0, 1))
+	assert.True(t, s.Conflict(2, 0))
+
+	cuts, err := CliqueSeparator{}.Separate(s)
+	require.NoError(t, err)
+	require.Len(t, cuts, 1)
+	assert.Equal(t, []float64{1, 1, 1}, cuts[0].A)
+	assert.Equal(t, 1.0, cuts[0].RHS)
+}
+
+func TestBinaries(t *testing.T) {
+	A := mat.NewDense(3, 3, []float64{
+		1, 1, 0,
+		0, 2, 0,
+		0, 0, 1,
+	})
+	b := mat.NewDense(3, 1, []float64{4, 3, 1})
+
+	assert.Equal(t, []bool{false, true, false}, binaries(A, b, []bool{true, true, false}))
+}
+
+func TestRegisterCutCallback(t *testing.T) {
+	c := mat.NewDense(1, 4, []float64{8, 11, 6, 4})
+	A := mat.NewDense(5, 4, []float64{
+		5, 7, 4, 3,
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1,
+	})
+	b := mat.NewDense(5, 1, []float64{14, 1, 1, 1, 1})
+
+	bb := BranchAndBound{}
+	err := bb.New(c, A, b, []bool{true, true, true, true})
+	require.NoError(t, err)
+	bb.Separators = nil
+	calls := 0
+	bb.RegisterCutCallback(func(s *Separation) ([]Cut, error) {
+		calls++
+		//x_1 + x_2 + x_3 <= 2 is a valid cover cut
+		return []Cut{{A: []float64{1, 1, 1}, RHS: 2}}, nil
+	})
+
+	results, score, err := bb.Solve(100)
+	require.NoError(t, err)
+	assert.True(t, calls > 0)
+	assert.Equal(t, 1, bb.Cuts)
+	assert.InEpsilon(t, 21.0, score, 0.000001)
+	assert.True(t, mat.EqualApprox(mat.NewDense(1, 4, []float64{0, 1, 1, 1}), results.Slice(0, 4, 0, 1).T(), 0.000001))
+}
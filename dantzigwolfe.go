@@ -0,0 +1,215 @@
+package goptimization
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// DantzigWolfe Solve a linear problem with a block-angular structure by Dantzig-Wolfe decomposition
+// blocks assigns each constraint to a block k >= 0, or to the linking constraints with -1.
+// The variables of a block are the ones of its constraints, a variable in two blocks is an error and
+// a variable without block constraints stays in the master. The master keeps the linking constraints
+// and a convexity constraint Σ(p) λ_k_p <= 1 per block over the extreme points p of the block,
+// the leftover weight goes to the origin. b must be non-negative and the blocks bounded.
+// At each round, the block subproblems max (c_k - π*A_k)*x_k, D_k*x_k <= d_k are solved in parallel goroutines.
+// The options configure the master and are given to the Simplex of the subproblems.
+// It returns the number of rounds, a matrix (n+m,1) like Simplex and the score.
+func DantzigWolfe(c, A, b *mat.Dense, blocks []int, maxRounds, maxIter int, opts ...Option) (int, *mat.Dense, float64, error) {
+	_, n := c.Dims()
+	m, cols := A.Dims()
+	if cols != n {
+		return 0, nil, 0, newError(ErrDimensionMismatch, "A dims.c != z dims.c")
+	}
+	if len(blocks) != m {
+		return 0, nil, 0, newError(ErrDimensionMismatch, "len(blocks) != A dims.r")
+	}
+	for i := 0; i < m; i++ {
+		if b.At(i, 0) < 0 {
+			return 0, nil, 0, errors.New("b must be non-negative")
+		}
+	}
+
+	// Rows and variables of each block, -1 for the master
+	nBlocks := 0
+	linking := []int{}
+	for i, k := range blocks {
+		if k < -1 {
+			return 0, nil, 0, errors.Errorf("invalid block %d", k)
+		}
+		if k == -1 {
+			linking = append(linking, i)
+		}
+		if k+1 > nBlocks {
+			nBlocks = k + 1
+		}
+	}
+	rows := make([][]int, nBlocks)
+	for i, k := range blocks {
+		if k >= 0 {
+			rows[k] = append(rows[k], i)
+		}
+	}
+	owner := make([]int, n)
+	for j := range owner {
+		owner[j] = -1
+	}
+	for i, k := range blocks {
+		if k == -1 {
+			continue
+		}
+		for j := 0; j < n; j++ {
+			if A.At(i, j) == 0 {
+				continue
+			}
+			if owner[j] != -1 && owner[j] != k {
+				return 0, nil, 0, errors.Errorf("variable %d is in blocks %d and %d", j, owner[j], k)
+			}
+			owner[j] = k
+		}
+	}
+	vars := make([][]int, nBlocks)
+	free := []int{}
+	for j, k := range owner {
+		if k == -1 {
+			free = append(free, j)
+		} else {
+			vars[k] = append(vars[k], j)
+		}
+	}
+
+	// The master starts with the origin of each block and the variables without blocks
+	masterRows := len(linking) + nBlocks
+	nInitial := nBlocks + len(free)
+	masterC := mat.NewDense(1, nInitial, nil)
+	masterA := mat.NewDense(masterRows, nInitial, nil)
+	masterB := mat.NewDense(masterRows, 1, nil)
+	for r, i := range linking {
+		masterB.Set(r, 0, b.At(i, 0))
+	}
+	for k := 0; k < nBlocks; k++ {
+		masterA.Set(len(linking)+k, k, 1)
+		masterB.Set(len(linking)+k, 0, 1)
+	}
+	for f, j := range free {
+		masterC.Set(0, nBlocks+f, c.At(0, j))
+		for r, i := range linking {
+			masterA.Set(r, nBlocks+f, A.At(i, j))
+		}
+	}
+	cg := ColumnGeneration{}
+	err := cg.New(masterC, masterA, masterB, opts...)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	cg.MaxIter = maxIter
+
+	// Extreme point of the block behind each generated column
+	points := []dwPoint{}
+	pricing := func(y []float64) ([]Column, error) {
+		found := make([]*dwPoint, nBlocks)
+		errs := make([]error, nBlocks)
+		wg := sync.WaitGroup{}
+		for k := 0; k < nBlocks; k++ {
+			if len(vars[k]) == 0 {
+				continue
+			}
+			wg.Add(1)
+			go func(k int) {
+				defer wg.Done()
+				found[k], errs[k] = dwSubproblem(c, A, b, linking, rows[k], vars[k], y, maxIter, opts)
+			}(k)
+		}
+		wg.Wait()
+
+		columns := []Column{}
+		for k, point := range found {
+			if errs[k] != nil {
+				return nil, errs[k]
+			}
+			if point == nil {
+				continue
+			}
+			point.block = k
+			column := Column{A: make([]float64, masterRows)}
+			for v, j := range vars[k] {
+				column.Cost += c.At(0, j) * point.x[v]
+				for r, i := range linking {
+					column.A[r] += A.At(i, j) * point.x[v]
+				}
+			}
+			column.A[len(linking)+k] = 1
+			reduced := column.Cost
+			for r, a := range column.A {
+				reduced -= y[r] * a
+			}
+			if reduced <= cg.master.tolerance {
+				continue
+			}
+			points = append(points, *point)
+			columns = append(columns, column)
+		}
+		return columns, nil
+	}
+
+	results, score, err := cg.Solve(pricing, maxRounds)
+	if err != nil {
+		return cg.Rounds, nil, 0, err
+	}
+
+	// x is the combination of the extreme points, the generated columns follow the initial ones
+	x := mat.NewDense(n+m, 1, nil)
+	for f, j := range free {
+		x.Set(j, 0, results.At(nBlocks+f, 0))
+	}
+	for p, point := range points {
+		lambda := results.At(nInitial+p, 0)
+		for v, j := range vars[point.block] {
+			x.Set(j, 0, x.At(j, 0)+lambda*point.x[v])
+		}
+	}
+	for i := 0; i < m; i++ {
+		leftover := b.At(i, 0)
+		for j := 0; j < n; j++ {
+			leftover -= A.At(i, j) * x.At(j, 0)
+		}
+		x.Set(n+i, 0, leftover)
+	}
+	return cg.Rounds, x, score, nil
+}
+
+// dwPoint Extreme point of a block, indexed like the variables of the block
+type dwPoint struct {
+	block int
+	x     []float64
+}
+
+// dwSubproblem Solve max (c_k - π*A_k)*x_k, D_k*x_k <= d_k for the block, π are the duals of the linking constraints
+func dwSubproblem(c, A, b *mat.Dense, linking, rows, vars []int, y []float64, maxIter int, opts []Option) (*dwPoint, error) {
+	subC := mat.NewDense(1, len(vars), nil)
+	subA := mat.NewDense(len(rows), len(vars), nil)
+	subB := mat.NewDense(len(rows), 1, nil)
+	for v, j := range vars {
+		cost := c.At(0, j)
+		for r, i := range linking {
+			cost -= y[r] * A.At(i, j)
+		}
+		subC.Set(0, v, cost)
+		for r, i := range rows {
+			subA.Set(r, v, A.At(i, j))
+		}
+	}
+	for r, i := range rows {
+		subB.Set(r, 0, b.At(i, 0))
+	}
+	_, results, _, err := Simplex(subC, subA, subB, append([]Option{WithMaxIter(maxIter)}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	point := &dwPoint{x: make([]float64, len(vars))}
+	for v := range vars {
+		point.x[v] = results.At(v, 0)
+	}
+	return point, nil
+}
@@ -0,0 +1,59 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestDantzigWolfe(t *testing.T) {
+	//Two blocks (x_0, x_1) and (x_2, x_3) linked by two constraints, x_4 is only in the linking constraints
+	c := mat.NewDense(1, 5, []float64{3, 2, 4, 1, 1})
+	A := mat.NewDense(6, 5, []float64{
+		1, 1, 1, 1, 1,
+		1, 0, 1, 0, 0,
+		1, 2, 0, 0, 0,
+		3, 1, 0, 0, 0,
+		0, 0, 2, 1, 0,
+		0, 0, 1, 1, 0,
+	})
+	b := mat.NewDense(6, 1, []float64{5, 3, 4, 6, 5, 3})
+	blocks := []int{-1, -1, 0, 0, 1, 1}
+
+	_, _, expectedScore, err := Simplex(c, A, b, WithMaxIter(100))
+	require.NoError(t, err)
+
+	_, results, score, err := DantzigWolfe(c, A, b, blocks, 50, 100, silent)
+	require.NoError(t, err)
+	assert.InEpsilon(t, expectedScore, score, 0.000001)
+
+	//The solution is feasible and reaches the optimal score
+	total := 0.0
+	for j := 0; j < 5; j++ {
+		assert.GreaterOrEqual(t, results.At(j, 0), -0.000001)
+		total += c.At(0, j) * results.At(j, 0)
+	}
+	assert.InEpsilon(t, expectedScore, total, 0.000001)
+	for i := 0; i < 6; i++ {
+		assert.GreaterOrEqual(t, results.At(5+i, 0), -0.000001)
+	}
+}
+
+func TestDantzigWolfeErrors(t *testing.T) {
+	c := mat.NewDense(1, 2, []float64{1, 1})
+	A := mat.NewDense(2, 2, []float64{
+		1, 1,
+		1, 0,
+	})
+	b := mat.NewDense(2, 1, []float64{1, 1})
+
+	_, _, _, err := DantzigWolfe(c, A, b, []int{0}, 10, 10, silent)
+	assert.Error(t, err)
+	//x_0 is in both blocks
+	_, _, _, err = DantzigWolfe(c, A, b, []int{0, 1}, 10, 10, silent)
+	assert.Error(t, err)
+	_, _, _, err = DantzigWolfe(c, A, mat.NewDense(2, 1, []float64{-1, 1}), []int{-1, 0}, 10, 10, silent)
+	assert.Error(t, err)
+}
@@ -0,0 +1,125 @@
+package goptimization
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// DEAModel Returns to scale of the frontier of DEA
+type DEAModel int
+
+const (
+	// CCR Constant returns to scale, model of Charnes, Cooper and Rhodes
+	CCR DEAModel = iota
+	// BCC Variable returns to scale, model of Banker, Charnes and Cooper
+	BCC
+)
+
+// DEAScore Efficiency of a decision making unit
+type DEAScore struct {
+	// Efficiency Factor θ in (0, 1] by which the inputs of the unit can be reduced, 1 on the frontier
+	Efficiency float64
+	// Lambda Weight of each unit in the combination dominating the unit
+	Lambda []float64
+	// Reference Units with a positive weight, the efficient peers of the unit
+	Reference []int
+}
+
+// DEA Input-oriented data envelopment analysis of the n decision making units whose inputs (n,m) and outputs (n,s)
+// are the rows of the matrices. The efficiency of the unit o is the envelopment problem:
+// Minimize θ
+// Constraints:
+// 1<=i<=m,  Σ(1<=j<=n) λ_j*x_j_i <= θ*x_o_i
+// 1<=r<=s,  Σ(1<=j<=n) λ_j*y_j_r >= y_o_r
+// Σ(1<=j<=n) λ_j = 1 with BCC
+// λ >= 0
+// The n problems are solved with Simplex by WithThreads workers, the number of CPUs by default,
+// the other options are given to Simplex. It returns the scores in the order of the units.
+func DEA(inputs, outputs *mat.Dense, model DEAModel, opts ...Option) ([]DEAScore, error) {
+	if inputs == nil || outputs == nil {
+		return nil, newError(ErrDimensionMismatch, "inputs and outputs must not be nil")
+	}
+	n, _ := inputs.Dims()
+	if rows, _ := outputs.Dims(); rows != n {
+		return nil, newError(ErrDimensionMismatch, "outputs dims.r must be inputs dims.r = %d", n)
+	}
+	threads := newOptions(opts).threads
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+
+	scores := make([]DEAScore, n)
+	errs := make([]error, n)
+	units := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < threads; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for o := range units {
+				scores[o], errs[o] = envelopment(inputs, outputs, o, model, opts)
+			}
+		}()
+	}
+	for o := 0; o < n; o++ {
+		units <- o
+	}
+	close(units)
+	wg.Wait()
+
+	for o, err := range errs {
+		if err != nil {
+			return nil, errors.Wrapf(err, "unit %d", o)
+		}
+	}
+	return scores, nil
+}
+
+// envelopment Solve the envelopment problem of the unit o, the variables are (θ, λ)
+func envelopment(inputs, outputs *mat.Dense, o int, model DEAModel, opts []Option) (DEAScore, error) {
+	n, m := inputs.Dims()
+	_, s := outputs.Dims()
+	rows := m + s
+	if model == BCC {
+		rows += 2
+	}
+	c := mat.NewDense(1, n+1, nil)
+	c.Set(0, 0, -1)
+	A := mat.NewDense(rows, n+1, nil)
+	b := mat.NewDense(rows, 1, nil)
+	for i := 0; i < m; i++ {
+		A.Set(i, 0, -inputs.At(o, i))
+		for j := 0; j < n; j++ {
+			A.Set(i, 1+j, inputs.At(j, i))
+		}
+	}
+	for r := 0; r < s; r++ {
+		for j := 0; j < n; j++ {
+			A.Set(m+r, 1+j, -outputs.At(j, r))
+		}
+		b.Set(m+r, 0, -outputs.At(o, r))
+	}
+	if model == BCC {
+		for j := 0; j < n; j++ {
+			A.Set(m+s, 1+j, 1)
+			A.Set(m+s+1, 1+j, -1)
+		}
+		b.Set(m+s, 0, 1)
+		b.Set(m+s+1, 0, -1)
+	}
+	_, results, _, err := Simplex(c, A, b, opts...)
+	if err != nil {
+		return DEAScore{}, err
+	}
+	score := DEAScore{Efficiency: results.At(0, 0), Lambda: make([]float64, n), Reference: []int{}}
+	for j := 0; j < n; j++ {
+		score.Lambda[j] = results.At(1+j, 0)
+		if score.Lambda[j] > epsilon {
+			score.Reference = append(score.Reference, j)
+		}
+	}
+	return score, nil
+}
@@ -0,0 +1,62 @@
+package goptimization
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestDEA(t *testing.T) {
+	// One input and one output: (2,2), (4,3), (3,1), (5,5)
+	inputs := mat.NewDense(4, 1, []float64{2, 4, 3, 5})
+	outputs := mat.NewDense(4, 1, []float64{2, 3, 1, 5})
+
+	// With constant returns the efficiency is the ratio output/input relative to the best ratio
+	scores, err := DEA(inputs, outputs, CCR, WithThreads(2))
+	require.NoError(t, err)
+	require.Len(t, scores, 4)
+	for o, expected := range []float64{1, 0.75, 1.0 / 3, 1} {
+		assert.InDelta(t, expected, scores[o].Efficiency, 0.000001)
+	}
+
+	// With variable returns the unit (3,1) is compared with (2,2) only
+	scores, err = DEA(inputs, outputs, BCC)
+	require.NoError(t, err)
+	for o, expected := range []float64{1, 0.75, 2.0 / 3, 1} {
+		assert.InDelta(t, expected, scores[o].Efficiency, 0.000001)
+	}
+	assert.Equal(t, []int{0}, scores[2].Reference)
+	assert.InDelta(t, 1, scores[2].Lambda[0], 0.000001)
+	assert.Equal(t, []int{0, 3}, scores[1].Reference)
+
+	_, err = DEA(inputs, mat.NewDense(3, 1, nil), CCR)
+	assert.Error(t, err)
+}
+
+func TestDEAThreads(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	inputs, outputs := mat.NewDense(20, 2, nil), mat.NewDense(20, 2, nil)
+	for _, M := range []*mat.Dense{inputs, outputs} {
+		M.Apply(func(i, j int, v float64) float64 {
+			return 1 + 9*rnd.Float64()
+		}, M)
+	}
+	for _, model := range []DEAModel{CCR, BCC} {
+		sequential, err := DEA(inputs, outputs, model, WithThreads(1))
+		require.NoError(t, err)
+		parallel, err := DEA(inputs, outputs, model, WithThreads(4))
+		require.NoError(t, err)
+		assert.Equal(t, sequential, parallel)
+		efficient := 0
+		for _, score := range sequential {
+			assert.True(t, score.Efficiency > 0 && score.Efficiency <= 1+0.000001)
+			if score.Efficiency > 1-0.000001 {
+				efficient++
+			}
+		}
+		assert.True(t, efficient > 0)
+	}
+}
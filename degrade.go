@@ -0,0 +1,154 @@
+package goptimization
+
+import (
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// degradedGap Relative gap of the branch and bound when the estimate exceeds the limits by less than degradedPressure
+const degradedGap = 0.01
+
+// degradedPressure Ratio of the estimate to the limits above which a MIP only explores its root with the heuristics
+const degradedPressure = 10
+
+// Quality Guarantee given by a solver on the score of the solution it returns
+type Quality int
+
+const (
+	// Optimal The score is optimal
+	Optimal Quality = iota
+	// Approximate The score is within a relative gap of the optimum, degradedGap for SolveDegraded
+	Approximate
+	// Feasible The solution satisfies the constraints, there is no bound on its distance to the optimum
+	Feasible
+)
+
+// Suboptimal Former name of Feasible.
+//
+// Deprecated: use Feasible.
+const Suboptimal = Feasible
+
+// String Name of the quality level
+func (q Quality) String() string {
+	switch q {
+	case Optimal:
+		return "optimal"
+	case Approximate:
+		return "approximate"
+	case Feasible:
+		return "feasible"
+	}
+	return "unknown"
+}
+
+// ResourceLimits Memory and time allowed to a solve, the zero values disable them
+type ResourceLimits struct {
+	MaxBytes  uint64
+	TimeLimit time.Duration
+}
+
+// pressure Ratio of the estimate to the limits, the largest of the memory and time ratios
+func (l ResourceLimits) pressure(e ResourceEstimate) float64 {
+	r := 0.0
+	if l.MaxBytes > 0 {
+		r = math.Max(r, float64(e.PeakBytes)/float64(l.MaxBytes))
+	}
+	if l.TimeLimit > 0 {
+		r = math.Max(r, float64(e.Time)/float64(l.TimeLimit))
+	}
+	return r
+}
+
+// SolveDegraded Solve the model within the limits, switching to cheaper strategies when EstimateResources predicts
+// that the exact solve does not fit and labelling the quality of the solution:
+// - the estimate fits: exact solve, the search stops with its incumbent if a limit is reached anyway
+// - the estimate exceeds the limits up to degradedPressure times: the branch and bound prunes with a relative gap
+// of degradedGap
+// - beyond: only the root relaxation and the primal heuristics are run
+// A linear model is solved by the simplex algorithm stopped at the time limit, after the phase one
+// the dictionary stays feasible so the last basic solution is returned. There is no first-order method,
+// a model whose dictionary alone exceeds MaxBytes is rejected.
+// maxIter is the maximum number of simplex iterations or of explored nodes. The options are those of Model.Solve,
+// limits.TimeLimit replaces WithTimeLimit and the gap of the degraded search is at least degradedGap.
+func (m *Model) SolveDegraded(maxIter int, limits ResourceLimits, opts ...Option) (*ModelSolution, error) {
+	err := m.check()
+	if err != nil {
+		return nil, err
+	}
+	estimate := m.EstimateResources()
+	if limits.MaxBytes > 0 && uint64(estimate.DictionaryBytes) > limits.MaxBytes {
+		return nil, errors.Errorf("the dictionary needs %d bytes, more than the limit of %d bytes",
+			estimate.DictionaryBytes, limits.MaxBytes)
+	}
+	o := newOptions(opts)
+	deadline := o.deadline()
+	if limits.TimeLimit > 0 {
+		deadline = time.Now().Add(limits.TimeLimit)
+	}
+	c, A, b, integer := m.Standard()
+
+	if estimate.Integers == 0 {
+		results, score, quality, err := truncatedSimplex(c, A, b, maxIter, deadline, o)
+		if err != nil {
+			return nil, err
+		}
+		return m.solution(results, score, quality), nil
+	}
+
+	bb, err := newMIP(c, A, b, integer, opts)
+	if err != nil {
+		return nil, err
+	}
+	bb.Deadline = deadline
+	bb.MaxBytes = limits.MaxBytes
+	r := limits.pressure(estimate)
+	if r > 1 {
+		bb.Gap = math.Max(bb.Gap, degradedGap)
+	}
+	if r > degradedPressure {
+		maxIter = 1
+	}
+	results, score, err := bb.Solve(maxIter)
+	if err != nil {
+		return nil, err
+	}
+	quality := Optimal
+	if bb.Stopped != "" {
+		quality = Feasible
+	} else if bb.Gap > 0 {
+		quality = Approximate
+	}
+	return m.solution(results, score, quality), nil
+}
+
+// truncatedSimplex Two-phase simplex whose primal iterations stop at the deadline or once the context of o is done,
+// the solution is Optimal if no entering variable is left and Feasible otherwise
+func truncatedSimplex(c, A, b *mat.Dense, maxIter int, deadline time.Time, o options) (*mat.Dense, float64, Quality, error) {
+	cf := CanonicalForm{}
+	err := cf.New(mat.DenseCopyOf(c), mat.DenseCopyOf(A), mat.DenseCopyOf(b))
+	if err != nil {
+		return nil, 0, Feasible, err
+	}
+	cf.configure(o)
+	maxIter = iterationLimit(maxIter, cf.n, cf.m)
+	iter, err := cf.phaseOne(maxIter)
+	if err != nil {
+		return nil, 0, Feasible, err
+	}
+	quality := Feasible
+	for ; iter < maxIter && (deadline.IsZero() || time.Now().Before(deadline)) && !o.cancelled(); iter++ {
+		end, err := cf.Iter(0)
+		if err != nil {
+			return nil, 0, Feasible, err
+		}
+		if end {
+			quality = Optimal
+			break
+		}
+	}
+	values, score := cf.values()
+	return mat.NewDense(len(values), 1, values), score, quality, nil
+}
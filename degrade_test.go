@@ -0,0 +1,99 @@
+package goptimization
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// degradedModel Maximize 5x+4y, 6x+4y <= 24, x+2y <= 6, the relaxation (3, 1.5) is fractional
+func degradedModel(integer bool) (*Model, Var, Var) {
+	m := &Model{}
+	x := m.AddVariable("x", integer)
+	y := m.AddVariable("y", integer)
+	m.Maximize(Expr{Terms: []Term{{x, 5}, {y, 4}}})
+	m.AddConstraint(Expr{Terms: []Term{{x, 6}, {y, 4}}}, 24)
+	m.AddConstraint(Expr{Terms: []Term{{x, 1}, {y, 2}}}, 6)
+	return m, x, y
+}
+
+func TestSolveDegraded(t *testing.T) {
+	m, _, _ := degradedModel(true)
+	solution, err := m.SolveDegraded(100, ResourceLimits{MaxBytes: 1 << 30, TimeLimit: time.Minute}, silent)
+	require.NoError(t, err)
+	assert.Equal(t, Optimal, solution.Quality)
+	assert.InDelta(t, 20.0, solution.Score, 0.000001)
+
+	// The estimate exceeds the limit by far, only the root and the heuristics are run,
+	// the incumbent of the heuristics closes the gap of the root
+	solution, err = m.SolveDegraded(100, ResourceLimits{TimeLimit: time.Nanosecond}, silent)
+	require.NoError(t, err)
+	assert.Equal(t, Approximate, solution.Quality)
+	assert.Equal(t, "approximate", solution.Quality.String())
+	assert.True(t, solution.Score <= 20.0+0.000001)
+	assert.True(t, solution.Value(0)*6+solution.Value(1)*4 <= 24+0.000001)
+
+	_, err = m.SolveDegraded(100, ResourceLimits{MaxBytes: 8}, silent)
+	assert.Error(t, err)
+}
+
+func TestSolveDegradedLinear(t *testing.T) {
+	m, x, y := degradedModel(false)
+	solution, err := m.SolveDegraded(100, ResourceLimits{}, silent)
+	require.NoError(t, err)
+	assert.Equal(t, Optimal, solution.Quality)
+	assert.InDelta(t, 21.0, solution.Score, 0.000001)
+	assert.InDelta(t, 3.0, solution.Value(x), 0.000001)
+	assert.InDelta(t, 1.5, solution.Value(y), 0.000001)
+
+	// No primal iteration before the deadline, the slack basis is returned
+	solution, err = m.SolveDegraded(100, ResourceLimits{TimeLimit: time.Nanosecond}, silent)
+	require.NoError(t, err)
+	assert.Equal(t, Feasible, solution.Quality)
+	assert.InDelta(t, 0.0, solution.Score, 0.000001)
+}
+
+func TestBranchAndBoundGap(t *testing.T) {
+	m, _, _ := degradedModel(true)
+	c, A, b, integer := m.Standard()
+	bb := BranchAndBound{}
+	require.NoError(t, bb.New(c, A, b, integer))
+	bb.Gap = 1
+	_, score, err := bb.Solve(100)
+	require.NoError(t, err)
+	assert.Equal(t, "", bb.Stopped)
+	assert.True(t, score >= 10.0-0.000001)
+
+	// Without cuts and heuristics the root branches, the search stops after it
+	require.NoError(t, bb.New(c, A, b, integer))
+	bb.CutRounds = 0
+	bb.Heuristics = nil
+	bb.Deadline = time.Now()
+	_, _, err = bb.Solve(100)
+	assert.Error(t, err)
+	assert.Equal(t, "time", bb.Stopped)
+	assert.Equal(t, 1, bb.Nodes)
+}
+
+func TestSolveDegradedOptions(t *testing.T) {
+	buf := &bytes.Buffer{}
+	m, _, _ := degradedModel(true)
+	_, err := m.SolveDegraded(100, ResourceLimits{}, WithLogger(log.New(buf, "", 0)))
+	require.NoError(t, err)
+	assert.NotEmpty(t, buf.String())
+
+	// The context stops the linear solve before its first primal iteration
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	m, _, _ = degradedModel(false)
+	buf.Reset()
+	solution, err := m.SolveDegraded(100, ResourceLimits{}, WithContext(ctx), WithLogger(log.New(buf, "", 0)))
+	require.NoError(t, err)
+	assert.Equal(t, Feasible, solution.Quality)
+	assert.InDelta(t, 0.0, solution.Score, 0.000001)
+}
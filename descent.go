@@ -0,0 +1,108 @@
+package goptimization
+
+import (
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// descentIterations Iteration limit of Minimize when maxIter <= 0
+	descentIterations = 10000
+	// armijo Fraction of the decrease predicted by the gradient that a step of the line search must achieve
+	armijo = 1e-4
+	// lineSearchSteps Maximum number of halvings of the step by the line search
+	lineSearchSteps = 50
+)
+
+// Gradient Gradient of an Objective at x
+type Gradient func(x []float64) []float64
+
+// Minimize Minimize the smooth function f from x0 by gradient descent. Each move is -step*grad(x),
+// plus beta times the previous move with WithMomentum or WithNesterov, the step being fixed by WithStepSize
+// or chosen by WithLineSearch. The descent stops once the norm of the gradient is at most the tolerance.
+// It returns the number of iterations, the point reached and its value, with ErrIterationLimit when
+// the limit given by WithMaxIter (10000 by default) or WithTimeLimit is reached first.
+func Minimize(f Objective, grad Gradient, x0 []float64, opts ...Option) (int, []float64, float64, error) {
+	if f == nil || grad == nil {
+		return 0, nil, 0, errors.New("f and grad must be defined")
+	}
+	if len(x0) == 0 {
+		return 0, nil, 0, newError(ErrDimensionMismatch, "x0 must not be empty")
+	}
+	o := newOptions(opts)
+	if o.stepSize <= 0 {
+		return 0, nil, 0, errors.Errorf("the step must be positive, got %g", o.stepSize)
+	}
+	maxIter := o.maxIter
+	if maxIter <= 0 {
+		maxIter = descentIterations
+	}
+	var deadline time.Time
+	if o.timeLimit > 0 {
+		deadline = time.Now().Add(o.timeLimit)
+	}
+
+	n := len(x0)
+	x := append([]float64(nil), x0...)
+	move := make([]float64, n)
+	y := make([]float64, n)
+	iter := 0
+	for ; iter < maxIter && (deadline.IsZero() || time.Now().Before(deadline)); iter++ {
+		// Nesterov evaluates the gradient at the point reached by the momentum
+		copy(y, x)
+		if o.nesterov {
+			for j := range y {
+				y[j] += o.momentum * move[j]
+			}
+		}
+		g := grad(y)
+		if len(g) != n {
+			return iter, nil, 0, newError(ErrDimensionMismatch, "len(grad) = %d, expected %d", len(g), n)
+		}
+		if norm(g) <= o.tolerance {
+			copy(x, y)
+			return iter, x, f(x), nil
+		}
+		step := o.stepSize
+		if o.lineSearch {
+			step = backtrack(f, y, g, step)
+		}
+		for j := range x {
+			next := y[j] - step*g[j]
+			if !o.nesterov {
+				next += o.momentum * move[j]
+			}
+			move[j] = next - x[j]
+			x[j] = next
+		}
+	}
+	return iter, x, f(x), ErrIterationLimit
+}
+
+// backtrack Halve step until f(x - step*g) <= f(x) - armijo*step*|g|²
+func backtrack(f Objective, x, g []float64, step float64) float64 {
+	fx := f(x)
+	decrease := armijo * norm(g) * norm(g)
+	trial := make([]float64, len(x))
+	for k := 0; k < lineSearchSteps; k++ {
+		for j := range x {
+			trial[j] = x[j] - step*g[j]
+		}
+		if f(trial) <= fx-step*decrease {
+			break
+		}
+		step /= 2
+	}
+	return step
+}
+
+// norm Euclidean norm of v
+func norm(v []float64) float64 {
+	sum := 0.0
+	for _, value := range v {
+		sum += value * value
+	}
+	return math.Sqrt(sum)
+}
@@ -0,0 +1,47 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinimize(t *testing.T) {
+	//Ill-conditioned quadratic, minimum 3 at (1, -2)
+	f := func(x []float64) float64 {
+		return (x[0]-1)*(x[0]-1) + 20*(x[1]+2)*(x[1]+2) + 3
+	}
+	grad := func(x []float64) []float64 {
+		return []float64{2 * (x[0] - 1), 40 * (x[1] + 2)}
+	}
+	x0 := []float64{5, 5}
+
+	iterations := map[string]int{}
+	for name, opts := range map[string][]Option{
+		"fixed":       {WithStepSize(0.02)},
+		"momentum":    {WithStepSize(0.02), WithMomentum(0.8)},
+		"nesterov":    {WithStepSize(0.02), WithNesterov(0.8)},
+		"line search": {WithStepSize(1), WithLineSearch()},
+	} {
+		iter, x, value, err := Minimize(f, grad, x0, append(opts, WithTolerance(0.000001))...)
+		require.NoError(t, err, name)
+		assert.InDelta(t, 1, x[0], 0.00001, name)
+		assert.InDelta(t, -2, x[1], 0.00001, name)
+		assert.InDelta(t, 3, value, 0.000001, name)
+		iterations[name] = iter
+	}
+	assert.Less(t, iterations["momentum"], iterations["fixed"])
+	assert.Less(t, iterations["nesterov"], iterations["fixed"])
+	assert.Equal(t, []float64{5, 5}, x0)
+
+	iter, x, _, err := Minimize(f, grad, x0, WithStepSize(0.02), WithMaxIter(5))
+	assert.Equal(t, ErrIterationLimit, err)
+	assert.Equal(t, 5, iter)
+	assert.Len(t, x, 2)
+
+	_, _, _, err = Minimize(f, grad, []float64{1, 2, 3})
+	assert.Error(t, err)
+	_, _, _, err = Minimize(f, grad, x0, WithStepSize(0))
+	assert.Error(t, err)
+}
@@ -0,0 +1,90 @@
+package goptimization
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// ElasticReport Breakdown of the objective of a problem with elastic constraints
+type ElasticReport struct {
+	// Objective Score of the solution, the true objective minus the penalties
+	Objective float64
+	// TrueObjective Σ(1<=j<=n) c_j*x_j
+	TrueObjective float64
+	// Violations Amount by which each constraint is violated
+	Violations []float64
+	// Penalties Penalty paid by each group of constraints
+	Penalties map[string]float64
+}
+
+// Elastic Solve a linear problem where some constraints can be violated at a price
+// Maximize z = Σ(1<=j<=n) c_j*x_j - Σ(1<=i<=m) p_i*e_i
+// Constraints:
+// 1<=i<=m,  Σ(1<=j<=n) a_i_j*x_j - e_i <= b_i
+// 1<=j<=n x_j >= 0, 1<=i<=m e_i >= 0
+// A constraint with a zero penalty stays hard, it does not get an elastic variable.
+// The penalties are reported by group, a constraint without group is reported alone under its index.
+// It returns a matrix (n+m,1) like Simplex where a violated constraint has a negative leftover, and the report.
+// The options are given to Simplex.
+func Elastic(c, A, b *mat.Dense, penalties []float64, groups []string, maxIter int, opts ...Option) (int, *mat.Dense, *ElasticReport, error) {
+	_, n := c.Dims()
+	m, cols := A.Dims()
+	if cols != n {
+		return 0, nil, nil, newError(ErrDimensionMismatch, "A dims.c != z dims.c")
+	}
+	if len(penalties) != m || len(groups) != m {
+		return 0, nil, nil, newError(ErrDimensionMismatch, "len(penalties) and len(groups) must be A dims.r")
+	}
+
+	elastic := []int{}
+	for i, p := range penalties {
+		if p < 0 {
+			return 0, nil, nil, errors.New("penalties must be non-negative")
+		}
+		if p > 0 {
+			elastic = append(elastic, i)
+		}
+	}
+
+	elasticC := mat.NewDense(1, n+len(elastic), nil)
+	elasticC.Slice(0, 1, 0, n).(*mat.Dense).Copy(c)
+	elasticA := mat.NewDense(m, n+len(elastic), nil)
+	elasticA.Slice(0, m, 0, n).(*mat.Dense).Copy(A)
+	for k, i := range elastic {
+		elasticC.Set(0, n+k, -penalties[i])
+		elasticA.Set(i, n+k, -1)
+	}
+
+	totalIter, results, score, err := Simplex(elasticC, elasticA, mat.DenseCopyOf(b), append([]Option{WithMaxIter(maxIter)}, opts...)...)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	report := &ElasticReport{
+		Objective:  score,
+		Violations: make([]float64, m),
+		Penalties:  map[string]float64{},
+	}
+	x := mat.NewDense(n+m, 1, nil)
+	for j := 0; j < n; j++ {
+		x.Set(j, 0, results.At(j, 0))
+		report.TrueObjective += c.At(0, j) * results.At(j, 0)
+	}
+	for i := 0; i < m; i++ {
+		x.Set(n+i, 0, results.At(n+len(elastic)+i, 0))
+	}
+	for k, i := range elastic {
+		violation := results.At(n+k, 0)
+		report.Violations[i] = violation
+		x.Set(n+i, 0, x.At(n+i, 0)-violation)
+
+		group := groups[i]
+		if group == "" {
+			group = strconv.Itoa(i)
+		}
+		report.Penalties[group] += penalties[i] * violation
+	}
+	return totalIter, x, report, nil
+}
@@ -0,0 +1,45 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestElastic(t *testing.T) {
+	//Each unit of x_1 earns 10 and each unit of x_2 earns 3
+	c := mat.NewDense(1, 2, []float64{10, 3})
+	A := mat.NewDense(4, 2, []float64{
+		1, 0,
+		0, 1,
+		1, 1,
+		1, 0,
+	})
+	b := mat.NewDense(4, 1, []float64{2, 1, 6, 5})
+	//Overtime on the two machines costs 4 and 5 per unit, the total capacity costs 1 and x_1 <= 5 is hard
+	penalties := []float64{4, 5, 1, 0}
+	groups := []string{"overtime", "overtime", "", ""}
+
+	_, results, report, err := Elastic(c, A, b, penalties, groups, 20, silent)
+	require.NoError(t, err)
+
+	//x_1 = 5 violates its machine by 3, x_2 = 1 is not worth overtime but x_1 + x_2 = 6 fits
+	assert.True(t, mat.EqualApprox(mat.NewDense(6, 1, []float64{5, 1, -3, 0, 0, 0}), results, 0.000001))
+	assert.InEpsilon(t, 53.0, report.TrueObjective, 0.000001)
+	assert.InEpsilon(t, 41.0, report.Objective, 0.000001)
+	assert.InDeltaSlice(t, []float64{3, 0, 0, 0}, report.Violations, 0.000001)
+	assert.InDeltaMapValues(t, map[string]float64{"overtime": 12, "2": 0}, report.Penalties, 0.000001)
+}
+
+func TestElasticDims(t *testing.T) {
+	c := mat.NewDense(1, 2, nil)
+	A := mat.NewDense(2, 2, nil)
+	b := mat.NewDense(2, 1, nil)
+
+	_, _, _, err := Elastic(c, A, b, []float64{1}, []string{"", ""}, 20, silent)
+	assert.Error(t, err)
+	_, _, _, err = Elastic(c, A, b, []float64{1, -1}, []string{"", ""}, 20, silent)
+	assert.Error(t, err)
+}
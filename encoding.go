@@ -0,0 +1,97 @@
+package goptimization
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"github.com/pkg/errors"
+)
+
+// modelState Exported copy of a Model for gob
+type modelState struct {
+	Version   int
+	Names     []string
+	Integer   []bool
+	Binary    []bool
+	Free      []bool
+	Objective Expr
+	Rows      []Expr
+	RHS       []float64
+	RowNames  []string
+	Ranges    []float64
+}
+
+// GobEncode Encode the variables, the objective and the constraints of the model with their names,
+// m can then be cached or sent to another process with encoding/gob
+func (m *Model) GobEncode() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	err := gob.NewEncoder(buf).Encode(modelState{
+		Version:   checkpointVersion,
+		Names:     m.names,
+		Integer:   m.integer,
+		Binary:    m.binary,
+		Free:      m.free,
+		Objective: m.objective,
+		Rows:      m.rows,
+		RHS:       m.rhs,
+		RowNames:  m.rowNames,
+		Ranges:    m.ranges,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "encode model")
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode Replace the model by the one encoded by GobEncode
+func (m *Model) GobDecode(data []byte) error {
+	s := modelState{}
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&s)
+	if err != nil {
+		return errors.Wrap(err, "decode model")
+	}
+	if s.Version != checkpointVersion {
+		return errors.Errorf("model version %d, expected %d", s.Version, checkpointVersion)
+	}
+	if len(s.Integer) != len(s.Names) || len(s.Binary) != len(s.Names) || len(s.Free) > len(s.Names) || len(s.RHS) != len(s.Rows) || len(s.RowNames) != len(s.Rows) ||
+		len(s.Ranges) > len(s.Rows) {
+		return newError(ErrDimensionMismatch, "the encoded model has inconsistent dimensions")
+	}
+	for _, e := range append([]Expr{s.Objective}, s.Rows...) {
+		for _, t := range e.Terms {
+			if t.Var < 0 || int(t.Var) >= len(s.Names) {
+				return errors.Errorf("variable %d is not in the encoded model", t.Var)
+			}
+		}
+	}
+	*m = Model{
+		names:     s.Names,
+		integer:   s.Integer,
+		binary:    s.Binary,
+		free:      s.Free,
+		objective: s.Objective,
+		rows:      s.Rows,
+		rhs:       s.RHS,
+		rowNames:  s.RowNames,
+		ranges:    s.Ranges,
+	}
+	return nil
+}
+
+// GobEncode Encode the dictionary like WriteCheckpoint, the pivot gate and the logger are not encoded
+func (cf *CanonicalForm) GobEncode() ([]byte, error) {
+	if cf.A == nil {
+		return nil, errors.New("the canonical form is not initialized")
+	}
+	buf := &bytes.Buffer{}
+	err := cf.WriteCheckpoint(buf)
+	if err != nil {
+		return nil, errors.Wrap(err, "encode canonical form")
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode Replace the dictionary by the one encoded by GobEncode, the trace goes to the standard output
+func (cf *CanonicalForm) GobDecode(data []byte) error {
+	return cf.ReadCheckpoint(bytes.NewReader(data))
+}
@@ -0,0 +1,78 @@
+package goptimization
+
+import (
+	"bytes"
+	"encoding/gob"
+	"io/ioutil"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestModelGob(t *testing.T) {
+	m := &Model{}
+	x := m.AddVariable("x", true)
+	y := m.AddBinary("y")
+	m.Maximize(Expr{Terms: []Term{{x, 5}, {y, 4}}, Constant: 1})
+	require.NoError(t, m.AddConstraint(Expr{Terms: []Term{{x, 6}, {y, 4}}}, 24))
+	require.NoError(t, m.AddNamedRow("demand", Expr{Terms: []Term{{x, 1}, {y, 1}}}, GreaterEq, 1))
+
+	// A model inside another value, as sent to another process
+	type message struct {
+		ID    int
+		Model *Model
+	}
+	buf := &bytes.Buffer{}
+	require.NoError(t, gob.NewEncoder(buf).Encode(message{ID: 7, Model: m}))
+	decoded := message{}
+	require.NoError(t, gob.NewDecoder(buf).Decode(&decoded))
+	assert.Equal(t, 7, decoded.ID)
+	assert.Equal(t, m, decoded.Model)
+	assert.True(t, decoded.Model.IsBinary(y))
+
+	expected, err := m.Solve(100, silent)
+	require.NoError(t, err)
+	solution, err := decoded.Model.Solve(100, silent)
+	require.NoError(t, err)
+	assert.Equal(t, expected, solution)
+
+	empty := &Model{}
+	data, err := empty.GobEncode()
+	require.NoError(t, err)
+	require.NoError(t, decoded.Model.GobDecode(data))
+	assert.Equal(t, 0, len(decoded.Model.names))
+
+	assert.Error(t, decoded.Model.GobDecode([]byte("not a model")))
+	bad := &Model{names: []string{"x"}, integer: []bool{false}, binary: []bool{false}, rows: []Expr{Var(3).Expr()}, rhs: []float64{1}, rowNames: []string{""}}
+	data, err = bad.GobEncode()
+	require.NoError(t, err)
+	assert.Error(t, decoded.Model.GobDecode(data))
+}
+
+func TestCanonicalFormGob(t *testing.T) {
+	c, A, b, _ := knapsack(8, 3)
+	cf := &CanonicalForm{}
+	require.NoError(t, cf.New(c, A, b))
+	cf.logger = log.New(ioutil.Discard, "", 0)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, gob.NewEncoder(buf).Encode(cf))
+	decoded := &CanonicalForm{}
+	require.NoError(t, gob.NewDecoder(buf).Decode(decoded))
+	decoded.logger = cf.logger
+
+	_, err := cf.Reoptimize(1000)
+	require.NoError(t, err)
+	_, err = decoded.Reoptimize(1000)
+	require.NoError(t, err)
+	results, score := cf.GetResults()
+	decodedResults, decodedScore := decoded.GetResults()
+	assert.Equal(t, score, decodedScore)
+	assert.True(t, mat.Equal(results, decodedResults))
+
+	_, err = (&CanonicalForm{}).GobEncode()
+	assert.Error(t, err)
+}
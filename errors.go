@@ -0,0 +1,126 @@
+package goptimization
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrInfeasible The constraints of the problem cannot be satisfied
+	ErrInfeasible = errors.New("problem is infeasible")
+	// ErrUnbounded The objective can increase without limit
+	ErrUnbounded = errors.New("problem is unbounded")
+	// ErrDimensionMismatch The dimensions of the inputs are inconsistent
+	ErrDimensionMismatch = errors.New("dimension mismatch")
+	// ErrSingularBasis The basis of the dictionary cannot be factorized
+	ErrSingularBasis = errors.New("singular basis")
+	// ErrIterationLimit The solver stopped at its limit of iterations, nodes, time or memory before the end
+	ErrIterationLimit = errors.New("iteration limit reached")
+	// ErrTimeLimit The solver stopped at the limit of WithTimeLimit, a kind of ErrIterationLimit:
+	// errors.Cause gives ErrIterationLimit and the standard errors.Is finds both
+	ErrTimeLimit = newError(ErrIterationLimit, "time limit reached")
+)
+
+// Status Outcome of a solve, see StatusOf
+type Status int
+
+const (
+	// StatusOptimal The solve ended without error
+	StatusOptimal Status = iota
+	// StatusInfeasible ErrInfeasible
+	StatusInfeasible
+	// StatusUnbounded ErrUnbounded
+	StatusUnbounded
+	// StatusIterationLimit ErrIterationLimit, or the cancellation of the context
+	StatusIterationLimit
+	// StatusTimeout ErrTimeLimit or the deadline of the context, the solution is the best reached, if any
+	StatusTimeout
+	// StatusFailed Any other error
+	StatusFailed
+)
+
+// StatusOf Status of a solve which returned err
+func StatusOf(err error) Status {
+	if err == nil {
+		return StatusOptimal
+	}
+	for e := err; e != nil; {
+		if e == ErrTimeLimit || e == context.DeadlineExceeded {
+			return StatusTimeout
+		}
+		unwrapper, ok := e.(interface{ Unwrap() error })
+		if !ok {
+			break
+		}
+		e = unwrapper.Unwrap()
+	}
+	switch errors.Cause(err) {
+	case ErrInfeasible:
+		return StatusInfeasible
+	case ErrUnbounded:
+		return StatusUnbounded
+	case ErrIterationLimit, context.Canceled:
+		return StatusIterationLimit
+	}
+	return StatusFailed
+}
+
+// String Name of the status, e.g. TIMEOUT
+func (s Status) String() string {
+	switch s {
+	case StatusOptimal:
+		return "OPTIMAL"
+	case StatusInfeasible:
+		return "INFEASIBLE"
+	case StatusUnbounded:
+		return "UNBOUNDED"
+	case StatusIterationLimit:
+		return "ITERATION_LIMIT"
+	case StatusTimeout:
+		return "TIMEOUT"
+	}
+	return "FAILED"
+}
+
+// limitError Error of a solve stopped before the end: ErrTimeLimit once the deadline is passed, ErrIterationLimit otherwise
+func limitError(deadline time.Time) error {
+	if expired(deadline) {
+		return newError(ErrTimeLimit, "the solve stopped at its deadline")
+	}
+	return ErrIterationLimit
+}
+
+// expired Check if the deadline, zero without limit, is passed
+func expired(deadline time.Time) bool {
+	return !deadline.IsZero() && !time.Now().Before(deadline)
+}
+
+// solverError Failure of a known kind with its details
+// Both errors.Cause and the standard errors.Is find the kind.
+type solverError struct {
+	kind   error
+	detail string
+}
+
+// newError Failure of the kind, the detail is formatted like fmt.Sprintf
+func newError(kind error, format string, args ...interface{}) error {
+	return &solverError{kind: kind, detail: fmt.Sprintf(format, args...)}
+}
+
+// Error Kind followed by the details
+func (e *solverError) Error() string {
+	return e.kind.Error() + ": " + e.detail
+}
+
+// Cause Kind of the failure, for errors.Cause
+func (e *solverError) Cause() error {
+	return e.kind
+}
+
+// Unwrap Kind of the failure, for the standard errors.Is
+func (e *solverError) Unwrap() error {
+	return e.kind
+}
@@ -0,0 +1,46 @@
+package goptimization
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestErrors(t *testing.T) {
+	_, _, _, err := Simplex(mat.NewDense(2, 2, nil), mat.NewDense(2, 2, nil), mat.NewDense(2, 1, nil), WithMaxIter(10))
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+	assert.True(t, stderrors.Is(err, ErrDimensionMismatch))
+	assert.Equal(t, "dimension mismatch: c must be a vector, got (2,2)", err.Error())
+
+	// Maximize x, x - y <= 1: x enters, then y is unbounded at the second iteration
+	iter, _, _, err := Simplex(mat.NewDense(1, 2, []float64{1, 0}), mat.NewDense(1, 2, []float64{1, -1}), mat.NewDense(1, 1, []float64{1}), WithMaxIter(10), silent)
+	assert.Equal(t, ErrUnbounded, err)
+	assert.Equal(t, 1, iter)
+
+	bb := BranchAndBound{}
+	assert.NoError(t, bb.New(mat.NewDense(1, 2, []float64{5, 4}), mat.NewDense(2, 2, []float64{6, 4, 1, 2}), mat.NewDense(2, 1, []float64{24, 6}), []bool{true, true}))
+	bb.CutRounds = 0
+	bb.Heuristics = nil
+	_, _, err = bb.Solve(1)
+	assert.Equal(t, ErrIterationLimit, errors.Cause(err))
+}
+
+func TestStatusOf(t *testing.T) {
+	assert.Equal(t, StatusOptimal, StatusOf(nil))
+	assert.Equal(t, StatusInfeasible, StatusOf(newError(ErrInfeasible, "phase one")))
+	assert.Equal(t, StatusUnbounded, StatusOf(ErrUnbounded))
+	assert.Equal(t, StatusIterationLimit, StatusOf(ErrIterationLimit))
+	assert.Equal(t, StatusIterationLimit, StatusOf(context.Canceled))
+	timeout := newError(ErrTimeLimit, "after 1s")
+	assert.Equal(t, StatusTimeout, StatusOf(timeout))
+	assert.Equal(t, StatusTimeout, StatusOf(context.DeadlineExceeded))
+	assert.Equal(t, ErrIterationLimit, errors.Cause(timeout))
+	assert.True(t, stderrors.Is(timeout, ErrIterationLimit))
+	assert.Equal(t, StatusFailed, StatusOf(ErrSingularBasis))
+	assert.Equal(t, "TIMEOUT", StatusTimeout.String())
+	assert.Equal(t, "OPTIMAL", StatusOptimal.String())
+}
@@ -0,0 +1,96 @@
+package goptimization
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// estimatedFlops Floating point operations per second assumed by EstimateResources
+	estimatedFlops = 1e9
+	// maxEstimatedNodes Cap on the number of nodes predicted for a MIP
+	maxEstimatedNodes = 100000
+)
+
+// ResourceEstimate Prediction of the resources needed to solve a problem, made before the solve starts
+type ResourceEstimate struct {
+	Variables   int
+	Constraints int
+	Integers    int
+	// Density Share of nonzero coefficients in the constraints
+	Density float64
+
+	// DictionaryBytes Memory of one dense dictionary: A (m, n+m), c, x, b and xBStar
+	DictionaryBytes int64
+	// PeakBytes Dictionaries alive at the same time (the depth first stack of the branch and bound)
+	// and the factorizations of B of an iteration
+	PeakBytes int64
+	// Iterations Simplex iterations predicted for the relaxation of the root, 3m
+	Iterations int
+	// Nodes Nodes predicted for the branch and bound, 2^Integers capped, 1 for a linear problem
+	Nodes int
+	// Time Rough solve time: each iteration factorizes B twice and prices the n nonbasic columns,
+	// the children re-optimize in a tenth of the iterations of the root
+	Time time.Duration
+}
+
+// EstimateResources Predict the peak memory and the solve time of the model from its dimensions and integrality,
+// so that oversized models can be rejected or routed before the solve.
+// The estimate follows the dense implementation of CanonicalForm, it is an order of magnitude.
+func (m *Model) EstimateResources() ResourceEstimate {
+	c, A, _, integer := m.Standard()
+	_, n := c.Dims()
+	rows, _ := A.Dims()
+	nonZeros := 0
+	for i := 0; i < rows; i++ {
+		for j := 0; j < n; j++ {
+			if A.At(i, j) != 0 {
+				nonZeros++
+			}
+		}
+	}
+	integers := 0
+	for _, isInteger := range integer {
+		if isInteger {
+			integers++
+		}
+	}
+	return estimateResources(n, rows, nonZeros, integers)
+}
+
+// estimateResources Estimate for n variables, m constraints, nonZeros coefficients and integers integer variables
+func estimateResources(n, m, nonZeros, integers int) ResourceEstimate {
+	e := ResourceEstimate{Variables: n, Constraints: m, Integers: integers}
+	if n > 0 && m > 0 {
+		e.Density = float64(nonZeros) / float64(n*m)
+	}
+
+	nm := int64(n + m)
+	e.DictionaryBytes = 8 * (int64(m)*nm + 2*nm + 2*int64(m))
+
+	e.Nodes = 1
+	alive := int64(1)
+	if integers > 0 {
+		e.Nodes = maxEstimatedNodes
+		if integers < 17 {
+			e.Nodes = 1 << uint(integers)
+		}
+		alive = int64(2*integers + 1)
+		if alive > int64(e.Nodes) {
+			alive = int64(e.Nodes)
+		}
+	}
+	// Each branching adds a row to the dictionary of the child
+	child := 8 * (int64(m+integers)*(nm+int64(integers)) + 2*(nm+int64(integers)) + 2*int64(m+integers))
+	factorizations := 3 * 8 * int64(m+integers) * int64(m+integers)
+	e.PeakBytes = e.DictionaryBytes + (alive-1)*child + factorizations
+
+	e.Iterations = 3 * m
+	if e.Iterations == 0 {
+		e.Iterations = 1
+	}
+	perIteration := 4.0/3*math.Pow(float64(m), 3) + 2*float64(m)*float64(n)
+	iterations := float64(e.Iterations) + float64(e.Nodes-1)*math.Max(1, float64(e.Iterations)/10)
+	e.Time = time.Duration(iterations * perIteration / estimatedFlops * float64(time.Second))
+	return e
+}
@@ -0,0 +1,43 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateResources(t *testing.T) {
+	build := func(n, m int, integer bool) *Model {
+		model := &Model{}
+		vars := make([]Var, n)
+		for j := range vars {
+			vars[j] = model.AddVariable("", integer)
+		}
+		for i := 0; i < m; i++ {
+			assert.NoError(t, model.AddConstraint(Expr{Terms: []Term{{vars[i%n], 1}}}, 1))
+		}
+		return model
+	}
+
+	lp := build(2, 3, false).EstimateResources()
+	assert.Equal(t, 2, lp.Variables)
+	assert.Equal(t, 3, lp.Constraints)
+	assert.Equal(t, 0, lp.Integers)
+	assert.InDelta(t, 0.5, lp.Density, 0.000001)
+	//A (3, 5), c and x (5), b and xBStar (3)
+	assert.Equal(t, int64(8*(15+10+6)), lp.DictionaryBytes)
+	assert.Equal(t, 1, lp.Nodes)
+	assert.Equal(t, 9, lp.Iterations)
+
+	mip := build(2, 3, true).EstimateResources()
+	assert.Equal(t, 4, mip.Nodes)
+	assert.Greater(t, mip.PeakBytes, lp.PeakBytes)
+	assert.Greater(t, int64(mip.Time), int64(lp.Time))
+
+	large := build(50, 200, false).EstimateResources()
+	assert.Greater(t, large.PeakBytes, lp.PeakBytes)
+	assert.Greater(t, int64(large.Time), int64(lp.Time))
+
+	huge := build(40, 40, true).EstimateResources()
+	assert.Equal(t, maxEstimatedNodes, huge.Nodes)
+}
@@ -0,0 +1,183 @@
+// Package examples Gallery of ready-to-run models with their expected optimal score,
+// for smoke tests, demos and onboarding.
+package examples
+
+import (
+	"sort"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Model Problem in the standard form of goptimization.Simplex
+// Maximize z = Σ c_j*x_j, Ax <= b, x >= 0, and x_j integer if Integer[j]
+type Model struct {
+	Name        string
+	Description string
+	C           *mat.Dense
+	A           *mat.Dense
+	B           *mat.Dense
+	// Integer Integrality of the variables, nil for a linear problem
+	Integer []bool
+	// Variables Name of each variable
+	Variables []string
+	// Optimum Expected optimal score
+	Optimum float64
+}
+
+// Solve Solve the model with goptimization.Simplex, or goptimization.MIP if it has integer variables.
+// maxIter is the maximum number of simplex iterations or of explored nodes.
+func (m *Model) Solve(maxIter int) (*mat.Dense, float64, error) {
+	if m.Integer != nil {
+		_, results, score, err := goptimization.MIP(m.C, m.A, m.B, m.Integer, maxIter)
+		return results, score, err
+	}
+	_, results, score, err := goptimization.Simplex(m.C, m.A, m.B, goptimization.WithMaxIter(maxIter))
+	return results, score, err
+}
+
+// registry Builders of the models, each call returns new matrices
+var registry = map[string]func() *Model{
+	"diet":       Diet,
+	"transport":  Transport,
+	"knapsack":   Knapsack,
+	"scheduling": Scheduling,
+}
+
+// Register Add a model to the gallery, the builder must return new matrices at each call
+func Register(name string, build func() *Model) error {
+	if _, ok := registry[name]; ok {
+		return errors.Errorf("model %s is already registered", name)
+	}
+	registry[name] = build
+	return nil
+}
+
+// Get Build the model registered under name
+func Get(name string) (*Model, error) {
+	build, ok := registry[name]
+	if !ok {
+		return nil, errors.Errorf("unknown model %s", name)
+	}
+	return build(), nil
+}
+
+// Names Names of the registered models, sorted
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Diet Cheapest diet covering the calories, protein and calcium requirements.
+// The primal min Σ cost_f*x_f subject to Σ nutrient_n_f*x_f >= requirement_n has >= constraints,
+// the model is its dual: maximize Σ requirement_n*y_n subject to Σ nutrient_n_f*y_n <= cost_f.
+// The optimum is the minimal cost of the diet.
+func Diet() *Model {
+	return &Model{
+		Name:        "diet",
+		Description: "Dual of the cheapest diet of oats, milk, eggs and beans covering 2000 calories, 55g of protein and 800mg of calcium",
+		C:           mat.NewDense(1, 3, []float64{2000, 55, 800}),
+		A: mat.NewDense(4, 3, []float64{
+			110, 4, 2,
+			160, 8, 285,
+			180, 13, 54,
+			260, 14, 80,
+		}),
+		B:         mat.NewDense(4, 1, []float64{0.3, 0.2, 0.5, 0.4}),
+		Variables: []string{"calories", "protein", "calcium"},
+		Optimum:   2.5,
+	}
+}
+
+// Transport Cheapest shipment from 2 plants with supplies (35, 50) to 3 markets with demands (30, 25, 30).
+// Like Diet, the model is the dual of the transportation problem: maximize Σ d_j*v_j - Σ s_i*u_i
+// subject to v_j - u_i <= cost_i_j. The optimum is the minimal cost of the shipment.
+func Transport() *Model {
+	return &Model{
+		Name:        "transport",
+		Description: "Dual of the cheapest shipment from 2 plants to 3 markets",
+		C:           mat.NewDense(1, 5, []float64{30, 25, 30, -35, -50}),
+		A: mat.NewDense(6, 5, []float64{
+			1, 0, 0, -1, 0,
+			0, 1, 0, -1, 0,
+			0, 0, 1, -1, 0,
+			1, 0, 0, 0, -1,
+			0, 1, 0, 0, -1,
+			0, 0, 1, 0, -1,
+		}),
+		B:         mat.NewDense(6, 1, []float64{4, 6, 9, 5, 3, 8}),
+		Variables: []string{"market1", "market2", "market3", "plant1", "plant2"},
+		Optimum:   440,
+	}
+}
+
+// Knapsack 0-1 knapsack with 4 items and a capacity of 14
+func Knapsack() *Model {
+	return &Model{
+		Name:        "knapsack",
+		Description: "0-1 knapsack with 4 items of weights (5, 7, 4, 3) and values (8, 11, 6, 4)",
+		C:           mat.NewDense(1, 4, []float64{8, 11, 6, 4}),
+		A: mat.NewDense(5, 4, []float64{
+			5, 7, 4, 3,
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, 1, 0,
+			0, 0, 0, 1,
+		}),
+		B:         mat.NewDense(5, 1, []float64{14, 1, 1, 1, 1}),
+		Integer:   []bool{true, true, true, true},
+		Variables: []string{"item1", "item2", "item3", "item4"},
+		Optimum:   21,
+	}
+}
+
+// Scheduling Most profitable set of jobs on a single machine, a job occupies the machine during [start, end).
+// There is a constraint for each hour: the jobs running at that hour sum to at most 1.
+func Scheduling() *Model {
+	jobs := []struct {
+		name       string
+		start, end int
+		profit     float64
+	}{
+		{"job1", 0, 3, 5},
+		{"job2", 2, 5, 6},
+		{"job3", 4, 7, 5},
+		{"job4", 6, 9, 4},
+		{"job5", 1, 8, 11},
+	}
+	horizon := 9
+	n := len(jobs)
+	m := horizon + n
+	c := mat.NewDense(1, n, nil)
+	A := mat.NewDense(m, n, nil)
+	b := mat.NewDense(m, 1, nil)
+	integer := make([]bool, n)
+	variables := make([]string, n)
+	for j, job := range jobs {
+		c.Set(0, j, job.profit)
+		for t := job.start; t < job.end; t++ {
+			A.Set(t, j, 1)
+		}
+		A.Set(horizon+j, j, 1)
+		integer[j] = true
+		variables[j] = job.name
+	}
+	for i := 0; i < m; i++ {
+		b.Set(i, 0, 1)
+	}
+	return &Model{
+		Name:        "scheduling",
+		Description: "Most profitable jobs with time windows on a single machine",
+		C:           c,
+		A:           A,
+		B:           b,
+		Integer:     integer,
+		Variables:   variables,
+		Optimum:     11,
+	}
+}
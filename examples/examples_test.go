@@ -0,0 +1,43 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestGallery(t *testing.T) {
+	assert.Equal(t, []string{"diet", "knapsack", "scheduling", "transport"}, Names())
+	for _, name := range Names() {
+		m, err := Get(name)
+		require.NoError(t, err)
+		assert.Equal(t, name, m.Name)
+		_, score, err := m.Solve(1000)
+		require.NoError(t, err, name)
+		assert.InEpsilon(t, m.Optimum, score, 0.000001, name)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	_, err := Get("unknown")
+	assert.Error(t, err)
+	assert.Error(t, Register("diet", Diet))
+
+	require.NoError(t, Register("single", func() *Model {
+		return &Model{
+			Name:    "single",
+			C:       mat.NewDense(1, 1, []float64{2}),
+			A:       mat.NewDense(1, 1, []float64{1}),
+			B:       mat.NewDense(1, 1, []float64{3}),
+			Optimum: 6,
+		}
+	}))
+	defer delete(registry, "single")
+	m, err := Get("single")
+	require.NoError(t, err)
+	_, score, err := m.Solve(10)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 6.0, score, 0.000001)
+}
@@ -0,0 +1,85 @@
+package goptimization
+
+// LinExpr Linear expression built with Add, Sub and Scale, e.g. to compose objectives and constraints in other packages:
+// cost := x.Expr().Scale(3).Add(y.Expr(), Expr{Constant: 1})
+// m.AddConstraint(cost.Sub(budget), 0)
+// The operations return new expressions and never change their operands, an expression can be reused in several
+// constraints and, with Map, in several models.
+type LinExpr = Expr
+
+// Add Sum of e and the expressions, the terms of a variable are merged
+func (e Expr) Add(exprs ...Expr) Expr {
+	sum := Expr{Constant: e.Constant}
+	terms := append([]Term{}, e.Terms...)
+	for _, other := range exprs {
+		terms = append(terms, other.Terms...)
+		sum.Constant += other.Constant
+	}
+	sum.Terms = mergeTerms(terms)
+	return sum
+}
+
+// Sub Difference e - other
+func (e Expr) Sub(other Expr) Expr {
+	return e.Add(other.Scale(-1))
+}
+
+// Scale Multiply the coefficients and the constant by s
+func (e Expr) Scale(s float64) Expr {
+	return Expr{Terms: scaleTerms(e.Terms, s), Constant: s * e.Constant}
+}
+
+// AddTerm Sum of e and coef*v
+func (e Expr) AddTerm(v Var, coef float64) Expr {
+	return e.Add(Expr{Terms: []Term{{Var: v, Coef: coef}}})
+}
+
+// Coef Coefficient of v in e, the sum of its terms
+func (e Expr) Coef(v Var) float64 {
+	coef := 0.0
+	for _, t := range e.Terms {
+		if t.Var == v {
+			coef += t.Coef
+		}
+	}
+	return coef
+}
+
+// Each Call f with each variable of e and its coefficient, in the order of their first term.
+// The terms of a variable are merged and the variables with a zero coefficient are skipped.
+func (e Expr) Each(f func(v Var, coef float64)) {
+	for _, t := range mergeTerms(e.Terms) {
+		f(t.Var, t.Coef)
+	}
+}
+
+// Map Same expression with each variable replaced by f(v), e.g. the variable of the same name in another model
+func (e Expr) Map(f func(v Var) Var) Expr {
+	mapped := Expr{Terms: make([]Term, len(e.Terms)), Constant: e.Constant}
+	for k, t := range e.Terms {
+		mapped.Terms[k] = Term{Var: f(t.Var), Coef: t.Coef}
+	}
+	return mapped
+}
+
+// mergeTerms Single term per variable in the order of their first term, without the zero coefficients
+func mergeTerms(terms []Term) []Term {
+	position := make(map[Var]int, len(terms))
+	merged := []Term{}
+	for _, t := range terms {
+		k, ok := position[t.Var]
+		if !ok {
+			position[t.Var] = len(merged)
+			merged = append(merged, t)
+			continue
+		}
+		merged[k].Coef += t.Coef
+	}
+	kept := merged[:0]
+	for _, t := range merged {
+		if t.Coef != 0 {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,77 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinExpr(t *testing.T) {
+	x, y, z := Var(0), Var(1), Var(2)
+	e := x.Expr().Scale(2).Add(y.Expr(), Expr{Terms: []Term{{Var: x, Coef: 1}}, Constant: 3})
+	assert.Equal(t, Expr{Terms: []Term{{Var: x, Coef: 3}, {Var: y, Coef: 1}}, Constant: 3}, e)
+	d := e.Sub(y.Expr()).AddTerm(z, -2).Scale(0.5)
+	assert.Equal(t, Expr{Terms: []Term{{Var: x, Coef: 1.5}, {Var: z, Coef: -1}}, Constant: 1.5}, d)
+	// The operands are not changed
+	assert.Equal(t, Expr{Terms: []Term{{Var: x, Coef: 3}, {Var: y, Coef: 1}}, Constant: 3}, e)
+
+	duplicated := Expr{Terms: []Term{{Var: y, Coef: 1}, {Var: x, Coef: 2}, {Var: y, Coef: -1}, {Var: x, Coef: 1}}}
+	assert.Equal(t, 3.0, duplicated.Coef(x))
+	assert.Equal(t, 0.0, duplicated.Coef(z))
+	vars, coefs := []Var{}, []float64{}
+	duplicated.Each(func(v Var, coef float64) {
+		vars, coefs = append(vars, v), append(coefs, coef)
+	})
+	assert.Equal(t, []Var{x}, vars)
+	assert.Equal(t, []float64{3}, coefs)
+	assert.Equal(t, Expr{Terms: []Term{{Var: z, Coef: 2}}}, Expr{}.AddTerm(x, 1).Sub(x.Expr()).Add(z.Expr().Scale(2)))
+}
+
+func TestLinExprModels(t *testing.T) {
+	// max 5x + 4y + 3z st 2x + 3y + z <= 5, 4x + y + 2z <= 11, 3x + 4y + 2z <= 8
+	var objective LinExpr
+	build := func(names ...string) (*Model, []Var) {
+		m := &Model{}
+		vars := make([]Var, len(names))
+		for k, name := range names {
+			vars[k] = m.AddVariable(name, false)
+		}
+		return m, vars
+	}
+	m, vars := build("x", "y", "z")
+	x, y, z := vars[0], vars[1], vars[2]
+	objective = x.Expr().Scale(5).AddTerm(y, 4).AddTerm(z, 3)
+	rows := []Expr{
+		x.Expr().Scale(2).Add(y.Expr().Scale(3), z.Expr()),
+		x.Expr().Scale(4).Add(y.Expr(), z.Expr().Scale(2)),
+		x.Expr().Scale(3).Add(y.Expr().Scale(4), z.Expr().Scale(2)),
+	}
+	rhs := []float64{5, 11, 8}
+	m.Maximize(objective)
+	for i, row := range rows {
+		require.NoError(t, m.AddConstraint(row, rhs[i]))
+	}
+	solution, err := m.Solve(100, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, 13, solution.Score, 1e-9)
+
+	// Same expressions in a model with the variables in another order
+	other, vars := build("z", "x", "y")
+	byName := map[string]Var{}
+	for _, v := range vars {
+		byName[other.Name(v)] = v
+	}
+	rename := func(v Var) Var {
+		return byName[m.Name(v)]
+	}
+	other.Maximize(objective.Map(rename))
+	for i, row := range rows {
+		require.NoError(t, other.AddConstraint(row.Map(rename), rhs[i]))
+	}
+	moved, err := other.Solve(100, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, 13, moved.Score, 1e-9)
+	assert.InDelta(t, solution.Value(x), moved.Value(byName["x"]), 1e-9)
+	assert.InDelta(t, solution.Value(z), moved.Value(byName["z"]), 1e-9)
+}
@@ -0,0 +1,275 @@
+// Package facility Facility location: open facilities and serve the demand of the customers from them
+// at the smallest fixed and assignment cost, with or without capacities.
+// The problem is solved exactly by a MIP formulation on goptimization or bounded by a Lagrangian relaxation.
+package facility
+
+import (
+	"math"
+	"sort"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+const (
+	// tolerance Tolerance on the assignment and the capacities of a solution
+	tolerance = 1e-6
+	// transportIterations Maximum number of simplex iterations of the transportation problem
+	transportIterations = 10000
+)
+
+// Problem Facilities with a fixed cost paid when they are open, and customers with a demand.
+// The demand of a customer can be split between several open facilities.
+type Problem struct {
+	FixedCosts []float64
+	// Capacities Capacity of each facility, nil for the uncapacitated problem
+	Capacities []float64
+	Demands    []float64
+	// Costs Cost of serving the whole demand of the customer j from the facility i, (facilities, customers)
+	Costs *mat.Dense
+}
+
+// Solution Open facilities and assignment of the customers
+type Solution struct {
+	Open []bool
+	// Assignment Fraction of the demand of the customer j served by the facility i, (facilities, customers)
+	Assignment *mat.Dense
+	Cost       float64
+}
+
+// capacitated Check if the facilities have a capacity
+func (p *Problem) capacitated() bool {
+	return p.Capacities != nil
+}
+
+// Validate Check the dimensions and the signs of the problem
+func (p *Problem) Validate() error {
+	if len(p.FixedCosts) == 0 || len(p.Demands) == 0 {
+		return errors.New("the problem needs facilities and customers")
+	}
+	if p.Costs == nil {
+		return errors.Wrap(goptimization.ErrDimensionMismatch, "Costs must not be nil")
+	}
+	r, c := p.Costs.Dims()
+	if r != len(p.FixedCosts) || c != len(p.Demands) {
+		return errors.Wrapf(goptimization.ErrDimensionMismatch, "Costs dims must be (%d,%d), got (%d,%d)", len(p.FixedCosts), len(p.Demands), r, c)
+	}
+	if p.capacitated() && len(p.Capacities) != len(p.FixedCosts) {
+		return errors.Wrapf(goptimization.ErrDimensionMismatch, "len(Capacities) must be %d, got %d", len(p.FixedCosts), len(p.Capacities))
+	}
+	for i, f := range p.FixedCosts {
+		if f < 0 {
+			return errors.Errorf("facility %d has the negative fixed cost %g", i, f)
+		}
+		if p.capacitated() && p.Capacities[i] < 0 {
+			return errors.Errorf("facility %d has the negative capacity %g", i, p.Capacities[i])
+		}
+	}
+	for j, d := range p.Demands {
+		if d < 0 {
+			return errors.Errorf("customer %d has the negative demand %g", j, d)
+		}
+	}
+	return nil
+}
+
+// Check Check that each customer is fully served by open facilities within their capacities, and the cost of the solution
+func (p *Problem) Check(s *Solution) error {
+	if len(s.Open) != len(p.FixedCosts) || s.Assignment == nil {
+		return goptimization.ErrDimensionMismatch
+	}
+	r, c := s.Assignment.Dims()
+	if r != len(p.FixedCosts) || c != len(p.Demands) {
+		return goptimization.ErrDimensionMismatch
+	}
+	cost := 0.0
+	for i, open := range s.Open {
+		load := 0.0
+		for j, d := range p.Demands {
+			x := s.Assignment.At(i, j)
+			if x < -tolerance || (!open && x > tolerance) {
+				return errors.Errorf("customer %d is served by the closed facility %d", j, i)
+			}
+			load += d * x
+			cost += p.Costs.At(i, j) * x
+		}
+		if p.capacitated() && load > p.Capacities[i]+tolerance {
+			return errors.Errorf("facility %d serves %g, more than its capacity %g", i, load, p.Capacities[i])
+		}
+		if open {
+			cost += p.FixedCosts[i]
+		}
+	}
+	for j := range p.Demands {
+		served := 0.0
+		for i := range p.FixedCosts {
+			served += s.Assignment.At(i, j)
+		}
+		if math.Abs(served-1) > tolerance {
+			return errors.Errorf("customer %d is served at %g, not 1", j, served)
+		}
+	}
+	if math.Abs(cost-s.Cost) > tolerance*math.Max(1, math.Abs(cost)) {
+		return errors.Errorf("the cost is %g, not %g", cost, s.Cost)
+	}
+	return nil
+}
+
+// Assign Cheapest assignment of the customers to the open facilities.
+// Without capacities each customer is served by its closest open facility,
+// otherwise the assignment is the solution of a transportation problem solved with the simplex algorithm.
+// It returns ErrInfeasible when the open facilities cannot serve the demand.
+// The options are those of goptimization.Simplex.
+func (p *Problem) Assign(open []bool, opts ...goptimization.Option) (*Solution, error) {
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+	if len(open) != len(p.FixedCosts) {
+		return nil, errors.Wrapf(goptimization.ErrDimensionMismatch, "len(open) must be %d, got %d", len(p.FixedCosts), len(open))
+	}
+	s := &Solution{Open: append([]bool{}, open...), Assignment: mat.NewDense(len(p.FixedCosts), len(p.Demands), nil)}
+	for i := range open {
+		if open[i] {
+			s.Cost += p.FixedCosts[i]
+		}
+	}
+	if p.capacitated() {
+		err := p.transport(s, opts)
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+
+	for j := range p.Demands {
+		closest := -1
+		for i := range open {
+			if open[i] && (closest == -1 || p.Costs.At(i, j) < p.Costs.At(closest, j)) {
+				closest = i
+			}
+		}
+		if closest == -1 {
+			return nil, errors.Wrap(goptimization.ErrInfeasible, "no facility is open")
+		}
+		s.Assignment.Set(closest, j, 1)
+		s.Cost += p.Costs.At(closest, j)
+	}
+	return s, nil
+}
+
+// transport Assign the customers to the open facilities of s with the transportation problem:
+// Minimize Σ c_ij*x_ij
+// Constraints:
+// j customer, Σ(i open) x_ij = 1
+// i open, Σ(j) d_j*x_ij <= s_i
+func (p *Problem) transport(s *Solution, opts []goptimization.Option) error {
+	total, capacity := 0.0, 0.0
+	for j := range p.Demands {
+		total += p.Demands[j]
+	}
+	for i, open := range s.Open {
+		if open {
+			capacity += p.Capacities[i]
+		}
+	}
+	if !anyOpen(s.Open) || capacity < total-tolerance {
+		return errors.Wrapf(goptimization.ErrInfeasible, "the open facilities have a capacity of %g for a demand of %g", capacity, total)
+	}
+
+	m := &goptimization.Model{}
+	x := map[[2]int]goptimization.Var{}
+	objective := goptimization.Expr{}
+	for i, open := range s.Open {
+		if !open {
+			continue
+		}
+		load := goptimization.Expr{}
+		for j, d := range p.Demands {
+			v := m.AddVariable("", false)
+			x[[2]int{i, j}] = v
+			objective.Terms = append(objective.Terms, goptimization.Term{Var: v, Coef: -p.Costs.At(i, j)})
+			load.Terms = append(load.Terms, goptimization.Term{Var: v, Coef: d})
+		}
+		err := m.AddConstraint(load, p.Capacities[i])
+		if err != nil {
+			return err
+		}
+	}
+	m.Maximize(objective)
+	for j := range p.Demands {
+		served := goptimization.Expr{}
+		for i, open := range s.Open {
+			if open {
+				served.Terms = append(served.Terms, goptimization.Term{Var: x[[2]int{i, j}], Coef: 1})
+			}
+		}
+		err := m.AddRow(served, goptimization.Equal, 1)
+		if err != nil {
+			return err
+		}
+	}
+	solution, err := m.Solve(transportIterations, opts...)
+	if err != nil {
+		return err
+	}
+	for i, open := range s.Open {
+		if !open {
+			continue
+		}
+		for j := range p.Demands {
+			s.Assignment.Set(i, j, solution.Value(x[[2]int{i, j}]))
+		}
+	}
+	s.Cost -= solution.Score
+	return nil
+}
+
+// anyOpen Check if a facility is open
+func anyOpen(open []bool) bool {
+	for _, isOpen := range open {
+		if isOpen {
+			return true
+		}
+	}
+	return false
+}
+
+// repair Open the closed facilities in the order until the open facilities can serve the demand
+func (p *Problem) repair(open []bool, order []int) []bool {
+	open = append([]bool{}, open...)
+	total, capacity := 0.0, 0.0
+	for _, d := range p.Demands {
+		total += d
+	}
+	for i := range open {
+		if open[i] && p.capacitated() {
+			capacity += p.Capacities[i]
+		}
+	}
+	for _, i := range order {
+		if anyOpen(open) && (!p.capacitated() || capacity >= total-tolerance) {
+			break
+		}
+		if !open[i] {
+			open[i] = true
+			if p.capacitated() {
+				capacity += p.Capacities[i]
+			}
+		}
+	}
+	return open
+}
+
+// byValue Facilities sorted by increasing value
+func byValue(values []float64) []int {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return values[order[a]] < values[order[b]]
+	})
+	return order
+}
@@ -0,0 +1,128 @@
+package facility
+
+import (
+	"io/ioutil"
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+// silent Logger of the solves
+var silent = goptimization.WithLogger(log.New(ioutil.Discard, "", 0))
+
+// random Facilities and customers at random points of the unit square, the cost is the distance times the demand.
+// The facilities have capacities when capacitated is true.
+func random(facilities, customers int, capacitated bool, seed int64) *Problem {
+	rnd := rand.New(rand.NewSource(seed))
+	p := &Problem{
+		FixedCosts: make([]float64, facilities),
+		Demands:    make([]float64, customers),
+		Costs:      mat.NewDense(facilities, customers, nil),
+	}
+	fx, fy := make([]float64, facilities), make([]float64, facilities)
+	for i := range p.FixedCosts {
+		p.FixedCosts[i] = 5 + 10*rnd.Float64()
+		fx[i], fy[i] = rnd.Float64(), rnd.Float64()
+	}
+	total := 0.0
+	for j := range p.Demands {
+		p.Demands[j] = float64(1 + rnd.Intn(5))
+		total += p.Demands[j]
+		x, y := rnd.Float64(), rnd.Float64()
+		for i := range p.FixedCosts {
+			p.Costs.Set(i, j, 10*math.Hypot(x-fx[i], y-fy[i])*p.Demands[j])
+		}
+	}
+	if capacitated {
+		p.Capacities = make([]float64, facilities)
+		for i := range p.Capacities {
+			p.Capacities[i] = math.Ceil(total * (0.3 + 0.3*rnd.Float64()))
+		}
+	}
+	return p
+}
+
+// bruteForce Cheapest cost over all the sets of open facilities
+func bruteForce(t *testing.T, p *Problem) float64 {
+	best := math.Inf(1)
+	for set := 1; set < 1<<len(p.FixedCosts); set++ {
+		open := make([]bool, len(p.FixedCosts))
+		for i := range open {
+			open[i] = set&(1<<i) != 0
+		}
+		s, err := p.Assign(open, silent)
+		if errors.Cause(err) == goptimization.ErrInfeasible {
+			continue
+		}
+		require.NoError(t, err)
+		best = math.Min(best, s.Cost)
+	}
+	return best
+}
+
+func TestValidate(t *testing.T) {
+	p := random(3, 4, true, 1)
+	assert.NoError(t, p.Validate())
+
+	p.Capacities = []float64{1}
+	assert.Equal(t, goptimization.ErrDimensionMismatch, errors.Cause(p.Validate()))
+	p = random(3, 4, false, 1)
+	p.Costs = mat.NewDense(4, 3, nil)
+	assert.Equal(t, goptimization.ErrDimensionMismatch, errors.Cause(p.Validate()))
+	p = random(3, 4, false, 1)
+	p.Demands[2] = -1
+	assert.Error(t, p.Validate())
+	assert.Error(t, (&Problem{}).Validate())
+}
+
+func TestAssign(t *testing.T) {
+	p := &Problem{
+		FixedCosts: []float64{1, 2},
+		Demands:    []float64{2, 3},
+		Costs:      mat.NewDense(2, 2, []float64{1, 4, 3, 2}),
+	}
+	s, err := p.Assign([]bool{true, true}, silent)
+	require.NoError(t, err)
+	require.NoError(t, p.Check(s))
+	assert.Equal(t, 6.0, s.Cost)
+	assert.Equal(t, 1.0, s.Assignment.At(0, 0))
+	assert.Equal(t, 1.0, s.Assignment.At(1, 1))
+
+	_, err = p.Assign([]bool{false, false}, silent)
+	assert.Equal(t, goptimization.ErrInfeasible, errors.Cause(err))
+
+	// The facility 0 serves the customer 0 and a third of the customer 1
+	p.Capacities = []float64{3, 2}
+	s, err = p.Assign([]bool{true, false}, silent)
+	assert.Equal(t, goptimization.ErrInfeasible, errors.Cause(err))
+	s, err = p.Assign([]bool{true, true}, silent)
+	require.NoError(t, err)
+	require.NoError(t, p.Check(s))
+	assert.InDelta(t, 1.0/3, s.Assignment.At(0, 1), 0.000001)
+	assert.InDelta(t, 3+1+4.0/3+2*2.0/3, s.Cost, 0.000001)
+}
+
+func TestCheck(t *testing.T) {
+	p := random(3, 4, true, 2)
+	s, err := p.Assign([]bool{true, true, true}, silent)
+	require.NoError(t, err)
+	require.NoError(t, p.Check(s))
+
+	s.Cost++
+	assert.Error(t, p.Check(s))
+	s.Cost--
+	s.Open[0] = false
+	if mat.Sum(s.Assignment.RowView(0)) > 0 {
+		assert.Error(t, p.Check(s))
+	}
+	s.Open[0] = true
+	s.Assignment.Set(0, 0, s.Assignment.At(0, 0)+0.5)
+	assert.Error(t, p.Check(s))
+}
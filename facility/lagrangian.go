@@ -0,0 +1,178 @@
+package facility
+
+import (
+	"math"
+	"sort"
+
+	"github.com/askiada/goptimization"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+const (
+	// lagrangianStep Initial factor of the subgradient step
+	lagrangianStep = 2.0
+	// lagrangianStall Number of iterations without improvement of the lower bound before the factor is halved
+	lagrangianStall = 20
+	// lagrangianMinStep Smallest factor of the subgradient step
+	lagrangianMinStep = 1e-4
+)
+
+// Bound Bounds on the optimal cost given by the Lagrangian relaxation
+type Bound struct {
+	// Lower Best lower bound on the optimal cost
+	Lower float64
+	// Best Cheapest solution built from the facilities opened by the relaxations, its cost is an upper bound
+	Best *Solution
+	// Multipliers Multipliers of the assignment constraints giving Lower
+	Multipliers []float64
+	Iterations  int
+}
+
+// Gap Relative gap between the cost of Best and Lower
+func (b *Bound) Gap() float64 {
+	return (b.Best.Cost - b.Lower) / math.Max(1, math.Abs(b.Best.Cost))
+}
+
+// Lagrangian Relax the assignment constraints Σ(i) x_ij = 1 with the multipliers λ_j:
+// L(λ) = Σ(j) λ_j + Σ(i) min(0, f_i + min Σ(j) (c_ij - λ_j)*x_ij), with 0 <= x_ij <= 1 and Σ(j) d_j*x_ij <= s_i.
+// The problem of each facility is a continuous knapsack, L(λ) is a lower bound on the optimal cost.
+// The multipliers start at the cheapest cost of each customer and are updated by subgradient steps with the Polyak rule.
+// At each iteration the facilities with a negative value are opened, completed until they can serve the demand,
+// and the customers are assigned to them to give an upper bound.
+// It stops after maxIter iterations, when the gap is closed or when the step is too small.
+// The options are passed to Assign.
+func Lagrangian(p *Problem, maxIter int, opts ...goptimization.Option) (*Bound, error) {
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+	lambda := make([]float64, len(p.Demands))
+	for j := range lambda {
+		lambda[j] = math.Inf(1)
+		for i := range p.FixedCosts {
+			lambda[j] = math.Min(lambda[j], p.Costs.At(i, j))
+		}
+	}
+	bound := &Bound{Lower: math.Inf(-1), Multipliers: append([]float64{}, lambda...)}
+	tried := map[string]bool{}
+	step, stall := lagrangianStep, 0
+
+	for bound.Iterations < maxIter && step >= lagrangianMinStep {
+		bound.Iterations++
+		lower, x, values := p.relax(lambda)
+		if lower > bound.Lower+tolerance {
+			bound.Lower = lower
+			copy(bound.Multipliers, lambda)
+			stall = 0
+		} else {
+			stall++
+			if stall >= lagrangianStall {
+				step /= 2
+				stall = 0
+			}
+		}
+
+		open := make([]bool, len(values))
+		for i, v := range values {
+			open[i] = v < 0
+		}
+		open = p.repair(open, byValue(values))
+		if key := openKey(open); !tried[key] {
+			tried[key] = true
+			s, err := p.Assign(open, opts...)
+			if err != nil {
+				return nil, err
+			}
+			if bound.Best == nil || s.Cost < bound.Best.Cost {
+				bound.Best = s
+			}
+		}
+		if bound.Gap() <= tolerance {
+			break
+		}
+
+		subgradient := make([]float64, len(lambda))
+		norm := 0.0
+		for j := range lambda {
+			subgradient[j] = 1
+			for i := range values {
+				subgradient[j] -= x.At(i, j)
+			}
+			norm += subgradient[j] * subgradient[j]
+		}
+		if norm == 0 {
+			// The assignment of the relaxation is feasible, it is optimal for the multipliers
+			break
+		}
+		t := step * (bound.Best.Cost - lower) / norm
+		for j := range lambda {
+			lambda[j] += t * subgradient[j]
+		}
+	}
+	bound.Lower = math.Min(bound.Lower, bound.Best.Cost)
+	return bound, nil
+}
+
+// relax Value of the relaxation for the multipliers lambda, with the assignment of the open facilities
+// and the value of each facility, which is open when its value is negative
+func (p *Problem) relax(lambda []float64) (float64, *mat.Dense, []float64) {
+	x := mat.NewDense(len(p.FixedCosts), len(p.Demands), nil)
+	values := make([]float64, len(p.FixedCosts))
+	lower := 0.0
+	for _, l := range lambda {
+		lower += l
+	}
+	for i := range p.FixedCosts {
+		customers := []int{}
+		for j := range p.Demands {
+			if p.Costs.At(i, j)-lambda[j] < 0 {
+				customers = append(customers, j)
+			}
+		}
+		reduced := func(j int) float64 {
+			return p.Costs.At(i, j) - lambda[j]
+		}
+		remaining := math.Inf(1)
+		if p.capacitated() {
+			remaining = p.Capacities[i]
+			// The customers with the most negative reduced cost per unit of demand are served first
+			sort.SliceStable(customers, func(a, b int) bool {
+				return reduced(customers[a])*p.Demands[customers[b]] < reduced(customers[b])*p.Demands[customers[a]]
+			})
+		}
+		values[i] = p.FixedCosts[i]
+		for _, j := range customers {
+			fraction := 1.0
+			if p.Demands[j] > 0 {
+				fraction = math.Min(1, remaining/p.Demands[j])
+			}
+			if fraction <= 0 {
+				break
+			}
+			x.Set(i, j, fraction)
+			remaining -= fraction * p.Demands[j]
+			values[i] += fraction * reduced(j)
+		}
+		if values[i] < 0 {
+			lower += values[i]
+		} else {
+			for j := range p.Demands {
+				x.Set(i, j, 0)
+			}
+		}
+	}
+	return lower, x, values
+}
+
+// openKey Key of the open facilities
+func openKey(open []bool) string {
+	key := make([]byte, len(open))
+	for i, isOpen := range open {
+		key[i] = '0'
+		if isOpen {
+			key[i] = '1'
+		}
+	}
+	return string(key)
+}
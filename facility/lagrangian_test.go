@@ -0,0 +1,37 @@
+package facility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLagrangian(t *testing.T) {
+	for _, capacitated := range []bool{false, true} {
+		for seed := int64(0); seed < 5; seed++ {
+			p := random(5, 8, capacitated, seed)
+			optimum := bruteForce(t, p)
+			bound, err := Lagrangian(p, 300, silent)
+			require.NoError(t, err)
+			require.NoError(t, p.Check(bound.Best))
+			assert.LessOrEqual(t, bound.Lower, optimum+0.000001)
+			assert.GreaterOrEqual(t, bound.Best.Cost, optimum-0.000001)
+			assert.GreaterOrEqual(t, bound.Gap(), -0.000001)
+			assert.LessOrEqual(t, bound.Iterations, 300)
+		}
+	}
+}
+
+func TestLagrangianTight(t *testing.T) {
+	// One facility is much cheaper for every customer, the relaxation opens only it
+	p := random(3, 4, false, 3)
+	p.FixedCosts = []float64{0, 100, 100}
+	for j := range p.Demands {
+		p.Costs.Set(0, j, 0)
+	}
+	bound, err := Lagrangian(p, 100, silent)
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false, false}, bound.Best.Open)
+	assert.InDelta(t, 0, bound.Best.Cost-bound.Lower, 0.000001)
+}
@@ -0,0 +1,136 @@
+package facility
+
+import (
+	"math"
+
+	"github.com/askiada/goptimization"
+)
+
+// lagrangianIterations Number of iterations of the Lagrangian relaxation giving the first incumbent of Solve
+const lagrangianIterations = 200
+
+// boundHeuristic Solution of the branch and bound given by the Lagrangian relaxation
+type boundHeuristic struct {
+	x []float64
+}
+
+// Name Name reported in the incumbents
+func (boundHeuristic) Name() string {
+	return "lagrangian"
+}
+
+// Run Return the solution
+func (h boundHeuristic) Run(*goptimization.HeuristicContext) ([]float64, bool, error) {
+	return h.x, true, nil
+}
+
+// Solve Minimize the cost with the strong formulation, y_i = 1 when the facility i is open:
+// Minimize Σ f_i*y_i + Σ c_ij*x_ij
+// Constraints:
+// j customer, Σ(i) x_ij = 1
+// i facility, j customer, x_ij <= y_i
+// With capacities:
+// i facility, Σ(j) d_j*x_ij <= s_i*y_i
+// Σ(i) s_i*y_i >= Σ(j) d_j
+// The best solution of Lagrangian is the first incumbent of the branch and bound,
+// which is skipped when the Lagrangian bound proves it optimal.
+// It returns the solution, with ErrIterationLimit and the best solution found when the search stopped after maxNodes nodes.
+// The options are those of goptimization.MIP, they are also passed to Lagrangian.
+func Solve(p *Problem, maxNodes int, opts ...goptimization.Option) (*Solution, error) {
+	bound, err := Lagrangian(p, lagrangianIterations, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if bound.Gap() <= tolerance {
+		return bound.Best, nil
+	}
+
+	m := &goptimization.Model{}
+	open := make([]goptimization.Var, len(p.FixedCosts))
+	serve := make([][]goptimization.Var, len(p.FixedCosts))
+	objective := goptimization.Expr{}
+	for i, f := range p.FixedCosts {
+		open[i] = m.AddBinary("")
+		objective.Terms = append(objective.Terms, goptimization.Term{Var: open[i], Coef: -f})
+		serve[i] = make([]goptimization.Var, len(p.Demands))
+		for j := range p.Demands {
+			serve[i][j] = m.AddVariable("", false)
+			objective.Terms = append(objective.Terms, goptimization.Term{Var: serve[i][j], Coef: -p.Costs.At(i, j)})
+		}
+	}
+	m.Maximize(objective)
+
+	for j := range p.Demands {
+		served := goptimization.Expr{}
+		for i := range p.FixedCosts {
+			served.Terms = append(served.Terms, goptimization.Term{Var: serve[i][j], Coef: 1})
+		}
+		err := m.AddRow(served, goptimization.Equal, 1)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i := range p.FixedCosts {
+		for j := range p.Demands {
+			err := m.AddConstraint(goptimization.Expr{Terms: []goptimization.Term{{Var: serve[i][j], Coef: 1}, {Var: open[i], Coef: -1}}}, 0)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if p.capacitated() {
+		total, capacity := 0.0, goptimization.Expr{}
+		for i := range p.FixedCosts {
+			load := goptimization.Expr{Terms: []goptimization.Term{{Var: open[i], Coef: -p.Capacities[i]}}}
+			for j, d := range p.Demands {
+				load.Terms = append(load.Terms, goptimization.Term{Var: serve[i][j], Coef: d})
+			}
+			err := m.AddConstraint(load, 0)
+			if err != nil {
+				return nil, err
+			}
+			capacity.Terms = append(capacity.Terms, goptimization.Term{Var: open[i], Coef: p.Capacities[i]})
+		}
+		for _, d := range p.Demands {
+			total += d
+		}
+		err := m.AddRow(capacity, goptimization.GreaterEq, total)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c, A, b, integer := m.Standard()
+	x := make([]float64, len(integer))
+	for i := range p.FixedCosts {
+		if bound.Best.Open[i] {
+			x[open[i]] = 1
+		}
+		for j := range p.Demands {
+			x[serve[i][j]] = bound.Best.Assignment.At(i, j)
+		}
+	}
+	bb := &goptimization.BranchAndBound{}
+	err = bb.New(c, A, b, integer)
+	if err != nil {
+		return nil, err
+	}
+	bb.Configure(opts...)
+	bb.Heuristics = append(bb.Heuristics, boundHeuristic{x: x})
+	results, _, err := bb.Solve(maxNodes)
+	if err != nil {
+		return nil, err
+	}
+	opened := make([]bool, len(p.FixedCosts))
+	for i, v := range open {
+		opened[i] = math.Round(results.At(int(v), 0)) == 1
+	}
+	s, err := p.Assign(opened, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if bb.Stopped != "" {
+		return s, goptimization.ErrIterationLimit
+	}
+	return s, nil
+}
@@ -0,0 +1,34 @@
+package facility
+
+import (
+	"bytes"
+	"log"
+	"testing"
+
+	"github.com/askiada/goptimization"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolve(t *testing.T) {
+	for _, capacitated := range []bool{false, true} {
+		for seed := int64(0); seed < 4; seed++ {
+			p := random(5, 8, capacitated, seed)
+			s, err := Solve(p, 1000, silent)
+			require.NoError(t, err)
+			require.NoError(t, p.Check(s))
+			assert.InDelta(t, bruteForce(t, p), s.Cost, 0.000001)
+		}
+	}
+}
+
+func TestSolveOptions(t *testing.T) {
+	//The logger reaches the transportation problems of Lagrangian and the branch and bound
+	var buf bytes.Buffer
+	p := random(5, 8, true, 1)
+	s, err := Solve(p, 1000, goptimization.WithLogger(log.New(&buf, "", 0)))
+	require.NoError(t, err)
+	require.NoError(t, p.Check(s))
+	assert.NotZero(t, buf.Len())
+}
@@ -0,0 +1,74 @@
+package goptimization
+
+import (
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// FindFeasible Find a point of {x >= 0, 1<=i<=m Σ(1<=j<=n) a_i_j*x_j senses[i] b_i} with the phase one alone,
+// there is no objective. It returns the point x (n,1), or ErrInfeasible and a Farkas certificate u (m,1):
+// Σ u_i*a_i_j >= 0 for each j and Σ u_i*b_i < 0, with u_i >= 0 for <=, u_i <= 0 for >= and u_i free for =,
+// which proves that the constraints have no solution. The options configure the dictionary, WithMaxIter aside.
+func FindFeasible(A, b *mat.Dense, senses []Sense, maxIter int, opts ...Option) (*mat.Dense, *mat.Dense, error) {
+	m, n := A.Dims()
+	if rows, _ := b.Dims(); rows != m {
+		return nil, nil, newError(ErrDimensionMismatch, "b dims.r != A dims.r")
+	}
+	if len(senses) != m {
+		return nil, nil, newError(ErrDimensionMismatch, "len(senses) != A dims.r")
+	}
+
+	// Each constraint becomes one or two <= rows, origin gives the constraint and the sign of each row
+	type origin struct {
+		i    int
+		sign float64
+	}
+	origins := []origin{}
+	for i, sense := range senses {
+		switch sense {
+		case LessEq:
+			origins = append(origins, origin{i, 1})
+		case GreaterEq:
+			origins = append(origins, origin{i, -1})
+		case Equal:
+			origins = append(origins, origin{i, 1}, origin{i, -1})
+		default:
+			return nil, nil, errors.Errorf("unknown sense %d", sense)
+		}
+	}
+	rowsA := mat.NewDense(len(origins), n, nil)
+	rowsB := mat.NewDense(len(origins), 1, nil)
+	for r, o := range origins {
+		for j := 0; j < n; j++ {
+			rowsA.Set(r, j, o.sign*A.At(o.i, j))
+		}
+		rowsB.Set(r, 0, o.sign*b.At(o.i, 0))
+	}
+
+	o := newOptions(opts)
+	cf := CanonicalForm{}
+	err := cf.New(mat.NewDense(1, n, nil), rowsA, rowsB)
+	if err != nil {
+		return nil, nil, err
+	}
+	cf.configure(o)
+	cf.deadline = o.deadline()
+	_, err = cf.phaseOne(maxIter)
+	if err == ErrInfeasible {
+		// The duals of the phase one are the multipliers of the <= rows
+		y, err := cf.FindY()
+		if err != nil {
+			return nil, nil, err
+		}
+		certificate := mat.NewDense(m, 1, nil)
+		for r, o := range origins {
+			certificate.Set(o.i, 0, certificate.At(o.i, 0)+o.sign*y.At(0, r))
+		}
+		return nil, certificate, ErrInfeasible
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	values, _ := cf.values()
+	return mat.NewDense(n, 1, values[:n]), nil, nil
+}
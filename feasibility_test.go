@@ -0,0 +1,46 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestFindFeasible(t *testing.T) {
+	// x + y >= 2, x - y = 1, x <= 3
+	A := mat.NewDense(3, 2, []float64{1, 1, 1, -1, 1, 0})
+	b := mat.NewDense(3, 1, []float64{2, 1, 3})
+	senses := []Sense{GreaterEq, Equal, LessEq}
+	x, certificate, err := FindFeasible(A, b, senses, 100, silent)
+	require.NoError(t, err)
+	assert.Nil(t, certificate)
+	assert.GreaterOrEqual(t, x.At(0, 0)+x.At(1, 0), 2-0.000001)
+	assert.InDelta(t, 1.0, x.At(0, 0)-x.At(1, 0), 0.000001)
+	assert.LessOrEqual(t, x.At(0, 0), 3+0.000001)
+
+	_, _, err = FindFeasible(A, b, senses[:2], 100, silent)
+	assert.Error(t, err)
+}
+
+func TestFeasibleCertificate(t *testing.T) {
+	// x + y >= 4, x <= 1, y = 2
+	A := mat.NewDense(3, 2, []float64{1, 1, 1, 0, 0, 1})
+	b := mat.NewDense(3, 1, []float64{4, 1, 2})
+	senses := []Sense{GreaterEq, LessEq, Equal}
+	x, u, err := FindFeasible(A, b, senses, 100, silent)
+	assert.Equal(t, ErrInfeasible, err)
+	assert.Nil(t, x)
+	require.NotNil(t, u)
+
+	assert.LessOrEqual(t, u.At(0, 0), 0.000001)
+	assert.GreaterOrEqual(t, u.At(1, 0), -0.000001)
+	var uA, ub mat.Dense
+	uA.Mul(u.T(), A)
+	ub.Mul(u.T(), b)
+	for j := 0; j < 2; j++ {
+		assert.GreaterOrEqual(t, uA.At(0, j), -0.000001)
+	}
+	assert.Less(t, ub.At(0, 0), 0.0)
+}
@@ -0,0 +1,409 @@
+package goptimization
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+const (
+	// tolerance32 Tolerance of the pivots and reduced costs of the float32 iterations, about 100 times the float32 epsilon
+	tolerance32 = 1e-5
+	// refinementSteps Number of iterative refinement steps of the float64 solves of SimplexFloat32
+	refinementSteps = 2
+)
+
+// SimplexFloat32 Solve the linear problem of Simplex with the iterations in float32, for the problems bounded by memory
+// where the lower precision of the pivots is acceptable. The iterations run on a dense tableau of float32,
+// half the storage of the float64 dictionary, with Dantzig's rule and Bland's rule after blandThreshold degenerate pivots,
+// and the phase one of phaseOne when some b_i < 0.
+// The final basis is then refined in float64: x_B and y are solved with A and iterative refinement, and if the refined
+// solution is primal infeasible or not optimal within the tolerance the float64 simplex finishes from the basis.
+// Gonum only computes in float64 and the module targets Go 1.13, so the float type is not a type parameter.
+// The options, results and errors are those of Simplex.
+func SimplexFloat32(c mat.Matrix, A *mat.Dense, b mat.Matrix, opts ...Option) (int, *mat.Dense, float64, error) {
+	o := newOptions(opts)
+	row, err := asRow(c, "c")
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	column, err := asColumn(b, "b")
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	err = checkDims(row, A, column)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	deadline := o.deadline()
+	m, n := A.Dims()
+	maxIter := iterationLimit(o.maxIter, n, m)
+	running := func(iter int) bool {
+		return iter < maxIter && !expired(deadline) && !o.cancelled()
+	}
+
+	t := newTableau32(row, A, column)
+	iter, err := t.phaseOne(running)
+	if err == ErrIterationLimit {
+		err = limitError(deadline)
+	}
+	if err != nil {
+		return iter, nil, 0, err
+	}
+	optimal := false
+	for ; running(iter); iter++ {
+		end, err := t.iter()
+		if err != nil {
+			return iter, nil, 0, err
+		}
+		if end {
+			optimal = true
+			break
+		}
+	}
+	o.logger.Printf("float32: %d iterations\n", iter)
+
+	results, score, refined := refine(row, A, column, t.basis, o.tolerance)
+	if !optimal {
+		if results == nil {
+			return iter, nil, 0, limitError(deadline)
+		}
+		return iter, results, score, limitError(deadline)
+	}
+	if refined {
+		return iter, results, score, nil
+	}
+	// The float32 basis is not optimal in float64, the float64 simplex finishes from it
+	o.logger.Printf("float32: the refined basis is not optimal, float64 iterations\n")
+	more, results, score, err := simplexFrom(row, A, column, t.basis, append(append([]Option(nil), opts...), WithMaxIter(maxIter-iter)))
+	return iter + more, results, score, err
+}
+
+// tableau32 Dense simplex tableau in float32 of the maximization of c over Ax + s = b, x, s >= 0
+type tableau32 struct {
+	m, n int
+	// Columns of the tableau: the n variables, the m slacks and the artificial variable of the phase one
+	cols int
+	// Rows i < m of the constraints and row m of the reduced costs, each row has cols entries followed by its right-hand side
+	data []float32
+	// Variable of each row
+	basis []int
+	// Column of the costs of the variables, fixed to 0 for the artificial variable once the phase one ends
+	costs []float32
+	// excluded Columns which cannot enter the basis
+	excluded   []bool
+	degenerate int
+}
+
+// newTableau32 Tableau of the slack basis
+func newTableau32(c, A, b *mat.Dense) *tableau32 {
+	m, n := A.Dims()
+	t := &tableau32{m: m, n: n, cols: n + m + 1}
+	t.data = make([]float32, (m+1)*(t.cols+1))
+	t.basis = make([]int, m)
+	t.costs = make([]float32, t.cols)
+	t.excluded = make([]bool, t.cols)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			t.set(i, j, float32(A.At(i, j)))
+		}
+		t.set(i, n+i, 1)
+		t.set(i, t.cols, float32(b.At(i, 0)))
+		t.basis[i] = n + i
+	}
+	for j := 0; j < n; j++ {
+		t.costs[j] = float32(c.At(0, j))
+	}
+	t.excluded[t.cols-1] = true
+	t.price()
+	return t
+}
+
+func (t *tableau32) at(i, j int) float32 {
+	return t.data[i*(t.cols+1)+j]
+}
+
+func (t *tableau32) set(i, j int, v float32) {
+	t.data[i*(t.cols+1)+j] = v
+}
+
+// price Recompute the row of the reduced costs c_j - c_B*B^-1*a_j and -z from the costs
+func (t *tableau32) price() {
+	for j := 0; j <= t.cols; j++ {
+		v := float32(0)
+		if j < t.cols {
+			v = t.costs[j]
+		}
+		for i := 0; i < t.m; i++ {
+			v -= t.costs[t.basis[i]] * t.at(i, j)
+		}
+		t.set(t.m, j, v)
+	}
+}
+
+// pivot Pivot on the row r and the column k, in float32
+func (t *tableau32) pivot(r, k int) {
+	width := t.cols + 1
+	pivotRow := t.data[r*width : (r+1)*width]
+	p := pivotRow[k]
+	for j := range pivotRow {
+		pivotRow[j] /= p
+	}
+	for i := 0; i <= t.m; i++ {
+		if i == r {
+			continue
+		}
+		current := t.data[i*width : (i+1)*width]
+		f := current[k]
+		if f == 0 {
+			continue
+		}
+		for j := range current {
+			current[j] -= f * pivotRow[j]
+		}
+	}
+	if math.Abs(float64(t.at(r, t.cols))) <= tolerance32 {
+		t.degenerate++
+	} else {
+		t.degenerate = 0
+	}
+	t.basis[r] = k
+}
+
+// iter Run one iteration, true once the tableau is optimal, ErrUnbounded when no row limits the entering column
+func (t *tableau32) iter() (bool, error) {
+	bland := t.degenerate >= blandThreshold
+	entering := -1
+	best := float32(tolerance32)
+	for j := 0; j < t.cols; j++ {
+		if t.excluded[j] || t.at(t.m, j) <= tolerance32 {
+			continue
+		}
+		if bland {
+			entering = j
+			break
+		}
+		if t.at(t.m, j) > best {
+			best, entering = t.at(t.m, j), j
+		}
+	}
+	if entering == -1 {
+		return true, nil
+	}
+	leaving := -1
+	ratio := float32(math.Inf(1))
+	for i := 0; i < t.m; i++ {
+		a := t.at(i, entering)
+		if a <= tolerance32 {
+			continue
+		}
+		r := t.at(i, t.cols) / a
+		if r < ratio || (r == ratio && t.basis[i] < t.basis[leaving]) {
+			ratio, leaving = r, i
+		}
+	}
+	if leaving == -1 {
+		return false, ErrUnbounded
+	}
+	t.pivot(leaving, entering)
+	return false, nil
+}
+
+// phaseOne Make the slack basis feasible like phaseOne: the artificial column -1 enters in place of the most
+// negative basic variable and the iterations maximize -x0. It returns the number of iterations.
+func (t *tableau32) phaseOne(running func(iter int) bool) (int, error) {
+	leaving := -1
+	min := float32(-tolerance32)
+	for i := 0; i < t.m; i++ {
+		if t.at(i, t.cols) < min {
+			min, leaving = t.at(i, t.cols), i
+		}
+	}
+	if leaving == -1 {
+		return 0, nil
+	}
+	artificial := t.cols - 1
+	costs := t.costs
+	t.costs = make([]float32, t.cols)
+	t.costs[artificial] = -1
+	for i := 0; i < t.m; i++ {
+		t.set(i, artificial, -1)
+	}
+	t.excluded[artificial] = false
+	t.price()
+	t.pivot(leaving, artificial)
+
+	iter := 1
+	optimal := false
+	for ; running(iter); iter++ {
+		end, err := t.iter()
+		if err != nil {
+			return iter, err
+		}
+		if end {
+			optimal = true
+			break
+		}
+	}
+	if !optimal {
+		return iter, ErrIterationLimit
+	}
+	for i := 0; i < t.m; i++ {
+		if t.basis[i] != artificial {
+			continue
+		}
+		if t.at(i, t.cols) > tolerance32 {
+			return iter, ErrInfeasible
+		}
+		// A degenerate x0 leaves for the column with the largest coefficient of its row
+		entering := -1
+		for j := 0; j < artificial; j++ {
+			if math.Abs(float64(t.at(i, j))) > tolerance32 && (entering == -1 || math.Abs(float64(t.at(i, j))) > math.Abs(float64(t.at(i, entering)))) {
+				entering = j
+			}
+		}
+		if entering == -1 {
+			return iter, ErrInfeasible
+		}
+		t.pivot(i, entering)
+		iter++
+	}
+	t.excluded[artificial] = true
+	t.costs = costs
+	t.price()
+	return iter, nil
+}
+
+// refine Solve the basis in float64 from A with iterative refinement. It returns the results and the score
+// of the basis when it is feasible, and whether it is also optimal within the tolerance.
+func refine(c, A, b *mat.Dense, basis []int, tolerance float64) (*mat.Dense, float64, bool) {
+	m, n := A.Dims()
+	at := func(i, j int) float64 {
+		if j < n {
+			return A.At(i, j)
+		}
+		if j-n == i {
+			return 1
+		}
+		return 0
+	}
+	cost := func(j int) float64 {
+		if j < n {
+			return c.At(0, j)
+		}
+		return 0
+	}
+	B := mat.NewDense(m, m, nil)
+	rhs := mat.NewVecDense(m, nil)
+	cB := mat.NewVecDense(m, nil)
+	for k, v := range basis {
+		if v >= n+m {
+			return nil, 0, false
+		}
+		for i := 0; i < m; i++ {
+			B.Set(i, k, at(i, v))
+		}
+		rhs.SetVec(k, b.At(k, 0))
+		cB.SetVec(k, cost(v))
+	}
+	var lu mat.LU
+	lu.Factorize(B)
+	xB, ok := refinedSolve(&lu, B, rhs, false)
+	if !ok {
+		return nil, 0, false
+	}
+	y, ok := refinedSolve(&lu, B, cB, true)
+	if !ok {
+		return nil, 0, false
+	}
+
+	values := make([]float64, n+m)
+	feasible := true
+	score := 0.0
+	for k, v := range basis {
+		values[v] = xB.AtVec(k)
+		feasible = feasible && values[v] >= -feasibilityTolerance
+		score += cost(v) * values[v]
+	}
+	if !feasible {
+		return nil, 0, false
+	}
+	inBasis := make([]bool, n+m)
+	for _, v := range basis {
+		inBasis[v] = true
+	}
+	optimal := true
+	for j := 0; j < n+m && optimal; j++ {
+		if inBasis[j] {
+			continue
+		}
+		reduced := cost(j)
+		for i := 0; i < m; i++ {
+			reduced -= y.AtVec(i) * at(i, j)
+		}
+		optimal = reduced <= tolerance
+	}
+	return mat.NewDense(n+m, 1, values), score, optimal
+}
+
+// refinedSolve Solve B*x = r, or B^T*x = r, with refinementSteps steps x += B^-1*(r - B*x) of iterative refinement
+func refinedSolve(lu *mat.LU, B *mat.Dense, r *mat.VecDense, trans bool) (*mat.VecDense, bool) {
+	m := r.Len()
+	x := mat.NewVecDense(m, nil)
+	err := lu.SolveVecTo(x, trans, r)
+	if err != nil {
+		return nil, false
+	}
+	var product, residual, correction mat.VecDense
+	var op mat.Matrix = B
+	if trans {
+		op = B.T()
+	}
+	for k := 0; k < refinementSteps; k++ {
+		product.MulVec(op, x)
+		residual.SubVec(r, &product)
+		err = lu.SolveVecTo(&correction, trans, &residual)
+		if err != nil {
+			return nil, false
+		}
+		x.AddVec(x, &correction)
+	}
+	return x, true
+}
+
+// simplexFrom Run the float64 simplex from the basis, or from the slack basis if it is singular or infeasible
+func simplexFrom(c, A, b *mat.Dense, basis []int, opts []Option) (int, *mat.Dense, float64, error) {
+	o := newOptions(opts)
+	cf := CanonicalForm{}
+	err := cf.New(mat.DenseCopyOf(c), mat.DenseCopyOf(A), b)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	cf.configure(o)
+	basic := make([]bool, cf.n+cf.m)
+	for _, v := range basis {
+		basic[v] = true
+	}
+	// Swap each basic variable of the basis with a slack variable which is not basic in the basis
+	k := 0
+	for j := 0; j < cf.n; j++ {
+		if !basic[cf.remap[j]] {
+			continue
+		}
+		for basic[cf.remap[cf.n+k]] {
+			k++
+		}
+		cf.swap(j, k)
+		k++
+	}
+	if cf.refactorize() != nil || !cf.primalFeasible() {
+		return Simplex(c, A, b, opts...)
+	}
+	iter, err := cf.Reoptimize(o.maxIter)
+	if err != nil && err != ErrIterationLimit {
+		return iter, nil, 0, err
+	}
+	results, score := cf.GetResults()
+	cf.traceResults(results, score)
+	return iter, results, score, err
+}
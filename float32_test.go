@@ -0,0 +1,60 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSimplexFloat32(t *testing.T) {
+	for seed := int64(1); seed <= 5; seed++ {
+		lp, err := GenerateLP(60, 40, 0.3, seed)
+		require.NoError(t, err)
+		_, results, score, err := SimplexFloat32(lp.C, lp.A, lp.B, silent)
+		require.NoError(t, err, "seed %d", seed)
+		// The refinement gives the float64 accuracy
+		assert.InDelta(t, lp.Optimum, score, 1e-9*(1+lp.Optimum), "seed %d", seed)
+		x := make([]float64, 60)
+		for j := range x {
+			x[j] = results.At(j, 0)
+		}
+		assert.True(t, feasible(lp.A, lp.B, x), "seed %d", seed)
+	}
+
+	// Negative right-hand sides go through the phase one
+	_, results, score, err := SimplexFloat32(mat.NewDense(1, 2, []float64{-2, -3}), mat.NewDense(2, 2, []float64{-1, -1, -1, 0}), mat.NewDense(2, 1, []float64{-4, -1}), silent)
+	require.NoError(t, err)
+	assert.InDelta(t, -8, score, 1e-9)
+	assert.InDelta(t, 4, results.At(0, 0), 1e-9)
+
+	_, _, _, err = SimplexFloat32(mat.NewDense(1, 1, []float64{1}), mat.NewDense(1, 1, []float64{1}), mat.NewDense(1, 1, []float64{-1}), silent)
+	assert.Equal(t, ErrInfeasible, errors.Cause(err))
+	_, _, _, err = SimplexFloat32(mat.NewDense(1, 1, []float64{1}), mat.NewDense(1, 1, []float64{-1}), mat.NewDense(1, 1, []float64{0}), silent)
+	assert.Equal(t, ErrUnbounded, errors.Cause(err))
+	lp, err := GenerateLP(60, 40, 0.3, 1)
+	require.NoError(t, err)
+	_, results, _, err = SimplexFloat32(lp.C, lp.A, lp.B, silent, WithMaxIter(3))
+	assert.Equal(t, ErrIterationLimit, errors.Cause(err))
+	assert.NotNil(t, results)
+	_, _, _, err = SimplexFloat32(mat.NewDense(1, 2, nil), mat.NewDense(1, 1, nil), mat.NewDense(1, 1, nil), silent)
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+}
+
+func TestSimplexFrom(t *testing.T) {
+	lp, err := GenerateLP(20, 15, 0.4, 3)
+	require.NoError(t, err)
+	// The slack basis is feasible but not optimal
+	slacks := make([]int, 15)
+	for i := range slacks {
+		slacks[i] = 20 + i
+	}
+	_, _, score, err := simplexFrom(lp.C, lp.A, lp.B, slacks, []Option{silent})
+	require.NoError(t, err)
+	assert.InDelta(t, lp.Optimum, score, 1e-6*(1+lp.Optimum))
+
+	_, _, refined := refine(lp.C, lp.A, lp.B, slacks, 1e-9)
+	assert.False(t, refined)
+}
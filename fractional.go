@@ -0,0 +1,81 @@
+package goptimization
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// FractionalSimplex Solve the linear-fractional problem:
+// Maximize (Σ(1<=j<=n) c_j*x_j + alpha) / (Σ(1<=j<=n) d_j*x_j + beta)
+// Constraints:
+// 1<=i<=m,  Σ(1<=j<=n) a_i_j*x_j <= b_i
+// 1<=j<=n x_j >= 0
+// The denominator must be positive on the feasible region. The substitution of Charnes and Cooper,
+// y = t*x with t = 1/(d*x + beta), gives the linear problem solved by SimplexRanged:
+// Maximize c*y + alpha*t
+// Constraints: A*y - b*t <= 0, d*y + beta*t = 1 as a row of range 0, y >= 0, t >= 0
+// and x = y/t. t = 0 at the optimum means the ratio is only approached along an unbounded ray, ErrUnbounded is returned.
+// The results follow the layout of Simplex in the original variables, the n values of x and the m slacks b - A*x,
+// and the score is the ratio. The options and the other errors are those of Simplex.
+func FractionalSimplex(c mat.Matrix, alpha float64, d mat.Matrix, beta float64, A *mat.Dense, b mat.Matrix, opts ...Option) (int, *mat.Dense, float64, error) {
+	row, err := asRow(c, "c")
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	denominator, err := asRow(d, "d")
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	column, err := asColumn(b, "b")
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	err = checkDims(row, A, column)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	m, n := A.Dims()
+	if _, cols := denominator.Dims(); cols != n {
+		return 0, nil, 0, newError(ErrDimensionMismatch, "d dims must be (1,%d) like c, got (1,%d)", n, cols)
+	}
+
+	// The variables y and then t
+	cc := mat.NewDense(1, n+1, nil)
+	cc.Slice(0, 1, 0, n).(*mat.Dense).Copy(row)
+	cc.Set(0, n, alpha)
+	ccA := mat.NewDense(m+1, n+1, nil)
+	ccA.Slice(0, m, 0, n).(*mat.Dense).Copy(A)
+	for i := 0; i < m; i++ {
+		ccA.Set(i, n, -column.At(i, 0))
+	}
+	ccA.Slice(m, m+1, 0, n).(*mat.Dense).Copy(denominator)
+	ccA.Set(m, n, beta)
+	ccB := mat.NewDense(m+1, 1, nil)
+	ccB.Set(m, 0, 1)
+	ranges := make([]float64, m+1)
+	for i := 0; i < m; i++ {
+		ranges[i] = math.Inf(1)
+	}
+	iter, results, score, err := SimplexRanged(cc, ccA, ccB, ranges, opts...)
+	if err != nil && errors.Cause(err) != ErrIterationLimit {
+		return iter, nil, 0, err
+	}
+	if results == nil {
+		return iter, nil, 0, err
+	}
+	t := results.At(n, 0)
+	if t <= feasibilityTolerance {
+		return iter, nil, 0, newError(ErrUnbounded, "the optimal ratio is only reached as x grows without bound")
+	}
+	x := mat.NewDense(n+m, 1, nil)
+	for j := 0; j < n; j++ {
+		x.Set(j, 0, results.At(j, 0)/t)
+	}
+	// The slack of A*y - b*t <= 0 is t*(b - A*x)
+	for i := 0; i < m; i++ {
+		x.Set(n+i, 0, results.At(n+1+i, 0)/t)
+	}
+	return iter, x, score, err
+}
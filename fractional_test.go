@@ -0,0 +1,62 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestFractionalSimplex(t *testing.T) {
+	// Maximize (x1 + 3*x2 + 1)/(x1 + x2 + 2) with x1 + x2 <= 4 and x2 <= 3: x = (0, 3) gives 2
+	c := mat.NewDense(1, 2, []float64{1, 3})
+	d := mat.NewDense(1, 2, []float64{1, 1})
+	A := mat.NewDense(2, 2, []float64{
+		1, 1,
+		0, 1,
+	})
+	b := mat.NewDense(2, 1, []float64{4, 3})
+	_, results, score, err := FractionalSimplex(c, 1, d, 2, A, b, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, 2, score, 1e-9)
+	assert.InDeltaSlice(t, []float64{0, 3, 1, 0}, mat.Col(nil, 0, results), 1e-9)
+
+	for seed := int64(1); seed <= 5; seed++ {
+		lp, err := GenerateLP(10, 8, 0.5, seed)
+		require.NoError(t, err)
+		// A positive denominator
+		d := mat.NewDense(1, 10, nil)
+		for j := 0; j < 10; j++ {
+			d.Set(0, j, float64(1+j%3))
+		}
+		_, results, ratio, err := FractionalSimplex(lp.C, 2, d, 5, lp.A, lp.B, silent)
+		require.NoError(t, err)
+		x := mat.Col(nil, 0, results)[:10]
+		assert.True(t, feasible(lp.A, lp.B, x))
+		numerator, denominator := 2.0, 5.0
+		for j, xj := range x {
+			numerator += lp.C.At(0, j) * xj
+			denominator += d.At(0, j) * xj
+		}
+		assert.InDelta(t, numerator/denominator, ratio, 1e-7)
+		// Dinkelbach: the maximum of c*x + 2 - ratio*(d*x + 5) is 0 at the optimal ratio
+		var parametric mat.Dense
+		parametric.Scale(-ratio, d)
+		parametric.Add(&parametric, lp.C)
+		_, _, score, err := Simplex(&parametric, mat.DenseCopyOf(lp.A), lp.B, silent)
+		require.NoError(t, err)
+		assert.InDelta(t, 0, score+2-5*ratio, 1e-6, "seed %d", seed)
+	}
+}
+
+func TestFractionalSimplexErrors(t *testing.T) {
+	// x1/(x1 + 1) tends to 1 as x1 grows, the only constraint is x2 <= 1
+	A := mat.NewDense(1, 2, []float64{0, 1})
+	b := mat.NewDense(1, 1, []float64{1})
+	_, _, _, err := FractionalSimplex(mat.NewDense(1, 2, []float64{1, 0}), 0, mat.NewDense(1, 2, []float64{1, 0}), 1, A, b, silent)
+	assert.Equal(t, ErrUnbounded, errors.Cause(err))
+	_, _, _, err = FractionalSimplex(mat.NewDense(1, 2, []float64{1, 0}), 0, mat.NewDense(1, 3, nil), 1, A, b, silent)
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+}
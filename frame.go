@@ -0,0 +1,118 @@
+package goptimization
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Frame Linear problem stored column by column and keyed by the names of the variables, like the columns of a data frame
+// Maximize Σ Objective[name]*x_name subject to Σ Columns[name][i]*x_name Senses[i] RHS[i] for each row i.
+type Frame struct {
+	// Objective Coefficient of each variable in the maximized objective, 0 for the missing variables
+	Objective map[string]float64
+	// Columns Coefficients of each variable in the rows, a shorter column has 0 in the last rows
+	Columns map[string][]float64
+	// Senses, RHS Sense and right-hand side of each row
+	Senses []Sense
+	RHS    []float64
+	// RowNames Name of each row, optional, see AddNamedRow
+	RowNames []string
+	// Integer Variables which must be integral
+	Integer map[string]bool
+	// Order Order of the variables in the model, the sorted names of Objective and Columns when it is empty
+	Order []string
+}
+
+// Model Model of the frame, with a variable per name in Order
+func (f *Frame) Model() (*Model, error) {
+	if len(f.Senses) != len(f.RHS) {
+		return nil, newError(ErrDimensionMismatch, "%d senses for %d right-hand sides", len(f.Senses), len(f.RHS))
+	}
+	if f.RowNames != nil && len(f.RowNames) != len(f.RHS) {
+		return nil, newError(ErrDimensionMismatch, "%d row names for %d rows", len(f.RowNames), len(f.RHS))
+	}
+	order, err := f.order()
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Model{}
+	rows := make([]Expr, len(f.RHS))
+	objective := Expr{}
+	for _, name := range order {
+		v := m.AddVariable(name, f.Integer[name])
+		column := f.Columns[name]
+		if len(column) > len(rows) {
+			return nil, newError(ErrDimensionMismatch, "column %s has %d coefficients for %d rows", name, len(column), len(rows))
+		}
+		for i, a := range column {
+			if math.IsNaN(a) {
+				return nil, errors.Errorf("column %s: coefficient %d is NaN", name, i)
+			}
+			if a != 0 {
+				rows[i].Terms = append(rows[i].Terms, Term{Var: v, Coef: a})
+			}
+		}
+		if c := f.Objective[name]; c != 0 {
+			objective.Terms = append(objective.Terms, Term{Var: v, Coef: c})
+		}
+	}
+	m.Maximize(objective)
+	for i, e := range rows {
+		name := ""
+		if f.RowNames != nil {
+			name = f.RowNames[i]
+		}
+		err := m.AddNamedRow(name, e, f.Senses[i], f.RHS[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// order Names of the variables in the order of the model, every name of Objective, Columns and Integer must be in Order
+func (f *Frame) order() ([]string, error) {
+	names := map[string]bool{}
+	for name := range f.Objective {
+		names[name] = true
+	}
+	for name := range f.Columns {
+		names[name] = true
+	}
+	for name := range f.Integer {
+		names[name] = true
+	}
+	if len(f.Order) == 0 {
+		order := make([]string, 0, len(names))
+		for name := range names {
+			order = append(order, name)
+		}
+		sort.Strings(order)
+		return order, nil
+	}
+	listed := map[string]bool{}
+	for _, name := range f.Order {
+		if listed[name] {
+			return nil, errors.Errorf("variable %s is twice in the order", name)
+		}
+		listed[name] = true
+	}
+	for name := range names {
+		if !listed[name] {
+			return nil, errors.Errorf("variable %s is not in the order", name)
+		}
+	}
+	return f.Order, nil
+}
+
+// NamedValues Value of each variable of the solution of the model by name, the last variable wins for a repeated name
+func (m *Model) NamedValues(s *ModelSolution) map[string]float64 {
+	values := make(map[string]float64, len(m.names))
+	for v, name := range m.names {
+		values[name] = s.Values[v]
+	}
+	return values
+}
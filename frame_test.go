@@ -0,0 +1,77 @@
+package goptimization
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrame(t *testing.T) {
+	f := &Frame{
+		Objective: map[string]float64{"chairs": 5, "tables": 4, "desks": 3},
+		Columns: map[string][]float64{
+			"chairs": {2, 4, 3},
+			"tables": {3, 1, 4},
+			"desks":  {1, 2, 2},
+		},
+		Senses:   []Sense{LessEq, LessEq, LessEq},
+		RHS:      []float64{5, 11, 8},
+		RowNames: []string{"wood", "labor", "paint"},
+	}
+	m, err := f.Model()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"chairs", "desks", "tables"}, m.Names())
+	assert.Equal(t, []string{"wood", "labor", "paint"}, m.RowNames())
+	solution, err := m.Solve(100)
+	require.NoError(t, err)
+	assert.InDelta(t, 13, solution.Score, 1e-9)
+	values := m.NamedValues(solution)
+	assert.InDelta(t, 2, values["chairs"], 1e-9)
+	assert.InDelta(t, 0, values["tables"], 1e-9)
+	assert.InDelta(t, 1, values["desks"], 1e-9)
+
+	// The order fixes the columns, a short column has 0 in the last rows
+	f.Order = []string{"tables", "chairs", "desks"}
+	f.Columns["desks"] = []float64{1}
+	f.Integer = map[string]bool{"chairs": true}
+	m, err = f.Model()
+	require.NoError(t, err)
+	p := m.Problem()
+	assert.Equal(t, []float64{4, 5, 3}, p.C.RawRowView(0))
+	assert.Equal(t, []float64{1, 4, 0}, p.A.RawRowView(1))
+	assert.Equal(t, []bool{false, true, false}, p.Integer)
+}
+
+func TestFrameErrors(t *testing.T) {
+	valid := func() *Frame {
+		return &Frame{
+			Objective: map[string]float64{"x": 1},
+			Columns:   map[string][]float64{"x": {1}},
+			Senses:    []Sense{LessEq},
+			RHS:       []float64{1},
+		}
+	}
+	f := valid()
+	f.Senses = nil
+	_, err := f.Model()
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+	f = valid()
+	f.Columns["x"] = []float64{1, 2}
+	_, err = f.Model()
+	assert.EqualError(t, err, "dimension mismatch: column x has 2 coefficients for 1 rows")
+	f = valid()
+	f.Columns["x"] = []float64{math.NaN()}
+	_, err = f.Model()
+	assert.EqualError(t, err, "column x: coefficient 0 is NaN")
+	f = valid()
+	f.Columns["y"] = []float64{1}
+	f.Order = []string{"x"}
+	_, err = f.Model()
+	assert.EqualError(t, err, "variable y is not in the order")
+	f.Order = []string{"x", "y", "x"}
+	_, err = f.Model()
+	assert.EqualError(t, err, "variable x is twice in the order")
+}
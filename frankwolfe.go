@@ -0,0 +1,123 @@
+package goptimization
+
+import (
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// goldenSectionSteps Number of reductions of the interval of the exact line search of FrankWolfe
+const goldenSectionSteps = 60
+
+// FrankWolfe Minimize the smooth convex function f over the polytope {Ax <= b, x >= 0}.
+// Each iteration solves the linear problem min grad(x)*s over the polytope with Simplex and moves
+// from x towards the vertex s: x + γ(s - x), γ = 2/(k+2) at the iteration k or, with WithLineSearch,
+// the γ of [0, 1] minimizing f. The iterations stop once the Frank-Wolfe gap grad(x)*(x - s),
+// an upper bound of f(x) - min f, is at most the tolerance. The first point is the vertex minimizing grad(0).
+// It returns the number of iterations, the point reached and its value, with ErrIterationLimit when
+// the limit given by WithMaxIter (10000 by default) or WithTimeLimit is reached first.
+// The default tolerance 1e-9 needs many iterations, the gap decreases like 1/k.
+func FrankWolfe(f Objective, grad Gradient, A, b *mat.Dense, opts ...Option) (int, []float64, float64, error) {
+	if f == nil || grad == nil {
+		return 0, nil, 0, errors.New("f and grad must be defined")
+	}
+	if A == nil || b == nil {
+		return 0, nil, 0, newError(ErrDimensionMismatch, "A and b must not be nil")
+	}
+	_, n := A.Dims()
+	o := newOptions(opts)
+	maxIter := o.maxIter
+	if maxIter <= 0 {
+		maxIter = descentIterations
+	}
+	var deadline time.Time
+	if o.timeLimit > 0 {
+		deadline = time.Now().Add(o.timeLimit)
+	}
+
+	// oracle Vertex of the polytope minimizing g*s
+	oracle := func(g []float64) ([]float64, error) {
+		if len(g) != n {
+			return nil, newError(ErrDimensionMismatch, "len(grad) = %d, expected %d", len(g), n)
+		}
+		c := mat.NewDense(1, n, nil)
+		for j := range g {
+			c.Set(0, j, -g[j])
+		}
+		_, results, _, err := Simplex(c, A, b, WithLogger(o.logger))
+		if err != nil {
+			return nil, errors.Wrap(err, "linear oracle")
+		}
+		s := make([]float64, n)
+		for j := range s {
+			s[j] = results.At(j, 0)
+		}
+		return s, nil
+	}
+
+	x, err := oracle(grad(make([]float64, n)))
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	direction := make([]float64, n)
+	step := func(gamma float64) []float64 {
+		p := make([]float64, n)
+		for j := range p {
+			p[j] = x[j] + gamma*direction[j]
+		}
+		return p
+	}
+	iter := 0
+	for ; iter < maxIter && (deadline.IsZero() || time.Now().Before(deadline)); iter++ {
+		g := grad(x)
+		s, err := oracle(g)
+		if err != nil {
+			return iter, nil, 0, err
+		}
+		gap := 0.0
+		for j := range x {
+			direction[j] = s[j] - x[j]
+			gap -= g[j] * direction[j]
+		}
+		if gap <= o.tolerance {
+			return iter, x, f(x), nil
+		}
+		gamma := 2 / float64(iter+2)
+		if o.lineSearch {
+			gamma = goldenSection(func(gamma float64) float64 {
+				return f(step(gamma))
+			})
+		}
+		x = step(gamma)
+	}
+	return iter, x, f(x), ErrIterationLimit
+}
+
+// goldenSection Minimum over [0, 1] of the unimodal function phi
+func goldenSection(phi func(float64) float64) float64 {
+	ratio := (math.Sqrt(5) - 1) / 2
+	lower, upper := 0.0, 1.0
+	left, right := upper-ratio*(upper-lower), lower+ratio*(upper-lower)
+	phiLeft, phiRight := phi(left), phi(right)
+	for k := 0; k < goldenSectionSteps; k++ {
+		if phiLeft < phiRight {
+			upper, right, phiRight = right, left, phiLeft
+			left = upper - ratio*(upper-lower)
+			phiLeft = phi(left)
+		} else {
+			lower, left, phiLeft = left, right, phiRight
+			right = lower + ratio*(upper-lower)
+			phiRight = phi(right)
+		}
+	}
+	// The ends of the interval are candidates too, the minimum is often a vertex
+	best, value := (lower+upper)/2, phi((lower+upper)/2)
+	for _, gamma := range []float64{0, 1} {
+		if v := phi(gamma); v < value {
+			best, value = gamma, v
+		}
+	}
+	return best
+}
@@ -0,0 +1,52 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestFrankWolfe(t *testing.T) {
+	//Projection of p on the triangle x1 + x2 <= 1, x >= 0
+	A := mat.NewDense(1, 2, []float64{1, 1})
+	b := mat.NewDense(1, 1, []float64{1})
+	distance := func(p []float64) (Objective, Gradient) {
+		f := func(x []float64) float64 {
+			return (x[0]-p[0])*(x[0]-p[0]) + (x[1]-p[1])*(x[1]-p[1])
+		}
+		grad := func(x []float64) []float64 {
+			return []float64{2 * (x[0] - p[0]), 2 * (x[1] - p[1])}
+		}
+		return f, grad
+	}
+
+	f, grad := distance([]float64{1, 1})
+	_, x, value, err := FrankWolfe(f, grad, A, b, WithLineSearch(), WithTolerance(0.000001))
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, x[0], 0.0001)
+	assert.InDelta(t, 0.5, x[1], 0.0001)
+	assert.InDelta(t, 0.5, value, 0.000001)
+
+	//Inside the triangle the gap decreases slowly with the default steps
+	f, grad = distance([]float64{0.2, 0.3})
+	iter, x, value, err := FrankWolfe(f, grad, A, b, WithTolerance(0.001))
+	require.NoError(t, err)
+	assert.InDelta(t, 0.2, x[0], 0.05)
+	assert.InDelta(t, 0.3, x[1], 0.05)
+	assert.InDelta(t, 0, value, 0.001)
+	lineIter, _, _, err := FrankWolfe(f, grad, A, b, WithTolerance(0.001), WithLineSearch())
+	require.NoError(t, err)
+	assert.Less(t, lineIter, iter)
+
+	_, _, _, err = FrankWolfe(f, grad, A, b, WithMaxIter(2), WithTolerance(0))
+	assert.Equal(t, ErrIterationLimit, err)
+
+	//The linear oracle is unbounded when the polytope is not
+	linear := func(x []float64) float64 { return -x[0] }
+	slope := func(x []float64) []float64 { return []float64{-1, 0} }
+	_, _, _, err = FrankWolfe(linear, slope, mat.NewDense(1, 2, []float64{1, -1}), b)
+	assert.Equal(t, ErrUnbounded, errors.Cause(err))
+}
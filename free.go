@@ -0,0 +1,86 @@
+package goptimization
+
+import (
+	"gonum.org/v1/gonum/mat"
+)
+
+// SimplexFree Solve the linear problem of Simplex where the variables x_j with free[j] have no sign restriction.
+// Each free variable is split into x_j = x⁺_j - x⁻_j with x⁺_j, x⁻_j >= 0: the column of x⁻_j, the opposite of the
+// column of x_j, is appended after the n columns of A. At most one of them is basic at a vertex, the other is 0.
+// The results follow the layout of Simplex, the n values of the variables followed by the slacks of the m constraints.
+// free can be shorter than the number of variables, the missing variables are non-negative.
+// The options and errors are those of Simplex.
+func SimplexFree(c mat.Matrix, A *mat.Dense, b mat.Matrix, free []bool, opts ...Option) (int, *mat.Dense, float64, error) {
+	row, err := asRow(c, "c")
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	column, err := asColumn(b, "b")
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	err = checkDims(row, A, column)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	_, n := A.Dims()
+	if len(free) > n {
+		return 0, nil, 0, newError(ErrDimensionMismatch, "len(free) must be at most %d, got %d", n, len(free))
+	}
+	splitC, splitA := splitFree(row, A, free)
+	iter, results, score, err := Simplex(splitC, splitA, column, opts...)
+	if results == nil {
+		return iter, nil, score, err
+	}
+	return iter, joinFree(results, n, free), score, err
+}
+
+// splitFree Append the opposite of the column of each free variable to c and A, in the order of the variables
+func splitFree(c, A *mat.Dense, free []bool) (*mat.Dense, *mat.Dense) {
+	m, n := A.Dims()
+	minus := []int{}
+	for j, isFree := range free {
+		if isFree {
+			minus = append(minus, j)
+		}
+	}
+	if len(minus) == 0 {
+		return c, A
+	}
+	splitC := mat.NewDense(1, n+len(minus), nil)
+	splitC.Slice(0, 1, 0, n).(*mat.Dense).Copy(c)
+	splitA := mat.NewDense(m, n+len(minus), nil)
+	splitA.Slice(0, m, 0, n).(*mat.Dense).Copy(A)
+	for k, j := range minus {
+		splitC.Set(0, n+k, -c.At(0, j))
+		for i := 0; i < m; i++ {
+			splitA.Set(i, n+k, -A.At(i, j))
+		}
+	}
+	return splitC, splitA
+}
+
+// joinFree Values of the n variables and the slacks of the results of a split problem, x_j = x⁺_j - x⁻_j
+func joinFree(results *mat.Dense, n int, free []bool) *mat.Dense {
+	rows, _ := results.Dims()
+	minus := 0
+	for _, isFree := range free {
+		if isFree {
+			minus++
+		}
+	}
+	joined := mat.NewDense(rows-minus, 1, nil)
+	k := 0
+	for j := 0; j < n; j++ {
+		joined.Set(j, 0, results.At(j, 0))
+		if j < len(free) && free[j] {
+			joined.Set(j, 0, results.At(j, 0)-results.At(n+k, 0))
+			k++
+		}
+	}
+	// The slacks follow the columns of x⁻
+	for i := n; i < rows-minus; i++ {
+		joined.Set(i, 0, results.At(i+minus, 0))
+	}
+	return joined
+}
@@ -0,0 +1,79 @@
+package goptimization
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSimplexFree(t *testing.T) {
+	// Maximize x1 + 2*x2 with x1 free, x1 + x2 <= 1, x2 <= 3 and x1 >= -5 as -x1 <= 5 : x1 = -2, x2 = 3
+	c := mat.NewDense(1, 2, []float64{1, 2})
+	A := mat.NewDense(3, 2, []float64{
+		1, 1,
+		0, 1,
+		-1, 0,
+	})
+	b := mat.NewDense(3, 1, []float64{1, 3, 5})
+	_, results, score, err := SimplexFree(c, A, b, []bool{true}, silent)
+	require.NoError(t, err)
+	rows, _ := results.Dims()
+	assert.Equal(t, 5, rows)
+	assert.InDelta(t, 4, score, 1e-9)
+	assert.InDelta(t, -2, results.At(0, 0), 1e-9)
+	assert.InDelta(t, 3, results.At(1, 0), 1e-9)
+	// The slacks follow the variables
+	assert.InDelta(t, 0, results.At(2, 0), 1e-9)
+	assert.InDelta(t, 0, results.At(3, 0), 1e-9)
+	assert.InDelta(t, 3, results.At(4, 0), 1e-9)
+
+	// Without the lower bound, the minimum of x1 is unbounded
+	_, _, _, err = SimplexFree(mat.NewDense(1, 2, []float64{-1, 0}), A.Slice(0, 2, 0, 2).(*mat.Dense), b.Slice(0, 2, 0, 1), []bool{true}, silent)
+	assert.Equal(t, ErrUnbounded, errors.Cause(err))
+	_, _, _, err = SimplexFree(c, A, b, []bool{true, false, true}, silent)
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+
+	// No free variable is Simplex
+	_, results, score, err = SimplexFree(c, A, b, nil, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, 2, score, 1e-9)
+	assert.InDelta(t, 1, results.At(1, 0), 1e-9)
+}
+
+func TestModelAddFreeVariable(t *testing.T) {
+	m := &Model{}
+	x := m.AddVariable("x", false)
+	y := m.AddFreeVariable("y", false)
+	z := m.AddVariable("z", false)
+	assert.False(t, m.IsFree(x))
+	assert.True(t, m.IsFree(y))
+	assert.False(t, m.IsFree(z))
+	// Maximize x - y + z with y >= -3, x + y <= 1 and z - y <= 4: y = -3, x = 4, z = 1
+	m.Maximize(Expr{Terms: []Term{{x, 1}, {y, -1}, {z, 1}}})
+	require.NoError(t, m.AddRange("y", y.Expr(), -3, math.Inf(1)))
+	require.NoError(t, m.AddConstraint(Expr{Terms: []Term{{x, 1}, {y, 1}}}, 1))
+	require.NoError(t, m.AddConstraint(Expr{Terms: []Term{{z, 1}, {y, -1}}}, 4))
+
+	c, A, _, integer := m.Standard()
+	_, n := A.Dims()
+	assert.Equal(t, 4, n)
+	assert.Equal(t, []float64{1, -1, 1, 1}, mat.Row(nil, 0, c))
+	assert.Equal(t, []bool{false, false, false, false}, integer)
+
+	solution, err := m.Solve(100, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, 8, solution.Score, 1e-9)
+	assert.InDelta(t, 4, solution.Value(x), 1e-9)
+	assert.InDelta(t, -3, solution.Value(y), 1e-9)
+	assert.InDelta(t, 1, solution.Value(z), 1e-9)
+
+	encoded, err := m.GobEncode()
+	require.NoError(t, err)
+	decoded := &Model{}
+	require.NoError(t, decoded.GobDecode(encoded))
+	assert.True(t, decoded.IsFree(y))
+}
@@ -0,0 +1,74 @@
+package goptimization
+
+import (
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// SolveMatrixGame Optimal mixed strategies of the two-player zero-sum game where the row player receives payoff_i_j
+// from the column player when they play the row i and the column j.
+// The payoff is shifted by s so that all its entries are at least 1, which makes the value v + s positive, then
+// the column player solves:
+// Maximize Σ(1<=j<=n) w_j
+// Constraints:
+// 1<=i<=m,  Σ(1<=j<=n) (a_i_j + s)*w_j <= 1
+// and the row player the dual problem:
+// Maximize -Σ(1<=i<=m) u_i
+// Constraints:
+// 1<=j<=n, -Σ(1<=i<=m) (a_i_j + s)*u_i <= -1
+// The strategies are w/Σw and u/Σu, and the value of the game is 1/Σw - s.
+// It returns the strategy of the row player (m,1), the strategy of the column player (n,1) and the value.
+// The options are given to both calls to Simplex.
+func SolveMatrixGame(payoff *mat.Dense, opts ...Option) (*mat.Dense, *mat.Dense, float64, error) {
+	if payoff == nil {
+		return nil, nil, 0, newError(ErrDimensionMismatch, "payoff must not be nil")
+	}
+	m, n := payoff.Dims()
+	shift := 1 - mat.Min(payoff)
+	shifted := mat.NewDense(m, n, nil)
+	shifted.Apply(func(i, j int, v float64) float64 {
+		return v + shift
+	}, payoff)
+
+	c := mat.NewDense(1, n, nil)
+	b := mat.NewDense(m, 1, nil)
+	for j := 0; j < n; j++ {
+		c.Set(0, j, 1)
+	}
+	for i := 0; i < m; i++ {
+		b.Set(i, 0, 1)
+	}
+	_, results, total, err := Simplex(c, shifted, b, opts...)
+	if err != nil {
+		return nil, nil, 0, errors.Wrap(err, "column player")
+	}
+	column := strategy(results, n, total)
+
+	dualC := mat.NewDense(1, m, nil)
+	dualA := mat.NewDense(n, m, nil)
+	dualB := mat.NewDense(n, 1, nil)
+	for i := 0; i < m; i++ {
+		dualC.Set(0, i, -1)
+	}
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			dualA.Set(j, i, -shifted.At(i, j))
+		}
+		dualB.Set(j, 0, -1)
+	}
+	_, results, dualTotal, err := Simplex(dualC, dualA, dualB, opts...)
+	if err != nil {
+		return nil, nil, 0, errors.Wrap(err, "row player")
+	}
+	row := strategy(results, m, -dualTotal)
+	return row, column, 1/total - shift, nil
+}
+
+// strategy Mixed strategy given by the first n variables of the results, whose sum is total
+func strategy(results *mat.Dense, n int, total float64) *mat.Dense {
+	s := mat.NewDense(n, 1, nil)
+	for j := 0; j < n; j++ {
+		s.Set(j, 0, results.At(j, 0)/total)
+	}
+	return s
+}
@@ -0,0 +1,60 @@
+package goptimization
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSolveMatrixGame(t *testing.T) {
+	// Rock, paper, scissors
+	payoff := mat.NewDense(3, 3, []float64{
+		0, -1, 1,
+		1, 0, -1,
+		-1, 1, 0,
+	})
+	row, column, value, err := SolveMatrixGame(payoff)
+	require.NoError(t, err)
+	assert.InDelta(t, 0, value, 0.000001)
+	for k := 0; k < 3; k++ {
+		assert.InDelta(t, 1.0/3, row.At(k, 0), 0.000001)
+		assert.InDelta(t, 1.0/3, column.At(k, 0), 0.000001)
+	}
+
+	// Saddle point: the row player plays the second row and the column player the second column
+	row, column, value, err = SolveMatrixGame(mat.NewDense(2, 2, []float64{3, 1, 4, 2}))
+	require.NoError(t, err)
+	assert.InDelta(t, 2, value, 0.000001)
+	assert.InDelta(t, 1, row.At(1, 0), 0.000001)
+	assert.InDelta(t, 1, column.At(1, 0), 0.000001)
+
+	_, _, _, err = SolveMatrixGame(nil)
+	assert.Error(t, err)
+}
+
+func TestSolveMatrixGameEquilibrium(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	payoff := mat.NewDense(4, 6, nil)
+	payoff.Apply(func(i, j int, v float64) float64 {
+		return 10*rnd.Float64() - 5
+	}, payoff)
+	row, column, value, err := SolveMatrixGame(payoff)
+	require.NoError(t, err)
+	assert.InDelta(t, 1, mat.Sum(row), 0.000001)
+	assert.InDelta(t, 1, mat.Sum(column), 0.000001)
+
+	// The row strategy guarantees at least the value against each column, the column strategy at most the value
+	var against mat.Dense
+	against.Mul(row.T(), payoff)
+	for j := 0; j < 6; j++ {
+		assert.GreaterOrEqual(t, against.At(0, j), value-0.000001)
+	}
+	var facing mat.Dense
+	facing.Mul(payoff, column)
+	for i := 0; i < 4; i++ {
+		assert.LessOrEqual(t, facing.At(i, 0), value+0.000001)
+	}
+}
@@ -0,0 +1,59 @@
+package goptimization
+
+import (
+	"math"
+)
+
+// MIPProgress Incumbent, bound and gap of the branch and bound search
+type MIPProgress struct {
+	// Nodes Number of explored nodes
+	Nodes int
+	// Incumbent Score of the best integer solution, -Inf before the first one
+	Incumbent float64
+	// BestBound Upper bound on the optimum: the largest relaxation bound of the open nodes,
+	// the incumbent once the tree is empty
+	BestBound float64
+	// AbsoluteGap BestBound - Incumbent and RelativeGap AbsoluteGap / |Incumbent|, +Inf before the first incumbent
+	AbsoluteGap float64
+	RelativeGap float64
+}
+
+// Status Progress of the search, the final gap once Solve has returned
+func (bb *BranchAndBound) Status() MIPProgress {
+	p := MIPProgress{
+		Nodes:       bb.Nodes,
+		Incumbent:   bb.score,
+		BestBound:   bb.BestBound,
+		AbsoluteGap: math.Inf(1),
+		RelativeGap: math.Inf(1),
+	}
+	if bb.incumbent == nil {
+		return p
+	}
+	p.AbsoluteGap = math.Max(0, p.BestBound-p.Incumbent)
+	p.RelativeGap = p.AbsoluteGap / math.Max(math.Abs(p.Incumbent), epsilon)
+	return p
+}
+
+// report Update BestBound from the open nodes and call Progress.
+// It returns true when no open node can improve the incumbent by more than the gap, the search is then over.
+func (bb *BranchAndBound) report(open []*node) bool {
+	bound := math.Inf(-1)
+	for _, nd := range open {
+		bound = math.Max(bound, nd.bound)
+	}
+	if bb.incumbent != nil {
+		bound = math.Max(bound, bb.score)
+	}
+	bb.BestBound = bound
+	if bb.Progress != nil {
+		bb.Progress(bb.Status())
+	}
+	if len(open) == 0 || !bb.cannotImprove(bound) {
+		return false
+	}
+	for _, nd := range open {
+		nd.mark("bound")
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+package goptimization
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMIPProgress(t *testing.T) {
+	c, A, b, integer := knapsack(12, 7)
+
+	bb := &BranchAndBound{}
+	require.NoError(t, bb.New(c, A, b, integer))
+	bb.CutRounds = 0
+	bb.Heuristics = nil
+	var progress []MIPProgress
+	bb.Progress = func(p MIPProgress) {
+		progress = append(progress, p)
+	}
+	_, score, err := bb.Solve(10000)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, progress)
+	assert.True(t, math.IsInf(progress[0].Incumbent, -1))
+	assert.True(t, math.IsInf(progress[0].RelativeGap, 1))
+	for k := 1; k < len(progress); k++ {
+		assert.GreaterOrEqual(t, progress[k].Incumbent, progress[k-1].Incumbent)
+		assert.LessOrEqual(t, progress[k].BestBound, progress[k-1].BestBound+0.000001)
+		assert.GreaterOrEqual(t, progress[k].BestBound, progress[k].Incumbent)
+	}
+	status := bb.Status()
+	assert.Equal(t, bb.Nodes, status.Nodes)
+	assert.InEpsilon(t, score, status.Incumbent, 0.000001)
+	assert.InDelta(t, 0.0, status.AbsoluteGap, 0.000001)
+	assert.InDelta(t, 0.0, status.RelativeGap, 0.000001)
+
+	//The search stops once the gap is closed up to 20%
+	nodes, _, approximate, err := MIP(c, A, b, integer, 10000, WithMIPGap(0.2))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, nodes, bb.Nodes)
+	assert.GreaterOrEqual(t, approximate, 0.8*score-0.000001)
+}
@@ -0,0 +1,95 @@
+package goptimization
+
+import (
+	"math/rand"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// GeneratedLP Random problem in the standard form of Simplex with a known optimal primal and dual solution
+type GeneratedLP struct {
+	C *mat.Dense
+	A *mat.Dense
+	B *mat.Dense
+	// X, Y Optimal solution (n,1) and optimal duals of the constraints (m,1)
+	X *mat.Dense
+	Y *mat.Dense
+	// Optimum c*X = b*Y
+	Optimum float64
+}
+
+// GenerateLP Build a feasible and bounded problem with n variables and m constraints from a constructed optimum.
+// Each coefficient of A is nonzero with probability density, uniform in [-1, 1].
+// About half of the variables and of the constraint duals are positive, complementary slackness then fixes
+// b = A*x + s with s_i = 0 where y_i > 0, and c = A^T*y - r with r_j = 0 where x_j > 0,
+// s and r being positive elsewhere. A row whose activity A_i*x is negative is negated so that b >= 0
+// and the slack basis is feasible. The same seed gives the same problem.
+func GenerateLP(n, m int, density float64, seed int64) (*GeneratedLP, error) {
+	if n <= 0 || m <= 0 {
+		return nil, errors.New("n and m must be positive")
+	}
+	if density <= 0 || density > 1 {
+		return nil, errors.New("density must be in (0, 1]")
+	}
+	rnd := rand.New(rand.NewSource(seed))
+
+	x := make([]float64, n)
+	for j := range x {
+		if rnd.Intn(2) == 0 {
+			x[j] = 1 + 9*rnd.Float64()
+		}
+	}
+	y := make([]float64, m)
+	for i := range y {
+		if rnd.Intn(2) == 0 {
+			y[i] = 1 + 9*rnd.Float64()
+		}
+	}
+
+	A := mat.NewDense(m, n, nil)
+	b := mat.NewDense(m, 1, nil)
+	for i := 0; i < m; i++ {
+		// Each row has at least one coefficient
+		forced := rnd.Intn(n)
+		activity := 0.0
+		for j := 0; j < n; j++ {
+			if j == forced || rnd.Float64() < density {
+				A.Set(i, j, 2*rnd.Float64()-1)
+				activity += A.At(i, j) * x[j]
+			}
+		}
+		if activity < 0 {
+			for j := 0; j < n; j++ {
+				A.Set(i, j, -A.At(i, j))
+			}
+			activity = -activity
+		}
+		if y[i] == 0 {
+			activity += 1 + 9*rnd.Float64()
+		}
+		b.Set(i, 0, activity)
+	}
+
+	c := mat.NewDense(1, n, nil)
+	optimum := 0.0
+	for j := 0; j < n; j++ {
+		cj := 0.0
+		for i := 0; i < m; i++ {
+			cj += A.At(i, j) * y[i]
+		}
+		if x[j] == 0 {
+			cj -= 1 + 9*rnd.Float64()
+		}
+		c.Set(0, j, cj)
+		optimum += cj * x[j]
+	}
+	return &GeneratedLP{
+		C:       c,
+		A:       A,
+		B:       b,
+		X:       mat.NewDense(n, 1, x),
+		Y:       mat.NewDense(m, 1, y),
+		Optimum: optimum,
+	}, nil
+}
@@ -0,0 +1,55 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestGenerateLP(t *testing.T) {
+	for seed := int64(1); seed <= 10; seed++ {
+		lp, err := GenerateLP(6, 4, 0.5, seed)
+		require.NoError(t, err)
+
+		// b >= 0, x is feasible and b*y is the optimum
+		dual := 0.0
+		for i := 0; i < 4; i++ {
+			assert.GreaterOrEqual(t, lp.B.At(i, 0), 0.0)
+			dual += lp.B.At(i, 0) * lp.Y.At(i, 0)
+		}
+		x := make([]float64, 6)
+		for j := range x {
+			x[j] = lp.X.At(j, 0)
+		}
+		assert.True(t, feasible(lp.A, lp.B, x))
+		assert.InEpsilon(t, lp.Optimum, dual, 0.000001)
+
+		_, _, score, err := Simplex(mat.DenseCopyOf(lp.C), mat.DenseCopyOf(lp.A), mat.DenseCopyOf(lp.B), WithMaxIter(100))
+		require.NoError(t, err)
+		assert.InDelta(t, lp.Optimum, score, 0.000001*(1+lp.Optimum), "seed %d", seed)
+	}
+
+	first, err := GenerateLP(5, 5, 0.3, 42)
+	require.NoError(t, err)
+	second, err := GenerateLP(5, 5, 0.3, 42)
+	require.NoError(t, err)
+	assert.True(t, mat.Equal(first.A, second.A))
+
+	_, err = GenerateLP(0, 5, 0.3, 42)
+	assert.Error(t, err)
+	_, err = GenerateLP(5, 5, 0, 42)
+	assert.Error(t, err)
+}
+
+func BenchmarkSimplexGenerated(b *testing.B) {
+	lp, err := GenerateLP(30, 20, 0.3, 1)
+	require.NoError(b, err)
+	for k := 0; k < b.N; k++ {
+		_, _, _, err := Simplex(mat.DenseCopyOf(lp.C), mat.DenseCopyOf(lp.A), mat.DenseCopyOf(lp.B), WithMaxIter(1000))
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
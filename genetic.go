@@ -0,0 +1,166 @@
+package goptimization
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Fitness Value to maximize
+type Fitness func(s State) float64
+
+// Selection Choose a parent in the population
+type Selection interface {
+	Select(population []State, fitness []float64, rnd *rand.Rand) State
+}
+
+// Crossover Child of two parents, the parents must not be modified
+type Crossover interface {
+	Cross(a, b State, rnd *rand.Rand) State
+}
+
+// Mutation Random change of a state, s must not be modified
+type Mutation interface {
+	Mutate(s State, rnd *rand.Rand) State
+}
+
+// TournamentSelection Fittest of Size individuals drawn at random
+type TournamentSelection struct {
+	Size int
+}
+
+// Select Implement Selection
+func (t TournamentSelection) Select(population []State, fitness []float64, rnd *rand.Rand) State {
+	best := rnd.Intn(len(population))
+	for k := 1; k < t.Size; k++ {
+		i := rnd.Intn(len(population))
+		if fitness[i] > fitness[best] {
+			best = i
+		}
+	}
+	return population[best]
+}
+
+// Genetic Genetic algorithm for black-box objectives
+type Genetic struct {
+	// Population Individuals of the current generation
+	Population []State
+	Fitness    Fitness
+
+	// Selection Tournament of 2 individuals by default
+	Selection Selection
+	// Crossover, Mutation Operators on the states, they depend on the problem and must be set
+	Crossover Crossover
+	Mutation  Mutation
+	// MutationRate Probability to mutate a child, 0.1 by default
+	MutationRate float64
+	// Elitism Number of the fittest individuals copied to the next generation, 1 by default
+	Elitism int
+	// Generations Number of generations, 100 by default
+	Generations int
+	// Threads Number of goroutines evaluating the fitness, 1 by default.
+	// With more than one thread the fitness must be safe for concurrent use.
+	Threads int
+	// Seed Seed of the random operators, the same seed gives the same search for any Threads
+	Seed int64
+
+	scores []float64
+}
+
+// New Initialize the algorithm with the first generation
+func (g *Genetic) New(population []State, fitness Fitness) error {
+	if len(population) < 2 {
+		return errors.New("the population must have at least 2 individuals")
+	}
+	if fitness == nil {
+		return errors.New("fitness must be defined")
+	}
+	g.Population = append([]State(nil), population...)
+	g.Fitness = fitness
+	g.Selection = TournamentSelection{Size: 2}
+	g.Crossover = nil
+	g.Mutation = nil
+	g.MutationRate = 0.1
+	g.Elitism = 1
+	g.Generations = 100
+	g.Threads = 1
+	g.Seed = 0
+	g.scores = nil
+	return nil
+}
+
+// evaluate Fitness of each individual, computed by Threads goroutines on interleaved individuals
+func (g *Genetic) evaluate(population []State) []float64 {
+	scores := make([]float64, len(population))
+	threads := g.Threads
+	if threads < 1 {
+		threads = 1
+	}
+	var wg sync.WaitGroup
+	for t := 0; t < threads; t++ {
+		wg.Add(1)
+		go func(t int) {
+			defer wg.Done()
+			for i := t; i < len(population); i += threads {
+				scores[i] = g.Fitness(population[i])
+			}
+		}(t)
+	}
+	wg.Wait()
+	return scores
+}
+
+// fittest Indices of the population by decreasing fitness, the first individual wins the ties
+func fittest(scores []float64, count int) []int {
+	best := make([]int, 0, count)
+	used := make([]bool, len(scores))
+	for len(best) < count {
+		k := -1
+		for i, score := range scores {
+			if !used[i] && (k == -1 || score > scores[k]) {
+				k = i
+			}
+		}
+		used[k] = true
+		best = append(best, k)
+	}
+	return best
+}
+
+// Solve Evolve the population for Generations generations: the Elitism fittest individuals are kept and
+// the others are replaced by children of selected parents, mutated with the probability MutationRate.
+// It returns the fittest individual of the last generation and its fitness.
+func (g *Genetic) Solve() (State, float64, error) {
+	if g.Fitness == nil || len(g.Population) < 2 {
+		return nil, 0, errors.New("genetic algorithm is not initialized")
+	}
+	if g.Selection == nil || g.Crossover == nil || g.Mutation == nil {
+		return nil, 0, errors.New("selection, crossover and mutation must be defined")
+	}
+	if g.Elitism < 0 || g.Elitism > len(g.Population) {
+		return nil, 0, errors.Errorf("elitism must be between 0 and %d, got %d", len(g.Population), g.Elitism)
+	}
+	rnd := rand.New(rand.NewSource(g.Seed))
+	g.scores = g.evaluate(g.Population)
+	for generation := 0; generation < g.Generations; generation++ {
+		next := make([]State, 0, len(g.Population))
+		for _, i := range fittest(g.scores, g.Elitism) {
+			next = append(next, g.Population[i])
+		}
+		// The operators are applied sequentially so the random draws do not depend on Threads
+		for len(next) < len(g.Population) {
+			a := g.Selection.Select(g.Population, g.scores, rnd)
+			b := g.Selection.Select(g.Population, g.scores, rnd)
+			child := g.Crossover.Cross(a, b, rnd)
+			if rnd.Float64() < g.MutationRate {
+				child = g.Mutation.Mutate(child, rnd)
+			}
+			next = append(next, child)
+		}
+		g.Population = next
+		g.scores = g.evaluate(next)
+	}
+	best := fittest(g.scores, 1)[0]
+	return g.Population[best], g.scores[best], nil
+}
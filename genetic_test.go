@@ -0,0 +1,77 @@
+package goptimization
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type onePointCrossover struct{}
+
+func (onePointCrossover) Cross(a, b State, rnd *rand.Rand) State {
+	x, y := a.([]bool), b.([]bool)
+	cut := rnd.Intn(len(x))
+	child := append([]bool(nil), x[:cut]...)
+	return append(child, y[cut:]...)
+}
+
+type bitFlip struct{}
+
+func (bitFlip) Mutate(s State, rnd *rand.Rand) State {
+	child := append([]bool(nil), s.([]bool)...)
+	k := rnd.Intn(len(child))
+	child[k] = !child[k]
+	return child
+}
+
+func TestGenetic(t *testing.T) {
+	//Maximize the number of bits set
+	bits := 20
+	ones := func(s State) float64 {
+		count := 0.0
+		for _, bit := range s.([]bool) {
+			if bit {
+				count++
+			}
+		}
+		return count
+	}
+	rnd := rand.New(rand.NewSource(3))
+	population := make([]State, 30)
+	for i := range population {
+		individual := make([]bool, bits)
+		for k := range individual {
+			individual[k] = rnd.Intn(4) == 0
+		}
+		population[i] = individual
+	}
+
+	solve := func(threads int) (State, float64) {
+		g := &Genetic{}
+		require.NoError(t, g.New(population, ones))
+		g.Crossover = onePointCrossover{}
+		g.Mutation = bitFlip{}
+		g.MutationRate = 0.5
+		g.Generations = 200
+		g.Threads = threads
+		g.Seed = 1
+		best, score, err := g.Solve()
+		require.NoError(t, err)
+		return best, score
+	}
+	best, score := solve(1)
+	assert.Equal(t, float64(bits), score)
+	assert.Equal(t, score, ones(best))
+
+	parallelBest, parallelScore := solve(4)
+	assert.Equal(t, best, parallelBest)
+	assert.Equal(t, score, parallelScore)
+
+	g := &Genetic{}
+	assert.Error(t, g.New(population[:1], ones))
+	require.NoError(t, g.New(population, ones))
+	_, _, err := g.Solve()
+	assert.Error(t, err)
+}
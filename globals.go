@@ -0,0 +1,154 @@
+package goptimization
+
+import (
+	"github.com/pkg/errors"
+)
+
+// AllDifferent Force the variables to take pairwise different values of the domain
+// Each variable gets a binary z_v for each value of the domain with Σ z_v = 1 and x = Σ v*z_v,
+// each value is taken at most once: Σ(vars) z_v <= 1. The domain should stay small.
+func (m *Model) AllDifferent(vars []Var, domain []float64) error {
+	if len(vars) > len(domain) {
+		return errors.New("the domain has less values than variables")
+	}
+	taken := make([]Expr, len(domain))
+	for _, x := range vars {
+		z, err := m.choose(len(domain))
+		if err != nil {
+			return err
+		}
+		value := Expr{Terms: []Term{{Var: x, Coef: -1}}}
+		for k, v := range domain {
+			value.Terms = append(value.Terms, Term{Var: z[k], Coef: v})
+			taken[k].Terms = append(taken[k].Terms, Term{Var: z[k], Coef: 1})
+		}
+		err = m.AddRow(value, Equal, 0)
+		if err != nil {
+			return err
+		}
+	}
+	for _, e := range taken {
+		err := m.AddConstraint(e, 1)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Element Add a variable y = values[index], index is an integer in [0, len(values))
+// Binaries z_k pick the element with Σ z_k = 1, index = Σ k*z_k and y = Σ values_k*z_k.
+// Like all the variables of the model, y is non-negative, so are the values.
+func (m *Model) Element(index Var, values []float64) (Var, error) {
+	if len(values) == 0 {
+		return 0, errors.New("at least one value is needed")
+	}
+	for _, v := range values {
+		if v < 0 {
+			return 0, errors.New("values must be non-negative")
+		}
+	}
+	y := m.AddVariable("", false)
+	z, err := m.choose(len(values))
+	if err != nil {
+		return 0, err
+	}
+	position := Expr{Terms: []Term{{Var: index, Coef: -1}}}
+	value := Expr{Terms: []Term{{Var: y, Coef: -1}}}
+	for k, v := range values {
+		position.Terms = append(position.Terms, Term{Var: z[k], Coef: float64(k)})
+		value.Terms = append(value.Terms, Term{Var: z[k], Coef: v})
+	}
+	err = m.AddRow(position, Equal, 0)
+	if err != nil {
+		return 0, err
+	}
+	return y, m.AddRow(value, Equal, 0)
+}
+
+// Table Force the variables to take the values of one of the tuples
+// Binaries z_t pick the tuple with Σ z_t = 1 and x_i = Σ tuple_t_i*z_t.
+// Like all the variables of the model, the values are non-negative.
+func (m *Model) Table(vars []Var, tuples [][]float64) error {
+	if len(tuples) == 0 {
+		return errors.New("at least one tuple is needed")
+	}
+	for _, tuple := range tuples {
+		if len(tuple) != len(vars) {
+			return newError(ErrDimensionMismatch, "len(tuple) != len(vars)")
+		}
+		for _, v := range tuple {
+			if v < 0 {
+				return errors.New("values must be non-negative")
+			}
+		}
+	}
+	z, err := m.choose(len(tuples))
+	if err != nil {
+		return err
+	}
+	for i, x := range vars {
+		value := Expr{Terms: []Term{{Var: x, Coef: -1}}}
+		for t, tuple := range tuples {
+			value.Terms = append(value.Terms, Term{Var: z[t], Coef: tuple[i]})
+		}
+		err = m.AddRow(value, Equal, 0)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Cumulative Schedule tasks on a resource of the capacity over the time steps 0..horizon-1
+// The task j starts at starts[j], lasts durations[j] time steps and uses demands[j] of the resource.
+// Time-indexed formulation: a binary s_j_t is 1 if the task j starts at t, Σ(t) s_j_t = 1,
+// start_j = Σ t*s_j_t and at each time step τ, Σ(j) demand_j * Σ(τ-d_j < t <= τ) s_j_t <= capacity.
+func (m *Model) Cumulative(starts []Var, durations []int, demands []float64, capacity float64, horizon int) error {
+	if len(durations) != len(starts) || len(demands) != len(starts) {
+		return newError(ErrDimensionMismatch, "len(durations) and len(demands) must be len(starts)")
+	}
+	usage := make([]Expr, horizon)
+	for j, start := range starts {
+		if durations[j] <= 0 || durations[j] > horizon {
+			return errors.Errorf("task %d does not fit in the horizon", j)
+		}
+		s, err := m.choose(horizon - durations[j] + 1)
+		if err != nil {
+			return err
+		}
+		value := Expr{Terms: []Term{{Var: start, Coef: -1}}}
+		for t := range s {
+			value.Terms = append(value.Terms, Term{Var: s[t], Coef: float64(t)})
+			for tau := t; tau < t+durations[j]; tau++ {
+				usage[tau].Terms = append(usage[tau].Terms, Term{Var: s[t], Coef: demands[j]})
+			}
+		}
+		err = m.AddRow(value, Equal, 0)
+		if err != nil {
+			return err
+		}
+	}
+	for _, e := range usage {
+		if len(e.Terms) == 0 {
+			continue
+		}
+		err := m.AddConstraint(e, capacity)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// choose Add k binary variables with Σ z = 1, added with AddBinary so that the branch and bound
+// probes them and finds the cliques of the encoding
+func (m *Model) choose(k int) ([]Var, error) {
+	z := make([]Var, k)
+	sum := Expr{}
+	for i := range z {
+		z[i] = m.AddBinary("")
+		sum.Terms = append(sum.Terms, Term{Var: z[i], Coef: 1})
+	}
+	return z, m.AddRow(sum, Equal, 1)
+}
@@ -0,0 +1,80 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllDifferent(t *testing.T) {
+	m := &Model{}
+	vars := []Var{m.AddVariable("x", true), m.AddVariable("y", true), m.AddVariable("z", true)}
+	require.NoError(t, m.AllDifferent(vars, []float64{1, 2, 3}))
+	m.Maximize(Expr{Terms: []Term{{vars[0], 3}, {vars[1], 2}, {vars[2], 1}}})
+	solution, err := m.Solve(1000)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 14.0, solution.Score, 0.000001)
+	assert.InDeltaSlice(t, []float64{3, 2, 1}, solution.Values[:3], 0.000001)
+
+	assert.Error(t, m.AllDifferent(vars, []float64{1, 2}))
+
+	//The binaries of the encoding are seen by the branch and bound
+	for v := 3; v < len(solution.Values); v++ {
+		assert.True(t, m.IsBinary(Var(v)))
+	}
+}
+
+func TestElement(t *testing.T) {
+	m := &Model{}
+	index := m.AddVariable("index", true)
+	y, err := m.Element(index, []float64{5, 2, 9, 4})
+	require.NoError(t, err)
+	m.Maximize(Expr{Terms: []Term{{y, 1}, {index, -1.5}}})
+	solution, err := m.Solve(1000)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 6.0, solution.Score, 0.000001)
+	assert.InDelta(t, 2.0, solution.Value(index), 0.000001)
+	assert.InDelta(t, 9.0, solution.Value(y), 0.000001)
+
+	_, err = m.Element(index, nil)
+	assert.Error(t, err)
+	_, err = m.Element(index, []float64{-1})
+	assert.Error(t, err)
+}
+
+func TestTable(t *testing.T) {
+	m := &Model{}
+	x := m.AddVariable("x", false)
+	y := m.AddVariable("y", false)
+	require.NoError(t, m.Table([]Var{x, y}, [][]float64{{1, 3}, {2, 2}, {4, 0}}))
+	m.Maximize(Expr{Terms: []Term{{x, 1}, {y, 2}}})
+	solution, err := m.Solve(1000)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 7.0, solution.Score, 0.000001)
+
+	require.NoError(t, m.AddRow(x.Expr(), GreaterEq, 2))
+	solution, err = m.Solve(1000)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 6.0, solution.Score, 0.000001)
+	assert.InDelta(t, 2.0, solution.Value(x), 0.000001)
+
+	assert.Error(t, m.Table([]Var{x, y}, nil))
+	assert.Error(t, m.Table([]Var{x, y}, [][]float64{{1}}))
+	assert.Error(t, m.Table([]Var{x, y}, [][]float64{{1, -1}}))
+}
+
+func TestCumulative(t *testing.T) {
+	//Task 1 cannot run with another task, the tasks run one after the other
+	m := &Model{}
+	starts := []Var{m.AddVariable("start0", true), m.AddVariable("start1", true), m.AddVariable("start2", true)}
+	require.NoError(t, m.Cumulative(starts, []int{2, 2, 1}, []float64{1, 2, 1}, 2, 5))
+	m.Maximize(Expr{Terms: []Term{{starts[0], -1}, {starts[1], -1}, {starts[2], -1}}})
+	solution, err := m.Solve(1000)
+	require.NoError(t, err)
+	assert.InDelta(t, -2.0, solution.Score, 0.000001)
+	assert.InDelta(t, 2.0, solution.Value(starts[1]), 0.000001)
+
+	assert.Error(t, m.Cumulative(starts, []int{2, 2}, []float64{1, 2, 1}, 2, 5))
+	assert.Error(t, m.Cumulative(starts, []int{2, 2, 6}, []float64{1, 2, 1}, 2, 5))
+}
@@ -14,6 +14,6 @@ require (
 	golang.org/x/tools v0.0.0-20191227053925-7b8e75db28f4 // indirect
 	gonum.org/v1/gonum v0.6.2
 	gonum.org/v1/netlib v0.0.0-20191031114514-eccb95939662 // indirect
-	gonum.org/v1/plot v0.0.0-20191107103940-ca91d9d40d0a // indirect
+	gonum.org/v1/plot v0.0.0-20191107103940-ca91d9d40d0a
 	gopkg.in/yaml.v2 v2.2.7 // indirect
 )
@@ -0,0 +1,103 @@
+package goptimization
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// hashRounds Number of refinements of the labels of the rows and columns in Fingerprint
+const hashRounds = 3
+
+// Fingerprint Canonical hash of a problem in the standard form of Simplex, a nil integer means a linear problem
+// The hash does not depend on the order of the rows and columns nor on the scaling of the rows:
+// each row is divided by its largest coefficient, then the labels of the rows and columns are refined
+// from the labels of their neighbours (in the style of Weisfeiler-Lehman) and the sorted labels are hashed.
+// Numbers are compared with 12 significant digits. Identical problems always have the same fingerprint,
+// very symmetric problems can collide.
+func Fingerprint(c, A, b *mat.Dense, integer []bool) string {
+	m, n := A.Dims()
+
+	// Normalized rows
+	coefs := make([][]float64, m)
+	rhs := make([]float64, m)
+	for i := 0; i < m; i++ {
+		scale := 0.0
+		for j := 0; j < n; j++ {
+			scale = math.Max(scale, math.Abs(A.At(i, j)))
+		}
+		if scale == 0 {
+			scale = 1
+		}
+		coefs[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			coefs[i][j] = A.At(i, j) / scale
+		}
+		rhs[i] = b.At(i, 0) / scale
+	}
+
+	rowLabels := make([]string, m)
+	for i := range rowLabels {
+		rowLabels[i] = hashLabel("r", formatNumber(rhs[i]))
+	}
+	colLabels := make([]string, n)
+	for j := range colLabels {
+		colLabels[j] = hashLabel("c", formatNumber(c.At(0, j)), strconv.FormatBool(integer != nil && integer[j]))
+	}
+	for round := 0; round < hashRounds; round++ {
+		newRows := make([]string, m)
+		for i := 0; i < m; i++ {
+			neighbours := []string{}
+			for j := 0; j < n; j++ {
+				if coefs[i][j] != 0 {
+					neighbours = append(neighbours, formatNumber(coefs[i][j])+"*"+colLabels[j])
+				}
+			}
+			sort.Strings(neighbours)
+			newRows[i] = hashLabel(append([]string{rowLabels[i]}, neighbours...)...)
+		}
+		newCols := make([]string, n)
+		for j := 0; j < n; j++ {
+			neighbours := []string{}
+			for i := 0; i < m; i++ {
+				if coefs[i][j] != 0 {
+					neighbours = append(neighbours, formatNumber(coefs[i][j])+"*"+rowLabels[i])
+				}
+			}
+			sort.Strings(neighbours)
+			newCols[j] = hashLabel(append([]string{colLabels[j]}, neighbours...)...)
+		}
+		rowLabels, colLabels = newRows, newCols
+	}
+
+	rows := append([]string(nil), rowLabels...)
+	cols := append([]string(nil), colLabels...)
+	sort.Strings(rows)
+	sort.Strings(cols)
+	return hashLabel(strconv.Itoa(m), strconv.Itoa(n), strings.Join(rows, ","), strings.Join(cols, ","))
+}
+
+// Hash Fingerprint of the standard form of the model
+func (m *Model) Hash() string {
+	c, A, b, integer := m.Standard()
+	return Fingerprint(c, A, b, integer)
+}
+
+// hashLabel Hexadecimal SHA-256 of the parts
+func hashLabel(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "|")))
+	return hex.EncodeToString(sum[:])
+}
+
+// formatNumber Format v with 12 significant digits, -0 is formatted like 0
+func formatNumber(v float64) string {
+	if v == 0 {
+		return "0"
+	}
+	return strconv.FormatFloat(v, 'g', 12, 64)
+}
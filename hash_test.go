@@ -0,0 +1,67 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestFingerprint(t *testing.T) {
+	c := mat.NewDense(1, 3, []float64{7, 9, 18})
+	A := mat.NewDense(3, 3, []float64{
+		2, 4, 5,
+		1, 1, 2,
+		1, 2, 3,
+	})
+	b := mat.NewDense(3, 1, []float64{42, 17, 24})
+	hash := Fingerprint(c, A, b, nil)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, Fingerprint(c, A, b, []bool{false, false, false}))
+
+	//Columns in the order (2, 0, 1), rows in the order (2, 0, 1) and the first row scaled by 10
+	permuted := Fingerprint(
+		mat.NewDense(1, 3, []float64{18, 7, 9}),
+		mat.NewDense(3, 3, []float64{
+			3, 1, 2,
+			50, 20, 40,
+			2, 1, 1,
+		}),
+		mat.NewDense(3, 1, []float64{24, 420, 17}), nil)
+	assert.Equal(t, hash, permuted)
+
+	//The pairing of the coefficients matters, not only their multiset
+	assert.NotEqual(t, hash, Fingerprint(c, mat.NewDense(3, 3, []float64{
+		2, 4, 5,
+		1, 2, 1,
+		1, 2, 3,
+	}), b, nil))
+	assert.NotEqual(t, hash, Fingerprint(c, A, mat.NewDense(3, 1, []float64{42, 17, 25}), nil))
+	assert.NotEqual(t, hash, Fingerprint(c, A, b, []bool{true, false, false}))
+}
+
+func TestModelHash(t *testing.T) {
+	build := func(reverse bool) *Model {
+		m := &Model{}
+		var x, y Var
+		if reverse {
+			y = m.AddVariable("y", false)
+			x = m.AddVariable("x", true)
+		} else {
+			x = m.AddVariable("x", true)
+			y = m.AddVariable("y", false)
+		}
+		m.Maximize(Expr{Terms: []Term{{x, 5}, {y, 4}}})
+		rows := []Expr{{Terms: []Term{{x, 6}, {y, 4}}}, {Terms: []Term{{x, 1}, {y, 2}}}}
+		rhs := []float64{24, 6}
+		if reverse {
+			rows[0], rows[1] = rows[1], rows[0]
+			rhs[0], rhs[1] = rhs[1], rhs[0]
+		}
+		for i := range rows {
+			assert.NoError(t, m.AddConstraint(rows[i], rhs[i]))
+		}
+		return m
+	}
+	assert.Equal(t, build(false).Hash(), build(true).Hash())
+}
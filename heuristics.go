@@ -0,0 +1,263 @@
+package goptimization
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// feasibilityTolerance Maximum violation of a constraint by a solution proposed by a heuristic
+const feasibilityTolerance = 1e-6
+
+// Heuristic Primal heuristic looking for an integer solution from the relaxation of a node
+// Run returns the values of the n original variables, the solution is checked against the original
+// constraints before it becomes the incumbent.
+type Heuristic interface {
+	Name() string
+	Run(h *HeuristicContext) ([]float64, bool, error)
+}
+
+// HeuristicContext Information available to the primal heuristics at a node of the search tree
+type HeuristicContext struct {
+	// X Value of each variable in the relaxation of the node, indexed by variable
+	X []float64
+	// Integer Integrality of the original variables
+	Integer []bool
+	// C, A, B Original problem
+	C *mat.Dense
+	A *mat.Dense
+	B *mat.Dense
+	// Incumbent Score of the best integer solution, -Inf if there is none
+	Incumbent float64
+	// MaxIter Maximum number of simplex iterations for each relaxation solved by the heuristic
+	MaxIter int
+
+	cf *CanonicalForm
+}
+
+// Incumbent Integer solution which improved the best score during the search
+type Incumbent struct {
+	Score float64
+	// Source Name of the heuristic which found the solution, "relaxation" for an integral relaxation
+	Source string
+	// Node Number of explored nodes when the solution was found
+	Node int
+}
+
+// RoundingHeuristic Round the integer variables of the relaxation to the nearest integer,
+// then down if the rounded solution is infeasible.
+type RoundingHeuristic struct{}
+
+// Name Name reported in the incumbents
+func (RoundingHeuristic) Name() string {
+	return "rounding"
+}
+
+// Run Round the relaxation
+func (RoundingHeuristic) Run(h *HeuristicContext) ([]float64, bool, error) {
+	for _, round := range []func(float64) float64{math.Round, math.Floor} {
+		x := make([]float64, len(h.Integer))
+		for j := range x {
+			x[j] = h.X[j]
+			if h.Integer[j] {
+				x[j] = round(h.X[j])
+			}
+		}
+		if feasible(h.A, h.B, x) {
+			return x, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// DivingHeuristic Fractional diving: repeatedly fix the least fractional integer variable to its closest integer
+// and re-optimize the relaxation, until it is integral or infeasible.
+// When the fixing makes the relaxation infeasible, the variable is fixed to the integer on the other side.
+type DivingHeuristic struct {
+	// MaxDepth Maximum number of fixings, 0 means the number of variables
+	MaxDepth int
+}
+
+// Name Name reported in the incumbents
+func (DivingHeuristic) Name() string {
+	return "diving"
+}
+
+// Run Dive from the relaxation of the node
+func (dh DivingHeuristic) Run(h *HeuristicContext) ([]float64, bool, error) {
+	maxDepth := dh.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = len(h.Integer)
+	}
+	cf := h.cf.Clone()
+	x := h.X
+	for depth := 0; depth < maxDepth; depth++ {
+		fixVar := -1
+		minFrac := 1.0
+		for j, integer := range h.Integer {
+			frac := math.Abs(x[j] - math.Round(x[j]))
+			if integer && frac > integerTolerance && frac < minFrac {
+				minFrac = frac
+				fixVar = j
+			}
+		}
+		if fixVar == -1 {
+			return x[:len(h.Integer)], true, nil
+		}
+
+		// Fix x_j to its closest integer, then to the integer on the other side if the relaxation is infeasible
+		closest := math.Round(x[fixVar])
+		other := closest - 1
+		if x[fixVar] > closest {
+			other = closest + 1
+		}
+		dived := false
+		for _, value := range []float64{closest, other} {
+			if value < 0 {
+				continue
+			}
+			candidate := cf.Clone()
+			for _, sign := range []float64{1, -1} {
+				a := make([]float64, candidate.n+candidate.m)
+				a[fixVar] = sign
+				err := candidate.AddConstraint(a, sign*value)
+				if err != nil {
+					return nil, false, err
+				}
+			}
+			_, err := candidate.Reoptimize(h.MaxIter)
+			if err == ErrInfeasible {
+				continue
+			}
+			if err != nil {
+				return nil, false, err
+			}
+			cf = candidate
+			dived = true
+			break
+		}
+		if !dived {
+			return nil, false, nil
+		}
+		var score float64
+		x, score = cf.values()
+		if score <= h.Incumbent+epsilon {
+			return nil, false, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// FeasibilityPump Alternate between rounding the relaxation and finding the point of the relaxation closest to
+// the rounding, Δ(x) = Σ(x~_j=0) x_j + Σ(x~_j=u_j) (u_j-x_j) for the integer variables at one of their bounds.
+// Other general integer variables only take part through the rounding.
+// When the rounding cycles, the integer variable farthest from its rounded value is flipped.
+type FeasibilityPump struct {
+	// MaxIter Maximum number of roundings, 0 means 20
+	MaxIter int
+}
+
+// Name Name reported in the incumbents
+func (FeasibilityPump) Name() string {
+	return "feasibility pump"
+}
+
+// Run Pump from the relaxation of the node
+func (fp FeasibilityPump) Run(h *HeuristicContext) ([]float64, bool, error) {
+	maxIter := fp.MaxIter
+	if maxIter == 0 {
+		maxIter = 20
+	}
+	n := len(h.Integer)
+	binary := binaries(h.A, h.B, h.Integer)
+
+	cf := h.cf.Clone()
+	x := h.X
+	var previous []float64
+	for iter := 0; iter < maxIter; iter++ {
+		integral := true
+		rounded := make([]float64, n)
+		for j := 0; j < n; j++ {
+			rounded[j] = x[j]
+			if h.Integer[j] {
+				rounded[j] = math.Round(x[j])
+				integral = integral && isIntegral(x[j])
+			}
+		}
+		if integral {
+			return x[:n], true, nil
+		}
+
+		// Flip the variable the farthest from its rounded value to escape a cycle
+		if previous != nil && equalValues(previous, rounded) {
+			farthest := -1
+			for j := 0; j < n; j++ {
+				if h.Integer[j] && (farthest == -1 || math.Abs(x[j]-rounded[j]) > math.Abs(x[farthest]-rounded[farthest])) {
+					farthest = j
+				}
+			}
+			if x[farthest] > rounded[farthest] {
+				rounded[farthest]++
+			} else if rounded[farthest] > 0 {
+				rounded[farthest]--
+			}
+		}
+		previous = rounded
+
+		// Maximize -Δ(x) over the relaxation of the node
+		costs := make([]float64, cf.n+cf.m)
+		for j := 0; j < n; j++ {
+			if !h.Integer[j] {
+				continue
+			}
+			if rounded[j] <= 0 {
+				costs[j] = -1
+			} else if binary[j] && rounded[j] >= 1 {
+				costs[j] = 1
+			}
+		}
+		cf.setCosts(costs)
+		_, err := cf.Reoptimize(h.MaxIter)
+		if err == ErrInfeasible {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		x, _ = cf.values()
+	}
+	return nil, false, nil
+}
+
+// feasible Check Ax <= b and x >= 0 up to feasibilityTolerance
+func feasible(A, b *mat.Dense, x []float64) bool {
+	m, n := A.Dims()
+	for j := 0; j < n; j++ {
+		if x[j] < -feasibilityTolerance {
+			return false
+		}
+	}
+	for i := 0; i < m; i++ {
+		activity := 0.0
+		for j := 0; j < n; j++ {
+			activity += A.At(i, j) * x[j]
+		}
+		if activity > b.At(i, 0)+feasibilityTolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// equalValues Check if the two vectors are identical
+func equalValues(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for j := range a {
+		if a[j] != b[j] {
+			return false
+		}
+	}
+	return true
+}
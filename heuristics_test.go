@@ -0,0 +1,110 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+// knapsackContext Heuristic context at the root of the knapsack max 8x_1+11x_2+6x_3+4x_4, 5x_1+7x_2+4x_3+3x_4 <= 14
+func knapsackContext(t *testing.T) *HeuristicContext {
+	c := mat.NewDense(1, 4, []float64{8, 11, 6, 4})
+	A := mat.NewDense(5, 4, []float64{
+		5, 7, 4, 3,
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1,
+	})
+	b := mat.NewDense(5, 1, []float64{14, 1, 1, 1, 1})
+
+	cf := &CanonicalForm{}
+	err := cf.New(mat.DenseCopyOf(c), mat.DenseCopyOf(A), mat.DenseCopyOf(b))
+	require.NoError(t, err)
+	_, err = cf.Reoptimize(100)
+	require.NoError(t, err)
+	values, _ := cf.values()
+	return &HeuristicContext{
+		X:         values,
+		Integer:   []bool{true, true, true, true},
+		C:         c,
+		A:         A,
+		B:         b,
+		Incumbent: -1,
+		MaxIter:   100,
+		cf:        cf,
+	}
+}
+
+func TestRoundingHeuristic(t *testing.T) {
+	h := knapsackContext(t)
+	//The relaxation is (1, 1, 0.5, 0), rounding 0.5 up breaks the knapsack constraint
+	assert.InEpsilon(t, 0.5, h.X[2], 0.000001)
+
+	x, ok, err := RoundingHeuristic{}.Run(h)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []float64{1, 1, 0, 0}, x)
+}
+
+func TestDivingHeuristic(t *testing.T) {
+	h := knapsackContext(t)
+
+	x, ok, err := DivingHeuristic{}.Run(h)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.True(t, feasible(h.A, h.B, x))
+	for _, v := range x {
+		assert.True(t, isIntegral(v))
+	}
+}
+
+func TestFeasibilityPump(t *testing.T) {
+	h := knapsackContext(t)
+
+	x, ok, err := FeasibilityPump{}.Run(h)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.True(t, feasible(h.A, h.B, x))
+	for _, v := range x {
+		assert.True(t, isIntegral(v))
+	}
+}
+
+func TestIncumbents(t *testing.T) {
+	c := mat.NewDense(1, 4, []float64{8, 11, 6, 4})
+	A := mat.NewDense(5, 4, []float64{
+		5, 7, 4, 3,
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1,
+	})
+	b := mat.NewDense(5, 1, []float64{14, 1, 1, 1, 1})
+	integer := []bool{true, true, true, true}
+
+	bb := BranchAndBound{}
+	err := bb.New(c, A, b, integer)
+	require.NoError(t, err)
+	bb.Heuristics = []Heuristic{RoundingHeuristic{}}
+	bb.CutRounds = 0
+	_, score, err := bb.Solve(100)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 21.0, score, 0.000001)
+	require.True(t, len(bb.Incumbents) > 0)
+	assert.Equal(t, Incumbent{Score: 19, Source: "rounding", Node: 1}, bb.Incumbents[0])
+	assert.InEpsilon(t, 21.0, bb.Incumbents[len(bb.Incumbents)-1].Score, 0.000001)
+
+	bb = BranchAndBound{}
+	err = bb.New(c, A, b, integer)
+	require.NoError(t, err)
+	bb.Heuristics = nil
+	_, score, err = bb.Solve(100)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 21.0, score, 0.000001)
+	for _, incumbent := range bb.Incumbents {
+		assert.Equal(t, "relaxation", incumbent.Source)
+	}
+}
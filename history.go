@@ -0,0 +1,97 @@
+package goptimization
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Pivot Record of one iteration of the simplex algorithm
+// Entering and Leaving are variable indexes (the n original variables followed by the slack variables),
+// they are -1 for the record of the initial basis.
+type Pivot struct {
+	Iteration int `json:"iteration"`
+	// Dual is true when the pivot was chosen by the dual simplex
+	Dual       bool    `json:"dual"`
+	Entering   int     `json:"entering"`
+	Leaving    int     `json:"leaving"`
+	Step       float64 `json:"step"`
+	Degenerate bool    `json:"degenerate"`
+	// Basis Variables in the basis after the pivot, ordered by row
+	Basis     []int   `json:"basis"`
+	Objective float64 `json:"objective"`
+}
+
+// EnableHistory Start recording every pivot of the dictionary, the current basis is the first record
+func (cf *CanonicalForm) EnableHistory() {
+	cf.recordHistory = true
+	cf.record(false, -1, -1, 0)
+}
+
+// History Pivot sequence recorded since EnableHistory
+func (cf *CanonicalForm) History() []Pivot {
+	return cf.history
+}
+
+// record Append the current basis to the history
+func (cf *CanonicalForm) record(dual bool, entering, leaving int, step float64) {
+	if !cf.recordHistory {
+		return
+	}
+	_, objective := cf.values()
+	iteration := 0
+	if len(cf.history) > 0 {
+		iteration = cf.history[len(cf.history)-1].Iteration + 1
+	}
+	cf.history = append(cf.history, Pivot{
+		Iteration:  iteration,
+		Dual:       dual,
+		Entering:   entering,
+		Leaving:    leaving,
+		Step:       step,
+		Degenerate: entering != -1 && cf.degenerate > 0,
+		Basis:      append([]int(nil), cf.remap[cf.n:]...),
+		Objective:  objective,
+	})
+}
+
+// WriteHistoryCSV Export the history with one pivot per line, the basis is a space separated list of variables
+func (cf *CanonicalForm) WriteHistoryCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write([]string{"iteration", "dual", "entering", "leaving", "step", "degenerate", "objective", "basis"})
+	if err != nil {
+		return err
+	}
+	for _, p := range cf.history {
+		basis := make([]string, len(p.Basis))
+		for i, v := range p.Basis {
+			basis[i] = strconv.Itoa(v)
+		}
+		err = writer.Write([]string{
+			strconv.Itoa(p.Iteration),
+			strconv.FormatBool(p.Dual),
+			strconv.Itoa(p.Entering),
+			strconv.Itoa(p.Leaving),
+			strconv.FormatFloat(p.Step, 'g', -1, 64),
+			strconv.FormatBool(p.Degenerate),
+			strconv.FormatFloat(p.Objective, 'g', -1, 64),
+			strings.Join(basis, " "),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteHistoryJSON Export the history as a JSON array
+func (cf *CanonicalForm) WriteHistoryJSON(w io.Writer) error {
+	history := cf.history
+	if history == nil {
+		history = []Pivot{}
+	}
+	return json.NewEncoder(w).Encode(history)
+}
@@ -0,0 +1,59 @@
+package goptimization
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestHistory(t *testing.T) {
+	c := mat.NewDense(1, 4, []float64{7, 9, 18, 17})
+	A := mat.NewDense(3, 4, []float64{
+		2, 4, 5, 7,
+		1, 1, 2, 2,
+		1, 2, 3, 3,
+	})
+	b := mat.NewDense(3, 1, []float64{42, 17, 24})
+
+	cf := CanonicalForm{}
+	err := cf.New(c, A, b)
+	require.NoError(t, err)
+	cf.EnableHistory()
+	_, err = cf.Reoptimize(10)
+	require.NoError(t, err)
+
+	history := cf.History()
+	require.Len(t, history, 3)
+	assert.Equal(t, Pivot{Iteration: 0, Entering: -1, Leaving: -1, Basis: []int{4, 5, 6}, Objective: 0}, history[0])
+	assert.Equal(t, 2, history[1].Entering)
+	assert.Equal(t, 6, history[1].Leaving)
+	assert.Equal(t, []int{4, 5, 2}, history[1].Basis)
+	assert.InEpsilon(t, 144.0, history[1].Objective, 0.000001)
+	assert.Equal(t, 0, history[2].Entering)
+	assert.Equal(t, 5, history[2].Leaving)
+	assert.Equal(t, []int{4, 0, 2}, history[2].Basis)
+	assert.InEpsilon(t, 147.0, history[2].Objective, 0.000001)
+
+	var buf bytes.Buffer
+	err = cf.WriteHistoryCSV(&buf)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, "iteration,dual,entering,leaving,step,degenerate,objective,basis", lines[0])
+	assert.Equal(t, "0,false,-1,-1,0,false,0,4 5 6", lines[1])
+	assert.True(t, strings.HasPrefix(lines[3], "2,false,0,5,"))
+	assert.True(t, strings.HasSuffix(lines[3], ",false,147,4 0 2"))
+
+	buf.Reset()
+	err = cf.WriteHistoryJSON(&buf)
+	require.NoError(t, err)
+	var decoded []Pivot
+	err = json.Unmarshal(buf.Bytes(), &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, history, decoded)
+}
@@ -0,0 +1,62 @@
+package goptimization
+
+import (
+	"gonum.org/v1/gonum/mat"
+)
+
+// asRow Convert the vector v to a row vector (1,n)
+// v is a *mat.VecDense or a matrix with one row or one column, a (1,n) *mat.Dense is returned as is.
+func asRow(v mat.Matrix, name string) (*mat.Dense, error) {
+	if d, ok := v.(*mat.Dense); ok {
+		if r, _ := d.Dims(); r == 1 {
+			return d, nil
+		}
+	}
+	values, err := vectorValues(v, name)
+	if err != nil {
+		return nil, err
+	}
+	return mat.NewDense(1, len(values), values), nil
+}
+
+// asColumn Convert the vector v to a column vector (m,1)
+// v is a *mat.VecDense or a matrix with one row or one column, a (m,1) *mat.Dense is returned as is.
+func asColumn(v mat.Matrix, name string) (*mat.Dense, error) {
+	if d, ok := v.(*mat.Dense); ok {
+		if _, c := d.Dims(); c == 1 {
+			return d, nil
+		}
+	}
+	values, err := vectorValues(v, name)
+	if err != nil {
+		return nil, err
+	}
+	return mat.NewDense(len(values), 1, values), nil
+}
+
+// vectorValues Copy the components of v, which must have one row or one column
+func vectorValues(v mat.Matrix, name string) ([]float64, error) {
+	if v == nil {
+		return nil, newError(ErrDimensionMismatch, "%s is nil", name)
+	}
+	r, c := v.Dims()
+	if r != 1 && c != 1 {
+		return nil, newError(ErrDimensionMismatch, "%s must be a vector, got (%d,%d)", name, r, c)
+	}
+	values := make([]float64, 0, r*c)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			values = append(values, v.At(i, j))
+		}
+	}
+	return values, nil
+}
+
+// SimplexSlices Simplex with the costs c and the right-hand sides b given as slices
+func SimplexSlices(c []float64, A *mat.Dense, b []float64, opts ...Option) (int, *mat.Dense, float64, error) {
+	if len(c) == 0 || len(b) == 0 {
+		return 0, nil, 0, newError(ErrDimensionMismatch, "c and b must not be empty, got len(c) = %d and len(b) = %d", len(c), len(b))
+	}
+	return Simplex(mat.NewDense(1, len(c), append([]float64(nil), c...)), A,
+		mat.NewDense(len(b), 1, append([]float64(nil), b...)), opts...)
+}
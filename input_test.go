@@ -0,0 +1,45 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSimplexVectors(t *testing.T) {
+	A := mat.NewDense(3, 4, []float64{
+		2, 4, 5, 7,
+		1, 1, 2, 2,
+		1, 2, 3, 3,
+	})
+	expected := mat.NewDense(7, 1, []float64{3, 0, 7, 0, 1, 0, 0})
+
+	_, results, score, err := Simplex(mat.NewVecDense(4, []float64{7, 9, 18, 17}), mat.DenseCopyOf(A),
+		mat.NewVecDense(3, []float64{42, 17, 24}), WithMaxIter(10))
+	require.NoError(t, err)
+	assert.True(t, mat.EqualApprox(expected, results, 0.000001))
+	assert.Equal(t, 147.0, score)
+
+	//c as a column and b as a row
+	_, results, score, err = Simplex(mat.NewDense(4, 1, []float64{7, 9, 18, 17}), mat.DenseCopyOf(A),
+		mat.NewDense(1, 3, []float64{42, 17, 24}), WithMaxIter(10))
+	require.NoError(t, err)
+	assert.True(t, mat.EqualApprox(expected, results, 0.000001))
+	assert.Equal(t, 147.0, score)
+
+	c := []float64{7, 9, 18, 17}
+	_, results, score, err = SimplexSlices(c, mat.DenseCopyOf(A), []float64{42, 17, 24}, WithMaxIter(10))
+	require.NoError(t, err)
+	assert.True(t, mat.EqualApprox(expected, results, 0.000001))
+	assert.Equal(t, 147.0, score)
+	assert.Equal(t, []float64{7, 9, 18, 17}, c)
+
+	_, _, _, err = Simplex(mat.NewDense(2, 2, nil), mat.DenseCopyOf(A), mat.NewVecDense(3, nil))
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+	assert.Contains(t, err.Error(), "c must be a vector, got (2,2)")
+	_, _, _, err = SimplexSlices(nil, mat.DenseCopyOf(A), []float64{1, 2, 3})
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+}
@@ -0,0 +1,216 @@
+package goptimization
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+const (
+	// centering Fraction of the duality gap targeted by each step of the interior point method
+	centering = 0.1
+	// stepFraction Fraction of the step to the boundary taken, which keeps the iterates in the interior
+	stepFraction = 0.99
+	// divergence Norm of the iterates above which the interior point method considers the problem infeasible or unbounded
+	divergence = 1e12
+)
+
+// InteriorPoint Solve the linear problem of Simplex with a primal-dual path following interior point method.
+// The slacks complete the constraints, Ax + s = b, and the method follows the central path of
+// min -c*z subject to [A I]z = b, z = (x, s) >= 0 from z = 1, without requiring a feasible start:
+// each iteration solves the normal equations [A I]D[A I]^T dy = r with D = Z/W by Cholesky,
+// then takes the largest step keeping z and the dual slacks w positive.
+// It stops once the relative primal and dual residuals and the average complementarity z_j*w_j are under the tolerance,
+// the solution is then in the interior of the optimal face, not necessarily a vertex like the one of Simplex.
+// It returns ErrUnbounded when the primal iterates diverge, ErrInfeasible when the dual ones do,
+// and ErrIterationLimit, or ErrTimeLimit, with the last iterate at the limit of iterations or time.
+// The results follow the layout of Simplex: the n variables then the m slacks.
+func InteriorPoint(c mat.Matrix, A *mat.Dense, b mat.Matrix, opts ...Option) (int, *mat.Dense, float64, error) {
+	o := newOptions(opts)
+	row, err := asRow(c, "c")
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	column, err := asColumn(b, "b")
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	err = checkDims(row, A, column)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	m, n := A.Dims()
+	// The minimization of -c over [A I], each variable is a cone of size 1
+	cost := make([]float64, n+m)
+	for j := 0; j < n; j++ {
+		cost[j] = -row.At(0, j)
+	}
+	M := mat.NewDense(m, n+m, nil)
+	M.Slice(0, m, 0, n).(*mat.Dense).Copy(A)
+	sizes := make([]int, n+m)
+	for j := range sizes {
+		sizes[j] = 1
+	}
+	for i := 0; i < m; i++ {
+		M.Set(i, n+i, 1)
+	}
+	iter, z, err := conicInteriorPoint(cost, M, mat.Col(nil, 0, column), sizes, o, iterationLimit(o.maxIter, n, m))
+	if z == nil {
+		return iter, nil, 0, err
+	}
+	score := 0.0
+	for j := 0; j < n; j++ {
+		score += row.At(0, j) * z[j]
+	}
+	return iter, mat.NewDense(n+m, 1, z), score, err
+}
+
+// conicInteriorPoint Primal-dual path following of min cost*z subject to M*z = rhs from z = e, where z is a product of
+// second-order cones of the given sizes, a cone of size 1 being z_j >= 0. Each iteration solves the normal equations
+// M*W^-2*M^T dy = r by Cholesky, W the scaling of Nesterov and Todd of the primal z and the dual slacks w, W^2 = W/Z
+// for the cones of size 1, then takes the largest step keeping z and w in the interior of the cones.
+// It returns the last iterate with ErrIterationLimit or ErrTimeLimit at the limit of iterations or time, and no iterate for the other errors.
+func conicInteriorPoint(cost []float64, M *mat.Dense, rhs []float64, sizes []int, o options, maxIter int) (int, []float64, error) {
+	rows, size := M.Dims()
+	z := make([]float64, size)
+	w := make([]float64, size)
+	y := make([]float64, rows)
+	// The identity e of each cone
+	start := 0
+	for _, s := range sizes {
+		z[start], w[start] = 1, 1
+		start += s
+	}
+	rp := make([]float64, rows)
+	rd := make([]float64, size)
+	lambda := make([]float64, size)
+	rc := make([]float64, size)
+	rho := make([]float64, size)
+	q := make([]float64, size)
+	dz := make([]float64, size)
+	dw := make([]float64, size)
+	scalings := make([]coneScaling, len(sizes))
+	G := mat.NewDense(rows, size, nil)
+	normal := mat.NewSymDense(rows, nil)
+	var chol mat.Cholesky
+	r := mat.NewVecDense(rows, nil)
+	dy := mat.NewVecDense(rows, nil)
+	rpVec := mat.NewVecDense(rows, rp)
+	rdVec := mat.NewVecDense(size, rd)
+	yVec := mat.NewVecDense(rows, y)
+	qVec := mat.NewVecDense(size, q)
+	step := make([]float64, size)
+
+	deadline := o.deadline()
+	iter := 0
+	for ; iter < maxIter && !expired(deadline) && !o.cancelled(); iter++ {
+		// Residuals rp = rhs - M*z, rd = cost - M^T y - w and the average complementarity
+		rpVec.MulVec(M, mat.NewVecDense(size, z))
+		floats.SubTo(rp, rhs, rp)
+		rdVec.MulVec(M.T(), yVec)
+		for j := range rd {
+			rd[j] = cost[j] - w[j] - rd[j]
+		}
+		mu := floats.Dot(z, w) / float64(len(sizes))
+		o.logger.Printf("interior point %d: primal residual %g, dual residual %g, gap %g\n", iter, floats.Norm(rp, 2), floats.Norm(rd, 2), mu)
+		if floats.Norm(rp, 2) <= o.tolerance*(1+floats.Norm(rhs, 2)) && floats.Norm(rd, 2) <= o.tolerance*(1+floats.Norm(cost, 2)) &&
+			mu <= o.tolerance*(1+math.Abs(floats.Dot(cost, z))) {
+			return iter, z, nil
+		}
+		if floats.Norm(z, math.Inf(1)) > divergence {
+			return iter, nil, newError(ErrUnbounded, "the primal iterates of the interior point method diverge")
+		}
+		if floats.Norm(y, math.Inf(1)) > divergence {
+			return iter, nil, newError(ErrInfeasible, "the dual iterates of the interior point method diverge")
+		}
+
+		// λ = W*z, rc = sigma*mu*e - λ∘λ, ρ = λ^-1∘rc and q = W^-1*rd - ρ, rc/w - z/w*rd for the cones of size 1
+		start = 0
+		for k, s := range sizes {
+			end := start + s
+			scalings[k] = newConeScaling(z[start:end], w[start:end])
+			scalings[k].apply(lambda[start:end], z[start:end])
+			coneProduct(rc[start:end], lambda[start:end], lambda[start:end])
+			floats.Scale(-1, rc[start:end])
+			rc[start] += centering * mu
+			coneDivide(rho[start:end], lambda[start:end], rc[start:end])
+			scalings[k].applyInverse(q[start:end], rd[start:end])
+			floats.Sub(q[start:end], rho[start:end])
+			for i := 0; i < rows; i++ {
+				scalings[k].applyInverse(G.RawRowView(i)[start:end], M.RawRowView(i)[start:end])
+			}
+			start = end
+		}
+		// Normal equations G*G^T dy = rp + G*q with G = M*W^-1
+		normal.SymOuterK(1, G)
+		r.MulVec(G, qVec)
+		r.AddVec(r, rpVec)
+		if !factorizeNormal(&chol, normal) {
+			return iter, nil, newError(ErrSingularBasis, "the normal equations of the interior point method are singular")
+		}
+		// The normal equations are ill-conditioned close to the optimum, where z_j/w_j goes to 0 or +inf,
+		// the solution is still accurate enough for the step and only a failed factorization stops the method
+		err := chol.SolveVecTo(dy, r)
+		if _, ok := err.(mat.Condition); err != nil && !ok {
+			return iter, nil, newError(ErrSingularBasis, "%v", err)
+		}
+		// dz = W^-1(G^T dy - q) and dw = W(ρ - W*dz)
+		mat.NewVecDense(size, step).MulVec(G.T(), dy)
+		floats.Sub(step, q)
+		primal, dual := 1.0, 1.0
+		start = 0
+		for k, s := range sizes {
+			end := start + s
+			scalings[k].applyInverse(dz[start:end], step[start:end])
+			scalings[k].apply(dw[start:end], dz[start:end])
+			floats.SubTo(dw[start:end], rho[start:end], dw[start:end])
+			scalings[k].apply(dw[start:end], dw[start:end])
+			primal = math.Min(primal, coneStep(z[start:end], dz[start:end]))
+			dual = math.Min(dual, coneStep(w[start:end], dw[start:end]))
+			start = end
+		}
+		floats.AddScaled(z, primal, dz)
+		floats.AddScaled(w, dual, dw)
+		floats.AddScaled(y, dual, dy.RawVector().Data)
+	}
+	if expired(deadline) {
+		return iter, z, newError(ErrTimeLimit, "the interior point method stopped at its deadline after %d iterations", iter)
+	}
+	return iter, z, newError(ErrIterationLimit, "the interior point method did not converge in %d iterations", iter)
+}
+
+// factorizeNormal Cholesky factorization of the normal equations. Close to the optimum rounding makes them
+// numerically indefinite, the diagonal is then regularized by a growing fraction of its largest entry.
+func factorizeNormal(chol *mat.Cholesky, normal *mat.SymDense) bool {
+	if chol.Factorize(normal) {
+		return true
+	}
+	n := normal.Symmetric()
+	max := 0.0
+	for i := 0; i < n; i++ {
+		max = math.Max(max, normal.At(i, i))
+	}
+	added := 0.0
+	for delta := 1e-14 * (1 + max); delta <= 1e-6*(1+max); delta *= 100 {
+		for i := 0; i < n; i++ {
+			normal.SetSym(i, i, normal.At(i, i)+delta-added)
+		}
+		added = delta
+		if chol.Factorize(normal) {
+			return true
+		}
+	}
+	return false
+}
+
+// stepLength Fraction of the step to the boundary of v + alpha*dv >= 0, at most 1
+func stepLength(v, dv []float64) float64 {
+	alpha := 1.0
+	for j := range v {
+		if dv[j] < 0 {
+			alpha = math.Min(alpha, -stepFraction*v[j]/dv[j])
+		}
+	}
+	return alpha
+}
@@ -0,0 +1,57 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestInteriorPoint(t *testing.T) {
+	c := mat.NewDense(1, 3, []float64{5, 4, 3})
+	A := mat.NewDense(3, 3, []float64{
+		2, 3, 1,
+		4, 1, 2,
+		3, 4, 2,
+	})
+	b := mat.NewDense(3, 1, []float64{5, 11, 8})
+	_, results, score, err := InteriorPoint(c, A, b, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, 13, score, 0.000001)
+	assert.True(t, mat.EqualApprox(mat.NewDense(6, 1, []float64{2, 0, 1, 0, 1, 0}), results, 0.000001))
+
+	// Negative right-hand sides need no phase one
+	_, _, score, err = InteriorPoint(mat.NewDense(1, 2, []float64{-2, -3}), mat.NewDense(2, 2, []float64{-1, -1, -1, 0}), mat.NewDense(2, 1, []float64{-4, -1}), silent)
+	require.NoError(t, err)
+	assert.InDelta(t, -8, score, 0.000001)
+
+	cc, AA, bb, _ := knapsack(30, 3)
+	_, _, expected, err := Simplex(cc, AA, bb, silent)
+	require.NoError(t, err)
+	_, _, score, err = InteriorPoint(cc, AA, bb, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, expected, score, 0.000001*expected)
+
+	_, _, _, err = InteriorPoint(mat.NewDense(1, 1, []float64{1}), mat.NewDense(2, 1, []float64{1, -1}), mat.NewDense(2, 1, []float64{1, -2}), silent)
+	assert.Equal(t, ErrInfeasible, errors.Cause(err))
+	_, _, _, err = InteriorPoint(mat.NewDense(1, 1, []float64{1}), mat.NewDense(1, 1, []float64{-1}), mat.NewDense(1, 1, []float64{1}), silent)
+	assert.Equal(t, ErrUnbounded, errors.Cause(err))
+	_, results, _, err = InteriorPoint(c, A, b, silent, WithMaxIter(2))
+	assert.Equal(t, ErrIterationLimit, errors.Cause(err))
+	assert.NotNil(t, results)
+	_, _, _, err = InteriorPoint(c, A, mat.NewDense(2, 1, nil), silent)
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+}
+
+func TestInteriorPointGenerated(t *testing.T) {
+	// The normal equations of these problems become numerically indefinite close to the optimum
+	for seed := int64(1); seed <= 3; seed++ {
+		lp, err := GenerateLP(100, 100, 0.1, seed)
+		require.NoError(t, err)
+		_, _, score, err := InteriorPoint(lp.C, lp.A, lp.B, silent)
+		require.NoError(t, err, "seed %d", seed)
+		assert.InDelta(t, lp.Optimum, score, 0.000001*(1+lp.Optimum), "seed %d", seed)
+	}
+}
@@ -0,0 +1,82 @@
+package goptimization
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// invariantTolerance Relative tolerance of the checks of EnableInvariants
+const invariantTolerance = 1e-6
+
+// ErrInvariant A pivot broke an invariant of the dictionary, see EnableInvariants
+var ErrInvariant = errors.New("simplex invariant violated")
+
+// EnableInvariants Check the dictionary after every pivot, the pivot returns an error wrapping ErrInvariant when:
+// - remap is not a permutation of the variables
+// - B*xBStar != b
+// - a primal pivot from a feasible dictionary makes a basic variable negative or decreases the objective
+// - a dual pivot from a dual feasible dictionary increases the objective
+// The checks cost a product by B at each pivot, they are meant for fuzzing and property tests.
+// Building with the invariants tag enables them for every dictionary.
+func (cf *CanonicalForm) EnableInvariants() {
+	cf.checkInvariants = true
+}
+
+// invariantState Feasibility and objective of the dictionary before a pivot
+type invariantState struct {
+	primalFeasible bool
+	dualFeasible   bool
+	score          float64
+}
+
+// stateBeforePivot Record the state of the dictionary before a pivot, y is the dual of the current basis
+func (cf *CanonicalForm) stateBeforePivot(y *mat.Dense) invariantState {
+	s := invariantState{primalFeasible: true, dualFeasible: true}
+	for i := 0; i < cf.m; i++ {
+		s.primalFeasible = s.primalFeasible && cf.xBStar.At(i, 0) >= -feasibilityTolerance
+	}
+	reduced := cf.reducedCosts(y)
+	for j := 0; j < cf.n; j++ {
+		s.dualFeasible = s.dualFeasible && reduced.At(0, j) <= feasibilityTolerance
+	}
+	_, s.score = cf.values()
+	return s
+}
+
+// checkAfterPivot Check the invariants of the dictionary after a pivot from the state before
+func (cf *CanonicalForm) checkAfterPivot(before invariantState, dual bool) error {
+	seen := make([]bool, cf.n+cf.m)
+	for _, v := range cf.remap {
+		if v < 0 || v >= cf.n+cf.m || seen[v] {
+			return errors.Wrapf(ErrInvariant, "remap %v is not a permutation", cf.remap)
+		}
+		seen[v] = true
+	}
+
+	var activity mat.Dense
+	activity.Mul(cf.B, cf.xBStar)
+	for i := 0; i < cf.m; i++ {
+		if math.Abs(activity.At(i, 0)-cf.b.At(i, 0)) > invariantTolerance*(1+math.Abs(cf.b.At(i, 0))) {
+			return errors.Wrapf(ErrInvariant, "row %d: B*xBStar = %g, b = %g", i, activity.At(i, 0), cf.b.At(i, 0))
+		}
+	}
+
+	_, score := cf.values()
+	slack := invariantTolerance * (1 + math.Abs(before.score))
+	if !dual && before.primalFeasible {
+		for i := 0; i < cf.m; i++ {
+			if cf.xBStar.At(i, 0) < -feasibilityTolerance {
+				return errors.Wrapf(ErrInvariant, "basic variable %d is negative after a primal pivot", cf.remap[cf.n+i])
+			}
+		}
+		if score < before.score-slack {
+			return errors.Wrapf(ErrInvariant, "primal pivot decreased the objective from %g to %g", before.score, score)
+		}
+	}
+	if dual && before.dualFeasible && score > before.score+slack {
+		return errors.Wrapf(ErrInvariant, "dual pivot increased the objective from %g to %g", before.score, score)
+	}
+	return nil
+}
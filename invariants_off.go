@@ -0,0 +1,7 @@
+//go:build !invariants
+// +build !invariants
+
+package goptimization
+
+// invariantsByDefault Check the invariants only of the dictionaries with EnableInvariants
+const invariantsByDefault = false
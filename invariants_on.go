@@ -0,0 +1,7 @@
+//go:build invariants
+// +build invariants
+
+package goptimization
+
+// invariantsByDefault Check the invariants of every dictionary
+const invariantsByDefault = true
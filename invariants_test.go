@@ -0,0 +1,44 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestInvariantsGenerated(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		lp, err := GenerateLP(8, 6, 0.4, seed)
+		require.NoError(t, err)
+		cf := CanonicalForm{}
+		require.NoError(t, cf.New(mat.DenseCopyOf(lp.C), mat.DenseCopyOf(lp.A), mat.DenseCopyOf(lp.B)))
+		cf.EnableInvariants()
+		end := false
+		for iter := 0; iter < 100 && !end; iter++ {
+			end, err = cf.Iter(0)
+			require.NoError(t, err, "seed %d", seed)
+		}
+		assert.True(t, end)
+
+		// The dual simplex after a cut of the optimum
+		a := make([]float64, cf.n+cf.m)
+		a[0] = 1
+		require.NoError(t, cf.AddConstraint(a, lp.X.At(0, 0)/2))
+		_, err = cf.Reoptimize(100)
+		if err != ErrInfeasible {
+			require.NoError(t, err, "seed %d", seed)
+		}
+	}
+}
+
+func TestInvariantsViolated(t *testing.T) {
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(mat.NewDense(1, 2, []float64{5, 4}), mat.NewDense(2, 2, []float64{6, 4, 1, 2}), mat.NewDense(2, 1, []float64{24, 6})))
+	cf.EnableInvariants()
+	cf.xBStar.Set(0, 0, 12)
+	_, err := cf.Iter(0)
+	assert.Equal(t, ErrInvariant, errors.Cause(err))
+}
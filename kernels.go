@@ -0,0 +1,27 @@
+package goptimization
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/mat"
+)
+
+// The hot loops of the iterations go through gonum/blas on the storage of the matrices,
+// At and Set check the bounds of each element and cannot be vectorized.
+
+// rowVector Row i of m as a BLAS vector, sharing its storage
+func rowVector(m *mat.Dense, i int) blas64.Vector {
+	raw := m.RawMatrix()
+	return blas64.Vector{N: raw.Cols, Data: raw.Data[i*raw.Stride : i*raw.Stride+raw.Cols], Inc: 1}
+}
+
+// colVector Column j of m as a BLAS vector, sharing its storage
+func colVector(m *mat.Dense, j int) blas64.Vector {
+	raw := m.RawMatrix()
+	return blas64.Vector{N: raw.Rows, Data: raw.Data[j : (raw.Rows-1)*raw.Stride+j+1], Inc: raw.Stride}
+}
+
+// gemvT Compute dst = beta*dst + alpha*x*m for the row vectors x and dst
+func gemvT(dst blas64.Vector, alpha float64, x blas64.Vector, m *mat.Dense, beta float64) {
+	blas64.Gemv(blas.Trans, alpha, m.RawMatrix(), x, beta, dst)
+}
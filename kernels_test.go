@@ -0,0 +1,41 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestKernels(t *testing.T) {
+	a := mat.NewDense(3, 4, []float64{
+		1, 2, 3, 4,
+		5, 6, 7, 8,
+		9, 10, 11, 12,
+	})
+	// The vectors of a view follow the stride of the matrix
+	view := a.Slice(1, 3, 1, 3).(*mat.Dense)
+	assert.Equal(t, []float64{6, 7}, rowVector(view, 0).Data)
+	column := colVector(view, 1)
+	assert.Equal(t, 2, column.N)
+	assert.Equal(t, 11.0, column.Data[column.Inc])
+
+	dst := blas64.Vector{N: 2, Data: []float64{1, 1}, Inc: 1}
+	gemvT(dst, 2, blas64.Vector{N: 2, Data: []float64{1, -1}, Inc: 1}, view, 1)
+	assert.Equal(t, []float64{1 + 2*(6-10), 1 + 2*(7-11)}, dst.Data)
+
+	lp, err := GenerateLP(12, 9, 0.5, 4)
+	require.NoError(t, err)
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(lp.C, mat.DenseCopyOf(lp.A), lp.B))
+	y := mat.NewDense(1, 9, []float64{1, -2, 3, 0, 5, -1, 2, 0.5, 4})
+	var expected mat.Dense
+	expected.Mul(y, cf.AN)
+	expected.Sub(cf.cN, &expected)
+	assert.True(t, mat.EqualApprox(&expected, cf.reducedCosts(y), 1e-12))
+	for j := 0; j < 12; j++ {
+		assert.InDelta(t, expected.At(0, j), cf.reducedCost(y, j), 1e-12)
+	}
+}
@@ -0,0 +1,69 @@
+package goptimization
+
+// LazyConstraints Constraints violated by the integer solution x of the original variables, nil when x satisfies them all.
+// The A of each cut is indexed by the original variables, len(A) <= n.
+type LazyConstraints func(x []float64) ([]Cut, error)
+
+// violatedLazy Lazy constraints violated by x by more than violationTolerance
+func (bb *BranchAndBound) violatedLazy(x []float64) ([]Cut, error) {
+	if bb.Lazy == nil {
+		return nil, nil
+	}
+	cuts, err := bb.Lazy(x)
+	if err != nil {
+		return nil, err
+	}
+	violated := []Cut{}
+	for _, cut := range cuts {
+		if len(cut.A) > bb.n {
+			return nil, newError(ErrDimensionMismatch, "len(cut.A) = %d > number of original variables %d", len(cut.A), bb.n)
+		}
+		activity := 0.0
+		for j, a := range cut.A {
+			activity += a * x[j]
+		}
+		if activity-cut.RHS > violationTolerance {
+			violated = append(violated, cut)
+		}
+	}
+	return violated, nil
+}
+
+// satisfiesLazy Check if the solution of a heuristic satisfies the lazy constraints
+func (bb *BranchAndBound) satisfiesLazy(x []float64) (bool, error) {
+	cuts, err := bb.violatedLazy(x)
+	return len(cuts) == 0, err
+}
+
+// addLazy Add the lazy constraints violated by the integral relaxation of the node.
+// The node is re-optimized and returned to be explored again, or dropped when it becomes infeasible
+// or cannot improve the incumbent. It returns false if no lazy constraint is violated.
+// The constraints are only added to the node and its descendants, another node finds them again when needed.
+func (bb *BranchAndBound) addLazy(nd *node, values []float64) ([]*node, bool, error) {
+	cuts, err := bb.violatedLazy(values[:bb.n])
+	if err != nil || len(cuts) == 0 {
+		return nil, false, err
+	}
+	for _, cut := range cuts {
+		err := bb.addCut(nd, cut)
+		if err != nil {
+			return nil, true, err
+		}
+	}
+	_, err = nd.cf.Reoptimize(bb.MaxIter)
+	if err == ErrInfeasible {
+		nd.mark("infeasible")
+		return nil, true, nil
+	}
+	if err != nil {
+		return nil, true, err
+	}
+	_, score := nd.cf.values()
+	if bb.cannotImprove(score) {
+		nd.mark("bound")
+		return nil, true, nil
+	}
+	// The node is explored again with the lazy constraints
+	nd.bound = score
+	return []*node{nd}, true, nil
+}
@@ -0,0 +1,38 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestLazy(t *testing.T) {
+	//Maximize x1 + 2x2 + 3x3 over binaries with the lazy constraint x1 + x2 + x3 <= 1
+	c := mat.NewDense(1, 3, []float64{1, 2, 3})
+	A := mat.NewDense(3, 3, []float64{1, 0, 0, 0, 1, 0, 0, 0, 1})
+	b := mat.NewDense(3, 1, []float64{1, 1, 1})
+	bb := &BranchAndBound{}
+	require.NoError(t, bb.New(c, A, b, []bool{true, true, true}))
+	checked := 0
+	bb.Lazy = func(x []float64) ([]Cut, error) {
+		checked++
+		assert.Len(t, x, 3)
+		return []Cut{{A: []float64{1, 1, 1}, RHS: 1}}, nil
+	}
+	results, score, err := bb.Solve(100)
+	require.NoError(t, err)
+	assert.InDelta(t, 3, score, 0.000001)
+	assert.InDeltaSlice(t, []float64{0, 0, 1}, mat.Col(nil, 0, results)[:3], 0.000001)
+	assert.Greater(t, checked, 0)
+	assert.Greater(t, bb.Cuts, 0)
+
+	bb = &BranchAndBound{}
+	require.NoError(t, bb.New(c, A, b, []bool{true, true, true}))
+	bb.Lazy = func(x []float64) ([]Cut, error) {
+		return []Cut{{A: []float64{1, 1, 1, 1}, RHS: 1}}, nil
+	}
+	_, _, err = bb.Solve(100)
+	assert.Error(t, err)
+}
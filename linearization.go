@@ -0,0 +1,104 @@
+package goptimization
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Abs Add a variable t = |e| to the model
+// Without big-M (bigM <= 0), t >= e and t >= -e: the linearization is exact when the objective decreases with t,
+// for example when |e| is minimized.
+// With bigM >= max |e|, a binary variable z selects the sign and t <= e + bigM*(1-z), t <= -e + bigM*z
+// make t = |e| in any position.
+func (m *Model) Abs(e Expr, bigM float64) (Var, error) {
+	t := m.AddVariable("", false)
+	minusT := Term{Var: t, Coef: -1}
+	rows := []Expr{
+		{Terms: append(append([]Term(nil), e.Terms...), minusT), Constant: e.Constant},
+		{Terms: append(scaleTerms(e.Terms, -1), minusT), Constant: -e.Constant},
+	}
+	rhs := []float64{0, 0}
+	if bigM > 0 {
+		z := m.AddVariable("", true)
+		rows = append(rows,
+			Expr{Terms: append(scaleTerms(e.Terms, -1), Term{Var: t, Coef: 1}, Term{Var: z, Coef: bigM}), Constant: -e.Constant},
+			Expr{Terms: append(append([]Term(nil), e.Terms...), Term{Var: t, Coef: 1}, Term{Var: z, Coef: -bigM}), Constant: e.Constant},
+			z.Expr(),
+		)
+		rhs = append(rhs, bigM, 0, 1)
+	}
+	return t, m.addConstraints(rows, rhs)
+}
+
+// Max Add a variable t = max(exprs) to the model
+// Without big-M (bigM <= 0), t >= e_k for each expression: the linearization is exact when the objective decreases with t.
+// With bigM >= max_k e_k - min_k e_k, binary variables pick the expression reaching the maximum,
+// z_k = 1 for k >= 1 and z_0 = 1 - Σ z_k, with t <= e_k + bigM*(1-z_k), so t = max(exprs) in any position.
+func (m *Model) Max(bigM float64, exprs ...Expr) (Var, error) {
+	return m.extremum(1, bigM, exprs)
+}
+
+// Min Add a variable t = min(exprs) to the model
+// Without big-M (bigM <= 0), t <= e_k for each expression: the linearization is exact when the objective increases with t.
+// With big-M, binary variables pick the expression reaching the minimum like Max.
+func (m *Model) Min(bigM float64, exprs ...Expr) (Var, error) {
+	return m.extremum(-1, bigM, exprs)
+}
+
+// extremum Add t = max(exprs) for sign 1, t = min(exprs) for sign -1.
+// Both are written as sign*e_k - sign*t <= 0 and sign*t - sign*e_k <= bigM*(1-z_k).
+func (m *Model) extremum(sign, bigM float64, exprs []Expr) (Var, error) {
+	if len(exprs) == 0 {
+		return 0, errors.New("at least one expression is needed")
+	}
+	t := m.AddVariable("", false)
+	rows := []Expr{}
+	rhs := []float64{}
+	for _, e := range exprs {
+		rows = append(rows, Expr{Terms: append(scaleTerms(e.Terms, sign), Term{Var: t, Coef: -sign}), Constant: sign * e.Constant})
+		rhs = append(rhs, 0)
+	}
+	if bigM > 0 && len(exprs) > 1 {
+		selection := Expr{}
+		z := make([]Var, len(exprs))
+		for k := 1; k < len(exprs); k++ {
+			z[k] = m.AddVariable("", true)
+			selection.Terms = append(selection.Terms, Term{Var: z[k], Coef: 1})
+		}
+		for k, e := range exprs {
+			row := Expr{Terms: append(scaleTerms(e.Terms, -sign), Term{Var: t, Coef: sign}), Constant: -sign * e.Constant}
+			if k == 0 {
+				// z_0 = 1 - Σ z_k
+				row.Terms = append(row.Terms, scaleTerms(selection.Terms, -bigM)...)
+				rows = append(rows, row)
+				rhs = append(rhs, 0)
+				continue
+			}
+			row.Terms = append(row.Terms, Term{Var: z[k], Coef: bigM})
+			rows = append(rows, row)
+			rhs = append(rhs, bigM)
+		}
+		rows = append(rows, selection)
+		rhs = append(rhs, 1)
+	}
+	return t, m.addConstraints(rows, rhs)
+}
+
+// addConstraints Add the constraints rows_i <= rhs_i
+func (m *Model) addConstraints(rows []Expr, rhs []float64) error {
+	for i, row := range rows {
+		err := m.AddConstraint(row, rhs[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scaleTerms Multiply each coefficient by s
+func scaleTerms(terms []Term, s float64) []Term {
+	scaled := make([]Term, len(terms))
+	for k, t := range terms {
+		scaled[k] = Term{Var: t.Var, Coef: s * t.Coef}
+	}
+	return scaled
+}
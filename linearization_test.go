@@ -0,0 +1,91 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// boxModel Model with x <= xMax and y <= yMax
+func boxModel(t *testing.T, xMax, yMax float64) (*Model, Var, Var) {
+	m := &Model{}
+	x := m.AddVariable("x", false)
+	y := m.AddVariable("y", false)
+	require.NoError(t, m.AddConstraint(x.Expr(), xMax))
+	require.NoError(t, m.AddConstraint(y.Expr(), yMax))
+	return m, x, y
+}
+
+func TestAbs(t *testing.T) {
+	//|x - y| is penalized, the epigraph is exact
+	m, x, y := boxModel(t, 4, 1)
+	abs, err := m.Abs(Expr{Terms: []Term{{x, 1}, {y, -1}}}, 0)
+	require.NoError(t, err)
+	m.Maximize(Expr{Terms: []Term{{x, 1}, {y, 1}, {abs, -2}}})
+	solution, err := m.Solve(100)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 2.0, solution.Score, 0.000001)
+	assert.InDelta(t, 1.0, solution.Value(x), 0.000001)
+	assert.InDelta(t, 0.0, solution.Value(abs), 0.000001)
+
+	//|x - y| is maximized, big-M is needed
+	m, x, y = boxModel(t, 4, 1)
+	abs, err = m.Abs(Expr{Terms: []Term{{x, 1}, {y, -1}}}, 10)
+	require.NoError(t, err)
+	m.Maximize(abs.Expr())
+	solution, err = m.Solve(100)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 4.0, solution.Score, 0.000001)
+	assert.InDelta(t, 4.0, solution.Value(x), 0.000001)
+	assert.InDelta(t, 0.0, solution.Value(y), 0.000001)
+}
+
+func TestMax(t *testing.T) {
+	m, x, y := boxModel(t, 2, 2)
+	max, err := m.Max(0, x.Expr(), y.Expr())
+	require.NoError(t, err)
+	m.Maximize(Expr{Terms: []Term{{x, 3}, {y, 2}, {max, -4}}})
+	solution, err := m.Solve(100)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 2.0, solution.Score, 0.000001)
+	assert.InDelta(t, 2.0, solution.Value(max), 0.000001)
+
+	m, x, y = boxModel(t, 3, 2)
+	max, err = m.Max(10, x.Expr(), y.Expr())
+	require.NoError(t, err)
+	m.Maximize(Expr{Terms: []Term{{max, 1}, {x, -0.5}, {y, -0.5}}})
+	solution, err = m.Solve(100)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 1.5, solution.Score, 0.000001)
+	assert.InDelta(t, 3.0, solution.Value(max), 0.000001)
+	assert.InDelta(t, 0.0, solution.Value(y), 0.000001)
+
+	_, err = m.Max(0)
+	assert.Error(t, err)
+}
+
+func TestMin(t *testing.T) {
+	m := &Model{}
+	x := m.AddVariable("x", false)
+	y := m.AddVariable("y", false)
+	require.NoError(t, m.AddConstraint(Expr{Terms: []Term{{x, 1}, {y, 1}}}, 3))
+	min, err := m.Min(0, x.Expr(), Expr{Terms: []Term{{y, 2}}})
+	require.NoError(t, err)
+	m.Maximize(min.Expr())
+	solution, err := m.Solve(100)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 2.0, solution.Score, 0.000001)
+	assert.InDelta(t, 2.0, solution.Value(x), 0.000001)
+	assert.InDelta(t, 1.0, solution.Value(y), 0.000001)
+
+	//min(x, y) is penalized, big-M is needed
+	m, x, y = boxModel(t, 2, 2)
+	min, err = m.Min(10, x.Expr(), y.Expr())
+	require.NoError(t, err)
+	m.Maximize(Expr{Terms: []Term{{x, 1}, {y, 1}, {min, -3}}})
+	solution, err = m.Solve(100)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 2.0, solution.Score, 0.000001)
+	assert.InDelta(t, 0.0, solution.Value(min), 0.000001)
+}
@@ -0,0 +1,1502 @@
+package goptimization
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// mpKind Kind of a token of a MathProg file
+type mpKind int
+
+const (
+	mpEOF mpKind = iota
+	mpName
+	mpNumber
+	mpString
+	mpPunct
+)
+
+// mpToken Token of a MathProg file, the text of a number is formatted like formatNumber so the numeric symbols match
+type mpToken struct {
+	kind  mpKind
+	text  string
+	value float64
+	line  int
+}
+
+// mpPunctuation Operators and delimiters of the model section, the longest match wins
+var mpPunctuation = []string{":=", "..", "<=", ">=", "==", "!=", "<>", "&&", "||", "**",
+	";", ",", ":", "{", "}", "[", "]", "(", ")", "+", "-", "*", "/", "^", "=", "<", ">", "!", "."}
+
+// mpTokenize Split a MathProg file into tokens. The model section is read until "data;",
+// the data section, where the symbols may contain the characters + - and ., until "end;" or the end of the file.
+// dataOnly reads the whole file as a data section.
+func mpTokenize(src string, dataOnly bool) ([]mpToken, error) {
+	tokens := []mpToken{}
+	data := dataOnly
+	line := 1
+	pos := 0
+	for {
+		var err error
+		pos, line, err = mpSkip(src, pos, line)
+		if err != nil {
+			return nil, err
+		}
+		if pos == len(src) {
+			return append(tokens, mpToken{kind: mpEOF, line: line}), nil
+		}
+		c := src[pos]
+		start := pos
+		switch {
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(src[pos+1:], c)
+			if end < 0 {
+				return nil, errors.Errorf("line %d: unterminated string", line)
+			}
+			tokens = append(tokens, mpToken{kind: mpString, text: src[pos+1 : pos+1+end], line: line})
+			pos += end + 2
+			continue
+		case data && !strings.ContainsRune(",;:=()[]{}", rune(c)):
+			for pos < len(src) && !unicode.IsSpace(rune(src[pos])) && !strings.ContainsRune(",;:=()[]{}'\"#", rune(src[pos])) {
+				pos++
+			}
+			text := src[start:pos]
+			if v, err := strconv.ParseFloat(text, 64); err == nil {
+				tokens = append(tokens, mpToken{kind: mpNumber, text: formatNumber(v), value: v, line: line})
+			} else {
+				tokens = append(tokens, mpToken{kind: mpName, text: text, line: line})
+			}
+			continue
+		case c == '_' || unicode.IsLetter(rune(c)):
+			for pos < len(src) && (src[pos] == '_' || unicode.IsLetter(rune(src[pos])) || unicode.IsDigit(rune(src[pos]))) {
+				pos++
+			}
+			tokens = append(tokens, mpToken{kind: mpName, text: src[start:pos], line: line})
+			// data; switches to the data section
+			if !data && src[start:pos] == "data" && (len(tokens) == 1 || tokens[len(tokens)-2].text == ";") {
+				rest := strings.TrimLeftFunc(src[pos:], unicode.IsSpace)
+				if strings.HasPrefix(rest, ";") {
+					line += strings.Count(src[pos:len(src)-len(rest)], "\n")
+					pos = len(src) - len(rest) + 1
+					tokens = append(tokens, mpToken{kind: mpPunct, text: ";", line: line})
+					data = true
+				}
+			}
+			continue
+		case unicode.IsDigit(rune(c)) || (c == '.' && pos+1 < len(src) && unicode.IsDigit(rune(src[pos+1]))):
+			for pos < len(src) && unicode.IsDigit(rune(src[pos])) {
+				pos++
+			}
+			// 1..n is a range, not the number 1.
+			if pos < len(src) && src[pos] == '.' && !strings.HasPrefix(src[pos:], "..") {
+				pos++
+				for pos < len(src) && unicode.IsDigit(rune(src[pos])) {
+					pos++
+				}
+			}
+			if pos < len(src) && (src[pos] == 'e' || src[pos] == 'E') {
+				exponent := pos + 1
+				if exponent < len(src) && (src[exponent] == '+' || src[exponent] == '-') {
+					exponent++
+				}
+				if exponent < len(src) && unicode.IsDigit(rune(src[exponent])) {
+					pos = exponent
+					for pos < len(src) && unicode.IsDigit(rune(src[pos])) {
+						pos++
+					}
+				}
+			}
+			v, err := strconv.ParseFloat(src[start:pos], 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "line %d", line)
+			}
+			tokens = append(tokens, mpToken{kind: mpNumber, text: formatNumber(v), value: v, line: line})
+			continue
+		}
+		matched := false
+		for _, p := range mpPunctuation {
+			if strings.HasPrefix(src[pos:], p) {
+				tokens = append(tokens, mpToken{kind: mpPunct, text: p, line: line})
+				pos += len(p)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return nil, errors.Errorf("line %d: unexpected character %q", line, c)
+		}
+	}
+}
+
+// mpSkip Position and line after the blanks and the comments from pos
+func mpSkip(src string, pos, line int) (int, int, error) {
+	for pos < len(src) {
+		switch {
+		case src[pos] == '\n':
+			line++
+			pos++
+		case unicode.IsSpace(rune(src[pos])):
+			pos++
+		case src[pos] == '#':
+			for pos < len(src) && src[pos] != '\n' {
+				pos++
+			}
+		case strings.HasPrefix(src[pos:], "/*"):
+			end := strings.Index(src[pos+2:], "*/")
+			if end < 0 {
+				return pos, line, errors.Errorf("line %d: unterminated comment", line)
+			}
+			line += strings.Count(src[pos:pos+2+end], "\n")
+			pos += end + 4
+		default:
+			return pos, line, nil
+		}
+	}
+	return pos, line, nil
+}
+
+// mpExpr Expression of a model: mpNumber, mpSymbol, mpRef, mpBinary, mpUnary or mpSum
+type mpExpr interface{}
+
+type mpNumberExpr struct{ value float64 }
+
+// mpSymbolExpr Quoted string
+type mpSymbolExpr struct{ text string }
+
+// mpRef Dummy index, parameter or variable, with its subscripts
+type mpRef struct {
+	name string
+	subs []mpExpr
+	line int
+}
+
+type mpBinary struct {
+	op   string
+	l, r mpExpr
+	line int
+}
+
+type mpUnary struct {
+	op string
+	e  mpExpr
+}
+
+type mpSum struct {
+	indexing *mpIndexing
+	body     mpExpr
+}
+
+// mpSetExpr Set named name, literal set of elements, or range from..to by by
+type mpSetExpr struct {
+	name     string
+	elements []mpExpr
+	literal  bool
+	from, to mpExpr
+	by       mpExpr
+	line     int
+}
+
+// mpIndexItem Member of an indexing expression, dummy is empty for {I}
+type mpIndexItem struct {
+	dummy string
+	set   *mpSetExpr
+}
+
+// mpIndexing Indexing expression {i in I, j in J : condition}
+type mpIndexing struct {
+	items     []mpIndexItem
+	condition mpExpr
+}
+
+// mpSet Set declared by the model, assigned by the model or the data
+type mpSet struct {
+	value    *mpSetExpr
+	data     []string
+	assigned bool
+	// evaluating Detect the sets defined from themselves
+	evaluating bool
+}
+
+// mpParam Parameter declared by the model, its values are given by the data, by the model or by a default
+type mpParam struct {
+	name        string
+	domain      *mpIndexing
+	value       mpExpr
+	defaultExpr mpExpr
+	data        map[string]float64
+	dataDefault *float64
+}
+
+// mpVar Variable declared by the model, terms are indexed by the key of the subscripts
+type mpVar struct {
+	name    string
+	domain  *mpIndexing
+	lower   mpExpr
+	upper   mpExpr
+	integer bool
+	binary  bool
+	terms   map[string][]Term
+}
+
+type mpObjective struct {
+	maximize bool
+	expr     mpExpr
+}
+
+// mpConstraint Constraint e1 rel e2, or e1 rel e2 rel e3 for a double inequality
+type mpConstraint struct {
+	name   string
+	domain *mpIndexing
+	exprs  []mpExpr
+	rels   []string
+	line   int
+}
+
+// mpReader Parser and translator of a MathProg model to a Model
+type mpReader struct {
+	tokens []mpToken
+	pos    int
+
+	sets   map[string]*mpSet
+	params map[string]*mpParam
+	vars   map[string]*mpVar
+	// declared Names of the sets, parameters, variables, objectives and constraints
+	declared map[string]bool
+	// statements Variables, objectives and constraints in the order of the model
+	statements []interface{}
+}
+
+// ReadMathProg Read a model written in a subset of GNU MathProg into a Model: one-dimensional sets, parameters and
+// variables indexed over them, the objective, linear constraints and a data section in the file or in the data readers.
+// The model supports
+// - set S; set S := {a, 'b', 3}; set S := 1..n by 2;
+// - param p{I, J} default 0; param q := 2 * p['a', 1]; the integer, >= and other checks are ignored
+// - var x{I} >= 0, <= u, integer; var y binary; a variable without a lower bound is free
+// - maximize or minimize name: expression;
+// - s.t., subject to or no keyword, name{i in I : condition}: expression <= expression; or l <= expression <= u
+// - sum{i in I, j in J : condition} expression, + - * / div mod ^, comparisons, and, or, not
+// - solve, display, printf and check statements are ignored, end stops the model
+// The data section supports set S := a b c; param p := a 1 b 2; param p default 0 : j1 j2 := i1 1 2 i2 3 4;
+// param : p q := a 1 2 b 3 4; where . is a missing value.
+// The variables are named like x[a,1] and the rows like name[a,1], a free variable is split into x+ and x-;
+// the bounds other than x >= 0 become rows named after the variable with a .bound suffix.
+// A minimization is read as the maximization of the opposite objective, like ReadMPS.
+func ReadMathProg(r io.Reader, data ...io.Reader) (*Model, error) {
+	src, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := mpTokenize(string(src), false)
+	if err != nil {
+		return nil, err
+	}
+	p := &mpReader{tokens: tokens, sets: map[string]*mpSet{}, params: map[string]*mpParam{}, vars: map[string]*mpVar{}, declared: map[string]bool{}}
+	err = p.parseModel()
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range data {
+		src, err := ioutil.ReadAll(d)
+		if err != nil {
+			return nil, err
+		}
+		p.tokens, err = mpTokenize(string(src), true)
+		if err != nil {
+			return nil, err
+		}
+		p.pos = 0
+		err = p.parseData()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p.build()
+}
+
+// peek Token at the offset k from the current token
+func (p *mpReader) peek(k int) mpToken {
+	if p.pos+k >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+k]
+}
+
+func (p *mpReader) next() mpToken {
+	t := p.peek(0)
+	if p.pos < len(p.tokens)-1 {
+		p.pos++
+	}
+	return t
+}
+
+// is Check if the current token is the punctuation or the keyword text
+func (p *mpReader) is(text string) bool {
+	t := p.peek(0)
+	return (t.kind == mpPunct || t.kind == mpName) && t.text == text
+}
+
+// accept Consume the token if it is text
+func (p *mpReader) accept(text string) bool {
+	if p.is(text) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *mpReader) expect(text string) error {
+	if !p.accept(text) {
+		return p.errorf("expected %q, got %q", text, p.peek(0).text)
+	}
+	return nil
+}
+
+func (p *mpReader) errorf(format string, args ...interface{}) error {
+	return errors.Errorf("line %d: %s", p.peek(0).line, fmt.Sprintf(format, args...))
+}
+
+// declaration Consume a name which is not declared yet
+func (p *mpReader) declaration() (string, error) {
+	t := p.next()
+	if t.kind != mpName {
+		return "", errors.Errorf("line %d: expected a name, got %q", t.line, t.text)
+	}
+	if p.declared[t.text] {
+		return "", errors.Errorf("line %d: %s is declared twice", t.line, t.text)
+	}
+	p.declared[t.text] = true
+	return t.text, nil
+}
+
+// skipStatement Consume the tokens until the end of the statement
+func (p *mpReader) skipStatement() error {
+	for !p.accept(";") {
+		if p.peek(0).kind == mpEOF {
+			return p.errorf("missing ;")
+		}
+		p.next()
+	}
+	return nil
+}
+
+// parseModel Read the statements of the model section, then its data section
+func (p *mpReader) parseModel() error {
+	for {
+		t := p.peek(0)
+		if t.kind == mpEOF {
+			return nil
+		}
+		var err error
+		switch {
+		case p.accept("end"):
+			return p.skipEnd()
+		case p.accept("data"):
+			err = p.expect(";")
+			if err == nil {
+				return p.parseData()
+			}
+		case p.accept("set"):
+			err = p.parseSet()
+		case p.accept("param"):
+			err = p.parseParam()
+		case p.accept("var"):
+			err = p.parseVar()
+		case p.is("maximize") || p.is("minimize"):
+			maximize := p.next().text == "maximize"
+			err = p.parseObjective(maximize)
+		case p.is("solve") || p.is("display") || p.is("printf") || p.is("check"):
+			err = p.skipStatement()
+		case t.kind == mpName:
+			err = p.parseConstraint()
+		default:
+			err = p.errorf("unexpected %q", t.text)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// skipEnd Consume the optional ; after end, the rest of the file is ignored
+func (p *mpReader) skipEnd() error {
+	p.accept(";")
+	return nil
+}
+
+func (p *mpReader) parseSet() error {
+	name, err := p.declaration()
+	if err != nil {
+		return err
+	}
+	set := &mpSet{}
+	if p.is("{") {
+		return p.errorf("indexed sets are not supported")
+	}
+	if p.accept(":=") || p.accept("=") {
+		set.value, err = p.parseSetExpr()
+		if err != nil {
+			return err
+		}
+	}
+	p.sets[name] = set
+	return p.expect(";")
+}
+
+func (p *mpReader) parseParam() error {
+	name, err := p.declaration()
+	if err != nil {
+		return err
+	}
+	param := &mpParam{name: name, data: map[string]float64{}}
+	if p.is("{") {
+		param.domain, err = p.parseIndexing()
+		if err != nil {
+			return err
+		}
+	}
+	for !p.accept(";") {
+		switch {
+		case p.accept(","), p.accept("integer"), p.accept("binary"):
+		case p.is("symbolic"):
+			return p.errorf("symbolic parameters are not supported")
+		case p.accept("default"):
+			param.defaultExpr, err = p.parseExpr()
+		case p.accept(":="), p.accept("="):
+			param.value, err = p.parseExpr()
+		case p.is("<") || p.is("<=") || p.is(">") || p.is(">=") || p.is("==") || p.is("!=") || p.is("<>") || p.is("in"):
+			// The checks of the values are ignored
+			p.next()
+			if p.is("in") {
+				_, err = p.parseSetExpr()
+			} else {
+				_, err = p.parseExpr()
+			}
+		default:
+			return p.errorf("unexpected %q in the declaration of %s", p.peek(0).text, name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	p.params[name] = param
+	return nil
+}
+
+func (p *mpReader) parseVar() error {
+	name, err := p.declaration()
+	if err != nil {
+		return err
+	}
+	v := &mpVar{name: name, terms: map[string][]Term{}}
+	if p.is("{") {
+		v.domain, err = p.parseIndexing()
+		if err != nil {
+			return err
+		}
+	}
+	for !p.accept(";") {
+		switch {
+		case p.accept(","):
+		case p.accept("integer"):
+			v.integer = true
+		case p.accept("binary"):
+			v.binary = true
+		case p.accept(">="):
+			v.lower, err = p.parseExpr()
+		case p.accept("<="):
+			v.upper, err = p.parseExpr()
+		case p.accept("=") || p.accept("=="):
+			v.lower, err = p.parseExpr()
+			v.upper = v.lower
+		default:
+			return p.errorf("unexpected %q in the declaration of %s", p.peek(0).text, name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	p.vars[name] = v
+	p.statements = append(p.statements, v)
+	return nil
+}
+
+func (p *mpReader) parseObjective(maximize bool) error {
+	_, err := p.declaration()
+	if err != nil {
+		return err
+	}
+	err = p.expect(":")
+	if err != nil {
+		return err
+	}
+	e, err := p.parseExpr()
+	if err != nil {
+		return err
+	}
+	p.statements = append(p.statements, &mpObjective{maximize: maximize, expr: e})
+	return p.expect(";")
+}
+
+// parseConstraint Read s.t. name{indexing}: e1 rel e2 [rel e3];
+func (p *mpReader) parseConstraint() error {
+	switch {
+	case p.is("s") && p.peek(1).text == "." && p.peek(2).text == "t" && p.peek(3).text == ".":
+		p.pos += 4
+	case (p.is("subject") || p.is("subj")) && p.peek(1).text == "to":
+		p.pos += 2
+	}
+	line := p.peek(0).line
+	name, err := p.declaration()
+	if err != nil {
+		return err
+	}
+	c := &mpConstraint{name: name, line: line}
+	if p.is("{") {
+		c.domain, err = p.parseIndexing()
+		if err != nil {
+			return err
+		}
+	}
+	err = p.expect(":")
+	if err != nil {
+		return err
+	}
+	for {
+		e, err := p.parseArithmetic()
+		if err != nil {
+			return err
+		}
+		c.exprs = append(c.exprs, e)
+		if p.accept(";") {
+			break
+		}
+		rel := p.next().text
+		if rel != "<=" && rel != ">=" && rel != "=" && rel != "==" {
+			return errors.Errorf("line %d: expected <=, >= or = in constraint %s, got %q", p.peek(0).line, name, rel)
+		}
+		c.rels = append(c.rels, rel)
+	}
+	if len(c.exprs) != 2 && len(c.exprs) != 3 {
+		return errors.Errorf("line %d: constraint %s needs one or two relations", line, name)
+	}
+	p.statements = append(p.statements, c)
+	return nil
+}
+
+// parseIndexing Read {item, ..., item : condition}
+func (p *mpReader) parseIndexing() (*mpIndexing, error) {
+	err := p.expect("{")
+	if err != nil {
+		return nil, err
+	}
+	idx := &mpIndexing{}
+	for {
+		item := mpIndexItem{}
+		if p.peek(0).kind == mpName && p.peek(1).text == "in" {
+			item.dummy = p.next().text
+			p.next()
+		}
+		item.set, err = p.parseSetExpr()
+		if err != nil {
+			return nil, err
+		}
+		idx.items = append(idx.items, item)
+		if !p.accept(",") {
+			break
+		}
+	}
+	if p.accept(":") {
+		idx.condition, err = p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return idx, p.expect("}")
+}
+
+// parseSetExpr Read a set name, a literal set {e, ..., e} or a range e..e [by e]
+func (p *mpReader) parseSetExpr() (*mpSetExpr, error) {
+	line := p.peek(0).line
+	if p.accept("{") {
+		se := &mpSetExpr{literal: true, line: line}
+		for !p.accept("}") {
+			e, err := p.parseArithmetic()
+			if err != nil {
+				return nil, err
+			}
+			se.elements = append(se.elements, e)
+			if !p.accept(",") && !p.is("}") {
+				return nil, p.errorf("expected , or } in a set")
+			}
+		}
+		return se, nil
+	}
+	e, err := p.parseArithmetic()
+	if err != nil {
+		return nil, err
+	}
+	if p.accept("..") {
+		se := &mpSetExpr{from: e, line: line}
+		se.to, err = p.parseArithmetic()
+		if err != nil {
+			return nil, err
+		}
+		if p.accept("by") {
+			se.by, err = p.parseArithmetic()
+			if err != nil {
+				return nil, err
+			}
+		}
+		return se, nil
+	}
+	ref, ok := e.(*mpRef)
+	if !ok || len(ref.subs) > 0 {
+		return nil, errors.Errorf("line %d: expected a set", line)
+	}
+	return &mpSetExpr{name: ref.name, line: line}, nil
+}
+
+// parseExpr Read a logical expression: or, and, not, then the comparisons of arithmetic expressions
+func (p *mpReader) parseExpr() (mpExpr, error) {
+	l, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.is("or") || p.is("||") {
+		line := p.next().line
+		r, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l = &mpBinary{op: "or", l: l, r: r, line: line}
+	}
+	return l, nil
+}
+
+func (p *mpReader) parseAnd() (mpExpr, error) {
+	l, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.is("and") || p.is("&&") {
+		line := p.next().line
+		r, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l = &mpBinary{op: "and", l: l, r: r, line: line}
+	}
+	return l, nil
+}
+
+func (p *mpReader) parseNot() (mpExpr, error) {
+	if p.accept("not") || p.accept("!") {
+		e, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &mpUnary{op: "not", e: e}, nil
+	}
+	l, err := p.parseArithmetic()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"<=", ">=", "==", "!=", "<>", "<", ">", "="} {
+		if p.is(op) {
+			line := p.next().line
+			r, err := p.parseArithmetic()
+			if err != nil {
+				return nil, err
+			}
+			return &mpBinary{op: op, l: l, r: r, line: line}, nil
+		}
+	}
+	return l, nil
+}
+
+// parseArithmetic Read a sum of terms
+func (p *mpReader) parseArithmetic() (mpExpr, error) {
+	l, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.is("+") || p.is("-") {
+		t := p.next()
+		r, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		l = &mpBinary{op: t.text, l: l, r: r, line: t.line}
+	}
+	return l, nil
+}
+
+// parseTerm Read a product of factors
+func (p *mpReader) parseTerm() (mpExpr, error) {
+	l, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.is("*") || p.is("/") || p.is("div") || p.is("mod") {
+		t := p.next()
+		r, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l = &mpBinary{op: t.text, l: l, r: r, line: t.line}
+	}
+	return l, nil
+}
+
+func (p *mpReader) parseUnary() (mpExpr, error) {
+	if p.is("-") || p.is("+") {
+		op := p.next().text
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &mpUnary{op: op, e: e}, nil
+	}
+	base, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if p.is("^") || p.is("**") {
+		line := p.next().line
+		exponent, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &mpBinary{op: "^", l: base, r: exponent, line: line}, nil
+	}
+	return base, nil
+}
+
+// parsePrimary Read a number, a string, a reference, an expression in parentheses or sum{indexing} term
+func (p *mpReader) parsePrimary() (mpExpr, error) {
+	t := p.peek(0)
+	switch {
+	case t.kind == mpNumber:
+		p.next()
+		return &mpNumberExpr{value: t.value}, nil
+	case t.kind == mpString:
+		p.next()
+		return &mpSymbolExpr{text: t.text}, nil
+	case p.accept("("):
+		e, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		return e, p.expect(")")
+	case t.kind == mpName && t.text == "sum" && p.peek(1).text == "{":
+		p.next()
+		idx, err := p.parseIndexing()
+		if err != nil {
+			return nil, err
+		}
+		body, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		return &mpSum{indexing: idx, body: body}, nil
+	case t.kind == mpName:
+		p.next()
+		ref := &mpRef{name: t.text, line: t.line}
+		if p.accept("[") {
+			for {
+				e, err := p.parseArithmetic()
+				if err != nil {
+					return nil, err
+				}
+				ref.subs = append(ref.subs, e)
+				if !p.accept(",") {
+					break
+				}
+			}
+			err := p.expect("]")
+			if err != nil {
+				return nil, err
+			}
+		}
+		return ref, nil
+	}
+	return nil, p.errorf("unexpected %q in an expression", t.text)
+}
+
+// dataValues Read the symbols and the numbers until the end of the statement, commas are ignored
+func (p *mpReader) dataValues() ([]mpToken, error) {
+	values := []mpToken{}
+	for !p.accept(";") {
+		t := p.next()
+		switch {
+		case t.kind == mpEOF:
+			return nil, errors.Errorf("line %d: missing ;", t.line)
+		case t.kind == mpPunct && t.text == ",":
+		case t.kind == mpPunct:
+			return nil, errors.Errorf("line %d: unexpected %q in the data", t.line, t.text)
+		default:
+			values = append(values, t)
+		}
+	}
+	return values, nil
+}
+
+// parseData Read the statements of a data section
+func (p *mpReader) parseData() error {
+	for {
+		t := p.next()
+		switch {
+		case t.kind == mpEOF:
+			return nil
+		case t.text == "end":
+			return p.skipEnd()
+		case t.text == "data":
+			err := p.expect(";")
+			if err != nil {
+				return err
+			}
+		case t.text == "set":
+			err := p.parseSetData()
+			if err != nil {
+				return err
+			}
+		case t.text == "param":
+			err := p.parseParamData()
+			if err != nil {
+				return err
+			}
+		default:
+			return errors.Errorf("line %d: unexpected %q in the data", t.line, t.text)
+		}
+	}
+}
+
+func (p *mpReader) parseSetData() error {
+	t := p.next()
+	set, ok := p.sets[t.text]
+	if !ok {
+		return errors.Errorf("line %d: set %s is not declared", t.line, t.text)
+	}
+	p.accept(":=")
+	values, err := p.dataValues()
+	if err != nil {
+		return err
+	}
+	set.data = []string{}
+	seen := map[string]bool{}
+	for _, v := range values {
+		if seen[v.text] {
+			return errors.Errorf("line %d: %s appears twice in set %s", v.line, v.text, t.text)
+		}
+		seen[v.text] = true
+		set.data = append(set.data, v.text)
+	}
+	set.assigned = true
+	return nil
+}
+
+// dataParam Parameter of the data section
+func (p *mpReader) dataParam(t mpToken) (*mpParam, error) {
+	param, ok := p.params[t.text]
+	if !ok {
+		return nil, errors.Errorf("line %d: param %s is not declared", t.line, t.text)
+	}
+	return param, nil
+}
+
+// setData Store the value of the parameter for the key, . is a missing value
+func setData(param *mpParam, key []string, value mpToken) error {
+	if value.kind == mpName && value.text == "." {
+		return nil
+	}
+	if value.kind != mpNumber {
+		return errors.Errorf("line %d: %s is not a number for param %s", value.line, value.text, param.name)
+	}
+	param.data[strings.Join(key, ",")] = value.value
+	return nil
+}
+
+// parseParamData Read param p [default v] := list; param p [default v] : columns := table; or param : p q := rows;
+func (p *mpReader) parseParamData() error {
+	if p.accept(":") {
+		return p.parseParamsData()
+	}
+	param, err := p.dataParam(p.next())
+	if err != nil {
+		return err
+	}
+	if p.accept("default") {
+		t := p.next()
+		if t.kind != mpNumber {
+			return errors.Errorf("line %d: the default of param %s must be a number", t.line, param.name)
+		}
+		param.dataDefault = &t.value
+	}
+	if p.accept(":") {
+		columns := []mpToken{}
+		for !p.accept(":=") {
+			t := p.next()
+			if t.kind == mpEOF || t.kind == mpPunct {
+				return errors.Errorf("line %d: expected the columns of param %s", t.line, param.name)
+			}
+			columns = append(columns, t)
+		}
+		if param.dims() != 2 {
+			return p.errorf("the table of param %s needs 2 dimensions", param.name)
+		}
+		values, err := p.dataValues()
+		if err != nil {
+			return err
+		}
+		if len(values)%(len(columns)+1) != 0 {
+			return p.errorf("the rows of param %s need %d values", param.name, len(columns))
+		}
+		for r := 0; r < len(values); r += len(columns) + 1 {
+			for c, column := range columns {
+				err := setData(param, []string{values[r].text, column.text}, values[r+1+c])
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	p.accept(":=")
+	values, err := p.dataValues()
+	if err != nil {
+		return err
+	}
+	d := param.dims()
+	if len(values)%(d+1) != 0 {
+		return p.errorf("the values of param %s need %d subscripts", param.name, d)
+	}
+	for k := 0; k < len(values); k += d + 1 {
+		key := make([]string, d)
+		for i := range key {
+			key[i] = values[k+i].text
+		}
+		err := setData(param, key, values[k+d])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseParamsData Read param : p q := key vp vq ...; the parameters share their subscripts
+func (p *mpReader) parseParamsData() error {
+	params := []*mpParam{}
+	for !p.accept(":=") {
+		param, err := p.dataParam(p.next())
+		if err != nil {
+			return err
+		}
+		params = append(params, param)
+	}
+	if len(params) == 0 {
+		return p.errorf("expected the names of the parameters")
+	}
+	d := params[0].dims()
+	values, err := p.dataValues()
+	if err != nil {
+		return err
+	}
+	width := d + len(params)
+	if len(values)%width != 0 {
+		return p.errorf("the rows need %d subscripts and %d values", d, len(params))
+	}
+	for r := 0; r < len(values); r += width {
+		key := make([]string, d)
+		for i := range key {
+			key[i] = values[r+i].text
+		}
+		for k, param := range params {
+			if param.dims() != d {
+				return p.errorf("param %s has %d dimensions, expected %d", param.name, param.dims(), d)
+			}
+			err := setData(param, key, values[r+d+k])
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dims Number of subscripts of the parameter
+func (param *mpParam) dims() int {
+	if param.domain == nil {
+		return 0
+	}
+	return len(param.domain.items)
+}
+
+// mpEnv Values of the dummy indices
+type mpEnv map[string]string
+
+// bind Copy of the environment with the dummy set to the symbol
+func (env mpEnv) bind(dummy, symbol string) mpEnv {
+	bound := mpEnv{}
+	for k, v := range env {
+		bound[k] = v
+	}
+	bound[dummy] = symbol
+	return bound
+}
+
+// setValues Elements of the set expression
+func (p *mpReader) setValues(se *mpSetExpr, env mpEnv) ([]string, error) {
+	switch {
+	case se.name != "":
+		set, ok := p.sets[se.name]
+		if !ok {
+			return nil, errors.Errorf("line %d: %s is not a set", se.line, se.name)
+		}
+		if set.assigned {
+			return set.data, nil
+		}
+		if set.value == nil {
+			return nil, errors.Errorf("line %d: set %s has no data", se.line, se.name)
+		}
+		if set.evaluating {
+			return nil, errors.Errorf("line %d: set %s is defined from itself", se.line, se.name)
+		}
+		set.evaluating = true
+		values, err := p.setValues(set.value, mpEnv{})
+		set.evaluating = false
+		if err != nil {
+			return nil, err
+		}
+		set.data, set.assigned = values, true
+		return values, nil
+	case se.literal:
+		values := []string{}
+		seen := map[string]bool{}
+		for _, e := range se.elements {
+			symbol, err := p.symbol(e, env)
+			if err != nil {
+				return nil, err
+			}
+			if !seen[symbol] {
+				seen[symbol] = true
+				values = append(values, symbol)
+			}
+		}
+		return values, nil
+	}
+	from, err := p.constant(se.from, env)
+	if err != nil {
+		return nil, err
+	}
+	to, err := p.constant(se.to, env)
+	if err != nil {
+		return nil, err
+	}
+	by := 1.0
+	if se.by != nil {
+		by, err = p.constant(se.by, env)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if by == 0 {
+		return nil, errors.Errorf("line %d: the step of a range must not be 0", se.line)
+	}
+	values := []string{}
+	for k := 0; ; k++ {
+		v := from + float64(k)*by
+		if (by > 0 && v > to+epsilon) || (by < 0 && v < to-epsilon) {
+			break
+		}
+		values = append(values, formatNumber(v))
+	}
+	return values, nil
+}
+
+// each Call f with the environment and the key of each member of the indexing expression, in the order of the sets
+func (p *mpReader) each(idx *mpIndexing, env mpEnv, f func(env mpEnv, key []string) error) error {
+	if idx == nil {
+		return f(env, nil)
+	}
+	var visit func(k int, env mpEnv, key []string) error
+	visit = func(k int, env mpEnv, key []string) error {
+		if k == len(idx.items) {
+			if idx.condition != nil {
+				ok, err := p.constant(idx.condition, env)
+				if err != nil || ok == 0 {
+					return err
+				}
+			}
+			return f(env, key)
+		}
+		values, err := p.setValues(idx.items[k].set, env)
+		if err != nil {
+			return err
+		}
+		for _, v := range values {
+			bound := env
+			if idx.items[k].dummy != "" {
+				bound = env.bind(idx.items[k].dummy, v)
+			}
+			err := visit(k+1, bound, append(append([]string(nil), key...), v))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return visit(0, env, nil)
+}
+
+// symbolic Check if the expression is a symbol: a string or a dummy index
+func symbolic(e mpExpr, env mpEnv) bool {
+	switch e := e.(type) {
+	case *mpSymbolExpr:
+		return true
+	case *mpRef:
+		_, ok := env[e.name]
+		return ok && len(e.subs) == 0
+	}
+	return false
+}
+
+// symbol Value of a subscript or of an element of a set, a number is formatted like formatNumber
+func (p *mpReader) symbol(e mpExpr, env mpEnv) (string, error) {
+	switch e := e.(type) {
+	case *mpSymbolExpr:
+		return e.text, nil
+	case *mpRef:
+		if symbol, ok := env[e.name]; ok && len(e.subs) == 0 {
+			return symbol, nil
+		}
+	}
+	v, err := p.constant(e, env)
+	if err != nil {
+		return "", err
+	}
+	return formatNumber(v), nil
+}
+
+// constant Value of an expression without variables
+func (p *mpReader) constant(e mpExpr, env mpEnv) (float64, error) {
+	value, err := p.linear(e, env)
+	if err != nil {
+		return 0, err
+	}
+	if len(value.Terms) > 0 {
+		return 0, errors.New("the expression must not contain variables")
+	}
+	return value.Constant, nil
+}
+
+// param Value of the parameter for the key
+func (p *mpReader) param(param *mpParam, key []string, line int) (float64, error) {
+	if len(key) != param.dims() {
+		return 0, errors.Errorf("line %d: param %s needs %d subscripts, got %d", line, param.name, param.dims(), len(key))
+	}
+	if v, ok := param.data[strings.Join(key, ",")]; ok {
+		return v, nil
+	}
+	expr := param.value
+	if expr == nil && param.dataDefault != nil {
+		return *param.dataDefault, nil
+	}
+	if expr == nil {
+		expr = param.defaultExpr
+	}
+	if expr == nil {
+		return 0, errors.Errorf("line %d: param %s[%s] has no value", line, param.name, strings.Join(key, ","))
+	}
+	env := mpEnv{}
+	if param.domain != nil {
+		for k, item := range param.domain.items {
+			if item.dummy != "" {
+				env = env.bind(item.dummy, key[k])
+			}
+		}
+	}
+	return p.constant(expr, env)
+}
+
+// linear Value of an expression, a linear expression of the variables
+func (p *mpReader) linear(e mpExpr, env mpEnv) (Expr, error) {
+	switch e := e.(type) {
+	case *mpNumberExpr:
+		return Expr{Constant: e.value}, nil
+	case *mpSymbolExpr:
+		v, err := strconv.ParseFloat(e.text, 64)
+		if err != nil {
+			return Expr{}, errors.Errorf("the symbol %s is not a number", e.text)
+		}
+		return Expr{Constant: v}, nil
+	case *mpRef:
+		key := make([]string, len(e.subs))
+		for k, sub := range e.subs {
+			symbol, err := p.symbol(sub, env)
+			if err != nil {
+				return Expr{}, err
+			}
+			key[k] = symbol
+		}
+		if symbol, ok := env[e.name]; ok && len(e.subs) == 0 {
+			v, err := strconv.ParseFloat(symbol, 64)
+			if err != nil {
+				return Expr{}, errors.Errorf("line %d: the symbol %s of %s is not a number", e.line, symbol, e.name)
+			}
+			return Expr{Constant: v}, nil
+		}
+		if param, ok := p.params[e.name]; ok {
+			v, err := p.param(param, key, e.line)
+			return Expr{Constant: v}, err
+		}
+		if v, ok := p.vars[e.name]; ok {
+			terms, ok := v.terms[strings.Join(key, ",")]
+			if !ok {
+				return Expr{}, errors.Errorf("line %d: %s[%s] is not a variable of the model", e.line, e.name, strings.Join(key, ","))
+			}
+			return Expr{Terms: append([]Term(nil), terms...)}, nil
+		}
+		return Expr{}, errors.Errorf("line %d: %s is not a parameter or a variable", e.line, e.name)
+	case *mpUnary:
+		value, err := p.linear(e.e, env)
+		if err != nil {
+			return Expr{}, err
+		}
+		switch e.op {
+		case "-":
+			return Expr{Terms: scaleTerms(value.Terms, -1), Constant: -value.Constant}, nil
+		case "not":
+			if len(value.Terms) > 0 {
+				return Expr{}, errors.New("not needs a constant")
+			}
+			return mpBool(value.Constant == 0), nil
+		}
+		return value, nil
+	case *mpSum:
+		total := Expr{}
+		err := p.each(e.indexing, env, func(env mpEnv, key []string) error {
+			value, err := p.linear(e.body, env)
+			if err != nil {
+				return err
+			}
+			total.Terms = append(total.Terms, value.Terms...)
+			total.Constant += value.Constant
+			return nil
+		})
+		return total, err
+	case *mpBinary:
+		return p.binary(e, env)
+	}
+	return Expr{}, errors.Errorf("unknown expression %T", e)
+}
+
+// mpBool 1 for true and 0 for false
+func mpBool(b bool) Expr {
+	if b {
+		return Expr{Constant: 1}
+	}
+	return Expr{}
+}
+
+// binary Value of an arithmetic, comparison or logical operation. = and != compare symbols when an operand is a symbol.
+func (p *mpReader) binary(e *mpBinary, env mpEnv) (Expr, error) {
+	if (e.op == "=" || e.op == "==" || e.op == "!=" || e.op == "<>") && (symbolic(e.l, env) || symbolic(e.r, env)) {
+		l, err := p.symbol(e.l, env)
+		if err != nil {
+			return Expr{}, err
+		}
+		r, err := p.symbol(e.r, env)
+		if err != nil {
+			return Expr{}, err
+		}
+		return mpBool((l == r) == (e.op == "=" || e.op == "==")), nil
+	}
+	l, err := p.linear(e.l, env)
+	if err != nil {
+		return Expr{}, err
+	}
+	r, err := p.linear(e.r, env)
+	if err != nil {
+		return Expr{}, err
+	}
+	switch e.op {
+	case "+":
+		return Expr{Terms: append(l.Terms, r.Terms...), Constant: l.Constant + r.Constant}, nil
+	case "-":
+		return Expr{Terms: append(l.Terms, scaleTerms(r.Terms, -1)...), Constant: l.Constant - r.Constant}, nil
+	case "*":
+		switch {
+		case len(l.Terms) == 0:
+			return Expr{Terms: scaleTerms(r.Terms, l.Constant), Constant: l.Constant * r.Constant}, nil
+		case len(r.Terms) == 0:
+			return Expr{Terms: scaleTerms(l.Terms, r.Constant), Constant: l.Constant * r.Constant}, nil
+		}
+		return Expr{}, errors.Errorf("line %d: the product of two variables is not linear", e.line)
+	}
+	if len(r.Terms) > 0 || (e.op != "/" && len(l.Terms) > 0) {
+		return Expr{}, errors.Errorf("line %d: %s needs constant operands", e.line, e.op)
+	}
+	switch e.op {
+	case "/":
+		if r.Constant == 0 {
+			return Expr{}, errors.Errorf("line %d: division by 0", e.line)
+		}
+		return Expr{Terms: scaleTerms(l.Terms, 1/r.Constant), Constant: l.Constant / r.Constant}, nil
+	case "div", "mod":
+		if r.Constant == 0 {
+			return Expr{}, errors.Errorf("line %d: division by 0", e.line)
+		}
+		if e.op == "div" {
+			return Expr{Constant: math.Trunc(l.Constant / r.Constant)}, nil
+		}
+		return Expr{Constant: l.Constant - r.Constant*math.Floor(l.Constant/r.Constant)}, nil
+	case "^":
+		return Expr{Constant: math.Pow(l.Constant, r.Constant)}, nil
+	case "<":
+		return mpBool(l.Constant < r.Constant), nil
+	case "<=":
+		return mpBool(l.Constant <= r.Constant), nil
+	case ">":
+		return mpBool(l.Constant > r.Constant), nil
+	case ">=":
+		return mpBool(l.Constant >= r.Constant), nil
+	case "=", "==":
+		return mpBool(l.Constant == r.Constant), nil
+	case "!=", "<>":
+		return mpBool(l.Constant != r.Constant), nil
+	case "and":
+		return mpBool(l.Constant != 0 && r.Constant != 0), nil
+	case "or":
+		return mpBool(l.Constant != 0 || r.Constant != 0), nil
+	}
+	return Expr{}, errors.Errorf("line %d: unknown operator %s", e.line, e.op)
+}
+
+// mpMember Name of the member key of a variable or a constraint
+func mpMember(name string, key []string) string {
+	if len(key) == 0 {
+		return name
+	}
+	return name + "[" + strings.Join(key, ",") + "]"
+}
+
+// build Create the variables, the objective and the constraints in the order of the model
+func (p *mpReader) build() (*Model, error) {
+	m := &Model{}
+	objective := false
+	for _, statement := range p.statements {
+		switch s := statement.(type) {
+		case *mpVar:
+			err := p.each(s.domain, mpEnv{}, func(env mpEnv, key []string) error {
+				return p.addVariable(m, s, env, key)
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "var %s", s.name)
+			}
+		case *mpObjective:
+			// Like GLPK the first objective is optimized
+			if objective {
+				continue
+			}
+			objective = true
+			e, err := p.linear(s.expr, mpEnv{})
+			if err != nil {
+				return nil, errors.Wrap(err, "objective")
+			}
+			if !s.maximize {
+				e = Expr{Terms: scaleTerms(e.Terms, -1), Constant: -e.Constant}
+			}
+			m.Maximize(e)
+		case *mpConstraint:
+			err := p.each(s.domain, mpEnv{}, func(env mpEnv, key []string) error {
+				return p.addConstraint(m, s, env, mpMember(s.name, key))
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "constraint %s", s.name)
+			}
+		}
+	}
+	return m, nil
+}
+
+// addVariable Add the member key of the variable with its bounds
+func (p *mpReader) addVariable(m *Model, v *mpVar, env mpEnv, key []string) error {
+	name := mpMember(v.name, key)
+	if v.binary {
+		v.terms[strings.Join(key, ",")] = []Term{{Var: m.AddBinary(name), Coef: 1}}
+		return nil
+	}
+	lower, upper := math.Inf(-1), math.Inf(1)
+	var err error
+	if v.lower != nil {
+		lower, err = p.constant(v.lower, env)
+		if err != nil {
+			return err
+		}
+	}
+	if v.upper != nil {
+		upper, err = p.constant(v.upper, env)
+		if err != nil {
+			return err
+		}
+	}
+	if lower > upper {
+		return errors.Errorf("%s has a lower bound above its upper bound", name)
+	}
+	terms := []Term{}
+	if lower >= 0 {
+		terms = append(terms, Term{Var: m.AddVariable(name, v.integer), Coef: 1})
+		// x >= 0 is implicit
+		if lower == 0 {
+			lower = math.Inf(-1)
+		}
+	} else {
+		terms = append(terms,
+			Term{Var: m.AddVariable(name+"+", v.integer), Coef: 1},
+			Term{Var: m.AddVariable(name+"-", v.integer), Coef: -1})
+	}
+	v.terms[strings.Join(key, ",")] = terms
+	return m.addInterval(name+".bound", Expr{Terms: terms}, lower, upper)
+}
+
+// addConstraint Add e1 rel e2, or the interval of e1 rel e2 rel e3 whose sides are constant
+func (p *mpReader) addConstraint(m *Model, c *mpConstraint, env mpEnv, name string) error {
+	exprs := make([]Expr, len(c.exprs))
+	for k, e := range c.exprs {
+		value, err := p.linear(e, env)
+		if err != nil {
+			return err
+		}
+		exprs[k] = value
+	}
+	if len(exprs) == 3 {
+		if len(exprs[0].Terms) > 0 || len(exprs[2].Terms) > 0 {
+			return errors.Errorf("line %d: the sides of a double inequality must be constant", c.line)
+		}
+		lower, upper := exprs[0].Constant, exprs[2].Constant
+		switch {
+		case c.rels[0] == "<=" && c.rels[1] == "<=":
+		case c.rels[0] == ">=" && c.rels[1] == ">=":
+			lower, upper = upper, lower
+		default:
+			return errors.Errorf("line %d: a double inequality needs two <= or two >=", c.line)
+		}
+		return m.addInterval(name, exprs[1], lower, upper)
+	}
+	e := Expr{Terms: append(exprs[0].Terms, scaleTerms(exprs[1].Terms, -1)...), Constant: exprs[0].Constant - exprs[1].Constant}
+	sense := Equal
+	switch c.rels[0] {
+	case "<=":
+		sense = LessEq
+	case ">=":
+		sense = GreaterEq
+	}
+	return m.AddNamedRow(name, e, sense, 0)
+}
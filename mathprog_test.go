@@ -0,0 +1,122 @@
+package goptimization
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testTransp Transportation problem of the GLPK examples
+const testTransp = `# A TRANSPORTATION PROBLEM
+set I;
+/* canning plants */
+set J;
+/* markets */
+param a{i in I};
+param b{j in J};
+param d{i in I, j in J};
+param f;
+param c{i in I, j in J} := f * d[i,j] / 1000;
+var x{i in I, j in J} >= 0;
+minimize cost: sum{i in I, j in J} c[i,j] * x[i,j];
+s.t. supply{i in I}: sum{j in J} x[i,j] <= a[i];
+s.t. demand{j in J}: sum{i in I} x[i,j] >= b[j];
+solve;
+display x;
+
+data;
+set I := Seattle San-Diego;
+set J := New-York Chicago Topeka;
+param a := Seattle 350
+           San-Diego 600;
+param b := New-York 325
+           Chicago 300
+           Topeka 275;
+param d :              New-York   Chicago   Topeka :=
+           Seattle     2.5        1.7       1.8
+           San-Diego   2.5        1.8       1.4  ;
+param f := 90;
+end;
+`
+
+func TestReadMathProg(t *testing.T) {
+	m, err := ReadMathProg(strings.NewReader(testTransp))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"x[Seattle,New-York]", "x[Seattle,Chicago]", "x[Seattle,Topeka]",
+		"x[San-Diego,New-York]", "x[San-Diego,Chicago]", "x[San-Diego,Topeka]",
+	}, m.Names())
+	assert.Equal(t, []string{
+		"supply[Seattle]", "supply[San-Diego]", "demand[New-York]", "demand[Chicago]", "demand[Topeka]",
+	}, m.RowNames())
+
+	solution, err := m.Solve(100)
+	require.NoError(t, err)
+	assert.InDelta(t, -153.675, solution.Score, 0.000001)
+	assert.InDelta(t, 300, solution.Value(1), 0.000001)
+	assert.InDelta(t, 275, solution.Value(5), 0.000001)
+}
+
+func TestReadMathProgData(t *testing.T) {
+	// The data in another reader, a MIP with bounds, free variables, conditions and a double inequality
+	m, err := ReadMathProg(strings.NewReader(`
+param n integer > 0;
+set K := 1..n;
+param w{K};
+param v{k in K} default 2 * w[k];
+param cap;
+var take{K} binary;
+var y integer, >= -3, <= 10;
+var z;
+maximize value: sum{k in K} v[k] * take[k] + y - z;
+weight: sum{k in K} w[k] * take[k] <= cap;
+s.t. pair{k in K : k < n and k mod 2 = 1}: take[k] + take[k + 1] <= 1;
+subject to link: -1 <= y - 2 * take[n] <= 2.5;
+free: z >= -4;
+end;
+`), strings.NewReader(`
+param n := 4;
+param : w v :=
+  1 3 .
+  2 4 5
+  3 2 .
+  4 5 1;
+param cap := 10;
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"take[1]", "take[2]", "take[3]", "take[4]", "y+", "y-", "z+", "z-"}, m.Names())
+	c, _, _, integer := m.Standard()
+	_, n := c.Dims()
+	assert.Equal(t, 8, n)
+	assert.Equal(t, []bool{true, true, true, true, true, true, false, false}, integer)
+	solution, err := m.Solve(1000)
+	require.NoError(t, err)
+	// v = 6 5 4 1, take[1] and take[3] with y = 2 and z = -4
+	assert.InDelta(t, 16, solution.Score, 0.000001)
+	assert.InDelta(t, 1, solution.Value(0), 0.000001)
+	assert.InDelta(t, 1, solution.Value(2), 0.000001)
+	assert.InDelta(t, 2, solution.Value(4)-solution.Value(5), 0.000001)
+}
+
+func TestReadMathProgErrors(t *testing.T) {
+	for _, model := range []string{
+		"var x >= 0; maximize z: x * x;",
+		"param p; var x; maximize z: p * x;",
+		"set I; var x{I}; data; set I := a a;",
+		"var x; var x;",
+		"var x >= 0; c: x;",
+		"var x >= 0; c: x <= 1 <= 2;",
+		"var x >= 0; c: x < 1;",
+		"var x{1..3}; c: x[4] <= 1;",
+		"param p{1..2}; var x; c: x <= p[1]; data; param p := 1 2 3;",
+		"var x >= 0 c: x <= 1;",
+		"/* unterminated",
+		"var x >= 0; c: x <= 'a';",
+		"data; param q := 1;",
+	} {
+		_, err := ReadMathProg(strings.NewReader(model))
+		assert.Error(t, err, model)
+	}
+}
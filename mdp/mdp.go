@@ -0,0 +1,257 @@
+// Package mdp Discounted Markov decision processes over an infinite horizon: in each state the agent chooses an action,
+// receives its reward and moves to a random next state. The optimal values and policy are computed by linear
+// programming with goptimization, by value iteration or by policy iteration.
+package mdp
+
+import (
+	"math"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// tolerance Tolerance on the probabilities of the transitions and on the ties between actions
+const tolerance = 1e-9
+
+// MDP Process with S states and A actions, all the actions are available in every state
+type MDP struct {
+	// Transitions Transitions[a] (S,S) is the probability to go from the state s to the state s' with the action a
+	Transitions []*mat.Dense
+	// Rewards (S,A) Reward of the action a in the state s
+	Rewards *mat.Dense
+	// Discount Discount factor of the future rewards, in [0, 1)
+	Discount float64
+}
+
+// Solution Optimal value of each state, the expected discounted sum of the rewards, and the action of each state
+type Solution struct {
+	Values     []float64
+	Policy     []int
+	Iterations int
+}
+
+// Validate Check the dimensions, the probabilities and the discount of the process
+func (p *MDP) Validate() error {
+	if p.Rewards == nil || len(p.Transitions) == 0 {
+		return errors.New("the process needs rewards and transitions")
+	}
+	S, A := p.Rewards.Dims()
+	if A != len(p.Transitions) {
+		return errors.Wrapf(goptimization.ErrDimensionMismatch, "Rewards dims.c = %d, expected %d actions", A, len(p.Transitions))
+	}
+	if p.Discount < 0 || p.Discount >= 1 {
+		return errors.Errorf("the discount %g must be in [0, 1)", p.Discount)
+	}
+	for a, P := range p.Transitions {
+		if P == nil {
+			return errors.Wrapf(goptimization.ErrDimensionMismatch, "the transitions of action %d are nil", a)
+		}
+		if r, c := P.Dims(); r != S || c != S {
+			return errors.Wrapf(goptimization.ErrDimensionMismatch, "Transitions[%d] dims must be (%d,%d), got (%d,%d)", a, S, S, r, c)
+		}
+		for s := 0; s < S; s++ {
+			total := 0.0
+			for next := 0; next < S; next++ {
+				if P.At(s, next) < 0 {
+					return errors.Errorf("negative probability from state %d to state %d with action %d", s, next, a)
+				}
+				total += P.At(s, next)
+			}
+			if math.Abs(total-1) > 1e-6 {
+				return errors.Errorf("the probabilities from state %d with action %d sum to %g", s, a, total)
+			}
+		}
+	}
+	return nil
+}
+
+// states Number of states
+func (p *MDP) states() int {
+	S, _ := p.Rewards.Dims()
+	return S
+}
+
+// q Value of the action a in the state s when the next states have the values v: r(s,a) + γ Σ(s') P(s'|s,a)*v(s')
+func (p *MDP) q(v []float64, s, a int) float64 {
+	expected := 0.0
+	for next, value := range v {
+		expected += p.Transitions[a].At(s, next) * value
+	}
+	return p.Rewards.At(s, a) + p.Discount*expected
+}
+
+// greedy Action of each state with the largest value of q, the action of current is kept on ties
+func (p *MDP) greedy(v []float64, current []int) []int {
+	policy := make([]int, len(v))
+	for s := range v {
+		best, value := 0, math.Inf(-1)
+		if current != nil {
+			best, value = current[s], p.q(v, s, current[s])
+		}
+		for a := range p.Transitions {
+			if q := p.q(v, s, a); q > value+tolerance {
+				best, value = a, q
+			}
+		}
+		policy[s] = best
+	}
+	return policy
+}
+
+// Evaluate Values of the states when the policy is followed, the solution of (I - γ P_π) v = r_π
+func (p *MDP) Evaluate(policy []int) ([]float64, error) {
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+	S := p.states()
+	if len(policy) != S {
+		return nil, errors.Wrapf(goptimization.ErrDimensionMismatch, "len(policy) must be %d, got %d", S, len(policy))
+	}
+	system := mat.NewDense(S, S, nil)
+	rewards := mat.NewVecDense(S, nil)
+	for s, a := range policy {
+		if a < 0 || a >= len(p.Transitions) {
+			return nil, errors.Errorf("unknown action %d in state %d", a, s)
+		}
+		for next := 0; next < S; next++ {
+			system.Set(s, next, -p.Discount*p.Transitions[a].At(s, next))
+		}
+		system.Set(s, s, system.At(s, s)+1)
+		rewards.SetVec(s, p.Rewards.At(s, a))
+	}
+	var v mat.VecDense
+	err = v.SolveVec(system, rewards)
+	if err != nil {
+		if _, ok := err.(mat.Condition); !ok {
+			return nil, err
+		}
+	}
+	return append([]float64(nil), v.RawVector().Data...), nil
+}
+
+// SolveLP Optimal policy from the dual linear program over the discounted occupancies x_s_a of the state-action pairs:
+// Maximize Σ(s,a) r(s,a)*x_s_a
+// Constraints:
+// s', Σ(a) x_s'_a - γ Σ(s,a) P(s'|s,a)*x_s_a = 1/S
+// x >= 0
+// Each state has a positive occupancy because every state starts with the probability 1/S, the policy takes the action
+// with the largest occupancy and the values come from Evaluate. maxIter is the maximum number of simplex iterations.
+// The options are those of goptimization.Simplex.
+func (p *MDP) SolveLP(maxIter int, opts ...goptimization.Option) (*Solution, error) {
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+	S, A := p.states(), len(p.Transitions)
+	m := &goptimization.Model{}
+	x := make([][]goptimization.Var, S)
+	objective := goptimization.Expr{}
+	for s := 0; s < S; s++ {
+		x[s] = make([]goptimization.Var, A)
+		for a := 0; a < A; a++ {
+			x[s][a] = m.AddVariable("", false)
+			objective.Terms = append(objective.Terms, goptimization.Term{Var: x[s][a], Coef: p.Rewards.At(s, a)})
+		}
+	}
+	m.Maximize(objective)
+	for next := 0; next < S; next++ {
+		flow := goptimization.Expr{}
+		for s := 0; s < S; s++ {
+			for a := 0; a < A; a++ {
+				coef := -p.Discount * p.Transitions[a].At(s, next)
+				if s == next {
+					coef++
+				}
+				if coef != 0 {
+					flow.Terms = append(flow.Terms, goptimization.Term{Var: x[s][a], Coef: coef})
+				}
+			}
+		}
+		err := m.AddRow(flow, goptimization.Equal, 1/float64(S))
+		if err != nil {
+			return nil, err
+		}
+	}
+	solution, err := m.Solve(maxIter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	policy := make([]int, S)
+	for s := 0; s < S; s++ {
+		for a := 1; a < A; a++ {
+			if solution.Value(x[s][a]) > solution.Value(x[s][policy[s]])+tolerance {
+				policy[s] = a
+			}
+		}
+	}
+	values, err := p.Evaluate(policy)
+	if err != nil {
+		return nil, err
+	}
+	return &Solution{Values: values, Policy: policy}, nil
+}
+
+// ValueIteration Apply v(s) = max(a) r(s,a) + γ Σ(s') P(s'|s,a)*v(s') from v = 0 until the largest change is below
+// epsilon*(1-γ)/(2γ), the values of the greedy policy are then within epsilon of the optimal values.
+// It returns ErrIterationLimit with the last values and their greedy policy after maxIter iterations.
+func (p *MDP) ValueIteration(epsilon float64, maxIter int) (*Solution, error) {
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+	S := p.states()
+	threshold := epsilon
+	if p.Discount > 0 {
+		threshold = epsilon * (1 - p.Discount) / (2 * p.Discount)
+	}
+	v := make([]float64, S)
+	for iter := 1; iter <= maxIter; iter++ {
+		next := make([]float64, S)
+		change := 0.0
+		for s := 0; s < S; s++ {
+			next[s] = math.Inf(-1)
+			for a := range p.Transitions {
+				next[s] = math.Max(next[s], p.q(v, s, a))
+			}
+			change = math.Max(change, math.Abs(next[s]-v[s]))
+		}
+		v = next
+		if change < threshold {
+			return &Solution{Values: v, Policy: p.greedy(v, nil), Iterations: iter}, nil
+		}
+	}
+	return &Solution{Values: v, Policy: p.greedy(v, nil), Iterations: maxIter}, goptimization.ErrIterationLimit
+}
+
+// PolicyIteration Evaluate the policy and replace it by its greedy policy until it does not change,
+// starting from the actions with the largest reward. A policy only changes when an action is strictly better,
+// so the iterations cannot cycle. It returns ErrIterationLimit with the last policy after maxIter iterations.
+func (p *MDP) PolicyIteration(maxIter int) (*Solution, error) {
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+	policy := p.greedy(make([]float64, p.states()), nil)
+	for iter := 1; iter <= maxIter; iter++ {
+		values, err := p.Evaluate(policy)
+		if err != nil {
+			return nil, err
+		}
+		improved := p.greedy(values, policy)
+		stable := true
+		for s := range policy {
+			stable = stable && improved[s] == policy[s]
+		}
+		if stable {
+			return &Solution{Values: values, Policy: policy, Iterations: iter}, nil
+		}
+		policy = improved
+	}
+	values, err := p.Evaluate(policy)
+	if err != nil {
+		return nil, err
+	}
+	return &Solution{Values: values, Policy: policy, Iterations: maxIter}, goptimization.ErrIterationLimit
+}
@@ -0,0 +1,123 @@
+package mdp
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"testing"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+// silent Logger of the solves
+var silent = goptimization.WithLogger(log.New(ioutil.Discard, "", 0))
+
+// machine A machine is good (0) or broken (1). Running a good machine earns 10 and breaks it with the probability
+// 0.3, running a broken machine earns 2. Repairing costs 5 and gives a good machine in the next period.
+func machine() *MDP {
+	return &MDP{
+		Transitions: []*mat.Dense{
+			mat.NewDense(2, 2, []float64{0.7, 0.3, 0, 1}),
+			mat.NewDense(2, 2, []float64{1, 0, 1, 0}),
+		},
+		Rewards:  mat.NewDense(2, 2, []float64{10, -5, 2, -5}),
+		Discount: 0.9,
+	}
+}
+
+// random Process with random transitions and rewards
+func random(S, A int, seed int64) *MDP {
+	rnd := rand.New(rand.NewSource(seed))
+	p := &MDP{Rewards: mat.NewDense(S, A, nil), Discount: 0.95}
+	for a := 0; a < A; a++ {
+		P := mat.NewDense(S, S, nil)
+		for s := 0; s < S; s++ {
+			total := 0.0
+			for next := 0; next < S; next++ {
+				P.Set(s, next, rnd.Float64())
+				total += P.At(s, next)
+			}
+			for next := 0; next < S; next++ {
+				P.Set(s, next, P.At(s, next)/total)
+			}
+			p.Rewards.Set(s, a, 10*rnd.Float64()-5)
+		}
+		p.Transitions = append(p.Transitions, P)
+	}
+	return p
+}
+
+func TestValidate(t *testing.T) {
+	p := machine()
+	assert.NoError(t, p.Validate())
+	p.Discount = 1
+	assert.Error(t, p.Validate())
+	p = machine()
+	p.Transitions[0].Set(0, 0, 0.5)
+	assert.Error(t, p.Validate())
+	p = machine()
+	p.Transitions = p.Transitions[:1]
+	assert.Error(t, p.Validate())
+}
+
+func TestMachine(t *testing.T) {
+	p := machine()
+	// Always run the good machine and repair the broken one:
+	// v0 = 10 + 0.9*(0.7*v0 + 0.3*v1), v1 = -5 + 0.9*v0
+	v0 := (10 - 0.27*5) / (1 - 0.63 - 0.243)
+	expected := []float64{v0, -5 + 0.9*v0}
+	lp, err := p.SolveLP(1000, silent)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, lp.Policy)
+	assert.InDeltaSlice(t, expected, lp.Values, 0.000001)
+
+	pi, err := p.PolicyIteration(100)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, pi.Policy)
+	assert.InDeltaSlice(t, expected, pi.Values, 0.000001)
+
+	vi, err := p.ValueIteration(0.000001, 10000)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, vi.Policy)
+	assert.InDeltaSlice(t, expected, vi.Values, 0.00001)
+
+	_, err = p.ValueIteration(0.000001, 5)
+	assert.Equal(t, goptimization.ErrIterationLimit, err)
+}
+
+func TestSolveLPOptions(t *testing.T) {
+	//The logger reaches the linear program, WithMaxIter overrides maxIter
+	var buf bytes.Buffer
+	lp, err := machine().SolveLP(1000, goptimization.WithLogger(log.New(&buf, "", 0)))
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, lp.Policy)
+	assert.NotZero(t, buf.Len())
+	_, err = machine().SolveLP(1000, silent, goptimization.WithMaxIter(1))
+	assert.Equal(t, goptimization.ErrIterationLimit, errors.Cause(err))
+}
+
+func TestMethods(t *testing.T) {
+	for seed := int64(0); seed < 5; seed++ {
+		p := random(8, 3, seed)
+		lp, err := p.SolveLP(10000, silent)
+		require.NoError(t, err)
+		pi, err := p.PolicyIteration(100)
+		require.NoError(t, err)
+		vi, err := p.ValueIteration(0.000001, 100000)
+		require.NoError(t, err)
+		assert.InDeltaSlice(t, pi.Values, lp.Values, 0.000001)
+		assert.InDeltaSlice(t, pi.Values, vi.Values, 0.00001)
+
+		// No action improves the optimal values
+		for s := range pi.Values {
+			for a := range p.Transitions {
+				assert.LessOrEqual(t, p.q(pi.Values, s, a), pi.Values[s]+0.000001)
+			}
+		}
+	}
+}
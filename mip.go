@@ -0,0 +1,651 @@
+package goptimization
+
+import (
+	"math"
+	"runtime"
+	"time"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// integerTolerance Distance to the closest integer under which a value is considered integral
+const integerTolerance = 1e-6
+
+// MIP Solve a mixed integer linear problem with the branch and cut algorithm.
+// Input follows the standard form of Simplex, the variables flagged in integer must take integer values.
+// b can be negative, a phase one then finds a feasible basis for the root.
+// It returns the number of explored nodes, the best integer solution and its score, maxNodes <= 0 means unlimited.
+// The options WithMaxIter (per node), WithTimeLimit, WithContext, WithThreads, WithMIPGap and WithLogger configure the search.
+func MIP(c, A, b *mat.Dense, integer []bool, maxNodes int, opts ...Option) (int, *mat.Dense, float64, error) {
+	bb, err := newMIP(c, A, b, integer, opts)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	results, score, err := bb.Solve(maxNodes)
+	if err != nil {
+		return bb.Nodes, nil, 0, err
+	}
+	return bb.Nodes, results, score, nil
+}
+
+// newMIP Search of the problem configured by the options of MIP
+func newMIP(c, A, b *mat.Dense, integer []bool, opts []Option) (*BranchAndBound, error) {
+	bb := &BranchAndBound{}
+	err := bb.New(c, A, b, integer)
+	if err != nil {
+		return nil, err
+	}
+	bb.Configure(opts...)
+	return bb, nil
+}
+
+// Configure Apply the options of MIP to the search after New, e.g. before adding heuristics or lazy constraints
+func (bb *BranchAndBound) Configure(opts ...Option) {
+	o := newOptions(opts)
+	if o.maxIter > 0 {
+		bb.MaxIter = o.maxIter
+	}
+	if o.timeLimit > 0 {
+		bb.Deadline = time.Now().Add(o.timeLimit)
+	}
+	if o.threads > 0 {
+		bb.Threads = o.threads
+	}
+	bb.Gap = o.mipGap
+	if o.ctx != nil {
+		bb.Interrupt = o.ctx.Done()
+	}
+	// The children copy the logger of the root
+	bb.root.cf.logger = o.logger
+}
+
+// BranchAndBound Branch and cut search for mixed integer linear problems
+// Maximize z = Σ(1<=j<=n) c_j*x_j
+// Constraints:
+// 1<=i<=m,  Σ(1<=j<=n) a_i_j*x_j <= b_i
+// 1<=j<=n x_j >= 0, x_j integer if integer[j]
+// - Probe the binary variables to fix some of them and find conflicts between them
+// - Solve the LP relaxation of the root node with the simplex algorithm
+// - Tighten the relaxation of each node with rounds of cuts (Gomory mixed-integer, cover, clique and user callbacks)
+// - Branch on the most fractional integer variable, then on the semi-continuous variables
+// and the violated special ordered sets,
+// the children start from the dictionary of their parent
+// and are re-optimized with the dual simplex
+// - Prune the nodes which are infeasible or whose bound is not better than the incumbent
+type BranchAndBound struct {
+	// Number of original variables and constraints
+	n int
+	m int
+
+	// Original problem, the binary variables and their conflicts for the separators
+	c         *mat.Dense
+	A         *mat.Dense
+	b         *mat.Dense
+	integer   []bool
+	binary    []bool
+	conflicts *conflictGraph
+
+	root *node
+
+	// MaxIter Maximum number of simplex iterations to solve the relaxation of a node
+	MaxIter int
+	// CutRounds Number of rounds of cuts at each node, 0 disables the cuts
+	CutRounds int
+	// MaxCuts Maximum number of cuts returned by each separator in a round
+	MaxCuts int
+	// Separators Cut generators run at each round, Gomory, cover and clique cuts by default
+	Separators []CutSeparator
+	// Lazy Constraints too many to be written in A, checked on the integer solutions only, see addLazy
+	Lazy LazyConstraints
+	// semi Semi-continuous and semi-integer variables
+	semi []semiContinuous
+	// sets Special ordered sets
+	sets []SOS
+
+	// Heuristics Primal heuristics run at the root and then every HeuristicFrequency nodes,
+	// rounding, diving and the feasibility pump by default
+	Heuristics         []Heuristic
+	HeuristicFrequency int
+
+	// Gap Relative gap: a node whose bound does not improve the incumbent by more than Gap*|incumbent| is pruned,
+	// 0 keeps the search exact
+	Gap float64
+	// Deadline, MaxBytes Stop the search with the incumbent once the deadline is passed or the heap exceeds MaxBytes,
+	// the zero values disable them
+	Deadline time.Time
+	MaxBytes uint64
+	// Interrupt Stop the search with the incumbent once the channel is closed, nil disables it
+	Interrupt <-chan struct{}
+	// Threads Number of nodes solved concurrently, 1 by default. The search is deterministic for a given Threads,
+	// see exploreParallel. With more than one thread the separators and the heuristics must be safe for concurrent use.
+	Threads int
+	// BestBound Upper bound on the optimum, see MIPProgress
+	BestBound float64
+	// Progress Called with the incumbent, the bound and the gap after each explored node,
+	// or after each round of nodes with more than one thread
+	Progress func(p MIPProgress)
+	// Stopped Reason why the search stopped before the tree was empty: "nodes", "time", "memory"
+	// or "interrupted", empty otherwise
+	Stopped string
+
+	// RecordTree Record the search tree for Tree, e.g. to draw it with WriteDOT or WriteHTML
+	RecordTree bool
+	tree       *SearchNode
+
+	// Probing Probe the binary variables before solving the root, see presolveProbing
+	Probing bool
+	// Fixed, Implications Number of binary variables fixed and of conflicts found by the probing
+	Fixed        int
+	Implications int
+
+	// Nodes Number of explored nodes
+	Nodes int
+	// Cuts Number of cuts added to the relaxations
+	Cuts int
+	// Incumbents Integer solutions which improved the score, in the order they were found
+	Incumbents []Incumbent
+
+	// CheckpointFile, CheckpointFrequency Write the state of the search to the file every CheckpointFrequency nodes,
+	// 100 by default, and when a limit stops the search, see WriteCheckpoint. An empty file disables the checkpoints.
+	CheckpointFile      string
+	CheckpointFrequency int
+	// started The root is solved, open Nodes left by the last exploration, checkpointed Nodes at the last checkpoint
+	started      bool
+	open         []*node
+	checkpointed int
+
+	incumbent []float64
+	score     float64
+}
+
+// node Subproblem of the search tree
+type node struct {
+	cf *CanonicalForm
+	// Integrality of each variable, indexed like the columns of the canonical form
+	integer []bool
+	depth   int
+	// bound Score of the relaxation when the node was created
+	bound float64
+	// entry Node of the recorded search tree, nil when the tree is not recorded
+	entry *SearchNode
+}
+
+// New Initialize the root node of the search tree
+func (bb *BranchAndBound) New(c, A, b *mat.Dense, integer []bool) error {
+	_, n := c.Dims()
+	if len(integer) != n {
+		return newError(ErrDimensionMismatch, "len(integer) != z dims.c")
+	}
+	cf := &CanonicalForm{}
+	err := cf.New(mat.DenseCopyOf(c), mat.DenseCopyOf(A), mat.DenseCopyOf(b))
+	if err != nil {
+		return err
+	}
+	bb.n = cf.n
+	bb.m = cf.m
+
+	//A slack variable is integer when its constraint only involves integer variables with integer coefficients
+	nodeInteger := make([]bool, cf.n+cf.m)
+	copy(nodeInteger, integer)
+	for i := 0; i < cf.m; i++ {
+		slackInteger := isIntegral(b.At(i, 0))
+		for j := 0; j < cf.n && slackInteger; j++ {
+			if A.At(i, j) != 0 && (!integer[j] || !isIntegral(A.At(i, j))) {
+				slackInteger = false
+			}
+		}
+		nodeInteger[cf.n+i] = slackInteger
+	}
+
+	bb.c = mat.DenseCopyOf(c)
+	bb.A = mat.DenseCopyOf(A)
+	bb.b = mat.DenseCopyOf(b)
+	bb.integer = append([]bool(nil), integer...)
+	bb.binary = binaries(A, b, integer)
+	bb.conflicts = newConflictGraph(A, b, bb.binary)
+
+	bb.root = &node{cf: cf, integer: nodeInteger}
+	bb.MaxIter = 1000
+	bb.CutRounds = 5
+	bb.MaxCuts = 10
+	bb.Separators = []CutSeparator{GomorySeparator{}, CoverSeparator{}, CliqueSeparator{}}
+	bb.Lazy = nil
+	bb.Heuristics = []Heuristic{RoundingHeuristic{}, DivingHeuristic{}, FeasibilityPump{}}
+	bb.HeuristicFrequency = 10
+	bb.Gap = 0
+	bb.Deadline = time.Time{}
+	bb.MaxBytes = 0
+	bb.Interrupt = nil
+	bb.Threads = 1
+	bb.BestBound = math.Inf(1)
+	bb.Stopped = ""
+	bb.RecordTree = false
+	bb.tree = nil
+	bb.Probing = true
+	bb.Fixed = 0
+	bb.Implications = 0
+	bb.Nodes = 0
+	bb.Cuts = 0
+	bb.Incumbents = nil
+	bb.CheckpointFile = ""
+	bb.CheckpointFrequency = 100
+	bb.started = false
+	bb.open = nil
+	bb.checkpointed = 0
+	bb.semi = nil
+	bb.sets = nil
+	bb.incumbent = nil
+	bb.score = math.Inf(-1)
+	return nil
+}
+
+// Solve Explore the search tree depth first until it is empty or maxNodes nodes have been explored.
+// maxNodes <= 0 means unlimited up to the safeguard of iterationLimit, 100 nodes per variable and at least 1000.
+// It returns the best integer solution, a matrix (n+m,1) like Simplex, and its score.
+func (bb *BranchAndBound) Solve(maxNodes int) (*mat.Dense, float64, error) {
+	if bb.root == nil {
+		return nil, 0, errors.New("branch and bound is not initialized")
+	}
+
+	var err error
+	if bb.Probing {
+		err = bb.presolveProbing()
+	}
+	// The root is solved from the slack basis, its children from the dictionary of their parent
+	if err == nil {
+		_, err = bb.root.cf.phaseOne(bb.MaxIter)
+	}
+	if err == nil {
+		_, err = bb.root.cf.Reoptimize(bb.MaxIter)
+	}
+	if err != nil && err != ErrInfeasible {
+		return nil, 0, err
+	}
+	stack := []*node{}
+	if err == nil {
+		_, bb.root.bound = bb.root.cf.values()
+		stack = append(stack, bb.root)
+		bb.recordRoot(bb.root)
+	} else {
+		bb.recordRoot(nil)
+	}
+	bb.started = true
+	bb.report(stack)
+	return bb.search(stack, maxNodes)
+}
+
+// search Explore the open nodes and return the incumbent like Solve
+func (bb *BranchAndBound) search(stack []*node, maxNodes int) (*mat.Dense, float64, error) {
+	maxNodes = iterationLimit(maxNodes, bb.n, bb.m)
+	var err error
+	if bb.Threads > 1 {
+		stack, err = bb.exploreParallel(stack, maxNodes)
+	} else {
+		stack, err = bb.explore(stack, maxNodes)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	bb.open = stack
+	if bb.Stopped != "" && bb.CheckpointFile != "" {
+		err = bb.checkpoint()
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if bb.incumbent == nil {
+		if len(stack) > 0 {
+			return nil, 0, newError(ErrIterationLimit, "no integer solution found within the %s limit", bb.Stopped)
+		}
+		return nil, 0, ErrInfeasible
+	}
+	return mat.NewDense(bb.n+bb.m, 1, bb.incumbent[:bb.n+bb.m]), bb.score, nil
+}
+
+// explore Explore the search tree depth first, one node at a time.
+// It returns the nodes left when a limit stopped the search.
+func (bb *BranchAndBound) explore(stack []*node, maxNodes int) ([]*node, error) {
+	for len(stack) > 0 {
+		bb.Stopped = bb.stopReason(maxNodes)
+		if bb.Stopped != "" {
+			break
+		}
+		nd := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		bb.Nodes++
+
+		children, err := bb.process(nd)
+		if err != nil {
+			return nil, err
+		}
+		stack = append(stack, children...)
+		if bb.report(stack) {
+			stack = nil
+		}
+		err = bb.periodicCheckpoint(stack)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stack, nil
+}
+
+// process Tighten the relaxation of an optimal node with cuts, then update the incumbent or branch.
+// It returns the children to explore.
+func (bb *BranchAndBound) process(nd *node) ([]*node, error) {
+	if nd.entry != nil {
+		nd.entry.Explored = bb.Nodes
+	}
+	values, score := nd.cf.values()
+	if bb.cannotImprove(score) {
+		nd.mark("bound")
+		return nil, nil
+	}
+
+	for round := 0; round < bb.CutRounds; round++ {
+		added, err := bb.addCuts(nd)
+		if err != nil {
+			return nil, err
+		}
+		if added == 0 {
+			break
+		}
+		_, err = nd.cf.Reoptimize(bb.MaxIter)
+		if err == ErrInfeasible {
+			nd.mark("infeasible")
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		previous := score
+		values, score = nd.cf.values()
+		if bb.cannotImprove(score) {
+			nd.mark("bound")
+			return nil, nil
+		}
+		// Stop when the cuts do not move the bound anymore
+		if previous-score <= epsilon {
+			break
+		}
+	}
+
+	if bb.HeuristicFrequency > 0 && (bb.Nodes-1)%bb.HeuristicFrequency == 0 {
+		err := bb.runHeuristics(nd, values)
+		if err != nil {
+			return nil, err
+		}
+		if bb.cannotImprove(score) {
+			nd.mark("bound")
+			return nil, nil
+		}
+	}
+
+	branchVar := -1
+	maxFrac := 0.0
+	for j := 0; j < bb.n; j++ {
+		if !nd.integer[j] {
+			continue
+		}
+		frac := math.Abs(values[j] - math.Round(values[j]))
+		if frac > integerTolerance && frac > maxFrac {
+			maxFrac = frac
+			branchVar = j
+		}
+	}
+
+	if branchVar == -1 {
+		children, violated, err := bb.branchSemiContinuous(nd, values)
+		if err != nil || violated {
+			nd.mark("branched")
+			return children, err
+		}
+		children, violated, err = bb.branchSOS(nd, values)
+		if err != nil || violated {
+			nd.mark("branched")
+			return children, err
+		}
+		children, violated, err = bb.addLazy(nd, values)
+		if err != nil || violated {
+			return children, err
+		}
+		// The relaxation is integral and satisfies the semi-continuous domains, the special ordered sets
+		// and the lazy constraints, it is the new incumbent
+		bb.updateIncumbent(values[:bb.n+bb.m], score, "relaxation")
+		nd.mark("integral")
+		return nil, nil
+	}
+
+	down, err := bb.branch(nd, []bound{{j: branchVar, sign: 1, rhs: math.Floor(values[branchVar])}})
+	if err != nil {
+		return nil, err
+	}
+	up, err := bb.branch(nd, []bound{{j: branchVar, sign: -1, rhs: -math.Ceil(values[branchVar])}})
+	if err != nil {
+		return nil, err
+	}
+
+	// The last child is explored first, pick the one in the rounding direction
+	children := []*node{}
+	for _, child := range []*node{up, down} {
+		if child != nil {
+			children = append(children, child)
+		}
+	}
+	if len(children) == 2 && values[branchVar]-math.Floor(values[branchVar]) > 0.5 {
+		children[0], children[1] = children[1], children[0]
+	}
+	nd.mark("branched")
+	return children, nil
+}
+
+// bound Constraint sign*x_j <= rhs added to a child node
+type bound struct {
+	j    int
+	sign float64
+	rhs  float64
+}
+
+// branch Create a child of nd with the bounds and re-optimize it.
+// It returns nil if the child is infeasible or cannot improve the incumbent.
+func (bb *BranchAndBound) branch(nd *node, bounds []bound) (*node, error) {
+	child := &node{
+		cf:      nd.cf.Clone(),
+		integer: append([]bool(nil), nd.integer...),
+		depth:   nd.depth + 1,
+	}
+	for _, bd := range bounds {
+		a := make([]float64, child.cf.n+child.cf.m)
+		a[bd.j] = bd.sign
+		err := child.cf.AddConstraint(a, bd.rhs)
+		if err != nil {
+			return nil, err
+		}
+		child.integer = append(child.integer, child.integer[bd.j] && isIntegral(bd.rhs))
+	}
+	_, err := child.cf.Reoptimize(bb.MaxIter)
+	if err == ErrInfeasible {
+		bb.recordChild(nd, bounds, nil, "infeasible", math.Inf(-1))
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	_, score := child.cf.values()
+	if bb.cannotImprove(score) {
+		bb.recordChild(nd, bounds, nil, "bound", score)
+		return nil, nil
+	}
+	child.bound = score
+	bb.recordChild(nd, bounds, child, "open", score)
+	return child, nil
+}
+
+// addCuts Run the separators on the relaxation of the node and add the violated cuts.
+// It returns the number of cuts added to the node.
+func (bb *BranchAndBound) addCuts(nd *node) (int, error) {
+	cf := nd.cf
+	values, _ := cf.values()
+	s := &Separation{
+		X:         values,
+		Integer:   nd.integer,
+		Binary:    bb.binary,
+		A:         bb.A,
+		B:         bb.b,
+		Depth:     nd.depth,
+		MaxCuts:   bb.MaxCuts,
+		cf:        cf,
+		conflicts: bb.conflicts,
+	}
+
+	// All the cuts are computed from the current dictionary before being added
+	cuts := []Cut{}
+	for _, separator := range bb.Separators {
+		separated, err := separator.Separate(s)
+		if err != nil {
+			return 0, err
+		}
+		for _, cut := range separated {
+			if len(cut.A) > cf.n+cf.m {
+				return 0, newError(ErrDimensionMismatch, "len(cut.A) > number of variables")
+			}
+			activity := 0.0
+			for j, a := range cut.A {
+				activity += a * values[j]
+			}
+			if activity-cut.RHS > violationTolerance {
+				cuts = append(cuts, cut)
+			}
+		}
+	}
+
+	for _, cut := range cuts {
+		err := bb.addCut(nd, cut)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(cuts), nil
+}
+
+// addCut Add the cut to the relaxation of the node
+func (bb *BranchAndBound) addCut(nd *node, cut Cut) error {
+	cf := nd.cf
+	a := append(append([]float64(nil), cut.A...), make([]float64, cf.n+cf.m-len(cut.A))...)
+	err := cf.AddConstraint(a, cut.RHS)
+	if err != nil {
+		return err
+	}
+	//The slack variable of the cut is integer when the cut only involves integer variables with integer coefficients
+	slackInteger := isIntegral(cut.RHS)
+	for j := range a {
+		if a[j] != 0 && (!nd.integer[j] || !isIntegral(a[j])) {
+			slackInteger = false
+		}
+	}
+	nd.integer = append(nd.integer, slackInteger)
+	bb.Cuts++
+	return nil
+}
+
+// cannotImprove Check if a node with the bound cannot improve the incumbent by more than the gap
+func (bb *BranchAndBound) cannotImprove(bound float64) bool {
+	if bb.incumbent == nil {
+		return false
+	}
+	return bound <= bb.score+epsilon+bb.Gap*math.Abs(bb.score)
+}
+
+// stopReason Check the limits of the search, the root is always explored and the heap is measured every 100 nodes
+func (bb *BranchAndBound) stopReason(maxNodes int) string {
+	if bb.Nodes >= maxNodes {
+		return "nodes"
+	}
+	if bb.Nodes == 0 {
+		return ""
+	}
+	if !bb.Deadline.IsZero() && time.Now().After(bb.Deadline) {
+		return "time"
+	}
+	select {
+	case <-bb.Interrupt:
+		return "interrupted"
+	default:
+	}
+	if bb.MaxBytes > 0 && bb.Nodes%100 == 0 {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		if stats.HeapAlloc > bb.MaxBytes {
+			return "memory"
+		}
+	}
+	return ""
+}
+
+// runHeuristics Look for a better incumbent from the relaxation of the node
+func (bb *BranchAndBound) runHeuristics(nd *node, values []float64) error {
+	for _, heuristic := range bb.Heuristics {
+		h := &HeuristicContext{
+			X:         values,
+			Integer:   bb.integer,
+			C:         bb.c,
+			A:         bb.A,
+			B:         bb.b,
+			Incumbent: bb.score,
+			MaxIter:   bb.MaxIter,
+			cf:        nd.cf,
+		}
+		x, ok, err := heuristic.Run(h)
+		if err != nil {
+			return err
+		}
+		if !ok || len(x) != bb.n || !feasible(bb.A, bb.b, x) {
+			continue
+		}
+		solution := make([]float64, bb.n+bb.m)
+		score := 0.0
+		integral := true
+		for j := 0; j < bb.n; j++ {
+			solution[j] = x[j]
+			score += bb.c.At(0, j) * x[j]
+			integral = integral && (!bb.integer[j] || isIntegral(x[j]))
+		}
+		if !integral || !bb.satisfiesSemiContinuous(x) || !bb.satisfiesSOS(x) || score <= bb.score+epsilon {
+			continue
+		}
+		satisfied, err := bb.satisfiesLazy(x)
+		if err != nil {
+			return err
+		}
+		if !satisfied {
+			continue
+		}
+		for i := 0; i < bb.m; i++ {
+			solution[bb.n+i] = bb.b.At(i, 0)
+			for j := 0; j < bb.n; j++ {
+				solution[bb.n+i] -= bb.A.At(i, j) * x[j]
+			}
+		}
+		bb.updateIncumbent(solution, score, heuristic.Name())
+	}
+	return nil
+}
+
+// updateIncumbent Store a better integer solution, indexed like the results of Solve
+func (bb *BranchAndBound) updateIncumbent(solution []float64, score float64, source string) {
+	bb.incumbent = solution
+	bb.score = score
+	bb.Incumbents = append(bb.Incumbents, Incumbent{Score: score, Source: source, Node: bb.Nodes})
+}
+
+// RegisterCutCallback Add a user defined separator called at each round of cuts
+func (bb *BranchAndBound) RegisterCutCallback(callback func(s *Separation) ([]Cut, error)) {
+	bb.Separators = append(bb.Separators, CutCallback(callback))
+}
+
+// isIntegral Check if v is an integer up to integerTolerance
+func isIntegral(v float64) bool {
+	return math.Abs(v-math.Round(v)) <= integerTolerance
+}
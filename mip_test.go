@@ -0,0 +1,122 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestMIP(t *testing.T) {
+	c := mat.NewDense(1, 2, []float64{5, 4})
+	A := mat.NewDense(2, 2, []float64{
+		6, 4,
+		1, 2,
+	})
+	b := mat.NewDense(2, 1, []float64{24, 6})
+
+	_, results, score, err := MIP(c, A, b, []bool{true, true}, 100)
+	require.NoError(t, err)
+	assert.True(t, mat.EqualApprox(mat.NewDense(4, 1, []float64{4, 0, 0, 2}), results, 0.000001))
+	assert.InEpsilon(t, 20.0, score, 0.000001)
+}
+
+func TestMIPUnlimitedNodes(t *testing.T) {
+	c := mat.NewDense(1, 2, []float64{5, 4})
+	A := mat.NewDense(2, 2, []float64{
+		6, 4,
+		1, 2,
+	})
+	b := mat.NewDense(2, 1, []float64{24, 6})
+
+	//maxNodes <= 0 explores the tree up to the safeguard of iterationLimit
+	nodes, results, score, err := MIP(c, A, b, []bool{true, true}, 0, silent)
+	require.NoError(t, err)
+	assert.True(t, nodes > 0)
+	assert.True(t, mat.EqualApprox(mat.NewDense(4, 1, []float64{4, 0, 0, 2}), results, 0.000001))
+	assert.InEpsilon(t, 20.0, score, 0.000001)
+
+	//Model.Solve gives its maxIter to MIP as maxNodes
+	m := &Model{}
+	x := m.AddVariable("x", true)
+	y := m.AddVariable("y", true)
+	m.Maximize(Expr{Terms: []Term{{x, 5}, {y, 4}}})
+	require.NoError(t, m.AddConstraint(Expr{Terms: []Term{{x, 6}, {y, 4}}}, 24))
+	require.NoError(t, m.AddConstraint(Expr{Terms: []Term{{x, 1}, {y, 2}}}, 6))
+	solution, err := m.Solve(0, silent)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 20.0, solution.Score, 0.000001)
+}
+
+func TestMIPKnapsack(t *testing.T) {
+	c := mat.NewDense(1, 4, []float64{8, 11, 6, 4})
+	A := mat.NewDense(5, 4, []float64{
+		5, 7, 4, 3,
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1,
+	})
+	b := mat.NewDense(5, 1, []float64{14, 1, 1, 1, 1})
+
+	_, results, score, err := MIP(c, A, b, []bool{true, true, true, true}, 100)
+	require.NoError(t, err)
+	assert.True(t, mat.EqualApprox(mat.NewDense(1, 4, []float64{0, 1, 1, 1}), results.Slice(0, 4, 0, 1).T(), 0.000001))
+	assert.InEpsilon(t, 21.0, score, 0.000001)
+}
+
+func TestMIPMixed(t *testing.T) {
+	c := mat.NewDense(1, 2, []float64{3, 2.5})
+	A := mat.NewDense(2, 2, []float64{
+		4, 2,
+		1, 2,
+	})
+	b := mat.NewDense(2, 1, []float64{15, 8})
+
+	_, results, score, err := MIP(c, A, b, []bool{true, false}, 100)
+	require.NoError(t, err)
+	assert.True(t, mat.EqualApprox(mat.NewDense(4, 1, []float64{2, 3, 1, 0}), results, 0.000001))
+	assert.InEpsilon(t, 13.5, score, 0.000001)
+}
+
+func TestBranchAndBoundCuts(t *testing.T) {
+	c := mat.NewDense(1, 3, []float64{4, 5, 3})
+	A := mat.NewDense(3, 3, []float64{
+		3, 4, 2,
+		2, 1, 3,
+		1, 3, 2,
+	})
+	b := mat.NewDense(3, 1, []float64{10, 9, 8})
+	integer := []bool{true, true, true}
+
+	withoutCuts := BranchAndBound{}
+	err := withoutCuts.New(c, A, b, integer)
+	require.NoError(t, err)
+	withoutCuts.CutRounds = 0
+	_, expected, err := withoutCuts.Solve(1000)
+	require.NoError(t, err)
+	assert.Equal(t, 0, withoutCuts.Cuts)
+
+	withCuts := BranchAndBound{}
+	err = withCuts.New(c, A, b, integer)
+	require.NoError(t, err)
+	results, score, err := withCuts.Solve(1000)
+	require.NoError(t, err)
+	assert.True(t, withCuts.Cuts > 0)
+	assert.True(t, withCuts.Nodes <= withoutCuts.Nodes)
+	assert.InEpsilon(t, expected, score, 0.000001)
+	assert.InEpsilon(t, 13.0, score, 0.000001)
+	for j := 0; j < 3; j++ {
+		assert.True(t, isIntegral(results.At(j, 0)))
+	}
+}
+
+func TestMIPNegativeB(t *testing.T) {
+	c := mat.NewDense(1, 2, []float64{1, 1})
+	A := mat.NewDense(1, 2, []float64{1, 1})
+	b := mat.NewDense(1, 1, []float64{-1})
+
+	_, _, _, err := MIP(c, A, b, []bool{true, true}, 100)
+	require.Error(t, err)
+}
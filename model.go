@@ -0,0 +1,346 @@
+package goptimization
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Var Variable of a Model, x >= 0 unless it is added with AddFreeVariable
+type Var int
+
+// Term Coefficient of a variable in a linear expression
+type Term struct {
+	Var  Var
+	Coef float64
+}
+
+// Expr Linear expression Σ Coef*Var + Constant
+type Expr struct {
+	Terms    []Term
+	Constant float64
+}
+
+// Expr Expression made of the variable alone
+func (v Var) Expr() Expr {
+	return Expr{Terms: []Term{{Var: v, Coef: 1}}}
+}
+
+// Sense Direction of a constraint
+type Sense int
+
+const (
+	// LessEq Σ a_j*x_j <= rhs
+	LessEq Sense = iota
+	// GreaterEq Σ a_j*x_j >= rhs
+	GreaterEq
+	// Equal Σ a_j*x_j = rhs
+	Equal
+)
+
+// Model Linear model built variable by variable, compiled to the standard form of Simplex and MIP
+// Maximize the objective subject to the constraints, the variables are non-negative except the free ones.
+// A >= constraint is negated and an equality becomes two <= constraints.
+type Model struct {
+	names   []string
+	integer []bool
+	binary  []bool
+	// free Variables without sign restriction, shorter than names when the last variables are not free
+	free      []bool
+	objective Expr
+	rows      []Expr
+	rhs       []float64
+	// rowNames Name of each row, empty when the constraint is not named
+	rowNames []string
+	// ranges Width of each ranged row rhs - range <= e <= rhs, see AddRange.
+	// It is shorter than rows when the last rows have no range, a missing range is +Inf.
+	ranges []float64
+}
+
+// ModelSolution Solution of a Model
+type ModelSolution struct {
+	// Values Value of each variable, indexed by Var
+	Values []float64
+	// Score Value of the objective, including its constant
+	Score float64
+	// Quality Guarantee on the score, Optimal unless the solve degraded
+	Quality Quality
+}
+
+// Value Value of the variable v
+func (s *ModelSolution) Value(v Var) float64 {
+	return s.Values[v]
+}
+
+// AddVariable Add a non-negative variable to the model
+func (m *Model) AddVariable(name string, integer bool) Var {
+	m.names = append(m.names, name)
+	m.integer = append(m.integer, integer)
+	m.binary = append(m.binary, false)
+	return Var(len(m.names) - 1)
+}
+
+// AddFreeVariable Add a variable without sign restriction.
+// The standard form splits it into x⁺ - x⁻, the column of x⁻ follows the columns of all the variables,
+// and the solution gives the value of x.
+func (m *Model) AddFreeVariable(name string, integer bool) Var {
+	v := m.AddVariable(name, integer)
+	for len(m.free) < int(v) {
+		m.free = append(m.free, false)
+	}
+	m.free = append(m.free, true)
+	return v
+}
+
+// IsFree Check if the variable v was added with AddFreeVariable
+func (m *Model) IsFree(v Var) bool {
+	return v >= 0 && int(v) < len(m.free) && m.free[v]
+}
+
+// AddBinary Add a variable in {0, 1}, an integer variable with the constraint x <= 1.
+// The branch and bound recognizes the bound row, the variable takes part in the clique cuts and the probing.
+func (m *Model) AddBinary(name string) Var {
+	v := m.AddVariable(name, true)
+	m.binary[v] = true
+	m.rows = append(m.rows, v.Expr())
+	m.rhs = append(m.rhs, 1)
+	m.rowNames = append(m.rowNames, "")
+	return v
+}
+
+// Name Name of the variable v given to AddVariable or AddBinary
+func (m *Model) Name(v Var) string {
+	return m.names[v]
+}
+
+// Names Name of each variable, indexed by Var
+func (m *Model) Names() []string {
+	return append([]string(nil), m.names...)
+}
+
+// RowNames Name of each row of the standard form, empty for the constraints added without a name, see AddNamedRow.
+// A range gives the rows name.ge and name.le, see AddRange.
+func (m *Model) RowNames() []string {
+	names := make([]string, 0, len(m.rowNames))
+	for i, name := range m.rowNames {
+		if math.IsInf(m.rangeOf(i), 1) {
+			names = append(names, name)
+			continue
+		}
+		ge, le := name, name
+		if name != "" {
+			ge, le = name+".ge", name+".le"
+		}
+		names = append(names, ge, le)
+	}
+	return names
+}
+
+// rangeOf Range of the row i, +Inf for a one-sided row
+func (m *Model) rangeOf(i int) float64 {
+	if i >= len(m.ranges) {
+		return math.Inf(1)
+	}
+	return m.ranges[i]
+}
+
+// IsBinary Check if the variable v was added with AddBinary
+func (m *Model) IsBinary(v Var) bool {
+	return v >= 0 && int(v) < len(m.binary) && m.binary[v]
+}
+
+// Maximize Set the objective of the model
+func (m *Model) Maximize(e Expr) {
+	m.objective = e
+}
+
+// AddConstraint Add the constraint e <= rhs, the constant of e is moved to the right-hand side
+func (m *Model) AddConstraint(e Expr, rhs float64) error {
+	return m.AddRow(e, LessEq, rhs)
+}
+
+// AddRow Add the constraint e sense rhs, the constant of e is moved to the right-hand side
+func (m *Model) AddRow(e Expr, sense Sense, rhs float64) error {
+	return m.AddNamedRow("", e, sense, rhs)
+}
+
+// AddNamedRow Add the constraint e sense rhs named name, see RowNames.
+// The two rows of an equality are named name.le and name.ge.
+func (m *Model) AddNamedRow(name string, e Expr, sense Sense, rhs float64) error {
+	for _, t := range e.Terms {
+		if t.Var < 0 || int(t.Var) >= len(m.names) {
+			return errors.Errorf("variable %d is not in the model", t.Var)
+		}
+	}
+	if sense != LessEq && sense != GreaterEq && sense != Equal {
+		return errors.Errorf("unknown sense %d", sense)
+	}
+	le, ge := name, name
+	if sense == Equal && name != "" {
+		le, ge = name+".le", name+".ge"
+	}
+	if sense != GreaterEq {
+		m.rows = append(m.rows, Expr{Terms: append([]Term(nil), e.Terms...)})
+		m.rhs = append(m.rhs, rhs-e.Constant)
+		m.rowNames = append(m.rowNames, le)
+	}
+	if sense != LessEq {
+		m.rows = append(m.rows, Expr{Terms: scaleTerms(e.Terms, -1)})
+		m.rhs = append(m.rhs, e.Constant-rhs)
+		m.rowNames = append(m.rowNames, ge)
+	}
+	return nil
+}
+
+// AddRange Add lower <= e <= upper named name as a single row, the constant of e is moved to the bounds.
+// An infinite side is dropped and equal sides give an equality, like AddNamedRow.
+// Solve gives the ranged rows to SimplexRanged, Standard expands each of them to the rows name.ge and name.le.
+func (m *Model) AddRange(name string, e Expr, lower, upper float64) error {
+	switch {
+	case lower > upper || math.IsNaN(lower) || math.IsNaN(upper):
+		return errors.Errorf("the range [%v, %v] of %q is empty", lower, upper, name)
+	case lower == upper:
+		return m.AddNamedRow(name, e, Equal, lower)
+	case math.IsInf(lower, -1) && math.IsInf(upper, 1):
+		return nil
+	case math.IsInf(lower, -1):
+		return m.AddNamedRow(name, e, LessEq, upper)
+	case math.IsInf(upper, 1):
+		return m.AddNamedRow(name, e, GreaterEq, lower)
+	}
+	err := m.AddNamedRow(name, e, LessEq, upper)
+	if err != nil {
+		return err
+	}
+	for len(m.ranges) < len(m.rows)-1 {
+		m.ranges = append(m.ranges, math.Inf(1))
+	}
+	m.ranges = append(m.ranges, upper-lower)
+	return nil
+}
+
+// Standard Compile the model to the standard form of Simplex, the terms of a variable repeated in an expression are summed.
+// A ranged row gives two rows, the negated >= row followed by the <= row.
+func (m *Model) Standard() (c, A, b *mat.Dense, integer []bool) {
+	c, ranged, rb, ranges, integer := m.Ranged()
+	_, n := ranged.Dims()
+	rows := len(m.rows)
+	for _, r := range ranges {
+		if !math.IsInf(r, 1) {
+			rows++
+		}
+	}
+	A = mat.NewDense(rows, n, nil)
+	b = mat.NewDense(rows, 1, nil)
+	k := 0
+	for i, r := range ranges {
+		if !math.IsInf(r, 1) {
+			for j := 0; j < n; j++ {
+				A.Set(k, j, -ranged.At(i, j))
+			}
+			b.Set(k, 0, r-rb.At(i, 0))
+			k++
+		}
+		A.Slice(k, k+1, 0, n).(*mat.Dense).Copy(ranged.Slice(i, i+1, 0, n))
+		b.Set(k, 0, rb.At(i, 0))
+		k++
+	}
+	return c, A, b, integer
+}
+
+// Ranged Compile the model to the input of SimplexRanged, one row per constraint with the range of each row,
+// +Inf for the one-sided rows. The columns of x⁻ of the free variables follow the columns of the variables,
+// like in SimplexFree.
+func (m *Model) Ranged() (c, A, b *mat.Dense, ranges []float64, integer []bool) {
+	n := len(m.names)
+	rows := len(m.rows)
+	c = mat.NewDense(1, n, nil)
+	for _, t := range m.objective.Terms {
+		c.Set(0, int(t.Var), c.At(0, int(t.Var))+t.Coef)
+	}
+	A = mat.NewDense(rows, n, nil)
+	b = mat.NewDense(rows, 1, nil)
+	ranges = make([]float64, rows)
+	for i, e := range m.rows {
+		for _, t := range e.Terms {
+			A.Set(i, int(t.Var), A.At(i, int(t.Var))+t.Coef)
+		}
+		b.Set(i, 0, m.rhs[i])
+		ranges[i] = m.rangeOf(i)
+	}
+	integer = append([]bool(nil), m.integer...)
+	for j, isFree := range m.free {
+		if isFree {
+			integer = append(integer, m.integer[j])
+		}
+	}
+	c, A = splitFree(c, A, m.free)
+	return c, A, b, ranges, integer
+}
+
+// Solve Solve the model with the simplex algorithm, or MIP if it has integer variables.
+// Negative right-hand sides, from >= constraints and equalities, are handled with a phase one.
+// A linear model with ranged rows is solved by SimplexRanged, MIP solves their two rows.
+// maxIter is the maximum number of simplex iterations or of explored nodes.
+// The options are given to Simplex or MIP, for example WithTimeLimit or WithLogger.
+func (m *Model) Solve(maxIter int, opts ...Option) (*ModelSolution, error) {
+	err := m.check()
+	if err != nil {
+		return nil, err
+	}
+	c, A, b, integer := m.Standard()
+
+	mip := false
+	for _, isInteger := range integer {
+		mip = mip || isInteger
+	}
+	var results *mat.Dense
+	var score float64
+	switch {
+	case mip:
+		_, results, score, err = MIP(c, A, b, integer, maxIter, opts...)
+	case m.hasRanges():
+		c, A, b, ranges, _ := m.Ranged()
+		_, results, score, err = SimplexRanged(c, A, b, ranges, append([]Option{WithMaxIter(maxIter)}, opts...)...)
+	default:
+		_, results, score, err = Simplex(c, A, b, append([]Option{WithMaxIter(maxIter)}, opts...)...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m.solution(results, score, Optimal), nil
+}
+
+// hasRanges Check if a row was added by AddRange
+func (m *Model) hasRanges() bool {
+	for _, r := range m.ranges {
+		if !math.IsInf(r, 1) {
+			return true
+		}
+	}
+	return false
+}
+
+// check Check that the model can be solved
+func (m *Model) check() error {
+	if len(m.names) == 0 || len(m.rows) == 0 {
+		return errors.New("the model needs variables and constraints")
+	}
+	for _, t := range m.objective.Terms {
+		if t.Var < 0 || int(t.Var) >= len(m.names) {
+			return errors.Errorf("variable %d is not in the model", t.Var)
+		}
+	}
+	return nil
+}
+
+// solution Solution of the model from the results of Simplex or MIP
+func (m *Model) solution(results *mat.Dense, score float64, quality Quality) *ModelSolution {
+	solution := &ModelSolution{Values: make([]float64, len(m.names)), Score: score + m.objective.Constant, Quality: quality}
+	values := joinFree(results, len(m.names), m.free)
+	for j := range solution.Values {
+		solution.Values[j] = values.At(j, 0)
+	}
+	return solution
+}
@@ -0,0 +1,110 @@
+package goptimization
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestModel(t *testing.T) {
+	m := &Model{}
+	x := m.AddVariable("x", true)
+	y := m.AddVariable("y", true)
+	m.Maximize(Expr{Terms: []Term{{x, 5}, {y, 4}}, Constant: 1})
+	require.NoError(t, m.AddConstraint(Expr{Terms: []Term{{x, 6}, {y, 4}}}, 24))
+	require.NoError(t, m.AddConstraint(Expr{Terms: []Term{{x, 1}, {y, 1}, {y, 1}}, Constant: -1}, 5))
+
+	c, A, b, integer := m.Standard()
+	assert.True(t, mat.Equal(mat.NewDense(1, 2, []float64{5, 4}), c))
+	assert.True(t, mat.Equal(mat.NewDense(2, 2, []float64{6, 4, 1, 2}), A))
+	assert.True(t, mat.Equal(mat.NewDense(2, 1, []float64{24, 6}), b))
+	assert.Equal(t, []bool{true, true}, integer)
+	assert.Equal(t, "y", m.Name(y))
+
+	solution, err := m.Solve(100)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 21.0, solution.Score, 0.000001)
+	assert.InDelta(t, 4.0, solution.Value(x), 0.000001)
+	assert.InDelta(t, 0.0, solution.Value(y), 0.000001)
+}
+
+func TestModelErrors(t *testing.T) {
+	m := &Model{}
+	_, err := m.Solve(10)
+	assert.Error(t, err)
+
+	x := m.AddVariable("x", false)
+	assert.Error(t, m.AddConstraint(Expr{Terms: []Term{{Var(1), 1}}}, 1))
+	assert.Error(t, m.AddRow(x.Expr(), Sense(3), 1))
+	require.NoError(t, m.AddConstraint(x.Expr(), -1))
+	_, err = m.Solve(10)
+	assert.Equal(t, ErrInfeasible, err)
+}
+
+func TestModelNames(t *testing.T) {
+	m := &Model{}
+	x := m.AddBinary("x")
+	y := m.AddVariable("", false)
+	m.Maximize(Expr{Terms: []Term{{x, 2}, {y, 1}}})
+	require.NoError(t, m.AddNamedRow("capacity", Expr{Terms: []Term{{x, 1}, {y, 1}}}, LessEq, 3))
+	require.NoError(t, m.AddNamedRow("demand", Expr{Terms: []Term{{y, 1}}}, GreaterEq, 1))
+	require.NoError(t, m.AddNamedRow("balance", Expr{Terms: []Term{{x, 1}, {y, -1}}, Constant: 1}, Equal, 0))
+	require.NoError(t, m.AddConstraint(Expr{Terms: []Term{{y, 1}}}, 5))
+	assert.Error(t, m.AddNamedRow("unknown", Var(7).Expr(), LessEq, 1))
+
+	assert.Equal(t, []string{"x", ""}, m.Names())
+	assert.Equal(t, "x", m.Name(x))
+	// The bound of the binary, then the rows of the constraints
+	assert.Equal(t, []string{"", "capacity", "demand", "balance.le", "balance.ge", ""}, m.RowNames())
+	_, A, _, _ := m.Standard()
+	rows, _ := A.Dims()
+	assert.Equal(t, rows, len(m.RowNames()))
+
+	// The names are copies
+	m.RowNames()[1] = "changed"
+	assert.Equal(t, "capacity", m.RowNames()[1])
+
+	// The names label the solution file of the standard form
+	c, A, b, _ := m.Standard()
+	cf := &CanonicalForm{}
+	require.NoError(t, cf.New(c, A, b))
+	cf.logger = log.New(ioutil.Discard, "", 0)
+	_, err := cf.twoPhase(100)
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	require.NoError(t, cf.WriteSol(buf, m.Names(), m.RowNames()))
+	assert.Contains(t, buf.String(), "\nx 1 ")
+	assert.Contains(t, buf.String(), "\nx1 2 ")
+	assert.Contains(t, buf.String(), "\ncapacity ")
+	assert.Contains(t, buf.String(), "\nc0 ")
+}
+
+func TestModelBinary(t *testing.T) {
+	m := &Model{}
+	x := m.AddBinary("x")
+	y := m.AddBinary("y")
+	z := m.AddVariable("z", false)
+	m.Maximize(Expr{Terms: []Term{{x, 3}, {y, 2}, {z, 1}}})
+	require.NoError(t, m.AddConstraint(Expr{Terms: []Term{{x, 1}, {y, 1}, {z, 1}}}, 1.5))
+	assert.True(t, m.IsBinary(x))
+	assert.False(t, m.IsBinary(z))
+	assert.False(t, m.IsBinary(Var(5)))
+
+	//x <= 1 and y <= 1 are the first rows
+	c, A, b, integer := m.Standard()
+	assert.Equal(t, []bool{true, true, false}, integer)
+	bb := BranchAndBound{}
+	require.NoError(t, bb.New(c, A, b, integer))
+	assert.Equal(t, []bool{true, true, false}, bb.binary)
+
+	solution, err := m.Solve(100)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 3.5, solution.Score, 0.000001)
+	assert.InDelta(t, 1.0, solution.Value(x), 0.000001)
+	assert.InDelta(t, 0.0, solution.Value(y), 0.000001)
+}
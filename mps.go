@@ -0,0 +1,303 @@
+package goptimization
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// mpsColumn Column of an MPS file with its coefficients by row and its bounds
+type mpsColumn struct {
+	name    string
+	integer bool
+	coefs   map[string]float64
+	lower   float64
+	upper   float64
+}
+
+// mpsRow Row of an MPS file, the range is NaN when the row has none
+type mpsRow struct {
+	name      string
+	kind      string
+	rhs       float64
+	rangeSize float64
+}
+
+// ReadMPS Read a linear problem in the free MPS format (sections NAME, ROWS, COLUMNS, RHS, RANGES, BOUNDS, ENDATA,
+// the OBJSENSE extension and the integer markers) into a Model.
+// A minimization is read as the maximization of the opposite objective, so the score of the solution is the opposite
+// of the objective of the file. The constraints and the bounds other than x >= 0 become rows of the model:
+// a column with a negative lower bound is split into two variables, named after the column with a "+" and a "-",
+// whose difference is the column.
+func ReadMPS(r io.Reader) (*Model, error) {
+	rows := []*mpsRow{}
+	rowIndex := map[string]*mpsRow{}
+	columns := []*mpsColumn{}
+	columnIndex := map[string]*mpsColumn{}
+	objective := ""
+	// free Other N rows, ignored
+	free := map[string]bool{}
+	objectiveConstant := 0.0
+	maximize := false
+
+	section := ""
+	integer := false
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		fields := strings.Fields(text)
+		if len(fields) == 0 || strings.HasPrefix(text, "*") {
+			continue
+		}
+		if text[0] != ' ' && text[0] != '\t' {
+			section = strings.ToUpper(fields[0])
+			switch section {
+			case "NAME", "ROWS", "COLUMNS", "RHS", "RANGES", "BOUNDS", "OBJSENSE":
+				if section == "OBJSENSE" && len(fields) > 1 {
+					maximize = strings.HasPrefix(strings.ToUpper(fields[1]), "MAX")
+				}
+				continue
+			case "ENDATA":
+				return mpsModel(rows, columns, objective, objectiveConstant, maximize)
+			}
+			return nil, errors.Errorf("line %d: unknown section %s", line, fields[0])
+		}
+
+		switch section {
+		case "OBJSENSE":
+			maximize = strings.HasPrefix(strings.ToUpper(fields[0]), "MAX")
+		case "ROWS":
+			if len(fields) != 2 {
+				return nil, errors.Errorf("line %d: a row needs a type and a name", line)
+			}
+			kind := strings.ToUpper(fields[0])
+			if kind != "N" && kind != "L" && kind != "G" && kind != "E" {
+				return nil, errors.Errorf("line %d: unknown row type %s", line, fields[0])
+			}
+			if _, ok := rowIndex[fields[1]]; ok || fields[1] == objective || free[fields[1]] {
+				return nil, errors.Errorf("line %d: row %s is defined twice", line, fields[1])
+			}
+			if kind == "N" {
+				if objective == "" {
+					objective = fields[1]
+				} else {
+					free[fields[1]] = true
+				}
+				continue
+			}
+			row := &mpsRow{name: fields[1], kind: kind, rangeSize: math.NaN()}
+			rows = append(rows, row)
+			rowIndex[row.name] = row
+		case "COLUMNS":
+			if len(fields) == 3 && strings.Contains(fields[1], "MARKER") {
+				integer = strings.Contains(fields[2], "INTORG")
+				continue
+			}
+			if len(fields) != 3 && len(fields) != 5 {
+				return nil, errors.Errorf("line %d: a column needs pairs of row and value", line)
+			}
+			col, ok := columnIndex[fields[0]]
+			if !ok {
+				col = &mpsColumn{name: fields[0], integer: integer, coefs: map[string]float64{}, upper: math.Inf(1)}
+				columns = append(columns, col)
+				columnIndex[col.name] = col
+			}
+			for k := 1; k < len(fields); k += 2 {
+				value, err := strconv.ParseFloat(fields[k+1], 64)
+				if err != nil {
+					return nil, errors.Wrapf(err, "line %d", line)
+				}
+				if free[fields[k]] {
+					continue
+				}
+				if _, ok := rowIndex[fields[k]]; !ok && fields[k] != objective {
+					return nil, errors.Errorf("line %d: unknown row %s", line, fields[k])
+				}
+				col.coefs[fields[k]] += value
+			}
+		case "RHS", "RANGES":
+			// The name of the set is optional
+			pairs := fields
+			if len(fields)%2 == 1 {
+				pairs = fields[1:]
+			}
+			for k := 0; k+1 < len(pairs); k += 2 {
+				value, err := strconv.ParseFloat(pairs[k+1], 64)
+				if err != nil {
+					return nil, errors.Wrapf(err, "line %d", line)
+				}
+				if pairs[k] == objective && section == "RHS" {
+					objectiveConstant = -value
+					continue
+				}
+				if free[pairs[k]] {
+					continue
+				}
+				row, ok := rowIndex[pairs[k]]
+				if !ok {
+					return nil, errors.Errorf("line %d: unknown row %s", line, pairs[k])
+				}
+				if section == "RHS" {
+					row.rhs = value
+				} else {
+					row.rangeSize = value
+				}
+			}
+		case "BOUNDS":
+			err := mpsBound(fields, columnIndex)
+			if err != nil {
+				return nil, errors.Wrapf(err, "line %d", line)
+			}
+		default:
+			return nil, errors.Errorf("line %d: data outside of a section", line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("missing ENDATA")
+}
+
+// mpsBound Apply a line of the BOUNDS section, the name of the set is optional
+func mpsBound(fields []string, columnIndex map[string]*mpsColumn) error {
+	kind := strings.ToUpper(fields[0])
+	valued := kind == "UP" || kind == "LO" || kind == "FX" || kind == "LI" || kind == "UI"
+	name := ""
+	value := 0.0
+	switch {
+	case valued && len(fields) == 4, !valued && len(fields) >= 3:
+		name = fields[2]
+		if valued {
+			v, err := strconv.ParseFloat(fields[3], 64)
+			if err != nil {
+				return err
+			}
+			value = v
+		}
+	case valued && len(fields) == 3, !valued && len(fields) == 2:
+		name = fields[1]
+		if valued {
+			v, err := strconv.ParseFloat(fields[2], 64)
+			if err != nil {
+				return err
+			}
+			value = v
+		}
+	default:
+		return errors.New("invalid bound")
+	}
+	col, ok := columnIndex[name]
+	if !ok {
+		return errors.Errorf("unknown column %s", name)
+	}
+	switch kind {
+	case "UP", "UI":
+		col.upper = value
+		col.integer = col.integer || kind == "UI"
+	case "LO", "LI":
+		col.lower = value
+		col.integer = col.integer || kind == "LI"
+	case "FX":
+		col.lower, col.upper = value, value
+	case "FR":
+		col.lower, col.upper = math.Inf(-1), math.Inf(1)
+	case "MI":
+		col.lower = math.Inf(-1)
+	case "PL":
+		col.upper = math.Inf(1)
+	case "BV":
+		col.lower, col.upper, col.integer = 0, 1, true
+	default:
+		return errors.Errorf("unknown bound type %s", fields[0])
+	}
+	return nil
+}
+
+// mpsModel Build the model of the rows and columns of an MPS file
+func mpsModel(rows []*mpsRow, columns []*mpsColumn, objective string, constant float64, maximize bool) (*Model, error) {
+	m := &Model{}
+	// Terms of each column, x = x⁺ - x⁻ for a negative lower bound
+	terms := map[string][]Term{}
+	for _, col := range columns {
+		if col.lower > col.upper {
+			return nil, errors.Errorf("column %s has a lower bound above its upper bound", col.name)
+		}
+		if col.lower >= 0 {
+			terms[col.name] = []Term{{Var: m.AddVariable(col.name, col.integer), Coef: 1}}
+			continue
+		}
+		plus := m.AddVariable(col.name+"+", col.integer)
+		minus := m.AddVariable(col.name+"-", col.integer)
+		terms[col.name] = []Term{{Var: plus, Coef: 1}, {Var: minus, Coef: -1}}
+	}
+	expr := func(row string, sign float64) Expr {
+		e := Expr{}
+		for _, col := range columns {
+			if a, ok := col.coefs[row]; ok && a != 0 {
+				e.Terms = append(e.Terms, scaleTerms(terms[col.name], sign*a)...)
+			}
+		}
+		return e
+	}
+
+	sign := -1.0
+	if maximize {
+		sign = 1
+	}
+	obj := expr(objective, sign)
+	obj.Constant = sign * constant
+	m.Maximize(obj)
+
+	for _, row := range rows {
+		e := expr(row.name, 1)
+		lower, upper := math.Inf(-1), math.Inf(1)
+		switch row.kind {
+		case "L":
+			upper = row.rhs
+		case "G":
+			lower = row.rhs
+		case "E":
+			lower, upper = row.rhs, row.rhs
+		}
+		// A range R gives [rhs-|R|, rhs] for L, [rhs, rhs+|R|] for G and [rhs, rhs+R] ordered for E
+		if !math.IsNaN(row.rangeSize) {
+			switch {
+			case row.kind == "L":
+				lower = row.rhs - math.Abs(row.rangeSize)
+			case row.kind == "G":
+				upper = row.rhs + math.Abs(row.rangeSize)
+			case row.rangeSize >= 0:
+				upper = row.rhs + row.rangeSize
+			default:
+				lower = row.rhs + row.rangeSize
+			}
+		}
+		err := m.addInterval(row.name, e, lower, upper)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, col := range columns {
+		// x >= 0 is implicit
+		lower := math.Inf(-1)
+		if col.lower != 0 && !math.IsInf(col.lower, -1) {
+			lower = col.lower
+		}
+		err := m.addInterval(col.name+".bound", Expr{Terms: terms[col.name]}, lower, col.upper)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// addInterval Add lower <= e <= upper named name, see AddRange
+func (m *Model) addInterval(name string, e Expr, lower, upper float64) error {
+	return m.AddRange(name, e, lower, upper)
+}
@@ -0,0 +1,102 @@
+package goptimization
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMPS = `* Minimize x + 2y - z
+NAME          TESTPROB
+ROWS
+ N  COST
+ L  LIM1
+ G  LIM2
+ E  MYEQN
+ L  R4
+COLUMNS
+    X         COST         1.0   LIM1         1.0
+    X         LIM2         1.0
+    Y         COST         2.0   LIM1         1.0
+    Y         MYEQN       -1.0
+    Z         COST        -1.0   MYEQN        1.0
+    Z         R4           1.0
+RHS
+    RHS       LIM1         4.0   LIM2         1.0
+    RHS       MYEQN        7.0   R4           6.0
+RANGES
+    RNG       R4           2.0
+BOUNDS
+ UP BND       X            4.0
+ LO BND       Y           -1.0
+ UP BND       Y            1.0
+ENDATA
+`
+
+func TestReadMPS(t *testing.T) {
+	m, err := ReadMPS(strings.NewReader(testMPS))
+	require.NoError(t, err)
+	// X, Y+, Y-, Z
+	c, _, _, integer := m.Standard()
+	_, n := c.Dims()
+	assert.Equal(t, 4, n)
+	assert.Equal(t, []bool{false, false, false, false}, integer)
+	assert.Equal(t, []string{"X", "Y+", "Y-", "Z"}, m.Names())
+	assert.Equal(t, []string{"LIM1", "LIM2", "MYEQN.le", "MYEQN.ge", "R4.ge", "R4.le", "X.bound", "Y.bound.ge", "Y.bound.le"}, m.RowNames())
+
+	solution, err := m.Solve(100)
+	require.NoError(t, err)
+	assert.InDelta(t, 7.0, solution.Score, 0.000001)
+	assert.InDelta(t, 1.0, solution.Value(0), 0.000001)
+	assert.InDelta(t, -1.0, solution.Value(1)-solution.Value(2), 0.000001)
+	assert.InDelta(t, 6.0, solution.Value(3), 0.000001)
+}
+
+func TestReadMPSIntegers(t *testing.T) {
+	m, err := ReadMPS(strings.NewReader(`NAME MIXED
+OBJSENSE MAX
+ROWS
+ N OBJ
+ L C1
+ L C2
+COLUMNS
+ MARKER 'MARKER' 'INTORG'
+ X OBJ 5 C1 6
+ X C2 1
+ Y OBJ 4 C1 4
+ Y C2 2
+ MARKER 'MARKER' 'INTEND'
+ W OBJ 3
+RHS
+ OBJ -2 C1 24
+ C2 6
+BOUNDS
+ UP W 1.5
+ENDATA
+`))
+	require.NoError(t, err)
+	_, _, _, integer := m.Standard()
+	assert.Equal(t, []bool{true, true, false}, integer)
+	solution, err := m.Solve(100)
+	require.NoError(t, err)
+	assert.InDelta(t, 26.5, solution.Score, 0.000001)
+}
+
+func TestReadMPSErrors(t *testing.T) {
+	for _, text := range []string{
+		"NAME X\nROWS\n N OBJ\n",
+		"NAME X\nSECTION\nENDATA\n",
+		"NAME X\nROWS\n Q R\nENDATA\n",
+		"NAME X\nROWS\n N OBJ\n L R\n L R\nENDATA\n",
+		"NAME X\nROWS\n N OBJ\nCOLUMNS\n X R 1\nENDATA\n",
+		"NAME X\nROWS\n N OBJ\nCOLUMNS\n X OBJ one\nENDATA\n",
+		"NAME X\nROWS\n N OBJ\nCOLUMNS\n X OBJ 1\nBOUNDS\n UP BND Y 1\nENDATA\n",
+		"NAME X\nROWS\n N OBJ\nCOLUMNS\n X OBJ 1\nBOUNDS\n XX BND X 1\nENDATA\n",
+		"NAME X\nROWS\n N OBJ\nCOLUMNS\n X OBJ 1\nBOUNDS\n LO BND X 2\n UP BND X 1\nENDATA\n",
+	} {
+		_, err := ReadMPS(strings.NewReader(text))
+		assert.Error(t, err, text)
+	}
+}
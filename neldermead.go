@@ -0,0 +1,187 @@
+package goptimization
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Coefficients of the moves of the Nelder-Mead simplex
+const (
+	reflection  = 1.0
+	expansion   = 2.0
+	contraction = 0.5
+	shrinkage   = 0.5
+)
+
+// NelderMead Derivative-free minimization of a continuous objective by the Nelder-Mead simplex.
+// Despite the name it is unrelated to the simplex of the linear programs: the simplex is a set of n+1 points
+// moved by reflections, expansions, contractions and shrinks towards a minimum.
+type NelderMead struct {
+	Objective Objective
+	// Start First point of the initial simplex, the others are Start + Step*e_j
+	Start []float64
+	// Step Size of the initial simplex, 1 by default
+	Step float64
+	// MaxIter Maximum number of moves over all the restarts, 5000 by default
+	MaxIter int
+	// Tolerance The simplex has converged once its points and their values are within Tolerance of the best one,
+	// 1e-8 by default
+	Tolerance float64
+	// Restarts Number of new simplexes of size Step built around the best point once a simplex has converged,
+	// the search stops earlier when a restart does not improve the best value. 2 by default.
+	// A restart escapes a simplex which collapsed away from the minimum.
+	Restarts int
+
+	// Iterations Number of moves done by the last Solve
+	Iterations int
+}
+
+// New Initialize the search from start
+func (nm *NelderMead) New(objective Objective, start []float64) error {
+	if objective == nil {
+		return errors.New("objective must be defined")
+	}
+	if len(start) == 0 {
+		return newError(ErrDimensionMismatch, "start must not be empty")
+	}
+	nm.Objective = objective
+	nm.Start = append([]float64(nil), start...)
+	nm.Step = 1
+	nm.MaxIter = 5000
+	nm.Tolerance = 1e-8
+	nm.Restarts = 2
+	nm.Iterations = 0
+	return nil
+}
+
+// vertex Point of the simplex and its value
+type vertex struct {
+	x     []float64
+	value float64
+}
+
+// simplex Initial simplex around x
+func (nm *NelderMead) simplex(x []float64) []vertex {
+	points := make([]vertex, len(x)+1)
+	for k := range points {
+		p := append([]float64(nil), x...)
+		if k > 0 {
+			p[k-1] += nm.Step
+		}
+		points[k] = vertex{x: p, value: nm.Objective(p)}
+	}
+	return points
+}
+
+// converged Check if the points and the values of the sorted simplex are within Tolerance of the best point
+func (nm *NelderMead) converged(points []vertex) bool {
+	for _, p := range points[1:] {
+		if math.Abs(p.value-points[0].value) > nm.Tolerance {
+			return false
+		}
+		for j := range p.x {
+			if math.Abs(p.x[j]-points[0].x[j]) > nm.Tolerance {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// towards Point from + coefficient*(to - from)
+func towards(from, to []float64, coefficient float64) []float64 {
+	p := make([]float64, len(from))
+	for j := range p {
+		p[j] = from[j] + coefficient*(to[j]-from[j])
+	}
+	return p
+}
+
+// move Replace the worst point of the sorted simplex by its reflection through the centroid of the others,
+// expanded or contracted, or shrink the simplex towards the best point
+func (nm *NelderMead) move(points []vertex) {
+	n := len(points) - 1
+	centroid := make([]float64, len(points[0].x))
+	for _, p := range points[:n] {
+		for j := range centroid {
+			centroid[j] += p.x[j] / float64(n)
+		}
+	}
+	worst := points[n]
+	evaluate := func(x []float64) vertex {
+		return vertex{x: x, value: nm.Objective(x)}
+	}
+	reflected := evaluate(towards(centroid, worst.x, -reflection))
+	switch {
+	case reflected.value < points[0].value:
+		expanded := evaluate(towards(centroid, worst.x, -expansion))
+		if expanded.value < reflected.value {
+			points[n] = expanded
+		} else {
+			points[n] = reflected
+		}
+		return
+	case reflected.value < points[n-1].value:
+		points[n] = reflected
+		return
+	}
+	// Contract on the side of the best of the reflected and the worst points
+	var contracted vertex
+	if reflected.value < worst.value {
+		contracted = evaluate(towards(centroid, reflected.x, contraction))
+	} else {
+		contracted = evaluate(towards(centroid, worst.x, contraction))
+	}
+	if contracted.value < math.Min(reflected.value, worst.value) {
+		points[n] = contracted
+		return
+	}
+	for k := 1; k <= n; k++ {
+		points[k] = evaluate(towards(points[0].x, points[k].x, shrinkage))
+	}
+}
+
+// Solve Move the simplex until it converges, then restart around the best point up to Restarts times.
+// It returns the best point found and its value, with ErrIterationLimit when MaxIter moves were done first.
+func (nm *NelderMead) Solve() ([]float64, float64, error) {
+	if nm.Objective == nil || len(nm.Start) == 0 {
+		return nil, 0, errors.New("Nelder-Mead is not initialized")
+	}
+	if nm.Step <= 0 {
+		return nil, 0, errors.Errorf("the step must be positive, got %g", nm.Step)
+	}
+	nm.Iterations = 0
+	best := vertex{x: nm.Start, value: nm.Objective(nm.Start)}
+	for restart := 0; restart <= nm.Restarts; restart++ {
+		points := nm.simplex(best.x)
+		sortVertices(points)
+		for !nm.converged(points) {
+			if nm.Iterations >= nm.MaxIter {
+				if points[0].value < best.value {
+					best = points[0]
+				}
+				return append([]float64(nil), best.x...), best.value, ErrIterationLimit
+			}
+			nm.move(points)
+			sortVertices(points)
+			nm.Iterations++
+		}
+		improved := points[0].value < best.value-nm.Tolerance
+		if points[0].value < best.value {
+			best = points[0]
+		}
+		if restart > 0 && !improved {
+			break
+		}
+	}
+	return append([]float64(nil), best.x...), best.value, nil
+}
+
+// sortVertices Sort the points by increasing value
+func sortVertices(points []vertex) {
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].value < points[j].value
+	})
+}
@@ -0,0 +1,46 @@
+package goptimization
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNelderMead(t *testing.T) {
+	//Rosenbrock function, minimum 0 at (1, 1)
+	rosenbrock := func(x []float64) float64 {
+		return 100*math.Pow(x[1]-x[0]*x[0], 2) + math.Pow(1-x[0], 2)
+	}
+	nm := &NelderMead{}
+	require.NoError(t, nm.New(rosenbrock, []float64{-1.2, 1}))
+	x, value, err := nm.Solve()
+	require.NoError(t, err)
+	assert.InDelta(t, 1, x[0], 0.00001)
+	assert.InDelta(t, 1, x[1], 0.00001)
+	assert.InDelta(t, 0, value, 0.000001)
+	assert.Equal(t, []float64{-1.2, 1}, nm.Start)
+
+	//The restarts never worsen the result on this non-smooth function
+	abs := func(x []float64) float64 {
+		return math.Abs(x[0]) + 2*math.Abs(x[1]) + math.Abs(x[0]-x[1])
+	}
+	nm = &NelderMead{}
+	require.NoError(t, nm.New(abs, []float64{3, 1}))
+	nm.Restarts = 0
+	_, single, err := nm.Solve()
+	require.NoError(t, err)
+	nm.Restarts = 5
+	_, restarted, err := nm.Solve()
+	require.NoError(t, err)
+	assert.LessOrEqual(t, restarted, single)
+	assert.InDelta(t, 0, restarted, 0.000001)
+
+	nm.MaxIter = 3
+	_, _, err = nm.Solve()
+	assert.Equal(t, ErrIterationLimit, err)
+	assert.Equal(t, 3, nm.Iterations)
+
+	assert.Error(t, nm.New(abs, nil))
+}
@@ -0,0 +1,104 @@
+//go:build glpk
+// +build glpk
+
+package netlib
+
+import (
+	"bufio"
+	"io/ioutil"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Comparison Objectives of an instance found by goptimization and by the glpsol binary of GLPK
+type Comparison struct {
+	Result
+	// GLPK Objective of glpsol in the sense of the file
+	GLPK float64
+	// Mismatch |Objective - GLPK| / max(1, |GLPK|)
+	Mismatch float64
+}
+
+// Agree Check if both solvers solved the instance with objectives within tolerance
+func (c Comparison) Agree(tolerance float64) bool {
+	return c.Err == nil && c.Mismatch <= tolerance
+}
+
+// Compare Solve the instance from the directory with goptimization, like Run, and with glpsol,
+// the path of the binary, which solves the LP or the MIP of the free MPS file
+func Compare(glpsol, dir string, instance Instance, maxIter int) Comparison {
+	c := Comparison{Result: Run(dir, instance, maxIter)}
+	if c.Err != nil {
+		return c
+	}
+	c.GLPK, c.Err = RunGLPK(glpsol, filepath.Join(dir, strings.ToLower(instance.Name)+".mps"), instance.Maximize)
+	if c.Err != nil {
+		return c
+	}
+	c.Mismatch = math.Abs(c.Objective-c.GLPK) / math.Max(1, math.Abs(c.GLPK))
+	return c
+}
+
+// RunGLPK Objective of the free MPS file solved by glpsol, an error if glpsol fails or does not find an optimum
+func RunGLPK(glpsol, file string, maximize bool) (float64, error) {
+	dir, err := ioutil.TempDir("", "glpk")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(dir)
+	report := filepath.Join(dir, "report.txt")
+	sense := "--min"
+	if maximize {
+		sense = "--max"
+	}
+	out, err := exec.Command(glpsol, "--freemps", file, sense, "-o", report).CombinedOutput()
+	if err != nil {
+		return 0, errors.Wrapf(err, "glpsol %s: %s", file, out)
+	}
+	f, err := os.Open(report)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return parseGLPKReport(bufio.NewScanner(f))
+}
+
+// parseGLPKReport Objective of the report written by glpsol -o, whose header has the lines
+// Status:     OPTIMAL (or INTEGER OPTIMAL)
+// Objective:  COST = -464.7531429 (MINimum)
+func parseGLPKReport(scanner *bufio.Scanner) (float64, error) {
+	status := ""
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "Status:":
+			status = strings.Join(fields[1:], " ")
+			if status != "OPTIMAL" && status != "INTEGER OPTIMAL" {
+				return 0, errors.Errorf("glpsol status %s", status)
+			}
+		case "Objective:":
+			for k, field := range fields {
+				if field == "=" && k+1 < len(fields) {
+					if status == "" {
+						return 0, errors.New("glpsol report has no status")
+					}
+					return strconv.ParseFloat(fields[k+1], 64)
+				}
+			}
+			return 0, errors.Errorf("glpsol objective %q", scanner.Text())
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, errors.New("glpsol report has no objective")
+}
@@ -0,0 +1,60 @@
+//go:build glpk
+// +build glpk
+
+package netlib
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run with go test -tags glpk ./netlib, glpsol is found in the PATH or at GLPSOL
+// and the netlib instances are compared too when NETLIB_DIR is set
+
+func TestParseGLPKReport(t *testing.T) {
+	objective, err := parseGLPKReport(bufio.NewScanner(strings.NewReader(`Problem:    TESTPROB
+Rows:       5
+Columns:    3
+Non-zeros:  10
+Status:     OPTIMAL
+Objective:  COST = -7 (MINimum)
+`)))
+	require.NoError(t, err)
+	assert.Equal(t, -7.0, objective)
+
+	_, err = parseGLPKReport(bufio.NewScanner(strings.NewReader("Status:     INTEGER EMPTY\nObjective:  OBJ = 0 (MAXimum)\n")))
+	assert.Error(t, err)
+	_, err = parseGLPKReport(bufio.NewScanner(strings.NewReader("Problem:    EMPTY\n")))
+	assert.Error(t, err)
+}
+
+func TestCompareGLPK(t *testing.T) {
+	glpsol := os.Getenv("GLPSOL")
+	if glpsol == "" {
+		glpsol = "glpsol"
+	}
+	if _, err := exec.LookPath(glpsol); err != nil {
+		t.Skip("glpsol is not installed")
+	}
+	suites := map[string][]Instance{"testdata": testdata}
+	if dir := os.Getenv("NETLIB_DIR"); dir != "" {
+		suites[dir] = Instances
+	}
+	for dir, instances := range suites {
+		for _, instance := range instances {
+			c := Compare(glpsol, dir, instance, 100000)
+			if os.IsNotExist(c.Err) {
+				t.Logf("%s: missing", instance.Name)
+				continue
+			}
+			assert.NoError(t, c.Err, instance.Name)
+			assert.True(t, c.Agree(1e-6), "%s: objective %g, GLPK %g", instance.Name, c.Objective, c.GLPK)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Package netlib Regression and performance harness on the netlib LP instances
+//
+// The instances are read from MPS files named after the instance in lower case (afiro.mps, ...).
+// The netlib distribution is compressed with emps, the files must be decompressed before they are read.
+// The small instances of testdata, with AFIRO, run with the tests of the package, the other netlib ones
+// when the NETLIB_DIR environment variable points to their directory.
+// The glpk build tag adds Compare, which cross-checks the objectives with the glpsol binary of GLPK.
+package netlib
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/askiada/goptimization"
+)
+
+// Instance Problem with its known optimal objective
+type Instance struct {
+	Name    string
+	Optimum float64
+	// Maximize The file maximizes its objective, netlib instances minimize it
+	Maximize bool
+}
+
+// Instances Subset of the netlib LP instances with the optima of the netlib readme,
+// they are all minimizations
+var Instances = []Instance{
+	{Name: "AFIRO", Optimum: -4.6475314286e+02},
+	{Name: "SC50A", Optimum: -6.4575077059e+01},
+	{Name: "SC50B", Optimum: -7.0000000000e+01},
+	{Name: "SC105", Optimum: -5.2202061212e+01},
+	{Name: "ADLITTLE", Optimum: 2.2549496316e+05},
+	{Name: "BLEND", Optimum: -3.0812149846e+01},
+	{Name: "KB2", Optimum: -1.7499001299e+03},
+	{Name: "SHARE2B", Optimum: -4.1573224074e+02},
+}
+
+// Result Outcome of the solve of an instance
+type Result struct {
+	Instance
+	// Objective Objective of the solution in the sense of the file
+	Objective float64
+	// RelativeError |Objective - Optimum| / max(1, |Optimum|)
+	RelativeError float64
+	Duration      time.Duration
+	// Err Error of the read or the solve, os.IsNotExist(Err) when the file is missing
+	Err error
+}
+
+// Solved Check if the instance was solved with a relative error under tolerance
+func (r Result) Solved(tolerance float64) bool {
+	return r.Err == nil && r.RelativeError <= tolerance
+}
+
+// Load Read the instance from the directory
+func Load(dir, name string) (*goptimization.Model, error) {
+	f, err := os.Open(filepath.Join(dir, strings.ToLower(name)+".mps"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return goptimization.ReadMPS(f)
+}
+
+// Run Solve the instance from the directory with at most maxIter simplex iterations or nodes,
+// the options are given to Model.Solve
+func Run(dir string, instance Instance, maxIter int, opts ...goptimization.Option) Result {
+	r := Result{Instance: instance}
+	m, err := Load(dir, instance.Name)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	start := time.Now()
+	solution, err := m.Solve(maxIter, opts...)
+	r.Duration = time.Since(start)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	r.Objective = solution.Score
+	if !instance.Maximize {
+		r.Objective = -solution.Score
+	}
+	r.RelativeError = math.Abs(r.Objective-instance.Optimum) / math.Max(1, math.Abs(instance.Optimum))
+	return r
+}
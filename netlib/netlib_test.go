@@ -0,0 +1,73 @@
+package netlib
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/askiada/goptimization"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testdata Instances of the testdata directory
+var testdata = []Instance{
+	{Name: "TESTPROB", Optimum: -7},
+	{Name: "MIXED", Optimum: 26.5, Maximize: true},
+	Instances[0],
+}
+
+// silent Logger of the solves
+var silent = goptimization.WithLogger(log.New(ioutil.Discard, "", 0))
+
+func TestTestdata(t *testing.T) {
+	for _, instance := range testdata {
+		r := Run("testdata", instance, 1000, silent)
+		require.NoError(t, r.Err, instance.Name)
+		assert.True(t, r.Solved(1e-6), "%s: objective %g, optimum %g", instance.Name, r.Objective, instance.Optimum)
+	}
+
+	r := Run("testdata", Instance{Name: "MISSING"}, 1000, silent)
+	assert.True(t, os.IsNotExist(r.Err))
+	assert.False(t, r.Solved(1))
+}
+
+func TestNetlib(t *testing.T) {
+	dir := os.Getenv("NETLIB_DIR")
+	if dir == "" {
+		t.Skip("NETLIB_DIR is not set")
+	}
+	for _, instance := range Instances {
+		r := Run(dir, instance, 100000, silent)
+		if os.IsNotExist(r.Err) {
+			t.Logf("%s: missing", instance.Name)
+			continue
+		}
+		assert.NoError(t, r.Err, instance.Name)
+		assert.True(t, r.Solved(1e-6), "%s: objective %g, optimum %g", instance.Name, r.Objective, instance.Optimum)
+		t.Logf("%s: objective %g in %v", instance.Name, r.Objective, r.Duration)
+	}
+}
+
+func BenchmarkNetlib(b *testing.B) {
+	instances := map[string][]Instance{"testdata": testdata}
+	if dir := os.Getenv("NETLIB_DIR"); dir != "" {
+		instances[dir] = Instances
+	}
+	for dir, list := range instances {
+		for _, instance := range list {
+			if _, err := Load(dir, instance.Name); err != nil {
+				continue
+			}
+			b.Run(instance.Name, func(b *testing.B) {
+				for k := 0; k < b.N; k++ {
+					r := Run(dir, instance, 100000, silent)
+					if !r.Solved(1e-6) {
+						b.Fatalf("%s: objective %g, optimum %g, error %v", instance.Name, r.Objective, instance.Optimum, r.Err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,22 @@
+* Maximize 5x + 4y + 3w with x, y integer, 6x + 4y <= 24, x + 2y <= 6, w <= 1.5 and the constant 2, the optimum is 26.5
+NAME          MIXED
+OBJSENSE
+    MAX
+ROWS
+ N  OBJ
+ L  C1
+ L  C2
+COLUMNS
+    MARKER    'MARKER'     'INTORG'
+    X         OBJ          5.0   C1           6.0
+    X         C2           1.0
+    Y         OBJ          4.0   C1           4.0
+    Y         C2           2.0
+    MARKER    'MARKER'     'INTEND'
+    W         OBJ          3.0
+RHS
+    RHS       OBJ         -2.0
+    RHS       C1          24.0   C2           6.0
+BOUNDS
+ UP BND       W            1.5
+ENDATA
@@ -0,0 +1,25 @@
+* Minimize x + 2y - z with a ranged row and bounds on x and y, the optimum is (1, -1, 6)
+NAME          TESTPROB
+ROWS
+ N  COST
+ L  LIM1
+ G  LIM2
+ E  MYEQN
+ L  R4
+COLUMNS
+    X         COST         1.0   LIM1         1.0
+    X         LIM2         1.0
+    Y         COST         2.0   LIM1         1.0
+    Y         MYEQN       -1.0
+    Z         COST        -1.0   MYEQN        1.0
+    Z         R4           1.0
+RHS
+    RHS       LIM1         4.0   LIM2         1.0
+    RHS       MYEQN        7.0   R4           6.0
+RANGES
+    RNG       R4           2.0
+BOUNDS
+ UP BND       X            4.0
+ LO BND       Y           -1.0
+ UP BND       Y            1.0
+ENDATA
@@ -0,0 +1,225 @@
+package goptimization
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PivotRule Rule used to pick the entering and leaving variables
+type PivotRule int
+
+const (
+	// Dantzig Largest reduced cost, switching to Bland's rule after blandThreshold consecutive degenerate pivots
+	Dantzig PivotRule = iota
+	// Bland Smallest variable index, slower but never cycles
+	Bland
+)
+
+// Logger Destination of the trace of the simplex iterations, *log.Logger implements it.
+// A logger writing to ioutil.Discard silences the trace.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// stdoutLogger Default logger, print the trace on the standard output
+type stdoutLogger struct{}
+
+func (stdoutLogger) Printf(format string, v ...interface{}) {
+	fmt.Printf(format, v...)
+}
+
+// options Configuration of Simplex, MIP and Minimize
+type options struct {
+	maxIter   int
+	tolerance float64
+	rule      PivotRule
+	logger    Logger
+	timeLimit time.Duration
+	pricing   PricingRule
+	update    BasisUpdate
+	threads   int
+	mipGap    float64
+	ctx       context.Context
+	progress  func(Progress) bool
+
+	stepSize   float64
+	momentum   float64
+	nesterov   bool
+	lineSearch bool
+}
+
+// Option Configure Simplex, MIP or Minimize
+type Option func(*options)
+
+// WithMaxIter Stop after maxIter iterations, maxIter <= 0 means no limit, see iterationLimit
+func WithMaxIter(maxIter int) Option {
+	return func(o *options) {
+		o.maxIter = maxIter
+	}
+}
+
+// WithTolerance Tolerance used to compare reduced costs, pivots and values to zero, 1e-9 by default.
+// Minimize stops once the norm of the gradient is at most the tolerance.
+func WithTolerance(tolerance float64) Option {
+	return func(o *options) {
+		o.tolerance = tolerance
+	}
+}
+
+// WithPivotRule Rule used to pick the entering and leaving variables, Dantzig by default
+func WithPivotRule(rule PivotRule) Option {
+	return func(o *options) {
+		o.rule = rule
+	}
+}
+
+// WithPricing Strategy used to choose the entering variable, FullPricing by default.
+// PartialPricing and MultiplePricing reduce the cost of an iteration when there are many more variables
+// than constraints, Bland's rule always prices every variable.
+func WithPricing(pricing PricingRule) Option {
+	return func(o *options) {
+		o.pricing = pricing
+	}
+}
+
+// WithBasisUpdate Scheme of the solves with the basis in the iterations of Simplex and DualSimplex,
+// Refactorization by default
+func WithBasisUpdate(update BasisUpdate) Option {
+	return func(o *options) {
+		o.update = update
+	}
+}
+
+// WithThreads Number of nodes of the branch and bound solved concurrently by MIP, see BranchAndBound.Threads,
+// or of units solved concurrently by DEA
+func WithThreads(threads int) Option {
+	return func(o *options) {
+		o.threads = threads
+	}
+}
+
+// WithMIPGap Stop MIP once the relative gap between the best bound and the incumbent is at most gap,
+// see BranchAndBound.Gap
+func WithMIPGap(gap float64) Option {
+	return func(o *options) {
+		o.mipGap = gap
+	}
+}
+
+// WithLogger Write the trace of the iterations to logger instead of the standard output
+func WithLogger(logger Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithTimeLimit Stop the iterations after d with ErrTimeLimit, a kind of ErrIterationLimit.
+// The primal simplex returns the basic feasible solution reached after the phase one, the dual simplex stopped before the feasibility
+// returns no solution and the upper bound of the optimum given by its dual feasible basis as the score.
+func WithTimeLimit(d time.Duration) Option {
+	return func(o *options) {
+		o.timeLimit = d
+	}
+}
+
+// WithContext Stop the iterations of Simplex, DualSimplex and InteriorPoint, or the search of MIP, once ctx is done,
+// like the limit of WithTimeLimit
+func WithContext(ctx context.Context) Option {
+	return func(o *options) {
+		o.ctx = ctx
+	}
+}
+
+// WithProgress Call progress before each iteration of Simplex and DualSimplex after the phase one, with the objective
+// of the basis and the bound of its dual values. Returning false stops the iterations like the limit of WithTimeLimit,
+// e.g. once Progress.Gap is small enough.
+func WithProgress(progress func(Progress) bool) Option {
+	return func(o *options) {
+		o.progress = progress
+	}
+}
+
+// WithStepSize Step of the gradient descent of Minimize, or first step tried by the line search, 0.01 by default
+func WithStepSize(step float64) Option {
+	return func(o *options) {
+		o.stepSize = step
+	}
+}
+
+// WithMomentum Add beta times the previous move to each move of Minimize (heavy ball)
+func WithMomentum(beta float64) Option {
+	return func(o *options) {
+		o.momentum = beta
+		o.nesterov = false
+	}
+}
+
+// WithNesterov Nesterov acceleration of Minimize: the gradient is evaluated after the move beta times the previous move
+func WithNesterov(beta float64) Option {
+	return func(o *options) {
+		o.momentum = beta
+		o.nesterov = true
+	}
+}
+
+// WithLineSearch Choose each step of Minimize by backtracking until the objective decreases enough (Armijo condition)
+func WithLineSearch() Option {
+	return func(o *options) {
+		o.lineSearch = true
+	}
+}
+
+// newOptions Apply opts to the default configuration
+func newOptions(opts []Option) options {
+	o := options{
+		tolerance: epsilon,
+		logger:    stdoutLogger{},
+		stepSize:  0.01,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.logger == nil {
+		o.logger = stdoutLogger{}
+	}
+	return o
+}
+
+// deadline Time of the limit of WithTimeLimit from now, zero without limit
+func (o options) deadline() time.Time {
+	if o.timeLimit <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(o.timeLimit)
+}
+
+// cancelled Check if the context of WithContext is done
+func (o options) cancelled() bool {
+	return o.ctx != nil && o.ctx.Err() != nil
+}
+
+// configure Use the tolerance, the pivot rule, the pricing, the logger and the context of o in the dictionary
+func (cf *CanonicalForm) configure(o options) {
+	cf.tolerance = o.tolerance
+	cf.rule = o.rule
+	cf.logger = o.logger
+	cf.pricing = o.pricing
+	cf.basisUpdate = o.update
+	cf.ctx = o.ctx
+}
+
+// cancelled Check if the context of the dictionary is done
+func (cf *CanonicalForm) cancelled() bool {
+	return cf.ctx != nil && cf.ctx.Err() != nil
+}
+
+// bland Check if the pivots follow Bland's rule
+func (cf *CanonicalForm) bland() bool {
+	return cf.rule == Bland || cf.degenerate >= blandThreshold
+}
+
+// logf Write the trace of the iterations
+func (cf *CanonicalForm) logf(format string, v ...interface{}) {
+	cf.logger.Printf(format, v...)
+}
@@ -0,0 +1,76 @@
+package goptimization
+
+import (
+	"bytes"
+	stderrors "errors"
+	"io/ioutil"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+// silent Logger of the solves
+var silent = WithLogger(log.New(ioutil.Discard, "", 0))
+
+func TestOptions(t *testing.T) {
+	c := mat.NewDense(1, 4, []float64{7, 9, 18, 17})
+	A := mat.NewDense(3, 4, []float64{
+		2, 4, 5, 7,
+		1, 1, 2, 2,
+		1, 2, 3, 3,
+	})
+	b := mat.NewDense(3, 1, []float64{42, 17, 24})
+	expected := mat.NewDense(7, 1, []float64{3, 0, 7, 0, 1, 0, 0})
+
+	var trace bytes.Buffer
+	_, results, score, err := Simplex(mat.DenseCopyOf(c), mat.DenseCopyOf(A), mat.DenseCopyOf(b),
+		WithMaxIter(10), WithTolerance(0.000001), WithPivotRule(Bland), WithLogger(log.New(&trace, "", 0)))
+	require.NoError(t, err)
+	assert.True(t, mat.EqualApprox(expected, results, 0.000001))
+	assert.InEpsilon(t, 147.0, score, 0.000001)
+	assert.Contains(t, trace.String(), "Score: 147")
+
+	//The deadline is over before the first iteration
+	totalIter, results, _, err := Simplex(mat.DenseCopyOf(c), mat.DenseCopyOf(A), mat.DenseCopyOf(b),
+		WithTimeLimit(time.Nanosecond))
+	assert.Equal(t, ErrIterationLimit, errors.Cause(err))
+	assert.Equal(t, StatusTimeout, StatusOf(err))
+	assert.Equal(t, 0, totalIter)
+	require.NotNil(t, results)
+}
+
+func TestTimeLimit(t *testing.T) {
+	// Maximize -2*x1 - 3*x2 with x1 + x2 >= 4 and x1 <= 3, the optimum is -9
+	c := mat.NewDense(1, 2, []float64{-2, -3})
+	A := mat.NewDense(2, 2, []float64{-1, -1, 1, 0})
+	b := mat.NewDense(2, 1, []float64{-4, 3})
+
+	// The dual simplex stops before the feasibility with the bound of its dual feasible basis
+	_, results, bound, err := DualSimplex(mat.DenseCopyOf(c), mat.DenseCopyOf(A), mat.DenseCopyOf(b), silent, WithTimeLimit(time.Nanosecond))
+	assert.Equal(t, StatusTimeout, StatusOf(err))
+	assert.Nil(t, results)
+	assert.True(t, bound >= -9)
+	_, results, score, err := DualSimplex(mat.DenseCopyOf(c), mat.DenseCopyOf(A), mat.DenseCopyOf(b), silent, WithTimeLimit(time.Minute))
+	require.NoError(t, err)
+	require.NotNil(t, results)
+	assert.InDelta(t, -9, score, 1e-9)
+
+	// The phase one of the primal simplex has no basic feasible solution to return
+	_, results, _, err = Simplex(mat.DenseCopyOf(c), mat.DenseCopyOf(A), mat.DenseCopyOf(b), silent, WithTimeLimit(time.Nanosecond))
+	assert.Equal(t, StatusTimeout, StatusOf(err))
+	assert.Nil(t, results)
+
+	_, results, _, err = InteriorPoint(c, A, b, silent, WithTimeLimit(time.Nanosecond))
+	assert.True(t, stderrors.Is(err, ErrTimeLimit))
+	assert.True(t, stderrors.Is(err, ErrIterationLimit))
+	assert.NotNil(t, results)
+
+	// The iteration limit is not a timeout
+	_, _, _, err = Simplex(mat.DenseCopyOf(c), mat.DenseCopyOf(A), mat.DenseCopyOf(b), silent, WithMaxIter(1))
+	assert.Equal(t, StatusIterationLimit, StatusOf(err))
+}
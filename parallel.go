@@ -0,0 +1,87 @@
+package goptimization
+
+import (
+	"sync"
+)
+
+// exploreParallel Explore the search tree by rounds of Threads nodes solved concurrently.
+// A round takes the nodes at the top of the stack, each node is processed by a copy of the search
+// which sees the incumbent of the start of the round and numbers the node as the sequential search would.
+// At the end of the round the improved solutions are reduced by incumbentPool and the children
+// are pushed in the order of their parents, so the result does not depend on the scheduling of the goroutines.
+// It returns the nodes left when a limit stopped the search.
+func (bb *BranchAndBound) exploreParallel(stack []*node, maxNodes int) ([]*node, error) {
+	for len(stack) > 0 {
+		bb.Stopped = bb.stopReason(maxNodes)
+		if bb.Stopped != "" {
+			break
+		}
+		size := bb.Threads
+		if len(stack) < size {
+			size = len(stack)
+		}
+		if maxNodes-bb.Nodes < size {
+			size = maxNodes - bb.Nodes
+		}
+		round := make([]*node, size)
+		for k := range round {
+			round[k] = stack[len(stack)-1-k]
+		}
+		stack = stack[:len(stack)-size]
+
+		workers := make([]BranchAndBound, size)
+		children := make([][]*node, size)
+		errs := make([]error, size)
+		pool := &incumbentPool{}
+		var wg sync.WaitGroup
+		for k := range round {
+			workers[k] = *bb
+			workers[k].Nodes = bb.Nodes + k + 1
+			workers[k].Cuts = 0
+			workers[k].Incumbents = nil
+		}
+		for k := range round {
+			wg.Add(1)
+			go func(k int) {
+				defer wg.Done()
+				w := &workers[k]
+				children[k], errs[k] = w.process(round[k])
+				if len(w.Incumbents) > 0 {
+					pool.propose(candidate{
+						solution: w.incumbent,
+						score:    w.score,
+						source:   w.Incumbents[len(w.Incumbents)-1].Source,
+						key:      w.Nodes,
+					})
+				}
+			}(k)
+		}
+		wg.Wait()
+
+		for k := range round {
+			if errs[k] != nil {
+				return nil, errs[k]
+			}
+			bb.Cuts += workers[k].Cuts
+		}
+		bb.Nodes += size
+		best, ok := pool.reduce()
+		if ok && (bb.incumbent == nil || best.score > bb.score+epsilon) {
+			bb.incumbent = best.solution
+			bb.score = best.score
+			bb.Incumbents = append(bb.Incumbents, Incumbent{Score: best.score, Source: best.source, Node: best.key})
+		}
+		// The children of the first node of the round are explored first
+		for k := size - 1; k >= 0; k-- {
+			stack = append(stack, children[k]...)
+		}
+		if bb.report(stack) {
+			stack = nil
+		}
+		err := bb.periodicCheckpoint(stack)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stack, nil
+}
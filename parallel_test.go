@@ -0,0 +1,61 @@
+package goptimization
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+// knapsack Random binary problem with two knapsack constraints and a bound of 1 on each variable
+func knapsack(n int, seed int64) (*mat.Dense, *mat.Dense, *mat.Dense, []bool) {
+	rnd := rand.New(rand.NewSource(seed))
+	c := mat.NewDense(1, n, nil)
+	A := mat.NewDense(2+n, n, nil)
+	b := mat.NewDense(2+n, 1, nil)
+	integer := make([]bool, n)
+	for j := 0; j < n; j++ {
+		c.Set(0, j, float64(1+rnd.Intn(20)))
+		A.Set(0, j, float64(1+rnd.Intn(10)))
+		A.Set(1, j, float64(1+rnd.Intn(10)))
+		A.Set(2+j, j, 1)
+		b.Set(2+j, 0, 1)
+		integer[j] = true
+	}
+	b.Set(0, 0, float64(2*n))
+	b.Set(1, 0, float64(2*n))
+	return c, A, b, integer
+}
+
+func TestParallelBranchAndBound(t *testing.T) {
+	c, A, b, integer := knapsack(12, 7)
+
+	solve := func(threads int) *BranchAndBound {
+		bb := &BranchAndBound{}
+		require.NoError(t, bb.New(c, A, b, integer))
+		bb.CutRounds = 0
+		bb.Heuristics = nil
+		bb.Threads = threads
+		_, _, err := bb.Solve(10000)
+		require.NoError(t, err)
+		return bb
+	}
+
+	sequential := solve(1)
+	parallel := solve(4)
+	assert.InEpsilon(t, sequential.score, parallel.score, 0.000001)
+	assert.Greater(t, parallel.Nodes, 4)
+	for k := 0; k < 5; k++ {
+		again := solve(4)
+		assert.Equal(t, parallel.Nodes, again.Nodes)
+		assert.Equal(t, parallel.incumbent, again.incumbent)
+		assert.Equal(t, parallel.Incumbents, again.Incumbents)
+	}
+
+	_, results, score, err := MIP(c, A, b, integer, 10000, WithThreads(4))
+	require.NoError(t, err)
+	assert.InEpsilon(t, sequential.score, score, 0.000001)
+	assert.NotNil(t, results)
+}
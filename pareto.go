@@ -0,0 +1,198 @@
+package goptimization
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// augmentation Weight of the slacks of the ε-constraints in the objective of ParetoFrontier
+const augmentation = 1e-3
+
+// ParetoPoint Pareto-optimal vertex of ParetoFrontier
+type ParetoPoint struct {
+	// X Value of each variable
+	X []float64
+	// Objectives Value of each objective at X
+	Objectives []float64
+}
+
+// ParetoFrontier Enumerate the Pareto-optimal vertices of the maximization of two or three linear objectives,
+// the rows of objectives, under the constraints of Simplex, with the augmented ε-constraint method (AUGMECON):
+// - Maximize each objective alone, the payoff table gives the range [min_k, max_k] of each objective k >= 1
+// - Maximize f_0 + δ*Σ s_k/(max_k-min_k) with the constraints f_k(x) - s_k = ε_k, for steps+1 values of ε_k
+// from min_k to max_k, on a grid for three objectives.
+// The small weight δ of the slacks s_k makes each solution Pareto-optimal, without the second, lexicographic,
+// solve of the plain ε-constraint method. The objective does not change along the grid: each ε_k is a right-hand
+// side, updated with SetRHS which re-optimizes with a few dual pivots from the basis of the previous point.
+// The vertices are sorted by the objectives from the last to the first, the duplicates are removed.
+// The options are given to the solves, the errors are those of Simplex for the payoff table.
+func ParetoFrontier(objectives *mat.Dense, A *mat.Dense, b mat.Matrix, steps int, opts ...Option) ([]ParetoPoint, error) {
+	o := newOptions(opts)
+	if objectives == nil || A == nil {
+		return nil, newError(ErrDimensionMismatch, "objectives and A must not be nil")
+	}
+	k, n := objectives.Dims()
+	if k != 2 && k != 3 {
+		return nil, newError(ErrDimensionMismatch, "ParetoFrontier needs 2 or 3 objectives, got %d", k)
+	}
+	if steps < 1 {
+		return nil, errors.Errorf("steps must be >= 1, got %d", steps)
+	}
+	column, err := asColumn(b, "b")
+	if err != nil {
+		return nil, err
+	}
+	err = checkDims(objectives.Slice(0, 1, 0, n).(*mat.Dense), A, column)
+	if err != nil {
+		return nil, err
+	}
+
+	// Payoff table, the value of each objective at the optimum of each other
+	payoff := make([][]float64, k)
+	for i := range payoff {
+		_, results, _, err := Simplex(objectives.Slice(i, i+1, 0, n), mat.DenseCopyOf(A), column, opts...)
+		if err != nil {
+			return nil, err
+		}
+		payoff[i] = objectiveValues(objectives, mat.Col(nil, 0, results)[:n])
+	}
+	lower := make([]float64, k)
+	width := make([]float64, k)
+	for obj := 1; obj < k; obj++ {
+		lower[obj] = payoff[obj][obj]
+		for i := range payoff {
+			lower[obj] = math.Min(lower[obj], payoff[i][obj])
+		}
+		width[obj] = payoff[obj][obj] - lower[obj]
+	}
+
+	// max f_0 + δ*Σ f_k/width_k, the constant parts of the slacks are dropped, with the rows -f_k(x) <= -ε_k
+	m, _ := A.Dims()
+	c := mat.NewDense(1, n, nil)
+	c.Copy(objectives.Slice(0, 1, 0, n))
+	augmented := mat.NewDense(m+k-1, n, nil)
+	augmented.Slice(0, m, 0, n).(*mat.Dense).Copy(A)
+	rhs := mat.NewDense(m+k-1, 1, nil)
+	rhs.Slice(0, m, 0, 1).(*mat.Dense).Copy(column)
+	for obj := 1; obj < k; obj++ {
+		scale := augmentation / math.Max(width[obj], 1)
+		for j := 0; j < n; j++ {
+			c.Set(0, j, c.At(0, j)+scale*objectives.At(obj, j))
+			augmented.Set(m+obj-1, j, -objectives.At(obj, j))
+		}
+		rhs.Set(m+obj-1, 0, -lower[obj])
+	}
+	cf := CanonicalForm{}
+	err = cf.New(c, augmented, rhs)
+	if err != nil {
+		return nil, err
+	}
+	cf.configure(o)
+	_, _, _, err = cf.run(o)
+	if err != nil {
+		return nil, err
+	}
+
+	epsilon := func(obj, step int) float64 {
+		return lower[obj] + width[obj]*float64(step)/float64(steps)
+	}
+	points := []ParetoPoint{}
+	add := func() {
+		x := append([]float64(nil), cf.Solution().X...)
+		points = append(points, ParetoPoint{X: x, Objectives: objectiveValues(objectives, x)})
+	}
+	inner := 0
+	if k == 3 {
+		inner = steps
+	}
+	for outer := 0; outer <= steps; outer++ {
+		if k == 3 && outer > 0 {
+			// The previous point is feasible with the loosest ε_2, before ε_1 is tightened
+			_, err = cf.SetRHS(m+1, -lower[2])
+			if err != nil {
+				return nil, err
+			}
+		}
+		_, err = cf.SetRHS(m, -epsilon(1, outer))
+		if errors.Cause(err) == ErrInfeasible {
+			// f_1 >= ε_1 is out of reach, and so are the next values
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for step := 0; step <= inner; step++ {
+			if k == 3 {
+				_, err = cf.SetRHS(m+1, -epsilon(2, step))
+				if errors.Cause(err) == ErrInfeasible {
+					break
+				}
+				if err != nil {
+					return nil, err
+				}
+			}
+			add()
+		}
+	}
+	return frontier(points, feasibilityTolerance), nil
+}
+
+// objectiveValues Value of each objective, the rows of objectives, at x
+func objectiveValues(objectives *mat.Dense, x []float64) []float64 {
+	k, _ := objectives.Dims()
+	values := make([]float64, k)
+	for i := range values {
+		for j, xj := range x {
+			values[i] += objectives.At(i, j) * xj
+		}
+	}
+	return values
+}
+
+// frontier Sort the points by their objectives from the last to the first and remove the duplicates
+// and the dominated points, the objectives are compared within tolerance
+func frontier(points []ParetoPoint, tolerance float64) []ParetoPoint {
+	sort.SliceStable(points, func(a, b int) bool {
+		pa, pb := points[a].Objectives, points[b].Objectives
+		for i := len(pa) - 1; i >= 0; i-- {
+			if math.Abs(pa[i]-pb[i]) > tolerance {
+				return pa[i] < pb[i]
+			}
+		}
+		return false
+	})
+	dominates := func(p, q []float64) bool {
+		strict := false
+		for i := range p {
+			if p[i] < q[i]-tolerance {
+				return false
+			}
+			strict = strict || p[i] > q[i]+tolerance
+		}
+		return strict
+	}
+	kept := []ParetoPoint{}
+	for _, p := range points {
+		keep := true
+		for _, q := range points {
+			if dominates(q.Objectives, p.Objectives) {
+				keep = false
+				break
+			}
+		}
+		for _, q := range kept {
+			equal := true
+			for i := range q.Objectives {
+				equal = equal && math.Abs(q.Objectives[i]-p.Objectives[i]) <= tolerance
+			}
+			keep = keep && !equal
+		}
+		if keep {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
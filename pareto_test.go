@@ -0,0 +1,76 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestParetoFrontier(t *testing.T) {
+	// Maximize x1 and x2 with x1 + x2 <= 4, x1 <= 3 and x2 <= 3: the frontier is the segment from (3, 1) to (1, 3)
+	objectives := mat.NewDense(2, 2, []float64{
+		1, 0,
+		0, 1,
+	})
+	A := mat.NewDense(3, 2, []float64{
+		1, 1,
+		1, 0,
+		0, 1,
+	})
+	b := mat.NewDense(3, 1, []float64{4, 3, 3})
+	points, err := ParetoFrontier(objectives, A, b, 4, silent)
+	require.NoError(t, err)
+	// ε_1 in {0, 0.75, 1.5, 2.25, 3}, the first two give (3, 1)
+	require.Len(t, points, 4)
+	assert.InDeltaSlice(t, []float64{3, 1}, points[0].Objectives, 1e-9)
+	assert.InDeltaSlice(t, []float64{1, 3}, points[3].Objectives, 1e-9)
+	for _, p := range points {
+		assert.InDelta(t, 4, p.X[0]+p.X[1], 1e-9)
+		assert.InDeltaSlice(t, p.X, p.Objectives, 1e-12)
+	}
+
+	_, err = ParetoFrontier(mat.NewDense(1, 2, []float64{1, 1}), A, b, 4, silent)
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+	_, err = ParetoFrontier(objectives, A, b, 0, silent)
+	assert.Error(t, err)
+	_, err = ParetoFrontier(objectives, A.Slice(0, 1, 0, 2).(*mat.Dense), mat.NewDense(1, 1, []float64{-1}), 2, silent)
+	assert.Equal(t, ErrInfeasible, errors.Cause(err))
+}
+
+func TestParetoFrontierThreeObjectives(t *testing.T) {
+	// The frontier of x1, x2 and x3 with x1 + x2 + x3 <= 1 and x1 + 2*x2 <= 1.5 is a part of the face Σ x_j = 1
+	objectives := mat.NewDense(3, 3, []float64{
+		1, 0, 0,
+		0, 1, 0,
+		0, 0, 1,
+	})
+	A := mat.NewDense(2, 3, []float64{
+		1, 1, 1,
+		1, 2, 0,
+	})
+	b := mat.NewDense(2, 1, []float64{1, 1.5})
+	points, err := ParetoFrontier(objectives, A, b, 4, silent)
+	require.NoError(t, err)
+	assert.True(t, len(points) > 6, "%d points", len(points))
+	for i, p := range points {
+		assert.InDelta(t, 1, p.X[0]+p.X[1]+p.X[2], 1e-9)
+		assert.True(t, feasible(A, b, p.X))
+		for k, q := range points {
+			if i == k {
+				continue
+			}
+			dominated := true
+			for obj := range p.Objectives {
+				dominated = dominated && q.Objectives[obj] >= p.Objectives[obj]-1e-9
+			}
+			assert.False(t, dominated, "%v dominated by %v", p.Objectives, q.Objectives)
+		}
+	}
+	// Sorted from the last objective to the first
+	for i := 1; i < len(points); i++ {
+		assert.True(t, points[i].Objectives[2] >= points[i-1].Objectives[2]-1e-6)
+	}
+}
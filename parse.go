@@ -0,0 +1,255 @@
+package goptimization
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// AddConstraintString Add a constraint written like "3x + 2y <= 10" to the model, the variables are named like in
+// AddVariable and must be in the model. The constraint is
+// - an optional name followed by a colon, see AddNamedRow
+// - two linear expressions separated by <=, >=, = (also written ==, ≤ or ≥), or lower <= e <= upper for a range, see AddRange
+// An expression is a sum of terms, each term is a number, a variable or a number times a variable, like 3x, 3 x or 3*x.
+// The variables may appear on both sides, e.g. "x <= 2y" is the constraint x - 2y <= 0.
+// The names start with a letter or _ and go on with letters, digits, _, . and bracketed indexes, like x[0][1] of Vars.
+func (m *Model) AddConstraintString(constraint string) error {
+	name := ""
+	p := &exprParser{src: constraint, names: m.nameIndex()}
+	if k := strings.Index(constraint, ":"); k >= 0 {
+		name = strings.TrimSpace(constraint[:k])
+		p.pos = k + 1
+	}
+	sides, senses, err := p.parseConstraint()
+	if err != nil {
+		return errors.Wrapf(err, "constraint %q", constraint)
+	}
+	if len(sides) == 3 {
+		// lower <= e <= upper, or upper >= e >= lower
+		lower, e, upper := sides[0], sides[1], sides[2]
+		if senses[0] != senses[1] || senses[0] == Equal {
+			return errors.Errorf("constraint %q: a range needs two <= or two >=", constraint)
+		}
+		if senses[0] == GreaterEq {
+			lower, upper = upper, lower
+		}
+		if len(lower.Terms) > 0 || len(upper.Terms) > 0 {
+			return errors.Errorf("constraint %q: the bounds of a range must be constants", constraint)
+		}
+		return m.AddRange(name, e, lower.Constant, upper.Constant)
+	}
+	// left - right sense 0, the constant moves to the right-hand side
+	e := Expr{Terms: append(sides[0].Terms, scaleTerms(sides[1].Terms, -1)...), Constant: sides[0].Constant - sides[1].Constant}
+	return m.AddNamedRow(name, e, senses[0], 0)
+}
+
+// ParseExpr Linear expression written like "3x + 2y - 1", see AddConstraintString, e.g. for the objective:
+// m.Maximize(e)
+func (m *Model) ParseExpr(expr string) (Expr, error) {
+	p := &exprParser{src: expr, names: m.nameIndex()}
+	e, err := p.parseExpr()
+	if err == nil && p.skipSpaces() < len(p.src) {
+		err = p.errorf("unexpected %q", p.src[p.pos:])
+	}
+	if err != nil {
+		return Expr{}, errors.Wrapf(err, "expression %q", expr)
+	}
+	return e, nil
+}
+
+// nameIndex Variable of each name, the first one for a repeated name
+func (m *Model) nameIndex() map[string]Var {
+	index := make(map[string]Var, len(m.names))
+	for v := len(m.names) - 1; v >= 0; v-- {
+		index[m.names[v]] = Var(v)
+	}
+	return index
+}
+
+// exprParser Recursive descent parser of the linear expressions and constraints
+type exprParser struct {
+	src   string
+	pos   int
+	names map[string]Var
+}
+
+// errorf Error at the current position
+func (p *exprParser) errorf(format string, args ...interface{}) error {
+	return errors.Errorf("column %d: "+format, append([]interface{}{p.pos + 1}, args...)...)
+}
+
+// skipSpaces Skip the spaces and return the position
+func (p *exprParser) skipSpaces() int {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+	return p.pos
+}
+
+// parseConstraint Two or three expressions separated by senses
+func (p *exprParser) parseConstraint() ([]Expr, []Sense, error) {
+	sides := []Expr{}
+	senses := []Sense{}
+	for {
+		e, err := p.parseExpr()
+		if err != nil {
+			return nil, nil, err
+		}
+		sides = append(sides, e)
+		if p.skipSpaces() == len(p.src) {
+			break
+		}
+		sense, ok := p.parseSense()
+		if !ok {
+			return nil, nil, p.errorf("unexpected %q", p.src[p.pos:])
+		}
+		if len(senses) == 2 {
+			return nil, nil, p.errorf("too many comparisons")
+		}
+		senses = append(senses, sense)
+	}
+	if len(senses) == 0 {
+		return nil, nil, errors.New("no <=, >= or =")
+	}
+	return sides, senses, nil
+}
+
+// parseSense Comparison at the current position
+func (p *exprParser) parseSense() (Sense, bool) {
+	for _, s := range []struct {
+		text  string
+		sense Sense
+	}{{"<=", LessEq}, {"≤", LessEq}, {">=", GreaterEq}, {"≥", GreaterEq}, {"==", Equal}, {"=", Equal}} {
+		if strings.HasPrefix(p.src[p.pos:], s.text) {
+			p.pos += len(s.text)
+			return s.sense, true
+		}
+	}
+	return 0, false
+}
+
+// parseExpr Sum of terms with their signs
+func (p *exprParser) parseExpr() (Expr, error) {
+	e := Expr{}
+	first := true
+	for {
+		sign := 1.0
+		p.skipSpaces()
+		switch {
+		case p.pos < len(p.src) && (p.src[p.pos] == '+' || p.src[p.pos] == '-'):
+			if p.src[p.pos] == '-' {
+				sign = -1
+			}
+			p.pos++
+		case !first:
+			return e, nil
+		}
+		first = false
+		coef, v, err := p.parseTerm()
+		if err != nil {
+			return Expr{}, err
+		}
+		if v < 0 {
+			e.Constant += sign * coef
+		} else {
+			e.Terms = append(e.Terms, Term{Var: v, Coef: sign * coef})
+		}
+	}
+}
+
+// parseTerm Number, variable or number times a variable, the variable is -1 for a number
+func (p *exprParser) parseTerm() (float64, Var, error) {
+	p.skipSpaces()
+	coef := 1.0
+	number := false
+	if start := p.pos; p.pos < len(p.src) && (isDigit(p.src[p.pos]) || p.src[p.pos] == '.') {
+		p.scanNumber()
+		value, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+		if err != nil {
+			text := p.src[start:p.pos]
+			p.pos = start
+			return 0, 0, p.errorf("invalid number %q", text)
+		}
+		coef, number = value, true
+		p.skipSpaces()
+		if p.pos < len(p.src) && p.src[p.pos] == '*' {
+			p.pos++
+			p.skipSpaces()
+			number = false
+		}
+	}
+	if p.pos >= len(p.src) || !isNameStart(p.src[p.pos:]) {
+		if number {
+			return coef, -1, nil
+		}
+		if p.pos >= len(p.src) {
+			return 0, 0, p.errorf("missing term at the end")
+		}
+		return 0, 0, p.errorf("unexpected %q", p.src[p.pos:])
+	}
+	start := p.pos
+	name := p.scanName()
+	v, ok := p.names[name]
+	if !ok {
+		p.pos = start
+		return 0, 0, p.errorf("variable %s is not in the model", name)
+	}
+	return coef, v, nil
+}
+
+// scanNumber Move past a decimal number with an optional exponent
+func (p *exprParser) scanNumber() {
+	for p.pos < len(p.src) && (isDigit(p.src[p.pos]) || p.src[p.pos] == '.') {
+		p.pos++
+	}
+	// An exponent needs digits, 2e is the coefficient 2 of the variable e
+	if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+		k := p.pos + 1
+		if k < len(p.src) && (p.src[k] == '+' || p.src[k] == '-') {
+			k++
+		}
+		if k < len(p.src) && isDigit(p.src[k]) {
+			p.pos = k
+			for p.pos < len(p.src) && isDigit(p.src[p.pos]) {
+				p.pos++
+			}
+		}
+	}
+}
+
+// scanName Move past a name with its bracketed indexes and return it
+func (p *exprParser) scanName() string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+		switch {
+		case r == '[':
+			end := strings.IndexByte(p.src[p.pos:], ']')
+			if end < 0 {
+				p.pos = len(p.src)
+				return p.src[start:]
+			}
+			p.pos += end + 1
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.':
+			p.pos += size
+			continue
+		}
+		break
+	}
+	return p.src[start:p.pos]
+}
+
+// isNameStart Check if s starts with a letter or _
+func isNameStart(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsLetter(r) || r == '_'
+}
+
+// isDigit Check if c is a decimal digit
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
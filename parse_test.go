@@ -0,0 +1,67 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddConstraintString(t *testing.T) {
+	m := &Model{}
+	m.AddVariable("x", false)
+	m.AddVariable("y", false)
+	m.AddVariable("e", false)
+	objective, err := m.ParseExpr("5x + 4 y + 3*e")
+	require.NoError(t, err)
+	assert.Equal(t, Expr{Terms: []Term{{Var: 0, Coef: 5}, {Var: 1, Coef: 4}, {Var: 2, Coef: 3}}}, objective)
+	m.Maximize(objective)
+	require.NoError(t, m.AddConstraintString("wood: 2x + 3y + e <= 5"))
+	require.NoError(t, m.AddConstraintString("4x + y + 2e - 1 <= 10"))
+	// 3x + 4y + 2e <= 8, 2e is 2 times e but 0.8e1 is 8
+	require.NoError(t, m.AddConstraintString("3x + 4y ≤ 0.8e1 - 2e"))
+	require.NoError(t, m.AddConstraintString("0 <= x - y <= 10"))
+	assert.Equal(t, []string{"wood", "", "", "", ""}, m.RowNames())
+
+	c, A, b, _ := m.Standard()
+	assert.Equal(t, []float64{5, 4, 3}, c.RawRowView(0))
+	assert.Equal(t, []float64{2, 3, 1}, A.RawRowView(0))
+	assert.Equal(t, []float64{4, 1, 2}, A.RawRowView(1))
+	assert.Equal(t, 11.0, b.At(1, 0))
+	assert.Equal(t, []float64{3, 4, 2}, A.RawRowView(2))
+	assert.Equal(t, 8.0, b.At(2, 0))
+	solution, err := m.Solve(100, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, 13, solution.Score, 1e-9)
+
+	v := &Model{}
+	x := v.Vars("x", 2, 2)
+	require.NoError(t, v.AddConstraintString("x[0][1] + x[1][0] >= 1"))
+	require.NoError(t, v.AddConstraintString("10 >= x[1][1] >= 2"))
+	require.NoError(t, v.AddConstraintString("-x[0][0] == -3"))
+	_, A, b, _ = v.Standard()
+	assert.Equal(t, []float64{0, -1, -1, 0}, A.RawRowView(0))
+	assert.Equal(t, -1.0, b.At(0, 0))
+	assert.Equal(t, x.At(1, 1), Var(3))
+}
+
+func TestAddConstraintStringErrors(t *testing.T) {
+	m := &Model{}
+	m.AddVariable("x", false)
+	for constraint, message := range map[string]string{
+		"x + z <= 1":       `constraint "x + z <= 1": column 5: variable z is not in the model`,
+		"x + 1":            `constraint "x + 1": no <=, >= or =`,
+		"x + <= 1":         `constraint "x + <= 1": column 5: unexpected "<= 1"`,
+		"x <= ":            `constraint "x <= ": column 6: missing term at the end`,
+		"x < 1":            `constraint "x < 1": column 3: unexpected "< 1"`,
+		"0 <= x >= 1":      `constraint "0 <= x >= 1": a range needs two <= or two >=`,
+		"x <= x <= 1":      `constraint "x <= x <= 1": the bounds of a range must be constants`,
+		"0 <= x <= 1 <= 2": `constraint "0 <= x <= 1 <= 2": column 15: too many comparisons`,
+		"1.2.3x <= 1":      `constraint "1.2.3x <= 1": column 1: invalid number "1.2.3"`,
+		"c: 2 * 3 <= x":    `constraint "c: 2 * 3 <= x": column 8: unexpected "3 <= x"`,
+	} {
+		assert.EqualError(t, m.AddConstraintString(constraint), message, constraint)
+	}
+	_, err := m.ParseExpr("x <= 1")
+	assert.EqualError(t, err, `expression "x <= 1": column 3: unexpected "<= 1"`)
+}
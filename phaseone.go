@@ -0,0 +1,161 @@
+package goptimization
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// phaseOne Find a feasible basis when the basis has negative values, e.g. the slack basis when b_i < 0
+// An artificial variable x0 with the column -B*1, -1 in every constraint for the slack basis, enters the basis
+// in place of the most negative basic variable, which makes the dictionary feasible. The primal simplex then maximizes -x0:
+// the problem is infeasible if x0 > 0 at the optimum. Otherwise x0 leaves the basis, its column is removed and the costs
+// are restored. It returns the number of iterations, maxIter <= 0 means unlimited.
+// The iterations stop at the deadline of the dictionary or once its context is done, see configure.
+func (cf *CanonicalForm) phaseOne(maxIter int) (int, error) {
+	maxIter = iterationLimit(maxIter, cf.n, cf.m)
+	// A basic variable above its upper bound is negative once substituted
+	cf.reflectAbove()
+	leaving := -1
+	min := -cf.tolerance
+	for i := 0; i < cf.m; i++ {
+		if cf.xBStar.At(i, 0) < min {
+			min = cf.xBStar.At(i, 0)
+			leaving = i
+		}
+	}
+	if leaving == -1 {
+		return 0, nil
+	}
+
+	costs := cf.costs()
+	err := cf.AddColumn(cf.phaseOneColumn(min), -1)
+	if err != nil {
+		return 0, err
+	}
+	artificial := cf.n + cf.m - 1
+	phaseOneCosts := make([]float64, cf.n+cf.m)
+	phaseOneCosts[artificial] = -1
+	cf.setCosts(phaseOneCosts)
+
+	// x0 enters at the position of the last nonbasic variable
+	y, err := cf.FindY()
+	if err != nil {
+		return 0, err
+	}
+	d, err := cf.SolveBd(cf.n - 1)
+	if err != nil {
+		return 0, err
+	}
+	err = cf.pivot(d, y, cf.xBStar.At(leaving, 0)/d.At(leaving, 0), cf.n-1, leaving, false)
+	if err != nil {
+		return 0, err
+	}
+
+	totalIter := 1
+	for ; totalIter < maxIter && !expired(cf.deadline) && !cf.cancelled(); totalIter++ {
+		end, err := cf.Iter(0)
+		if err != nil {
+			return totalIter, err
+		}
+		if end {
+			break
+		}
+	}
+	optimal, err := cf.optimal()
+	if err != nil {
+		return totalIter, err
+	}
+	if !optimal {
+		return totalIter, limitError(cf.deadline)
+	}
+	values, _ := cf.values()
+	if values[artificial] > feasibilityTolerance {
+		return totalIter, ErrInfeasible
+	}
+
+	// A degenerate x0 leaves the basis for the nonbasic variable with the largest coefficient in its row
+	for i := 0; i < cf.m; i++ {
+		if cf.remap[cf.n+i] != artificial {
+			continue
+		}
+		row, err := cf.tableauRow(i)
+		if err != nil {
+			return totalIter, err
+		}
+		entering := -1
+		for j := 0; j < cf.n; j++ {
+			if math.Abs(row.At(0, j)) > cf.tolerance && (entering == -1 || math.Abs(row.At(0, j)) > math.Abs(row.At(0, entering))) {
+				entering = j
+			}
+		}
+		if entering == -1 {
+			return totalIter, errors.New("the artificial variable cannot leave the basis")
+		}
+		y, err := cf.FindY()
+		if err != nil {
+			return totalIter, err
+		}
+		d, err := cf.SolveBd(entering)
+		if err != nil {
+			return totalIter, err
+		}
+		err = cf.pivot(d, y, cf.xBStar.At(i, 0)/d.At(i, 0), entering, i, false)
+		if err != nil {
+			return totalIter, err
+		}
+		totalIter++
+	}
+
+	for j := 0; j < cf.n; j++ {
+		if cf.remap[j] == artificial {
+			cf.drop(j, -1)
+			break
+		}
+	}
+	cf.setCosts(costs)
+	return totalIter, nil
+}
+
+// twoPhaseSimplex Solve a linear problem in the standard form of Simplex where b can be negative,
+// with phaseOne before the primal simplex. The options configure the dictionary, WithMaxIter aside.
+func twoPhaseSimplex(c, A, b *mat.Dense, maxIter int, opts ...Option) (int, *mat.Dense, float64, error) {
+	o := newOptions(opts)
+	cf := CanonicalForm{}
+	err := cf.New(mat.DenseCopyOf(c), mat.DenseCopyOf(A), mat.DenseCopyOf(b))
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	cf.configure(o)
+	cf.deadline = o.deadline()
+	totalIter, err := cf.twoPhase(maxIter)
+	if err != nil {
+		return totalIter, nil, 0, err
+	}
+	values, score := cf.values()
+	return totalIter, mat.NewDense(len(values), 1, values), score, nil
+}
+
+// twoPhase Run phaseOne and then the primal simplex from the slack basis, maxIter <= 0 means unlimited.
+// It returns the number of iterations of both phases.
+func (cf *CanonicalForm) twoPhase(maxIter int) (int, error) {
+	maxIter = iterationLimit(maxIter, cf.n, cf.m)
+	totalIter, err := cf.phaseOne(maxIter)
+	if err != nil {
+		return totalIter, err
+	}
+	// Reoptimize(0) would be unlimited
+	if totalIter >= maxIter {
+		optimal, err := cf.optimal()
+		if err != nil {
+			return totalIter, err
+		}
+		if !optimal {
+			return totalIter, ErrIterationLimit
+		}
+		return totalIter, nil
+	}
+	iter, err := cf.Reoptimize(maxIter - totalIter)
+	return totalIter + iter, err
+}
@@ -0,0 +1,94 @@
+package goptimization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestPhaseOne(t *testing.T) {
+	//Minimize x + 2y with x + y >= 2, x <= 1.5
+	c := mat.NewDense(1, 2, []float64{-1, -2})
+	A := mat.NewDense(2, 2, []float64{
+		-1, -1,
+		1, 0,
+	})
+	b := mat.NewDense(2, 1, []float64{-2, 1.5})
+
+	_, results, score, err := twoPhaseSimplex(c, A, b, 100, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, -2.5, score, 0.000001)
+	assert.True(t, mat.EqualApprox(mat.NewDense(4, 1, []float64{1.5, 0.5, 0, 0}), results, 0.000001))
+
+	//x <= 1 and x >= 2
+	_, _, _, err = twoPhaseSimplex(mat.NewDense(1, 1, []float64{1}), mat.NewDense(2, 1, []float64{1, -1}), mat.NewDense(2, 1, []float64{1, -2}), 100, silent)
+	assert.Equal(t, ErrInfeasible, err)
+
+	//The options configure the dictionary, a cancelled context stops it in phase one
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, _, err = twoPhaseSimplex(c, A, b, 100, WithContext(ctx), silent)
+	assert.Equal(t, ErrIterationLimit, err)
+}
+
+func TestMIPPhaseOne(t *testing.T) {
+	//Minimize 3x + 2y with 2x + 3y >= 7, x, y integer
+	c := mat.NewDense(1, 2, []float64{-3, -2})
+	A := mat.NewDense(1, 2, []float64{-2, -3})
+	b := mat.NewDense(1, 1, []float64{-7})
+
+	_, results, score, err := MIP(c, A, b, []bool{true, true}, 100)
+	require.NoError(t, err)
+	assert.InDelta(t, -6.0, score, 0.000001)
+	assert.True(t, mat.EqualApprox(mat.NewDense(3, 1, []float64{0, 3, 2}), results, 0.000001))
+}
+
+func TestPhaseOneTolerance(t *testing.T) {
+	//x + y <= 1 and x >= 1e-7, which is 0 within the tolerance 1e-6 of WithTolerance
+	c := mat.NewDense(1, 2, []float64{1, 1})
+	A := mat.NewDense(2, 2, []float64{1, 1, -1, 0})
+	b := mat.NewDense(2, 1, []float64{1, -1e-7})
+
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(c, A, b))
+	cf.configure(newOptions([]Option{WithTolerance(1e-6), silent}))
+	iter, err := cf.phaseOne(100)
+	require.NoError(t, err)
+	assert.Equal(t, 0, iter)
+
+	require.NoError(t, cf.New(c, A, b))
+	cf.configure(newOptions([]Option{silent}))
+	iter, err = cf.phaseOne(100)
+	require.NoError(t, err)
+	assert.True(t, iter > 0)
+}
+
+func TestPhaseOneContext(t *testing.T) {
+	//Minimize Σ x_i with x_i >= 1
+	c := mat.NewDense(1, 5, []float64{-1, -1, -1, -1, -1})
+	A := mat.NewDense(5, 5, nil)
+	b := mat.NewDense(5, 1, nil)
+	for i := 0; i < 5; i++ {
+		A.Set(i, i, -1)
+		b.Set(i, 0, -1)
+	}
+
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(c, A, b))
+	cf.configure(newOptions([]Option{silent}))
+	iter, err := cf.phaseOne(100)
+	require.NoError(t, err)
+	assert.True(t, iter > 1)
+
+	//The artificial variable enters, then the cancelled context stops the iterations
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, cf.New(c, A, b))
+	cf.configure(newOptions([]Option{WithContext(ctx), silent}))
+	iter, err = cf.phaseOne(100)
+	assert.Equal(t, ErrIterationLimit, err)
+	assert.Equal(t, 1, iter)
+}
@@ -0,0 +1,168 @@
+package goptimization
+
+import (
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// PiecewiseLinear Term f(x_Var) of the objective, linear between the points (X_k, Y_k)
+// X starts at 0 and is strictly increasing, x_Var is restricted to [0, X_K].
+// The objective is maximized: a convex cost is a concave term, pass the opposite of the cost.
+type PiecewiseLinear struct {
+	Var int
+	X   []float64
+	Y   []float64
+}
+
+// slopes Slope of each segment
+func (p PiecewiseLinear) slopes() []float64 {
+	slopes := make([]float64, len(p.X)-1)
+	for k := range slopes {
+		slopes[k] = (p.Y[k+1] - p.Y[k]) / (p.X[k+1] - p.X[k])
+	}
+	return slopes
+}
+
+// Concave Check if the slopes are non-increasing, the term is then exact in a linear problem
+func (p PiecewiseLinear) Concave() bool {
+	slopes := p.slopes()
+	for k := 1; k < len(slopes); k++ {
+		if slopes[k] > slopes[k-1]+epsilon {
+			return false
+		}
+	}
+	return true
+}
+
+// Piecewise Solve a problem whose objective has piecewise linear terms
+// Maximize z = Σ(1<=j<=n) c_j*x_j + Σ(terms) f(x_Var)
+// Constraints:
+// 1<=i<=m,  Σ(1<=j<=n) a_i_j*x_j <= b_i
+// 1<=j<=n x_j >= 0, x_j integer if integer[j]
+// Each term becomes segment variables x_Var = Σ d_k with 0 <= d_k <= X_k+1 - X_k. A concave term fills its
+// segments in order by itself, a non-concave term gets binary variables z_k = 1 when the segment k is full:
+// (X_k+1 - X_k)*z_k <= d_k and d_k+1 <= (X_k+2 - X_k+1)*z_k.
+// The problem is solved with Simplex when all the terms are concave and integer is nil, with MIP otherwise,
+// maxIter is then the maximum number of explored nodes.
+// The options are given to Simplex or MIP.
+// It returns the number of iterations or nodes, a matrix (n+m,1) like Simplex and the score.
+func Piecewise(c, A, b *mat.Dense, terms []PiecewiseLinear, integer []bool, maxIter int, opts ...Option) (int, *mat.Dense, float64, error) {
+	_, n := c.Dims()
+	m, cols := A.Dims()
+	if cols != n {
+		return 0, nil, 0, newError(ErrDimensionMismatch, "A dims.c != z dims.c")
+	}
+	if integer != nil && len(integer) != n {
+		return 0, nil, 0, newError(ErrDimensionMismatch, "len(integer) != z dims.c")
+	}
+	seen := map[int]bool{}
+	for _, p := range terms {
+		if p.Var < 0 || p.Var >= n {
+			return 0, nil, 0, errors.Errorf("variable %d is not an original variable", p.Var)
+		}
+		if seen[p.Var] {
+			return 0, nil, 0, errors.Errorf("variable %d has several piecewise linear terms", p.Var)
+		}
+		seen[p.Var] = true
+		if len(p.X) < 2 || len(p.X) != len(p.Y) {
+			return 0, nil, 0, newError(ErrDimensionMismatch, "a piecewise linear term needs len(X) == len(Y) >= 2")
+		}
+		if p.X[0] != 0 {
+			return 0, nil, 0, errors.New("X must start at 0")
+		}
+		for k := 1; k < len(p.X); k++ {
+			if p.X[k] <= p.X[k-1] {
+				return 0, nil, 0, errors.New("X must be strictly increasing")
+			}
+		}
+	}
+
+	// Count the segment and binary variables, and the rows: 2 to link x_Var with the segments, 1 bound by segment
+	// and 2 by binary variable
+	aux, rows := 0, 0
+	mip := integer != nil
+	for _, p := range terms {
+		segments := len(p.X) - 1
+		aux += segments
+		rows += 2 + segments
+		if !p.Concave() {
+			aux += segments - 1
+			rows += 2 * (segments - 1)
+			mip = true
+		}
+	}
+
+	pwC := mat.NewDense(1, n+aux, nil)
+	pwC.Slice(0, 1, 0, n).(*mat.Dense).Copy(c)
+	pwA := mat.NewDense(m+rows, n+aux, nil)
+	pwA.Slice(0, m, 0, n).(*mat.Dense).Copy(A)
+	pwB := mat.NewDense(m+rows, 1, nil)
+	pwB.Slice(0, m, 0, 1).(*mat.Dense).Copy(b)
+	pwInteger := make([]bool, n+aux)
+	copy(pwInteger, integer)
+
+	constant := 0.0
+	col, row := n, m
+	for _, p := range terms {
+		constant += p.Y[0]
+		slopes := p.slopes()
+		segments := len(slopes)
+		first := col
+
+		// x_Var = Σ d_k
+		pwA.Set(row, p.Var, 1)
+		pwA.Set(row+1, p.Var, -1)
+		for k := 0; k < segments; k++ {
+			pwC.Set(0, first+k, slopes[k])
+			pwA.Set(row, first+k, -1)
+			pwA.Set(row+1, first+k, 1)
+		}
+		row += 2
+
+		// d_k <= X_k+1 - X_k, the binary variables are bounded through the segments
+		for k := 0; k < segments; k++ {
+			pwA.Set(row, first+k, 1)
+			pwB.Set(row, 0, p.X[k+1]-p.X[k])
+			row++
+		}
+		col += segments
+		if p.Concave() {
+			continue
+		}
+
+		for k := 0; k < segments-1; k++ {
+			z := col + k
+			pwInteger[z] = true
+			// (X_k+1 - X_k)*z_k <= d_k
+			pwA.Set(row, z, p.X[k+1]-p.X[k])
+			pwA.Set(row, first+k, -1)
+			// d_k+1 <= (X_k+2 - X_k+1)*z_k
+			pwA.Set(row+1, first+k+1, 1)
+			pwA.Set(row+1, z, -(p.X[k+2] - p.X[k+1]))
+			row += 2
+		}
+		col += segments - 1
+	}
+
+	var iter int
+	var results *mat.Dense
+	var score float64
+	var err error
+	if mip {
+		iter, results, score, err = MIP(pwC, pwA, pwB, pwInteger, maxIter, opts...)
+	} else {
+		iter, results, score, err = Simplex(pwC, pwA, pwB, append([]Option{WithMaxIter(maxIter)}, opts...)...)
+	}
+	if err != nil {
+		return 0, nil, 0, err
+	}
+
+	x := mat.NewDense(n+m, 1, nil)
+	for j := 0; j < n; j++ {
+		x.Set(j, 0, results.At(j, 0))
+	}
+	for i := 0; i < m; i++ {
+		x.Set(n+i, 0, results.At(n+aux+i, 0))
+	}
+	return iter, x, score + constant, nil
+}
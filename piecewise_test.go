@@ -0,0 +1,54 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestPiecewiseConcave(t *testing.T) {
+	//Each unit of x costs 0.5, the revenue has decreasing returns and there is a fixed income of 1
+	c := mat.NewDense(1, 1, []float64{-0.5})
+	A := mat.NewDense(1, 1, []float64{1})
+	b := mat.NewDense(1, 1, []float64{6})
+	revenue := PiecewiseLinear{Var: 0, X: []float64{0, 2, 5, 8}, Y: []float64{1, 5, 8, 9}}
+	assert.True(t, revenue.Concave())
+
+	_, results, score, err := Piecewise(c, A, b, []PiecewiseLinear{revenue}, nil, 100, silent)
+	require.NoError(t, err)
+	assert.True(t, mat.EqualApprox(mat.NewDense(2, 1, []float64{5, 1}), results, 0.000001))
+	assert.InEpsilon(t, 5.5, score, 0.000001)
+}
+
+func TestPiecewiseNonConcave(t *testing.T) {
+	//The revenue only starts after the first unit, filling the second segment alone would be worth 4
+	c := mat.NewDense(1, 1, []float64{-1})
+	A := mat.NewDense(1, 1, []float64{1})
+	b := mat.NewDense(1, 1, []float64{3})
+	revenue := PiecewiseLinear{Var: 0, X: []float64{0, 1, 3}, Y: []float64{0, 0, 6}}
+	assert.False(t, revenue.Concave())
+
+	_, results, score, err := Piecewise(c, A, b, []PiecewiseLinear{revenue}, nil, 100, silent)
+	require.NoError(t, err)
+	assert.True(t, mat.EqualApprox(mat.NewDense(2, 1, []float64{3, 0}), results, 0.000001))
+	assert.InEpsilon(t, 3.0, score, 0.000001)
+}
+
+func TestPiecewiseErrors(t *testing.T) {
+	c := mat.NewDense(1, 1, nil)
+	A := mat.NewDense(1, 1, []float64{1})
+	b := mat.NewDense(1, 1, []float64{1})
+	for _, terms := range [][]PiecewiseLinear{
+		{{Var: 1, X: []float64{0, 1}, Y: []float64{0, 1}}},
+		{{Var: 0, X: []float64{0}, Y: []float64{0}}},
+		{{Var: 0, X: []float64{0, 1}, Y: []float64{0}}},
+		{{Var: 0, X: []float64{1, 2}, Y: []float64{0, 1}}},
+		{{Var: 0, X: []float64{0, 1, 1}, Y: []float64{0, 1, 2}}},
+		{{Var: 0, X: []float64{0, 1}, Y: []float64{0, 1}}, {Var: 0, X: []float64{0, 1}, Y: []float64{0, 1}}},
+	} {
+		_, _, _, err := Piecewise(c, A, b, terms, nil, 10, silent)
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,299 @@
+// Package plot2d Draw the linear problems with two variables with gonum/plot: the feasible region, the constraint lines,
+// the contours of the objective and the path of the simplex algorithm, e.g. for demos and docs.
+package plot2d
+
+import (
+	"fmt"
+	"image/color"
+	"io/ioutil"
+	"log"
+	"math"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+)
+
+// contours Number of contours of the objective, evenly spaced up to the optimum
+const contours = 4
+
+// Region Plot of the problem of goptimization.Simplex with two variables, maximize c*x subject to A*x <= b and x >= 0.
+// The plot shows the feasible region, a line per constraint, the contours of the objective and the vertices visited
+// by the primal simplex from the slack basis, or from the basis found by the phase one when a b_i is negative.
+// The axes show the vertices of the region and the intersections of the constraint lines, an unbounded region is cut.
+// Save the plot with its Save method, e.g. p.Save(4*vg.Inch, 4*vg.Inch, "region.svg").
+func Region(c, A, b *mat.Dense) (*plot.Plot, error) {
+	m, err := check(c, A, b)
+	if err != nil {
+		return nil, err
+	}
+	path, err := simplexPath(c, A, b)
+	if err != nil && err != goptimization.ErrUnbounded {
+		return nil, err
+	}
+	// The last vertex is the optimum, or where the simplex found an unbounded direction
+	last := path[len(path)-1]
+	optimum := c.At(0, 0)*last[0] + c.At(0, 1)*last[1]
+	unbounded := err == goptimization.ErrUnbounded
+	width, height := viewBox(A, b, path)
+
+	p, err := plot.New()
+	if err != nil {
+		return nil, err
+	}
+	p.Title.Text = fmt.Sprintf("maximize %s", expression(c.RawRowView(0)))
+	if unbounded {
+		p.Title.Text += ", unbounded"
+	} else {
+		p.Title.Text += fmt.Sprintf(", optimum %.6g", optimum)
+	}
+	p.X.Label.Text = "x0"
+	p.Y.Label.Text = "x1"
+	p.X.Min, p.X.Max = 0, width
+	p.Y.Min, p.Y.Max = 0, height
+
+	region, err := plotter.NewPolygon(xys(feasibleRegion(A, b, width, height)))
+	if err != nil {
+		return nil, err
+	}
+	region.Color = color.RGBA{R: 0x9e, G: 0xca, B: 0xe1, A: 0xff}
+	region.LineStyle.Width = 0
+	p.Add(region)
+	p.Legend.Add("feasible region", region)
+
+	for i := 0; i < m; i++ {
+		a := A.RawRowView(i)
+		line, err := segmentLine(a, b.At(i, 0), width, height)
+		if err != nil {
+			return nil, err
+		}
+		if line == nil {
+			continue
+		}
+		line.Color = plotutil.Color(i)
+		line.Width = vg.Points(1.5)
+		p.Add(line)
+		p.Legend.Add(fmt.Sprintf("%s <= %.6g", expression(a), b.At(i, 0)), line)
+	}
+
+	// Without optimum the contours go up to the objective of the farthest corner of the box
+	top := optimum
+	if unbounded {
+		top = math.Max(c.At(0, 0)*width, 0) + math.Max(c.At(0, 1)*height, 0)
+	}
+	for k := 1; k <= contours; k++ {
+		level := top * float64(k) / contours
+		line, err := segmentLine(c.RawRowView(0), level, width, height)
+		if err != nil {
+			return nil, err
+		}
+		if line == nil {
+			continue
+		}
+		line.Color = color.Gray{Y: 0x80}
+		line.Dashes = []vg.Length{vg.Points(4), vg.Points(3)}
+		p.Add(line)
+		if k == contours {
+			p.Legend.Add("objective contours", line)
+		}
+	}
+
+	steps, points, err := plotter.NewLinePoints(xys(path))
+	if err != nil {
+		return nil, err
+	}
+	steps.Color = color.Black
+	steps.Width = vg.Points(2)
+	points.Shape = nil
+	points.Color = color.Black
+	points.Radius = vg.Points(3)
+	p.Add(steps, points)
+	p.Legend.Add("simplex path", steps, points)
+	p.Legend.Top = true
+	return p, nil
+}
+
+// check Number of constraints of the problem with two variables
+func check(c, A, b *mat.Dense) (int, error) {
+	if c == nil || A == nil || b == nil {
+		return 0, errors.Wrap(goptimization.ErrDimensionMismatch, "c, A and b must not be nil")
+	}
+	if r, n := c.Dims(); r != 1 || n != 2 {
+		return 0, errors.Wrapf(goptimization.ErrDimensionMismatch, "c dims must be (1,2), got (%d,%d)", r, n)
+	}
+	m, n := A.Dims()
+	if n != 2 {
+		return 0, errors.Wrapf(goptimization.ErrDimensionMismatch, "A dims must be (m,2), got (%d,%d)", m, n)
+	}
+	if r, k := b.Dims(); r != m || k != 1 {
+		return 0, errors.Wrapf(goptimization.ErrDimensionMismatch, "b dims must be (%d,1), got (%d,%d)", m, r, k)
+	}
+	return m, nil
+}
+
+// simplexPath Vertices (x0, x1) visited by the primal simplex, the first one is the starting basis.
+// The error is ErrUnbounded with the vertices visited before the unbounded direction was found.
+func simplexPath(c, A, b *mat.Dense) ([][2]float64, error) {
+	stepper, err := goptimization.NewStepper(c, mat.DenseCopyOf(A), b, goptimization.WithLogger(log.New(ioutil.Discard, "", 0)))
+	if err != nil {
+		return nil, err
+	}
+	vertex := func() [2]float64 {
+		x := stepper.Dictionary().Solution().X
+		return [2]float64{x[0], x[1]}
+	}
+	path := [][2]float64{vertex()}
+	for {
+		step, err := stepper.Step()
+		if err != nil {
+			return path, err
+		}
+		if step.Optimal {
+			return path, nil
+		}
+		// A degenerate pivot stays on the same vertex
+		if v := vertex(); v != path[len(path)-1] {
+			path = append(path, v)
+		}
+	}
+}
+
+// viewBox Size of the axes: 20% beyond the path and the nonnegative intersections of the constraint lines and the axes
+func viewBox(A, b *mat.Dense, path [][2]float64) (float64, float64) {
+	m, _ := A.Dims()
+	// The axes are the lines x0 = 0 and x1 = 0
+	lines := [][3]float64{{1, 0, 0}, {0, 1, 0}}
+	for i := 0; i < m; i++ {
+		lines = append(lines, [3]float64{A.At(i, 0), A.At(i, 1), b.At(i, 0)})
+	}
+	width, height := 0.0, 0.0
+	for _, v := range path {
+		width, height = math.Max(width, v[0]), math.Max(height, v[1])
+	}
+	for k := range lines {
+		for l := k + 1; l < len(lines); l++ {
+			p, ok := intersection(lines[k], lines[l])
+			if ok && p[0] >= -epsilon && p[1] >= -epsilon {
+				width, height = math.Max(width, p[0]), math.Max(height, p[1])
+			}
+		}
+	}
+	return boxSide(width), boxSide(height)
+}
+
+// boxSide Side of the view box for the largest coordinate v
+func boxSide(v float64) float64 {
+	if v <= epsilon {
+		return 1
+	}
+	return 1.2 * v
+}
+
+// epsilon Tolerance of the geometry, like the one of the simplex
+const epsilon = 1e-9
+
+// intersection Point where the lines a*x0 + b*x1 = c meet, false when they are parallel
+func intersection(u, v [3]float64) ([2]float64, bool) {
+	det := u[0]*v[1] - u[1]*v[0]
+	if math.Abs(det) <= epsilon {
+		return [2]float64{}, false
+	}
+	return [2]float64{(u[2]*v[1] - u[1]*v[2]) / det, (u[0]*v[2] - u[2]*v[0]) / det}, true
+}
+
+// feasibleRegion Vertices of the feasible region within the box [0,width]x[0,height], in order.
+// The box is cut by each half-plane A_i*x <= b_i with the Sutherland-Hodgman algorithm, x >= 0 is the box itself.
+func feasibleRegion(A, b *mat.Dense, width, height float64) [][2]float64 {
+	polygon := [][2]float64{{0, 0}, {width, 0}, {width, height}, {0, height}}
+	m, _ := A.Dims()
+	for i := 0; i < m && len(polygon) > 0; i++ {
+		a, rhs := A.RawRowView(i), b.At(i, 0)
+		slack := func(p [2]float64) float64 {
+			return rhs - a[0]*p[0] - a[1]*p[1]
+		}
+		clipped := [][2]float64{}
+		for k, current := range polygon {
+			previous := polygon[(k+len(polygon)-1)%len(polygon)]
+			sc, sp := slack(current), slack(previous)
+			if (sc >= 0) != (sp >= 0) {
+				t := sp / (sp - sc)
+				clipped = append(clipped, [2]float64{previous[0] + t*(current[0]-previous[0]), previous[1] + t*(current[1]-previous[1])})
+			}
+			if sc >= 0 {
+				clipped = append(clipped, current)
+			}
+		}
+		polygon = clipped
+	}
+	return polygon
+}
+
+// segmentLine Part of the line a*x = rhs within the box [0,width]x[0,height], nil when the line misses the box
+func segmentLine(a []float64, rhs, width, height float64) (*plotter.Line, error) {
+	edges := [][3]float64{{1, 0, 0}, {1, 0, width}, {0, 1, 0}, {0, 1, height}}
+	points := [][2]float64{}
+	for _, edge := range edges {
+		p, ok := intersection([3]float64{a[0], a[1], rhs}, edge)
+		if !ok || p[0] < -epsilon || p[0] > width+epsilon || p[1] < -epsilon || p[1] > height+epsilon {
+			continue
+		}
+		points = append(points, p)
+	}
+	if len(points) < 2 {
+		return nil, nil
+	}
+	// A line through a corner meets two edges at the same point, keep the two farthest points
+	first, second := points[0], points[1]
+	for _, p := range points[2:] {
+		if distance(first, p) > distance(first, second) {
+			second = p
+		}
+	}
+	if distance(first, second) <= epsilon {
+		return nil, nil
+	}
+	return plotter.NewLine(xys([][2]float64{first, second}))
+}
+
+// distance Euclidean distance between the points
+func distance(p, q [2]float64) float64 {
+	return math.Hypot(p[0]-q[0], p[1]-q[1])
+}
+
+// xys Points for the plotters
+func xys(points [][2]float64) plotter.XYs {
+	xy := make(plotter.XYs, len(points))
+	for k, p := range points {
+		xy[k].X, xy[k].Y = p[0], p[1]
+	}
+	return xy
+}
+
+// expression Linear expression a0*x0 + a1*x1 for the labels
+func expression(a []float64) string {
+	s := ""
+	for j, v := range a {
+		switch {
+		case v == 0:
+			continue
+		case s == "" && v < 0:
+			s = "-"
+		case s != "" && v < 0:
+			s += " - "
+		case s != "":
+			s += " + "
+		}
+		if math.Abs(v) != 1 {
+			s += fmt.Sprintf("%.6g", math.Abs(v))
+		}
+		s += fmt.Sprintf("x%d", j)
+	}
+	if s == "" {
+		return "0"
+	}
+	return s
+}
@@ -0,0 +1,84 @@
+package plot2d
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/plot/vg"
+)
+
+// area Area of the polygon with the shoelace formula
+func area(polygon [][2]float64) float64 {
+	s := 0.0
+	for k, p := range polygon {
+		q := polygon[(k+1)%len(polygon)]
+		s += p[0]*q[1] - q[0]*p[1]
+	}
+	return math.Abs(s) / 2
+}
+
+func TestRegion(t *testing.T) {
+	// Maximize 3*x0 + 2*x1, x0 + x1 <= 4, x0 + 3*x1 <= 6, x0 <= 3
+	c := mat.NewDense(1, 2, []float64{3, 2})
+	A := mat.NewDense(3, 2, []float64{1, 1, 1, 3, 1, 0})
+	b := mat.NewDense(3, 1, []float64{4, 6, 3})
+
+	path, err := simplexPath(c, A, b)
+	require.NoError(t, err)
+	assert.Equal(t, [][2]float64{{0, 0}, {3, 0}, {3, 1}}, path)
+
+	width, height := viewBox(A, b, path)
+	// x0 + x1 = 4 meets the axes at (4,0) and (0,4), x0 + 3*x1 = 6 at (6,0)
+	assert.InDelta(t, 7.2, width, 1e-9)
+	assert.InDelta(t, 4.8, height, 1e-9)
+	region := feasibleRegion(A, b, width, height)
+	assert.InDelta(t, 4.5, area(region), 1e-9)
+	for _, v := range region {
+		assert.True(t, v[0]+v[1] <= 4+1e-9 && v[0]+3*v[1] <= 6+1e-9 && v[0] <= 3+1e-9, "%v", v)
+	}
+
+	p, err := Region(c, A, b)
+	require.NoError(t, err)
+	assert.Equal(t, "maximize 3x0 + 2x1, optimum 11", p.Title.Text)
+	assert.InDelta(t, 7.2, p.X.Max, 1e-9)
+
+	dir, err := ioutil.TempDir("", "plot2d")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "region.svg")
+	require.NoError(t, p.Save(4*vg.Inch, 4*vg.Inch, file))
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	assert.True(t, info.Size() > 0)
+}
+
+func TestRegionUnbounded(t *testing.T) {
+	// Maximize x0, x0 - x1 <= 1
+	c := mat.NewDense(1, 2, []float64{1, 0})
+	A := mat.NewDense(1, 2, []float64{1, -1})
+	b := mat.NewDense(1, 1, []float64{1})
+	p, err := Region(c, A, b)
+	require.NoError(t, err)
+	assert.Equal(t, "maximize x0, unbounded", p.Title.Text)
+	// The region is cut by the box
+	// The box loses the triangle (1,0), (1.2,0), (1.2,0.2)
+	assert.InDelta(t, 1.44-0.02, area(feasibleRegion(A, b, 1.2, 1.2)), 1e-9)
+}
+
+func TestRegionErrors(t *testing.T) {
+	_, err := Region(mat.NewDense(1, 3, nil), mat.NewDense(1, 3, nil), mat.NewDense(1, 1, nil))
+	assert.Equal(t, goptimization.ErrDimensionMismatch, errors.Cause(err))
+	// x0 <= -1
+	_, err = Region(mat.NewDense(1, 2, []float64{1, 1}), mat.NewDense(1, 2, []float64{1, 0}), mat.NewDense(1, 1, []float64{-1}))
+	assert.Equal(t, goptimization.ErrInfeasible, errors.Cause(err))
+	assert.Equal(t, "x0 - 2.5x1", expression([]float64{1, -2.5}))
+	assert.Equal(t, "-x1", expression([]float64{0, -1}))
+}
@@ -0,0 +1,155 @@
+// Package portfolio Portfolio optimization: the weights of the assets, non-negative and summing to 1,
+// minimizing the risk of the portfolio subject to a target for its expected return.
+// The risk is the variance of the return with Markowitz and its mean absolute deviation with MAD.
+package portfolio
+
+import (
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// simplexIterations Maximum number of simplex iterations of the linear programs
+const simplexIterations = 100000
+
+// Markowitz Weights of the portfolio with the smallest variance whose expected return reaches targetReturn:
+// Minimize w'Σw
+// Constraints:
+// Σ(j) w_j = 1
+// Σ(j) r_j*w_j >= targetReturn
+// w >= 0
+// returns are the expected returns r of the assets and covariance their covariance matrix Σ, positive definite.
+// A feasible portfolio is found with the simplex algorithm, then the quadratic problem is solved by an active set method.
+// The weights are non-negative and sum to 1.
+// It returns ErrInfeasible when no asset reaches targetReturn.
+// The options are those of goptimization.Simplex.
+func Markowitz(returns *mat.VecDense, covariance *mat.SymDense, targetReturn float64, opts ...goptimization.Option) (*mat.VecDense, error) {
+	if returns == nil || covariance == nil {
+		return nil, errors.Wrap(goptimization.ErrDimensionMismatch, "returns and covariance must not be nil")
+	}
+	n := returns.Len()
+	if covariance.Symmetric() != n {
+		return nil, errors.Wrapf(goptimization.ErrDimensionMismatch, "covariance dims must be (%d,%d), got (%d,%d)", n, n, covariance.Symmetric(), covariance.Symmetric())
+	}
+	x, err := feasible(returns, targetReturn, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	A := mat.NewDense(n+2, n, nil)
+	b := make([]float64, n+2)
+	for j := 0; j < n; j++ {
+		A.Set(0, j, 1)
+		A.Set(1, j, returns.AtVec(j))
+		A.Set(2+j, j, 1)
+	}
+	b[0], b[1] = 1, targetReturn
+	q := quadratic{Q: covariance, A: A, b: b, equalities: 1}
+	w, err := q.solve(x)
+	if err != nil {
+		return nil, err
+	}
+	weights := mat.NewVecDense(n, nil)
+	for j := range w {
+		// The weights of the assets out of the portfolio can be slightly negative after the steps
+		if w[j] > 0 {
+			weights.SetVec(j, w[j])
+		}
+	}
+	// The clipped weights are scaled back to a budget of 1
+	weights.ScaleVec(1/mat.Sum(weights), weights)
+	return weights, nil
+}
+
+// feasible Portfolio with the largest expected return, it reaches targetReturn unless the problem is infeasible
+func feasible(returns *mat.VecDense, targetReturn float64, opts []goptimization.Option) ([]float64, error) {
+	m := &goptimization.Model{}
+	budget, expected := goptimization.Expr{}, goptimization.Expr{}
+	weights := make([]goptimization.Var, returns.Len())
+	for j := range weights {
+		weights[j] = m.AddVariable("", false)
+		budget.Terms = append(budget.Terms, goptimization.Term{Var: weights[j], Coef: 1})
+		expected.Terms = append(expected.Terms, goptimization.Term{Var: weights[j], Coef: returns.AtVec(j)})
+	}
+	m.Maximize(expected)
+	err := m.AddRow(budget, goptimization.Equal, 1)
+	if err != nil {
+		return nil, err
+	}
+	solution, err := m.Solve(simplexIterations, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if solution.Score < targetReturn-tolerance {
+		return nil, errors.Wrapf(goptimization.ErrInfeasible, "the largest expected return is %g, below the target %g", solution.Score, targetReturn)
+	}
+	return solution.Values, nil
+}
+
+// MAD Weights of the portfolio with the smallest mean absolute deviation of its return over the scenarios
+// whose expected return reaches targetReturn, with the linear program of Konno and Yamazaki:
+// Minimize 1/T Σ(t) u_t
+// Constraints:
+// 1<=t<=T, -u_t <= Σ(j) (r_tj - r_j)*w_j <= u_t
+// Σ(j) w_j = 1
+// Σ(j) r_j*w_j >= targetReturn
+// w >= 0
+// scenarios (T,n) are the returns r_tj of the assets in T equally likely scenarios, r_j is the mean of the asset j.
+// It returns ErrInfeasible when no asset reaches targetReturn.
+// The options are those of goptimization.Simplex.
+func MAD(scenarios *mat.Dense, targetReturn float64, opts ...goptimization.Option) (*mat.VecDense, error) {
+	if scenarios == nil {
+		return nil, errors.Wrap(goptimization.ErrDimensionMismatch, "scenarios must not be nil")
+	}
+	T, n := scenarios.Dims()
+	means := make([]float64, n)
+	for j := range means {
+		means[j] = mat.Sum(scenarios.ColView(j)) / float64(T)
+	}
+
+	m := &goptimization.Model{}
+	weights := make([]goptimization.Var, n)
+	budget, expected := goptimization.Expr{}, goptimization.Expr{}
+	for j := range weights {
+		weights[j] = m.AddVariable("", false)
+		budget.Terms = append(budget.Terms, goptimization.Term{Var: weights[j], Coef: 1})
+		expected.Terms = append(expected.Terms, goptimization.Term{Var: weights[j], Coef: means[j]})
+	}
+	objective := goptimization.Expr{}
+	for t := 0; t < T; t++ {
+		u := m.AddVariable("", false)
+		objective.Terms = append(objective.Terms, goptimization.Term{Var: u, Coef: -1 / float64(T)})
+		above, below := goptimization.Expr{}, goptimization.Expr{}
+		for j, w := range weights {
+			deviation := scenarios.At(t, j) - means[j]
+			above.Terms = append(above.Terms, goptimization.Term{Var: w, Coef: deviation})
+			below.Terms = append(below.Terms, goptimization.Term{Var: w, Coef: -deviation})
+		}
+		above.Terms = append(above.Terms, goptimization.Term{Var: u, Coef: -1})
+		below.Terms = append(below.Terms, goptimization.Term{Var: u, Coef: -1})
+		for _, row := range []goptimization.Expr{above, below} {
+			err := m.AddConstraint(row, 0)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	m.Maximize(objective)
+	err := m.AddRow(budget, goptimization.Equal, 1)
+	if err != nil {
+		return nil, err
+	}
+	err = m.AddRow(expected, goptimization.GreaterEq, targetReturn)
+	if err != nil {
+		return nil, err
+	}
+	solution, err := m.Solve(simplexIterations, opts...)
+	if err != nil {
+		return nil, err
+	}
+	result := mat.NewVecDense(n, nil)
+	for j, w := range weights {
+		result.SetVec(j, solution.Value(w))
+	}
+	return result, nil
+}
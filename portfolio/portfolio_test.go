@@ -0,0 +1,165 @@
+package portfolio
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+// silent Logger of the solves
+var silent = goptimization.WithLogger(log.New(ioutil.Discard, "", 0))
+
+// market Expected returns and covariance of n assets driven by two random factors, with the scenarios of the returns
+func market(n, T int, seed int64) (*mat.VecDense, *mat.SymDense, *mat.Dense) {
+	rnd := rand.New(rand.NewSource(seed))
+	scenarios := mat.NewDense(T, n, nil)
+	loadings := mat.NewDense(n, 2, nil)
+	for j := 0; j < n; j++ {
+		loadings.Set(j, 0, rnd.Float64())
+		loadings.Set(j, 1, rnd.NormFloat64())
+	}
+	for t := 0; t < T; t++ {
+		f0, f1 := rnd.NormFloat64(), rnd.NormFloat64()
+		for j := 0; j < n; j++ {
+			scenarios.Set(t, j, 0.01*float64(j)+0.05*(loadings.At(j, 0)*f0+loadings.At(j, 1)*f1)+0.02*rnd.NormFloat64())
+		}
+	}
+	returns := mat.NewVecDense(n, nil)
+	for j := 0; j < n; j++ {
+		returns.SetVec(j, mat.Sum(scenarios.ColView(j))/float64(T))
+	}
+	covariance := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			c := 0.0
+			for t := 0; t < T; t++ {
+				c += (scenarios.At(t, i) - returns.AtVec(i)) * (scenarios.At(t, j) - returns.AtVec(j))
+			}
+			covariance.SetSym(i, j, c/float64(T))
+		}
+	}
+	return returns, covariance, scenarios
+}
+
+// admissible Check that the weights are non-negative, sum to 1 and reach the target
+func admissible(t *testing.T, weights, returns *mat.VecDense, target float64) {
+	for j := 0; j < weights.Len(); j++ {
+		assert.GreaterOrEqual(t, weights.AtVec(j), 0.0)
+	}
+	assert.InDelta(t, 1, mat.Sum(weights), 0.000001)
+	assert.GreaterOrEqual(t, mat.Dot(weights, returns), target-0.000001)
+}
+
+func TestMarkowitz(t *testing.T) {
+	// Uncorrelated assets, the minimum variance portfolio has weights proportional to 1/σ²
+	returns := mat.NewVecDense(2, []float64{0.1, 0.2})
+	covariance := mat.NewSymDense(2, []float64{1, 0, 0, 4})
+	w, err := Markowitz(returns, covariance, 0, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.8, w.AtVec(0), 0.000001)
+	assert.InDelta(t, 0.2, w.AtVec(1), 0.000001)
+
+	w, err = Markowitz(returns, covariance, 0.15, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, w.AtVec(0), 0.000001)
+	assert.InDelta(t, 0.5, w.AtVec(1), 0.000001)
+
+	_, err = Markowitz(returns, covariance, 0.3, silent)
+	assert.Equal(t, goptimization.ErrInfeasible, errors.Cause(err))
+	_, err = Markowitz(mat.NewVecDense(3, nil), covariance, 0, silent)
+	assert.Equal(t, goptimization.ErrDimensionMismatch, errors.Cause(err))
+}
+
+func TestMarkowitzOptimal(t *testing.T) {
+	returns, covariance, _ := market(6, 200, 1)
+	variance := func(w *mat.VecDense) float64 {
+		return mat.Inner(w, covariance, w)
+	}
+	rnd := rand.New(rand.NewSource(3))
+	for _, target := range []float64{0, 0.02, 0.04} {
+		w, err := Markowitz(returns, covariance, target, silent)
+		require.NoError(t, err)
+		admissible(t, w, returns, target)
+		// The clipped weights keep the budget exactly
+		assert.InDelta(t, 1, mat.Sum(w), 1e-12)
+
+		// The problem is convex, no move towards another admissible portfolio decreases the variance
+		for k := 0; k < 200; k++ {
+			other := mat.NewVecDense(6, nil)
+			for j := 0; j < 6; j++ {
+				other.SetVec(j, rnd.ExpFloat64())
+			}
+			other.ScaleVec(1/mat.Sum(other), other)
+			if mat.Dot(other, returns) < target {
+				continue
+			}
+			for _, step := range []float64{0.001, 0.1, 1} {
+				var moved mat.VecDense
+				moved.AddScaledVec(w, step, other)
+				moved.ScaleVec(1/(1+step), &moved)
+				assert.LessOrEqual(t, variance(w), variance(&moved)+1e-12)
+			}
+		}
+	}
+}
+
+func TestMAD(t *testing.T) {
+	// The first asset has a constant return, the second one deviates by 0.1 in each scenario
+	scenarios := mat.NewDense(4, 2, []float64{
+		0.05, 0.2,
+		0.05, 0.0,
+		0.05, 0.2,
+		0.05, 0.0,
+	})
+	returns := mat.NewVecDense(2, []float64{0.05, 0.1})
+	w, err := MAD(scenarios, 0, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, 1, w.AtVec(0), 0.000001)
+
+	w, err = MAD(scenarios, 0.08, silent)
+	require.NoError(t, err)
+	admissible(t, w, returns, 0.08)
+	assert.InDelta(t, 0.4, w.AtVec(0), 0.000001)
+
+	_, err = MAD(scenarios, 0.2, silent)
+	assert.Equal(t, goptimization.ErrInfeasible, errors.Cause(err))
+}
+
+func TestMADGrid(t *testing.T) {
+	returns, _, scenarios := market(2, 50, 2)
+	T, _ := scenarios.Dims()
+	deviation := func(w float64) float64 {
+		d := 0.0
+		for t := 0; t < T; t++ {
+			d += math.Abs(w*(scenarios.At(t, 0)-returns.AtVec(0)) + (1-w)*(scenarios.At(t, 1)-returns.AtVec(1)))
+		}
+		return d / float64(T)
+	}
+	w, err := MAD(scenarios, 0, silent)
+	require.NoError(t, err)
+	admissible(t, w, returns, 0)
+	for k := 0; k <= 1000; k++ {
+		assert.LessOrEqual(t, deviation(w.AtVec(0)), deviation(float64(k)/1000)+0.000001)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	//The logger reaches the linear programs of Markowitz and MAD
+	returns, covariance, scenarios := market(4, 50, 2)
+	var markowitz, mad bytes.Buffer
+	_, err := Markowitz(returns, covariance, 0, goptimization.WithLogger(log.New(&markowitz, "", 0)))
+	require.NoError(t, err)
+	assert.NotZero(t, markowitz.Len())
+	_, err = MAD(scenarios, 0, goptimization.WithLogger(log.New(&mad, "", 0)))
+	require.NoError(t, err)
+	assert.NotZero(t, mad.Len())
+}
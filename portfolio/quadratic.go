@@ -0,0 +1,154 @@
+package portfolio
+
+import (
+	"math"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+const (
+	// quadraticIterations Maximum number of changes of the working set of the active set method
+	quadraticIterations = 1000
+	// tolerance Tolerance on the activity of the constraints, the steps and the multipliers
+	tolerance = 1e-10
+)
+
+// quadratic Convex quadratic problem, Q is positive definite on the null space of the equalities
+// Minimize 1/2 x'Qx
+// Constraints:
+// 1<=i<=equalities, a_i*x = b_i
+// equalities<i<=m, a_i*x >= b_i
+type quadratic struct {
+	Q          *mat.SymDense
+	A          *mat.Dense
+	b          []float64
+	equalities int
+}
+
+// activity Value of a_i*x
+func (q *quadratic) activity(i int, x []float64) float64 {
+	return mat.Dot(q.A.RowView(i), mat.NewVecDense(len(x), x))
+}
+
+// independent Check if the rows of A are linearly independent
+func (q *quadratic) independent(rows []int) bool {
+	_, n := q.A.Dims()
+	if len(rows) > n {
+		return false
+	}
+	sub := mat.NewDense(len(rows), n, nil)
+	for k, i := range rows {
+		sub.SetRow(k, q.A.RawRowView(i))
+	}
+	var svd mat.SVD
+	if !svd.Factorize(sub, mat.SVDNone) {
+		return false
+	}
+	values := svd.Values(nil)
+	return values[len(values)-1] > 1e-9*math.Max(1, values[0])
+}
+
+// step Step p minimizing the objective from x while keeping the constraints of the working set active,
+// and the multipliers of these constraints, from the system
+// [Q  -A_W'] [p]   [-Qx]
+// [A_W   0 ] [λ] = [  0]
+func (q *quadratic) step(x []float64, working []int) ([]float64, []float64, error) {
+	n := len(x)
+	size := n + len(working)
+	K := mat.NewDense(size, size, nil)
+	rhs := mat.NewVecDense(size, nil)
+	var qx mat.VecDense
+	qx.MulVec(q.Q, mat.NewVecDense(n, x))
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			K.Set(i, j, q.Q.At(i, j))
+		}
+		rhs.SetVec(i, -qx.AtVec(i))
+	}
+	for k, row := range working {
+		for j := 0; j < n; j++ {
+			K.Set(j, n+k, -q.A.At(row, j))
+			K.Set(n+k, j, q.A.At(row, j))
+		}
+	}
+	var solution mat.VecDense
+	err := solution.SolveVec(K, rhs)
+	if err != nil {
+		if condition, ok := err.(mat.Condition); !ok || math.IsInf(float64(condition), 1) {
+			return nil, nil, errors.Wrap(goptimization.ErrSingularBasis, "the covariance is not positive definite on the working set")
+		}
+	}
+	p, lambda := make([]float64, n), make([]float64, len(working))
+	for j := range p {
+		p[j] = solution.AtVec(j)
+	}
+	for k := range lambda {
+		lambda[k] = solution.AtVec(n + k)
+	}
+	return p, lambda, nil
+}
+
+// solve Primal active set method from the feasible point x, see Nocedal and Wright, Numerical Optimization, 16.3.
+// The working set holds the equalities and independent active inequalities. Each iteration moves along the step
+// of the working set until it reaches a new constraint, which joins the working set, or, when the step is zero,
+// removes the inequality with the most negative multiplier. x is optimal when no multiplier is negative.
+func (q *quadratic) solve(x []float64) ([]float64, error) {
+	m, _ := q.A.Dims()
+	x = append([]float64(nil), x...)
+	working := []int{}
+	for i := 0; i < q.equalities; i++ {
+		working = append(working, i)
+	}
+	for i := q.equalities; i < m; i++ {
+		if math.Abs(q.activity(i, x)-q.b[i]) <= tolerance && q.independent(append(working, i)) {
+			working = append(working, i)
+		}
+	}
+
+	for iter := 0; iter < quadraticIterations; iter++ {
+		p, lambda, err := q.step(x, working)
+		if err != nil {
+			return nil, err
+		}
+		if mat.Norm(mat.NewVecDense(len(p), p), 2) <= tolerance*math.Max(1, mat.Norm(mat.NewVecDense(len(x), x), 2)) {
+			leave, smallest := -1, -tolerance
+			for k := q.equalities; k < len(working); k++ {
+				if lambda[k] < smallest {
+					leave, smallest = k, lambda[k]
+				}
+			}
+			if leave == -1 {
+				return x, nil
+			}
+			working = append(working[:leave:leave], working[leave+1:]...)
+			continue
+		}
+
+		alpha, blocking := 1.0, -1
+		inWorking := map[int]bool{}
+		for _, i := range working {
+			inWorking[i] = true
+		}
+		for i := q.equalities; i < m; i++ {
+			if inWorking[i] {
+				continue
+			}
+			ap := q.activity(i, p)
+			if ap < -tolerance {
+				ratio := math.Max(0, (q.b[i]-q.activity(i, x))/ap)
+				if ratio < alpha {
+					alpha, blocking = ratio, i
+				}
+			}
+		}
+		for j := range x {
+			x[j] += alpha * p[j]
+		}
+		if blocking != -1 {
+			working = append(working, blocking)
+		}
+	}
+	return x, goptimization.ErrIterationLimit
+}
@@ -0,0 +1,31 @@
+package portfolio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestQuadratic(t *testing.T) {
+	// Minimize 1/2 (x1² + x2²) subject to x1 + x2 >= 2, x1 >= 0, x2 >= 1.5, from the point (0, 3)
+	q := quadratic{
+		Q: mat.NewSymDense(2, []float64{1, 0, 0, 1}),
+		A: mat.NewDense(3, 2, []float64{1, 1, 1, 0, 0, 1}),
+		b: []float64{2, 0, 1.5},
+	}
+	x, err := q.solve([]float64{0, 3})
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, x[0], 0.000001)
+	assert.InDelta(t, 1.5, x[1], 0.000001)
+
+	// With the equality x1 = x2
+	q.A = mat.NewDense(2, 2, []float64{1, -1, 1, 1})
+	q.b = []float64{0, 2}
+	q.equalities = 1
+	x, err = q.solve([]float64{3, 3})
+	require.NoError(t, err)
+	assert.InDelta(t, 1, x[0], 0.000001)
+	assert.InDelta(t, 1, x[1], 0.000001)
+}
@@ -0,0 +1,115 @@
+package goptimization
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/mat"
+)
+
+// PricingRule Strategy used to choose the entering variable among the nonbasic variables
+type PricingRule int
+
+const (
+	// FullPricing Compute the reduced cost of every nonbasic variable at each iteration
+	FullPricing PricingRule = iota
+	// PartialPricing Scan the nonbasic variables by segments of pricingSegment(), starting after the segment
+	// of the last entering variable, and stop at the first segment with a candidate
+	PartialPricing
+	// MultiplePricing Keep the multiplePricingCandidates best variables of a full pricing and pick the entering
+	// variable among them (minor iterations) until none of them is attractive anymore
+	MultiplePricing
+)
+
+// minPricingSegment Smallest segment of the partial pricing
+const minPricingSegment = 10
+
+// multiplePricingCandidates Number of variables kept by a full pricing of the multiple pricing
+const multiplePricingCandidates = 4
+
+// reducedCost Compute c_j - y*a_j for the nonbasic variable at position j
+func (cf *CanonicalForm) reducedCost(y *mat.Dense, j int) float64 {
+	return cf.cN.At(0, j) - blas64.Dot(rowVector(y, 0), colVector(cf.AN, j))
+}
+
+// pricingSegment Number of columns scanned by a segment of the partial pricing, the number of constraints
+// so that the pricing of a segment costs about as much as the solves of the iteration
+func (cf *CanonicalForm) pricingSegment() int {
+	if cf.m > minPricingSegment {
+		return cf.m
+	}
+	return minPricingSegment
+}
+
+// partialPricing Find the entering variable with the largest reduced cost in the first segment,
+// starting at cf.priceStart, with a positive one. It returns -1 when no variable has a positive reduced cost.
+func (cf *CanonicalForm) partialPricing(y *mat.Dense) int {
+	if cf.priceStart >= cf.n {
+		cf.priceStart = 0
+	}
+	segment := cf.pricingSegment()
+	enteringVarIndex := -1
+	max := 0.0
+	for k := 0; k < cf.n; k++ {
+		j := (cf.priceStart + k) % cf.n
+		if reduced := cf.reducedCost(y, j); reduced > cf.tolerance && reduced > max {
+			max = reduced
+			enteringVarIndex = j
+		}
+		//End of a segment
+		if (k+1)%segment == 0 && enteringVarIndex != -1 {
+			cf.priceStart = (j + 1) % cf.n
+			return enteringVarIndex
+		}
+	}
+	return enteringVarIndex
+}
+
+// multiplePricing Find the entering variable with the largest reduced cost among the candidates,
+// run a full pricing to choose new candidates when none of them has a positive reduced cost.
+// It returns -1 when no variable has a positive reduced cost.
+func (cf *CanonicalForm) multiplePricing(y *mat.Dense) int {
+	enteringVarIndex := cf.bestCandidate(y)
+	if enteringVarIndex != -1 {
+		return enteringVarIndex
+	}
+	type candidate struct {
+		j       int
+		reduced float64
+	}
+	var candidates []candidate
+	for j := 0; j < cf.n; j++ {
+		if reduced := cf.reducedCost(y, j); reduced > cf.tolerance {
+			candidates = append(candidates, candidate{j, reduced})
+		}
+	}
+	sort.Slice(candidates, func(a, b int) bool {
+		return candidates[a].reduced > candidates[b].reduced
+	})
+	if len(candidates) > multiplePricingCandidates {
+		candidates = candidates[:multiplePricingCandidates]
+	}
+	cf.candidates = cf.candidates[:0]
+	for _, c := range candidates {
+		cf.candidates = append(cf.candidates, c.j)
+	}
+	return cf.bestCandidate(y)
+}
+
+// bestCandidate Candidate with the largest positive reduced cost, -1 if there is none
+// The candidates are positions of nonbasic columns, a pivot puts the leaving variable
+// at the position of the entering one.
+func (cf *CanonicalForm) bestCandidate(y *mat.Dense) int {
+	enteringVarIndex := -1
+	max := 0.0
+	for _, j := range cf.candidates {
+		if j >= cf.n {
+			continue
+		}
+		if reduced := cf.reducedCost(y, j); reduced > cf.tolerance && reduced > max {
+			max = reduced
+			enteringVarIndex = j
+		}
+	}
+	return enteringVarIndex
+}
@@ -0,0 +1,58 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestPricing(t *testing.T) {
+	//Many more variables than constraints
+	lp, err := GenerateLP(60, 8, 0.5, 3)
+	require.NoError(t, err)
+	for _, pricing := range []PricingRule{FullPricing, PartialPricing, MultiplePricing} {
+		_, results, score, err := Simplex(mat.DenseCopyOf(lp.C), mat.DenseCopyOf(lp.A), mat.DenseCopyOf(lp.B), WithPricing(pricing))
+		require.NoError(t, err, "pricing %d", pricing)
+		assert.InDelta(t, lp.Optimum, score, 0.000001*(1+lp.Optimum), "pricing %d", pricing)
+		x := make([]float64, 60)
+		for j := range x {
+			x[j] = results.At(j, 0)
+		}
+		assert.True(t, feasible(lp.A, lp.B, x), "pricing %d", pricing)
+	}
+}
+
+func TestPartialPricing(t *testing.T) {
+	c := mat.NewDense(1, 25, nil)
+	//The best reduced cost is in the second segment, the first segment has a candidate
+	c.Set(0, 3, 1)
+	c.Set(0, 15, 5)
+	A := mat.NewDense(1, 25, nil)
+	for j := 0; j < 25; j++ {
+		A.Set(0, j, 1)
+	}
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(c, A, mat.NewDense(1, 1, []float64{1})))
+	cf.pricing = PartialPricing
+	y, err := cf.FindY()
+	require.NoError(t, err)
+
+	entering, err := cf.FindEnteringVariable(y, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 3, entering)
+	//The next pricing starts at the second segment
+	entering, err = cf.FindEnteringVariable(y, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 15, entering)
+	entering, err = cf.FindEnteringVariable(y, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 3, entering)
+
+	cf.pricing = MultiplePricing
+	entering, err = cf.FindEnteringVariable(y, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 15, entering)
+	assert.ElementsMatch(t, []int{15, 3}, cf.candidates)
+}
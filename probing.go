@@ -0,0 +1,193 @@
+package goptimization
+
+import (
+	"math"
+)
+
+// probingRounds Maximum number of passes over the constraints to propagate a probe
+const probingRounds = 10
+
+// domains Lower and upper bound of each original variable
+type domains struct {
+	lower []float64
+	upper []float64
+}
+
+// clone Copy of the bounds
+func (d domains) clone() domains {
+	return domains{lower: append([]float64(nil), d.lower...), upper: append([]float64(nil), d.upper...)}
+}
+
+// initialDomains Bounds of the variables given by the constraints with a single variable,
+// rounded for the integer variables
+func (bb *BranchAndBound) initialDomains() domains {
+	d := domains{lower: make([]float64, bb.n), upper: make([]float64, bb.n)}
+	for j := range d.upper {
+		d.upper[j] = math.Inf(1)
+	}
+	for i := 0; i < bb.m; i++ {
+		j := -1
+		single := true
+		for k := 0; k < bb.n && single; k++ {
+			if bb.A.At(i, k) == 0 {
+				continue
+			}
+			single = j == -1
+			j = k
+		}
+		if !single || j == -1 {
+			continue
+		}
+		bound := bb.b.At(i, 0) / bb.A.At(i, j)
+		if bb.A.At(i, j) > 0 {
+			d.upper[j] = math.Min(d.upper[j], bound)
+		} else {
+			d.lower[j] = math.Max(d.lower[j], bound)
+		}
+	}
+	for j := range d.upper {
+		d.round(bb.integer, j)
+	}
+	return d
+}
+
+// round Round the bounds of an integer variable
+func (d domains) round(integer []bool, j int) {
+	if !integer[j] {
+		return
+	}
+	d.lower[j] = math.Ceil(d.lower[j] - integerTolerance)
+	d.upper[j] = math.Floor(d.upper[j] + integerTolerance)
+}
+
+// propagate Tighten the bounds with the minimum activity of each constraint:
+// a_i_k*x_k <= b_i - Σ(j != k) min(a_i_j*x_j). It returns false when a constraint cannot be satisfied.
+func (bb *BranchAndBound) propagate(d domains) bool {
+	for round := 0; round < probingRounds; round++ {
+		changed := false
+		for i := 0; i < bb.m; i++ {
+			// Finite part of the minimum activity and number of infinite terms
+			finite, infinite, unbounded := 0.0, 0, -1
+			for j := 0; j < bb.n; j++ {
+				a := bb.A.At(i, j)
+				switch {
+				case a > 0:
+					finite += a * d.lower[j]
+				case a < 0 && math.IsInf(d.upper[j], 1):
+					infinite++
+					unbounded = j
+				case a < 0:
+					finite += a * d.upper[j]
+				}
+			}
+			if infinite == 0 && finite > bb.b.At(i, 0)+feasibilityTolerance {
+				return false
+			}
+			if infinite > 1 {
+				continue
+			}
+			for k := 0; k < bb.n; k++ {
+				a := bb.A.At(i, k)
+				if a == 0 || (infinite == 1 && k != unbounded) {
+					continue
+				}
+				rest := finite
+				if infinite == 0 && a > 0 {
+					rest -= a * d.lower[k]
+				} else if infinite == 0 {
+					rest -= a * d.upper[k]
+				}
+				bound := (bb.b.At(i, 0) - rest) / a
+				if a > 0 && bound < d.upper[k]-feasibilityTolerance {
+					d.upper[k] = bound
+					changed = true
+				} else if a < 0 && bound > d.lower[k]+feasibilityTolerance {
+					d.lower[k] = bound
+					changed = true
+				}
+				d.round(bb.integer, k)
+				if d.lower[k] > d.upper[k]+feasibilityTolerance {
+					return false
+				}
+			}
+		}
+		if !changed {
+			return true
+		}
+	}
+	return true
+}
+
+// probe Propagate the bounds with x_j = value, it returns false when the probe is infeasible
+func (bb *BranchAndBound) probe(d domains, j int, value float64) (domains, bool) {
+	probed := d.clone()
+	probed.lower[j], probed.upper[j] = value, value
+	return probed, bb.propagate(probed)
+}
+
+// presolveProbing Probe each binary variable at 0 and 1:
+// - a binary is fixed to the other value when a probe is infeasible, the problem is infeasible when both are
+// - a binary fixed to the same value by both probes is fixed
+// - x_j = 1 forcing the binary x_k to 0 is a conflict added to the conflict graph of the clique cuts
+// Each fixed binary is a constraint added to the root. It returns ErrInfeasible when a binary cannot take any value.
+func (bb *BranchAndBound) presolveProbing() error {
+	d := bb.initialDomains()
+	if !bb.propagate(d) {
+		return ErrInfeasible
+	}
+	fixed := func(j int) bool {
+		return d.lower[j] == d.upper[j]
+	}
+	for j := 0; j < bb.n; j++ {
+		if !bb.binary[j] || fixed(j) {
+			continue
+		}
+		zero, zeroFeasible := bb.probe(d, j, 0)
+		one, oneFeasible := bb.probe(d, j, 1)
+		switch {
+		case !zeroFeasible && !oneFeasible:
+			return ErrInfeasible
+		case !oneFeasible:
+			d = zero
+			continue
+		case !zeroFeasible:
+			d = one
+			continue
+		}
+		for k := 0; k < bb.n; k++ {
+			if k == j || !bb.binary[k] || fixed(k) {
+				continue
+			}
+			if one.upper[k] < 0.5 && !bb.conflicts.has(j, k) {
+				bb.conflicts.add(j, k)
+				bb.Implications++
+			}
+			// Both probes agree
+			if zero.lower[k] == zero.upper[k] && one.lower[k] == one.upper[k] && zero.lower[k] == one.lower[k] {
+				d.lower[k], d.upper[k] = zero.lower[k], zero.lower[k]
+			}
+		}
+	}
+
+	root := bb.root
+	for j := 0; j < bb.n; j++ {
+		if !bb.binary[j] || !fixed(j) {
+			continue
+		}
+		// x_j <= 0 or -x_j <= -1
+		a := make([]float64, root.cf.n+root.cf.m)
+		a[j] = 1
+		rhs := 0.0
+		if d.lower[j] > 0.5 {
+			a[j] = -1
+			rhs = -1
+		}
+		err := root.cf.AddConstraint(a, rhs)
+		if err != nil {
+			return err
+		}
+		root.integer = append(root.integer, true)
+		bb.Fixed++
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestProbing(t *testing.T) {
+	//Binaries x, y, z and u >= 0
+	c := mat.NewDense(1, 4, []float64{3, 2, 4, 1})
+	A := mat.NewDense(7, 4, []float64{
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		//z = 1 forces y = 0 and then z <= 0: z is fixed to 0
+		0, 2, 2, 0,
+		0, -1, 1, 0,
+		//x = 1 forces u = 0 and then y = 0 and z = 0: x conflicts with y and z
+		1, 0, 0, 1,
+		0, 1, 0, -1,
+	})
+	b := mat.NewDense(7, 1, []float64{1, 1, 1, 3, 0, 1, 0})
+	integer := []bool{true, true, true, false}
+
+	bb := BranchAndBound{}
+	require.NoError(t, bb.New(c, A, b, integer))
+	assert.False(t, bb.conflicts.has(0, 1))
+	results, score, err := bb.Solve(100)
+	require.NoError(t, err)
+	assert.Equal(t, 1, bb.Fixed)
+	assert.Equal(t, 2, bb.Implications)
+	assert.True(t, bb.conflicts.has(0, 1))
+	assert.True(t, bb.conflicts.has(0, 2))
+	assert.InEpsilon(t, 3.0, score, 0.000001)
+	assert.InDelta(t, 0.0, results.At(2, 0), 0.000001)
+
+	without := BranchAndBound{}
+	require.NoError(t, without.New(c, A, b, integer))
+	without.Probing = false
+	_, expected, err := without.Solve(100)
+	require.NoError(t, err)
+	assert.InEpsilon(t, expected, score, 0.000001)
+	assert.Equal(t, 0, without.Fixed)
+
+	//x = 1 and x = 0 are both infeasible
+	bb = BranchAndBound{}
+	require.NoError(t, bb.New(mat.NewDense(1, 1, []float64{1}), mat.NewDense(3, 1, []float64{1, 2, -2}), mat.NewDense(3, 1, []float64{1, 1.5, -0.5}), []bool{true}))
+	_, _, err = bb.Solve(100)
+	assert.Equal(t, ErrInfeasible, err)
+}
@@ -0,0 +1,98 @@
+package goptimization
+
+import "gonum.org/v1/gonum/mat"
+
+// BasisUpdate Scheme keeping the solves with the basis B up to date after each pivot
+type BasisUpdate int
+
+const (
+	// Refactorization Factorize B with LU at each iteration
+	Refactorization BasisUpdate = iota
+	// ProductForm Product form of the inverse: B is factorized every productFormLimit pivots,
+	// each pivot in between appends an eta vector, so that B^-1 = E_k...E_1*B0^-1.
+	// An iteration then costs O(k*m) on top of the solves with the LU of B0 instead of a factorization in O(m^3),
+	// which pays off on medium-size dense problems.
+	ProductForm
+)
+
+// productFormLimit Number of eta vectors after which the product form refactorizes B
+const productFormLimit = 50
+
+// productForm Eta file of the product form of the inverse, the LU of B0 is the one of the workspace
+type productForm struct {
+	// valid is set while the LU of the workspace and the eta vectors describe the current B
+	valid bool
+	// Pivot row and column d = B^-1*a^k of each eta vector, the columns are reused by the next eta files
+	rows    []int
+	columns [][]float64
+}
+
+// reset Empty the eta file, the next solve factorizes B
+func (pf *productForm) reset() {
+	pf.valid = false
+	pf.rows = pf.rows[:0]
+}
+
+// add Append the eta vector of the pivot on row r with the column d (m,1)
+func (pf *productForm) add(r int, d *mat.Dense) {
+	m, _ := d.Dims()
+	k := len(pf.rows)
+	pf.rows = append(pf.rows, r)
+	if k == len(pf.columns) {
+		pf.columns = append(pf.columns, nil)
+	}
+	if cap(pf.columns[k]) < m {
+		pf.columns[k] = make([]float64, m)
+	}
+	column := pf.columns[k][:m]
+	for i := range column {
+		column[i] = d.At(i, 0)
+	}
+	pf.columns[k] = column
+}
+
+// ftran Apply E_k...E_1 to the column x, after the solve with B0
+func (pf *productForm) ftran(x []float64) {
+	for k, r := range pf.rows {
+		d := pf.columns[k]
+		t := x[r] / d[r]
+		for i := range x {
+			x[i] -= d[i] * t
+		}
+		x[r] = t
+	}
+}
+
+// btran Multiply the row v by E_k...E_1, before the solve with B0
+func (pf *productForm) btran(v []float64) {
+	for k := len(pf.rows) - 1; k >= 0; k-- {
+		r, d := pf.rows[k], pf.columns[k]
+		t := v[r]
+		for i := range v {
+			if i != r {
+				t -= v[i] * d[i]
+			}
+		}
+		v[r] = t / d[r]
+	}
+}
+
+// invalidate Drop the factorization of the workspace after B changed outside a pivot
+func (cf *CanonicalForm) invalidate() {
+	if cf.work != nil {
+		cf.work.eta.reset()
+	}
+}
+
+// recordEta Append the eta vector of the pivot on leavingVarIndex, or refactorize after productFormLimit of them
+func (cf *CanonicalForm) recordEta(d *mat.Dense, leavingVarIndex int) {
+	if cf.basisUpdate != ProductForm || cf.work == nil {
+		return
+	}
+	pf := &cf.work.eta
+	if !pf.valid || len(pf.rows) >= productFormLimit {
+		pf.reset()
+		return
+	}
+	pf.add(leavingVarIndex, d)
+}
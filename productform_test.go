@@ -0,0 +1,59 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestProductForm(t *testing.T) {
+	lp, err := GenerateLP(80, 60, 0.3, 5)
+	require.NoError(t, err)
+	for _, method := range []lpMethod{Simplex, DualSimplex} {
+		iter, results, score, err := method(lp.C, mat.DenseCopyOf(lp.A), lp.B, silent, WithBasisUpdate(ProductForm))
+		require.NoError(t, err)
+		// More pivots than productFormLimit, the eta file is refactorized
+		assert.True(t, iter > productFormLimit, "%d iterations", iter)
+		assert.InDelta(t, lp.Optimum, score, 0.000001*(1+lp.Optimum))
+		x := make([]float64, 80)
+		for j := range x {
+			x[j] = results.At(j, 0)
+		}
+		assert.True(t, feasible(lp.A, lp.B, x))
+	}
+}
+
+func TestProductFormSolves(t *testing.T) {
+	lp, err := GenerateLP(20, 15, 0.5, 2)
+	require.NoError(t, err)
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(lp.C, mat.DenseCopyOf(lp.A), lp.B))
+	cf.configure(newOptions([]Option{silent, WithBasisUpdate(ProductForm)}))
+	for k := 0; k < 8; k++ {
+		end, err := cf.Iter(0)
+		require.NoError(t, err)
+		if end {
+			break
+		}
+	}
+	require.NotEmpty(t, cf.work.eta.rows)
+
+	// The eta file solves like a factorization of the current basis
+	y, err := cf.FindY()
+	require.NoError(t, err)
+	var yT mat.Dense
+	require.NoError(t, yT.Solve(cf.B.T(), cf.cB.T()))
+	assert.True(t, mat.EqualApprox(yT.T(), y, 1e-9))
+	d, err := cf.SolveBd(0)
+	require.NoError(t, err)
+	var expected mat.Dense
+	require.NoError(t, expected.Solve(cf.B, cf.AN.ColView(0)))
+	assert.True(t, mat.EqualApprox(&expected, d, 1e-9))
+
+	// A change of the basis outside a pivot drops the eta file
+	cf.swap(0, 0)
+	assert.Empty(t, cf.work.eta.rows)
+	assert.False(t, cf.work.eta.valid)
+}
@@ -0,0 +1,256 @@
+// Package production Multi-period production planning: products are produced in each period to meet their demand,
+// the production of a period consumes a shared capacity and the surplus is kept in inventory at a holding cost.
+// The plan is compiled to a goptimization.Model whose variables are indexed by product and period.
+package production
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+)
+
+// Product Demand and costs of a product, the costs are per unit and per period
+type Product struct {
+	Name string
+	// Demand Demand of each period
+	Demand []float64
+	// Usage Capacity used to produce one unit
+	Usage          float64
+	ProductionCost float64
+	// HoldingCost Cost of a unit in inventory at the end of a period
+	HoldingCost float64
+	// BacklogCost Cost of a unit of demand served one period late, the demand cannot be served late when it is 0.
+	// The demand must be served by the last period.
+	BacklogCost float64
+	// SetupCost Fixed cost of a period where the product is produced, it adds a binary variable per period when positive
+	SetupCost        float64
+	InitialInventory float64
+}
+
+// Planning Products planned over the periods
+type Planning struct {
+	Periods  int
+	Products []Product
+	// Capacity Capacity shared by the products in each period, nil when the production is not limited
+	Capacity []float64
+}
+
+// Variables Variables of the model built by Build, indexed by product and period.
+// Backlog is nil for the products without BacklogCost and Setup for the products without SetupCost.
+type Variables struct {
+	Production [][]goptimization.Var
+	Inventory  [][]goptimization.Var
+	Backlog    [][]goptimization.Var
+	Setup      [][]goptimization.Var
+}
+
+// Plan Solution of the planning, indexed by product and period
+type Plan struct {
+	Production [][]float64
+	Inventory  [][]float64
+	Backlog    [][]float64
+	Setup      [][]bool
+	Cost       float64
+}
+
+// Validate Check the dimensions and the signs of the planning
+func (p *Planning) Validate() error {
+	if p.Periods <= 0 || len(p.Products) == 0 {
+		return errors.New("the planning needs periods and products")
+	}
+	if p.Capacity != nil && len(p.Capacity) != p.Periods {
+		return errors.Wrapf(goptimization.ErrDimensionMismatch, "len(Capacity) must be %d, got %d", p.Periods, len(p.Capacity))
+	}
+	for t, c := range p.Capacity {
+		if c < 0 {
+			return errors.Errorf("period %d has the negative capacity %g", t, c)
+		}
+	}
+	for k, product := range p.Products {
+		if len(product.Demand) != p.Periods {
+			return errors.Wrapf(goptimization.ErrDimensionMismatch, "len(Demand) of product %d must be %d, got %d", k, p.Periods, len(product.Demand))
+		}
+		for t, d := range product.Demand {
+			if d < 0 {
+				return errors.Errorf("product %d has the negative demand %g in period %d", k, d, t)
+			}
+		}
+		for _, value := range []float64{product.Usage, product.HoldingCost, product.BacklogCost, product.SetupCost, product.InitialInventory} {
+			if value < 0 {
+				return errors.Errorf("product %d has a negative usage, cost or initial inventory", k)
+			}
+		}
+	}
+	return nil
+}
+
+// name Name of the variable of the product in the period
+func name(kind string, product Product, k, t int) string {
+	if product.Name == "" {
+		return fmt.Sprintf("%s_%d_%d", kind, k, t)
+	}
+	return fmt.Sprintf("%s_%s_%d", kind, product.Name, t)
+}
+
+// Build Model of the planning, x_t is the production, I_t the inventory, B_t the backlog and y_t the setup
+// of a product in the period t:
+// Minimize Σ(products, t) p*x_t + h*I_t + b*B_t + f*y_t
+// Constraints:
+// product, t, I_(t-1) - B_(t-1) + x_t - d_t = I_t - B_t, with I_(-1) the initial inventory, B_(-1) = 0 and B_(T-1) = 0
+// t, Σ(products) u*x_t <= C_t
+// product with a setup cost, t, x_t <= M_t*y_t, M_t is the total demand, or C_t/u if smaller
+// The variables can be used to add constraints to the model before solving it.
+func (p *Planning) Build() (*goptimization.Model, *Variables, error) {
+	err := p.Validate()
+	if err != nil {
+		return nil, nil, err
+	}
+	m := &goptimization.Model{}
+	v := &Variables{
+		Production: make([][]goptimization.Var, len(p.Products)),
+		Inventory:  make([][]goptimization.Var, len(p.Products)),
+		Backlog:    make([][]goptimization.Var, len(p.Products)),
+		Setup:      make([][]goptimization.Var, len(p.Products)),
+	}
+	objective := goptimization.Expr{}
+	cost := func(variable goptimization.Var, coef float64) {
+		if coef != 0 {
+			objective.Terms = append(objective.Terms, goptimization.Term{Var: variable, Coef: -coef})
+		}
+	}
+	for k, product := range p.Products {
+		v.Production[k] = make([]goptimization.Var, p.Periods)
+		v.Inventory[k] = make([]goptimization.Var, p.Periods)
+		if product.BacklogCost > 0 {
+			v.Backlog[k] = make([]goptimization.Var, p.Periods-1)
+		}
+		if product.SetupCost > 0 {
+			v.Setup[k] = make([]goptimization.Var, p.Periods)
+		}
+		for t := 0; t < p.Periods; t++ {
+			v.Production[k][t] = m.AddVariable(name("produce", product, k, t), false)
+			cost(v.Production[k][t], product.ProductionCost)
+			v.Inventory[k][t] = m.AddVariable(name("inventory", product, k, t), false)
+			cost(v.Inventory[k][t], product.HoldingCost)
+			if v.Backlog[k] != nil && t < p.Periods-1 {
+				v.Backlog[k][t] = m.AddVariable(name("backlog", product, k, t), false)
+				cost(v.Backlog[k][t], product.BacklogCost)
+			}
+			if v.Setup[k] != nil {
+				v.Setup[k][t] = m.AddBinary(name("setup", product, k, t))
+				cost(v.Setup[k][t], product.SetupCost)
+			}
+		}
+	}
+	m.Maximize(objective)
+
+	for k, product := range p.Products {
+		total := 0.0
+		for _, d := range product.Demand {
+			total += d
+		}
+		for t := 0; t < p.Periods; t++ {
+			// x_t + I_(t-1) - B_(t-1) - I_t + B_t = d_t
+			balance := goptimization.Expr{Terms: []goptimization.Term{
+				{Var: v.Production[k][t], Coef: 1},
+				{Var: v.Inventory[k][t], Coef: -1},
+			}}
+			rhs := product.Demand[t]
+			if t == 0 {
+				rhs -= product.InitialInventory
+			} else {
+				balance.Terms = append(balance.Terms, goptimization.Term{Var: v.Inventory[k][t-1], Coef: 1})
+			}
+			if v.Backlog[k] != nil {
+				if t > 0 {
+					balance.Terms = append(balance.Terms, goptimization.Term{Var: v.Backlog[k][t-1], Coef: -1})
+				}
+				if t < p.Periods-1 {
+					balance.Terms = append(balance.Terms, goptimization.Term{Var: v.Backlog[k][t], Coef: 1})
+				}
+			}
+			err := m.AddRow(balance, goptimization.Equal, rhs)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if v.Setup[k] != nil {
+				bigM := total
+				if p.Capacity != nil && product.Usage > 0 {
+					bigM = math.Min(bigM, p.Capacity[t]/product.Usage)
+				}
+				err := m.AddConstraint(goptimization.Expr{Terms: []goptimization.Term{
+					{Var: v.Production[k][t], Coef: 1},
+					{Var: v.Setup[k][t], Coef: -bigM},
+				}}, 0)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+	}
+	for t, capacity := range p.Capacity {
+		used := goptimization.Expr{}
+		for k, product := range p.Products {
+			if product.Usage != 0 {
+				used.Terms = append(used.Terms, goptimization.Term{Var: v.Production[k][t], Coef: product.Usage})
+			}
+		}
+		if len(used.Terms) == 0 {
+			continue
+		}
+		err := m.AddConstraint(used, capacity)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return m, v, nil
+}
+
+// Plan Plan given by the solution of the model built by Build
+func (v *Variables) Plan(s *goptimization.ModelSolution) *Plan {
+	plan := &Plan{
+		Production: make([][]float64, len(v.Production)),
+		Inventory:  make([][]float64, len(v.Production)),
+		Backlog:    make([][]float64, len(v.Production)),
+		Setup:      make([][]bool, len(v.Production)),
+		Cost:       -s.Score,
+	}
+	for k := range v.Production {
+		periods := len(v.Production[k])
+		plan.Production[k] = make([]float64, periods)
+		plan.Inventory[k] = make([]float64, periods)
+		plan.Backlog[k] = make([]float64, periods)
+		plan.Setup[k] = make([]bool, periods)
+		for t := 0; t < periods; t++ {
+			plan.Production[k][t] = s.Value(v.Production[k][t])
+			plan.Inventory[k][t] = s.Value(v.Inventory[k][t])
+			if v.Backlog[k] != nil && t < periods-1 {
+				plan.Backlog[k][t] = s.Value(v.Backlog[k][t])
+			}
+			if v.Setup[k] != nil {
+				plan.Setup[k][t] = math.Round(s.Value(v.Setup[k][t])) == 1
+			} else {
+				plan.Setup[k][t] = plan.Production[k][t] > 1e-9
+			}
+		}
+	}
+	return plan
+}
+
+// Solve Build the model of the planning and solve it, maxIter is the maximum number of simplex iterations
+// or of explored nodes when a product has a setup cost. It returns ErrInfeasible when the capacity cannot meet the demand.
+// The options are those of goptimization.Model.Solve.
+func (p *Planning) Solve(maxIter int, opts ...goptimization.Option) (*Plan, error) {
+	m, v, err := p.Build()
+	if err != nil {
+		return nil, err
+	}
+	s, err := m.Solve(maxIter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return v.Plan(s), nil
+}
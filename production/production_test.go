@@ -0,0 +1,149 @@
+package production
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// silent Logger of the solves
+var silent = goptimization.WithLogger(log.New(ioutil.Discard, "", 0))
+
+// wagnerWhitin Cost of the uncapacitated lot sizing of one product by the dynamic program of Wagner and Whitin:
+// the production of a period covers the demand of the following periods up to the next production,
+// the periods without demand before the first production need no setup
+func wagnerWhitin(product Product) float64 {
+	T := len(product.Demand)
+	best := make([]float64, T+1)
+	for end := 1; end <= T; end++ {
+		best[end] = math.Inf(1)
+		for start := 0; start < end; start++ {
+			cost, demand := best[start], 0.0
+			for t := start; t < end; t++ {
+				cost += product.ProductionCost*product.Demand[t] + product.HoldingCost*float64(t-start)*product.Demand[t]
+				demand += product.Demand[t]
+			}
+			if demand > 0 {
+				cost += product.SetupCost
+			}
+			best[end] = math.Min(best[end], cost)
+		}
+	}
+	return best[T]
+}
+
+func TestValidate(t *testing.T) {
+	p := &Planning{Periods: 2, Products: []Product{{Demand: []float64{1, 2}}}}
+	assert.NoError(t, p.Validate())
+
+	p.Capacity = []float64{1}
+	assert.Equal(t, goptimization.ErrDimensionMismatch, errors.Cause(p.Validate()))
+	p.Capacity = nil
+	p.Products[0].Demand = []float64{1}
+	assert.Equal(t, goptimization.ErrDimensionMismatch, errors.Cause(p.Validate()))
+	p.Products[0].Demand = []float64{1, 2}
+	p.Products[0].HoldingCost = -1
+	assert.Error(t, p.Validate())
+	assert.Error(t, (&Planning{}).Validate())
+}
+
+func TestSolve(t *testing.T) {
+	// The capacity forces to produce the demand of the last period in advance
+	p := &Planning{
+		Periods:  3,
+		Products: []Product{{Name: "a", Demand: []float64{0, 0, 10}, Usage: 1, ProductionCost: 2, HoldingCost: 1}},
+		Capacity: []float64{5, 5, 5},
+	}
+	plan, err := p.Solve(1000, silent)
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{0, 5, 5}, plan.Production[0], 0.000001)
+	assert.InDeltaSlice(t, []float64{0, 5, 0}, plan.Inventory[0], 0.000001)
+	assert.InDelta(t, 25, plan.Cost, 0.000001)
+	assert.Equal(t, []bool{false, true, true}, plan.Setup[0])
+
+	// The initial inventory covers the first period
+	p.Products[0].Demand = []float64{4, 5, 5}
+	p.Products[0].InitialInventory = 4
+	plan, err = p.Solve(1000, silent)
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{0, 5, 5}, plan.Production[0], 0.000001)
+
+	// Two products share the capacity
+	p.Products[0].InitialInventory = 0
+	p.Products = append(p.Products, Product{Name: "b", Demand: []float64{1, 1, 1}, Usage: 2, ProductionCost: 1, HoldingCost: 0.5})
+	_, err = p.Solve(1000, silent)
+	assert.Equal(t, goptimization.ErrInfeasible, errors.Cause(err))
+	p.Capacity = []float64{10, 10, 10}
+	plan, err = p.Solve(1000, silent)
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{4, 5, 5}, plan.Production[0], 0.000001)
+	assert.InDeltaSlice(t, []float64{1, 1, 1}, plan.Production[1], 0.000001)
+}
+
+func TestBacklog(t *testing.T) {
+	p := &Planning{
+		Periods:  2,
+		Products: []Product{{Demand: []float64{10, 0}, Usage: 1, ProductionCost: 1}},
+		Capacity: []float64{0, 10},
+	}
+	_, err := p.Solve(1000, silent)
+	assert.Equal(t, goptimization.ErrInfeasible, errors.Cause(err))
+
+	p.Products[0].BacklogCost = 2
+	plan, err := p.Solve(1000, silent)
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{0, 10}, plan.Production[0], 0.000001)
+	assert.InDeltaSlice(t, []float64{10, 0}, plan.Backlog[0], 0.000001)
+	assert.InDelta(t, 30, plan.Cost, 0.000001)
+}
+
+func TestSetup(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for k := 0; k < 5; k++ {
+		product := Product{Demand: make([]float64, 6), ProductionCost: 1, HoldingCost: 1, SetupCost: 20 + 20*rnd.Float64()}
+		for period := range product.Demand {
+			product.Demand[period] = float64(rnd.Intn(15))
+		}
+		p := &Planning{Periods: 6, Products: []Product{product}}
+		plan, err := p.Solve(1000, silent)
+		require.NoError(t, err)
+		assert.InDelta(t, wagnerWhitin(product), plan.Cost, 0.000001)
+		for period := range product.Demand {
+			if plan.Production[0][period] > 0.000001 {
+				assert.True(t, plan.Setup[0][period])
+			}
+		}
+	}
+}
+
+func TestSolveOptions(t *testing.T) {
+	//The logger reaches the solver of the model
+	var buf bytes.Buffer
+	p := &Planning{Periods: 2, Products: []Product{{Name: "a", Demand: []float64{1, 1}, ProductionCost: 1, HoldingCost: 1}}}
+	plan, err := p.Solve(1000, goptimization.WithLogger(log.New(&buf, "", 0)))
+	require.NoError(t, err)
+	assert.InDelta(t, 1, plan.Production[0][0], 0.000001)
+	assert.NotZero(t, buf.Len())
+}
+
+func TestBuild(t *testing.T) {
+	p := &Planning{Periods: 2, Products: []Product{{Name: "a", Demand: []float64{1, 1}, ProductionCost: 1, HoldingCost: 1}}}
+	m, v, err := p.Build()
+	require.NoError(t, err)
+
+	// Forbid the production of the second period, the first period produces both demands
+	require.NoError(t, m.AddConstraint(v.Production[0][1].Expr(), 0))
+	s, err := m.Solve(1000, silent)
+	require.NoError(t, err)
+	plan := v.Plan(s)
+	assert.InDeltaSlice(t, []float64{2, 0}, plan.Production[0], 0.000001)
+	assert.InDelta(t, 3, plan.Cost, 0.000001)
+}
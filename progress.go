@@ -0,0 +1,111 @@
+package goptimization
+
+import (
+	"math"
+)
+
+// Progress State of the dictionary reported before each iteration of Simplex and DualSimplex, see WithProgress
+type Progress struct {
+	Iteration int
+	// Primal Objective of the current basic solution, a lower bound of the optimum when Feasible
+	Primal float64
+	// Feasible The basic solution satisfies the constraints and the upper bounds
+	Feasible bool
+	// Dual Upper bound of the optimum from the dual values of the basis, see DualBound
+	Dual float64
+}
+
+// Gap Relative duality gap (Dual - Primal)/max(1, |Primal|), +Inf while the basic solution is infeasible or
+// the dual values give no bound. Stopping once Gap() <= 0.001 returns a solution within 0.1% of the optimum.
+func (p Progress) Gap() float64 {
+	if !p.Feasible || math.IsInf(p.Dual, 1) {
+		return math.Inf(1)
+	}
+	return math.Max(p.Dual-p.Primal, 0) / math.Max(1, math.Abs(p.Primal))
+}
+
+// DualBound Upper bound of the maximum from the dual values y of the current basis, valid at every iteration and
+// not only at the optimum: with the upper bounds U_j of the variables, slacks included, the Lagrangian relaxation gives
+// max c*x <= y*b + Σ_j max(c_j - y*a_j, 0)*U_j
+// The dual values of the unbounded slacks are raised to 0 first. A row with no negative coefficient implies the
+// bounds x_j <= b_i/a_i_j, without them the bound is +Inf until the basis is dual feasible, it is then the objective.
+// The errors are those of FindY.
+func (cf *CanonicalForm) DualBound() (float64, error) {
+	yT, err := cf.findY()
+	if err != nil {
+		return 0, err
+	}
+	size := cf.n + cf.m
+	positions := make([]int, size)
+	for p, v := range cf.remap {
+		positions[v] = p
+	}
+	// The coefficients of the variables without the substitutions of the upper bounds, see reflect
+	a := func(i, v int) float64 {
+		if cf.reflected != nil && cf.reflected[v] {
+			return -cf.A.At(i, positions[v])
+		}
+		return cf.A.At(i, positions[v])
+	}
+	y := make([]float64, cf.m)
+	rhs := make([]float64, cf.m)
+	upper := make([]float64, size)
+	for v := range upper {
+		upper[v] = cf.upperOf(v)
+	}
+	for i := range y {
+		y[i] = yT.At(0, i)
+		if y[i] < 0 && math.IsInf(cf.upperOf(cf.slackOf(i)), 1) {
+			y[i] = 0
+		}
+		rhs[i] = cf.rhs(i)
+		nonnegative := true
+		for v := 0; v < size && nonnegative; v++ {
+			nonnegative = a(i, v) >= 0
+		}
+		if !nonnegative {
+			continue
+		}
+		for v := 0; v < size; v++ {
+			if a(i, v) > 0 {
+				upper[v] = math.Min(upper[v], math.Max(rhs[i], 0)/a(i, v))
+			}
+		}
+	}
+
+	bound := 0.0
+	for i := range y {
+		bound += y[i] * rhs[i]
+	}
+	costs := cf.costs()
+	for v := 0; v < size; v++ {
+		d := costs[v]
+		for i := range y {
+			d -= y[i] * a(i, v)
+		}
+		// The reduced costs within the tolerance are rounding errors, like in optimal
+		if d <= cf.tolerance {
+			continue
+		}
+		if math.IsInf(upper[v], 1) {
+			return math.Inf(1), nil
+		}
+		bound += d * upper[v]
+	}
+	return bound, nil
+}
+
+// progress Progress of the dictionary before the iteration iter
+func (cf *CanonicalForm) progress(iter int) Progress {
+	_, primal := cf.values()
+	dual, err := cf.DualBound()
+	if err != nil {
+		dual = math.Inf(1)
+	}
+	return Progress{Iteration: iter, Primal: primal, Feasible: cf.primalFeasible(), Dual: dual}
+}
+
+// proceed Report the progress of cf to the callback of WithProgress, false when it stops the iterations
+func (o options) proceed(cf *CanonicalForm, iter int) bool {
+	return o.progress == nil || o.progress(cf.progress(iter))
+}
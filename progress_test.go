@@ -0,0 +1,88 @@
+package goptimization
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestWithProgress(t *testing.T) {
+	c, A, b, _ := knapsack(30, 3)
+	_, _, optimum, err := Simplex(c, mat.DenseCopyOf(A), b, silent)
+	require.NoError(t, err)
+
+	reports := []Progress{}
+	record := WithProgress(func(p Progress) bool {
+		reports = append(reports, p)
+		return true
+	})
+	_, _, score, err := Simplex(c, mat.DenseCopyOf(A), b, silent, record)
+	require.NoError(t, err)
+	assert.InDelta(t, optimum, score, 1e-9)
+	require.NotEmpty(t, reports)
+	for k, p := range reports {
+		assert.Equal(t, k, p.Iteration)
+		assert.True(t, p.Feasible)
+		// The rows of the knapsack bound every variable, the bound is finite from the slack basis
+		assert.False(t, math.IsInf(p.Dual, 1), "iteration %d", k)
+		assert.True(t, p.Primal <= optimum+1e-9 && p.Dual >= optimum-1e-9, "iteration %d: %v", k, p)
+	}
+	last := reports[len(reports)-1]
+	assert.InDelta(t, 0, last.Gap(), 1e-9)
+	assert.True(t, reports[0].Gap() > last.Gap())
+
+	// Stop within 1% of the optimum
+	_, results, score, err := Simplex(c, mat.DenseCopyOf(A), b, silent, WithProgress(func(p Progress) bool {
+		return p.Gap() > 0.01
+	}))
+	if err != nil {
+		assert.Equal(t, ErrIterationLimit, errors.Cause(err))
+	}
+	require.NotNil(t, results)
+	assert.True(t, score >= optimum-0.01*math.Max(1, optimum)-1e-9)
+}
+
+func TestDualBound(t *testing.T) {
+	for seed := int64(1); seed <= 3; seed++ {
+		lp, err := GenerateLP(20, 15, 0.3, seed)
+		require.NoError(t, err)
+		reports := []Progress{}
+		_, _, score, err := DualSimplex(lp.C, mat.DenseCopyOf(lp.A), lp.B, silent, WithProgress(func(p Progress) bool {
+			reports = append(reports, p)
+			return true
+		}))
+		require.NoError(t, err)
+		for _, p := range reports {
+			assert.True(t, p.Dual >= lp.Optimum-1e-6, "seed %d iteration %d: %v", seed, p.Iteration, p)
+		}
+
+		// At the optimum the dual values give the optimum
+		cf := CanonicalForm{}
+		require.NoError(t, cf.New(lp.C, mat.DenseCopyOf(lp.A), lp.B))
+		cf.configure(newOptions([]Option{silent}))
+		_, _, _, err = cf.run(newOptions([]Option{silent}))
+		require.NoError(t, err)
+		bound, err := cf.DualBound()
+		require.NoError(t, err)
+		assert.InDelta(t, score, bound, 1e-6*(1+math.Abs(score)), "seed %d", seed)
+	}
+
+	// The slack basis of max x1 + x2 with x1 + 2*x2 <= 4 and x1 <= 3: y = 0, x1 <= 3 and x2 <= 2
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(mat.NewDense(1, 2, []float64{1, 1}), mat.NewDense(2, 2, []float64{1, 2, 1, 0}), mat.NewDense(2, 1, []float64{4, 3})))
+	cf.configure(newOptions([]Option{silent}))
+	bound, err := cf.DualBound()
+	require.NoError(t, err)
+	assert.InDelta(t, 5, bound, 1e-9)
+	// A negative coefficient leaves x2 unbounded
+	cf = CanonicalForm{}
+	require.NoError(t, cf.New(mat.NewDense(1, 2, []float64{1, 1}), mat.NewDense(1, 2, []float64{1, -1}), mat.NewDense(1, 1, []float64{4})))
+	cf.configure(newOptions([]Option{silent}))
+	bound, err = cf.DualBound()
+	require.NoError(t, err)
+	assert.True(t, math.IsInf(bound, 1))
+}
@@ -0,0 +1,7 @@
+//go:build !race
+// +build !race
+
+package goptimization
+
+// raceEnabled The race detector instruments the memory accesses and allocates
+const raceEnabled = false
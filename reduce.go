@@ -0,0 +1,81 @@
+package goptimization
+
+import (
+	"math"
+	"sync"
+)
+
+// candidate Integer solution proposed for the incumbent by a worker of the search
+type candidate struct {
+	solution []float64
+	score    float64
+	source   string
+	// key Deterministic identifier of the node which found the solution, independent of the scheduling
+	key int
+}
+
+// preferredTo Break the tie between two candidates with the same score:
+// smaller key, then lexicographically smaller solution, then source name
+func (c candidate) preferredTo(other candidate) bool {
+	if c.key != other.key {
+		return c.key < other.key
+	}
+	for j := 0; j < len(c.solution) && j < len(other.solution); j++ {
+		if c.solution[j] != other.solution[j] {
+			return c.solution[j] < other.solution[j]
+		}
+	}
+	if len(c.solution) != len(other.solution) {
+		return len(c.solution) < len(other.solution)
+	}
+	return c.source < other.source
+}
+
+// reduceCandidates Pick the best candidate whatever the order of the slice:
+// among the candidates within epsilon of the best score, the preferred one wins.
+// It returns false if there is no candidate.
+func reduceCandidates(candidates []candidate) (candidate, bool) {
+	if len(candidates) == 0 {
+		return candidate{}, false
+	}
+	best := math.Inf(-1)
+	for _, c := range candidates {
+		best = math.Max(best, c.score)
+	}
+	var reduced candidate
+	found := false
+	for _, c := range candidates {
+		if c.score < best-epsilon {
+			continue
+		}
+		if !found || c.preferredTo(reduced) {
+			reduced = c
+			found = true
+		}
+	}
+	return reduced, true
+}
+
+// incumbentPool Candidates proposed by concurrent workers between two synchronization points.
+// The workers propose in any order, the reduction only depends on the set of candidates,
+// so the returned solution does not depend on the scheduling of the goroutines.
+type incumbentPool struct {
+	mu      sync.Mutex
+	pending []candidate
+}
+
+// propose Add a candidate, it is safe for concurrent use
+func (p *incumbentPool) propose(c candidate) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending = append(p.pending, c)
+}
+
+// reduce Drain the pending candidates and return the best one
+func (p *incumbentPool) reduce() (candidate, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	reduced, ok := reduceCandidates(p.pending)
+	p.pending = nil
+	return reduced, ok
+}
@@ -0,0 +1,50 @@
+package goptimization
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReduceCandidates(t *testing.T) {
+	candidates := []candidate{
+		{solution: []float64{1, 0}, score: 10, source: "rounding", key: 4},
+		{solution: []float64{0, 1}, score: 10 + 1e-12, source: "diving", key: 4},
+		{solution: []float64{2, 2}, score: 9, source: "relaxation", key: 1},
+		{solution: []float64{1, 1}, score: 10, source: "relaxation", key: 7},
+	}
+	_, ok := reduceCandidates(nil)
+	assert.False(t, ok)
+
+	// Every rotation of the candidates gives the same solution
+	for shift := range candidates {
+		rotated := append(append([]candidate(nil), candidates[shift:]...), candidates[:shift]...)
+		reduced, ok := reduceCandidates(rotated)
+		require.True(t, ok)
+		assert.Equal(t, []float64{0, 1}, reduced.solution)
+		assert.Equal(t, "diving", reduced.source)
+	}
+}
+
+func TestIncumbentPool(t *testing.T) {
+	for run := 0; run < 20; run++ {
+		pool := &incumbentPool{}
+		wg := sync.WaitGroup{}
+		for w := 0; w < 8; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				pool.propose(candidate{solution: []float64{float64(w % 2)}, score: 5, key: w % 3})
+			}(w)
+		}
+		wg.Wait()
+		reduced, ok := pool.reduce()
+		require.True(t, ok)
+		assert.Equal(t, 0, reduced.key)
+		assert.Equal(t, []float64{0}, reduced.solution)
+		_, ok = pool.reduce()
+		assert.False(t, ok)
+	}
+}
@@ -0,0 +1,78 @@
+package goptimization
+
+import (
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// L1Regression Least absolute deviations fit of y (N,1) by X (N,p)
+// Minimize Σ(1<=i<=N) |y_i - X_i*β|
+// The coefficients are free, β = β⁺ - β⁻, and each residual is bounded by a variable e_i:
+// Maximize -Σ e_i
+// Constraints:
+// 1<=i<=N,  X_i*(β⁺ - β⁻) - e_i <= y_i
+// 1<=i<=N, -X_i*(β⁺ - β⁻) - e_i <= -y_i
+// An intercept is a column of ones in X.
+// It returns the coefficients β (p,1) and the sum of the absolute residuals.
+// The options configure the dictionary, WithMaxIter aside.
+func L1Regression(X, y *mat.Dense, maxIter int, opts ...Option) (*mat.Dense, float64, error) {
+	return regression(X, y, false, maxIter, opts)
+}
+
+// ChebyshevRegression Minimax fit of y (N,1) by X (N,p)
+// Minimize max(1<=i<=N) |y_i - X_i*β|
+// The coefficients are free, β = β⁺ - β⁻, and all the residuals are bounded by a variable t:
+// Maximize -t
+// Constraints:
+// 1<=i<=N,  X_i*(β⁺ - β⁻) - t <= y_i
+// 1<=i<=N, -X_i*(β⁺ - β⁻) - t <= -y_i
+// It returns the coefficients β (p,1) and the largest absolute residual. The options are those of L1Regression.
+func ChebyshevRegression(X, y *mat.Dense, maxIter int, opts ...Option) (*mat.Dense, float64, error) {
+	return regression(X, y, true, maxIter, opts)
+}
+
+// regression Build and solve the linear problem of L1Regression or ChebyshevRegression
+func regression(X, y *mat.Dense, chebyshev bool, maxIter int, opts []Option) (*mat.Dense, float64, error) {
+	N, p := X.Dims()
+	if rows, cols := y.Dims(); rows != N || cols != 1 {
+		return nil, 0, newError(ErrDimensionMismatch, "y dims must be (X dims.r, 1)")
+	}
+	if N == 0 || p == 0 {
+		return nil, 0, errors.New("X is empty")
+	}
+	residuals := N
+	if chebyshev {
+		residuals = 1
+	}
+	n := 2*p + residuals
+	c := mat.NewDense(1, n, nil)
+	for k := 0; k < residuals; k++ {
+		c.Set(0, 2*p+k, -1)
+	}
+	A := mat.NewDense(2*N, n, nil)
+	b := mat.NewDense(2*N, 1, nil)
+	for i := 0; i < N; i++ {
+		e := 2*p + i
+		if chebyshev {
+			e = 2 * p
+		}
+		for sign, r := 1.0, i; r < 2*N; sign, r = -sign, r+N {
+			for j := 0; j < p; j++ {
+				A.Set(r, j, sign*X.At(i, j))
+				A.Set(r, p+j, -sign*X.At(i, j))
+			}
+			A.Set(r, e, -1)
+			b.Set(r, 0, sign*y.At(i, 0))
+		}
+	}
+
+	_, results, score, err := twoPhaseSimplex(c, A, b, maxIter, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	beta := mat.NewDense(p, 1, nil)
+	for j := 0; j < p; j++ {
+		beta.Set(j, 0, results.At(j, 0)-results.At(p+j, 0))
+	}
+	return beta, -score, nil
+}
@@ -0,0 +1,48 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestL1Regression(t *testing.T) {
+	// y = 1 - 2x on four points, the outlier at x = 3 does not move the fit
+	X := mat.NewDense(5, 2, []float64{
+		1, 0,
+		1, 1,
+		1, 2,
+		1, 3,
+		1, 4,
+	})
+	y := mat.NewDense(5, 1, []float64{1, -1, -3, 20, -7})
+	beta, deviation, err := L1Regression(X, y, 100, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0, beta.At(0, 0), 0.000001)
+	assert.InDelta(t, -2.0, beta.At(1, 0), 0.000001)
+	assert.InDelta(t, 25.0, deviation, 0.000001)
+}
+
+func TestChebyshevRegression(t *testing.T) {
+	// The best constant for 0, 4 and 10 is their midrange 5
+	X := mat.NewDense(3, 1, []float64{1, 1, 1})
+	y := mat.NewDense(3, 1, []float64{0, 4, 10})
+	beta, deviation, err := ChebyshevRegression(X, y, 100, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, 5.0, beta.At(0, 0), 0.000001)
+	assert.InDelta(t, 5.0, deviation, 0.000001)
+
+	// Exact fit of y = 2x - 3
+	X = mat.NewDense(3, 2, []float64{1, 1, 1, 2, 1, 5})
+	y = mat.NewDense(3, 1, []float64{-1, 1, 7})
+	beta, deviation, err = ChebyshevRegression(X, y, 100, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, -3.0, beta.At(0, 0), 0.000001)
+	assert.InDelta(t, 2.0, beta.At(1, 0), 0.000001)
+	assert.InDelta(t, 0.0, deviation, 0.000001)
+
+	_, _, err = ChebyshevRegression(X, mat.NewDense(2, 1, nil), 100, silent)
+	assert.Error(t, err)
+}
@@ -0,0 +1,214 @@
+package goptimization
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// RemoveConstraint Remove the constraint i, in the order of Solution.Slacks, from the dictionary and re-optimize
+// from its basis instead of building the problem again.
+// The slack variable of the constraint leaves the dictionary with it: when it is nonbasic, the constraint is binding,
+// it takes the place of the basic variable with the largest coefficient in the column i of B^-1, which keeps B
+// nonsingular. The row and the slack column then leave B, the basis of the other constraints stays nonsingular.
+// A basic solution made infeasible by the exchange goes through phaseOne, then Reoptimize restores the optimality.
+// It returns the number of iterations, the errors are those of phaseOne and Reoptimize.
+func (cf *CanonicalForm) RemoveConstraint(i int) (int, error) {
+	if i < 0 || i >= cf.m {
+		return 0, newError(ErrDimensionMismatch, "constraint %d not in [0, %d)", i, cf.m)
+	}
+	if cf.m == 1 {
+		return 0, newError(ErrDimensionMismatch, "the dictionary needs a constraint")
+	}
+	p := cf.position(cf.slackOf(i))
+	err := cf.unreflect(p)
+	if err != nil {
+		return 0, err
+	}
+	if p < cf.n {
+		// The column of the slack is e_i, d is the column i of B^-1
+		d, err := cf.solveBd(p)
+		if err != nil {
+			return 0, err
+		}
+		k := 0
+		for r := 1; r < cf.m; r++ {
+			if math.Abs(d.At(r, 0)) > math.Abs(d.At(k, 0)) {
+				k = r
+			}
+		}
+		cf.swap(p, k)
+		p = cf.n + k
+	}
+	cf.drop(p, i)
+	return cf.reoptimizeRemoved()
+}
+
+// RemoveVariable Remove the decision variable j, in the order of Solution.X, from the dictionary and re-optimize
+// from its basis instead of building the problem again.
+// A basic variable is first replaced in the basis by the nonbasic slack variable with the largest coefficient
+// in its row of the dictionary, so that B stays nonsingular, see repair.
+// A basic solution made infeasible by the exchange goes through phaseOne, then Reoptimize restores the optimality.
+// It returns the number of iterations, the errors are those of phaseOne and Reoptimize.
+func (cf *CanonicalForm) RemoveVariable(j int) (int, error) {
+	decisions := cf.decisions()
+	if j < 0 || j >= len(decisions) {
+		return 0, newError(ErrDimensionMismatch, "decision variable %d not in [0, %d)", j, len(decisions))
+	}
+	if cf.n == 1 {
+		return 0, newError(ErrDimensionMismatch, "the dictionary needs a nonbasic variable")
+	}
+	p := cf.position(decisions[j])
+	err := cf.unreflect(p)
+	if err != nil {
+		return 0, err
+	}
+	if p >= cf.n {
+		row, err := cf.tableauRow(p - cf.n)
+		if err != nil {
+			return 0, err
+		}
+		entering := -1
+		for k := 0; k < cf.n; k++ {
+			if cf.slack[cf.remap[k]] && math.Abs(row.At(0, k)) > cf.tolerance &&
+				(entering == -1 || math.Abs(row.At(0, k)) > math.Abs(row.At(0, entering))) {
+				entering = k
+			}
+		}
+		if entering == -1 {
+			return 0, errors.New("no slack variable can replace the basic variable")
+		}
+		cf.swap(entering, p-cf.n)
+		p = entering
+	}
+	cf.drop(p, -1)
+	return cf.reoptimizeRemoved()
+}
+
+// reoptimizeRemoved Recompute the basic solution after drop and re-optimize it
+func (cf *CanonicalForm) reoptimizeRemoved() (int, error) {
+	err := cf.refactorize()
+	if err != nil {
+		return 0, err
+	}
+	totalIter := 0
+	if !cf.primalFeasible() {
+		totalIter, err = cf.phaseOne(0)
+		if err != nil {
+			return totalIter, err
+		}
+	}
+	iter, err := cf.Reoptimize(0)
+	return totalIter + iter, err
+}
+
+// slackOf Slack variable of the constraint i, the slack variables follow the order of the constraints
+func (cf *CanonicalForm) slackOf(i int) int {
+	for v, slack := range cf.slack {
+		if slack {
+			if i == 0 {
+				return v
+			}
+			i--
+		}
+	}
+	return -1
+}
+
+// position Position of the variable v in the dictionary
+func (cf *CanonicalForm) position(v int) int {
+	for p, w := range cf.remap {
+		if w == v {
+			return p
+		}
+	}
+	return -1
+}
+
+// unreflect Undo the substitution of the variable at the position p by its upper bound, see reflect
+func (cf *CanonicalForm) unreflect(p int) error {
+	if cf.reflected == nil || !cf.reflected[cf.remap[p]] {
+		return nil
+	}
+	if p >= cf.n {
+		cf.reflect(p, nil)
+		return nil
+	}
+	d, err := cf.solveBd(p)
+	if err != nil {
+		return err
+	}
+	cf.reflect(p, d)
+	return nil
+}
+
+// drop Remove the variable at the position p, which must not be substituted by its upper bound, and
+// the row when it is >= 0, p is then the basic position of the slack of the row. The variables after
+// it are renumbered, the basic solution must be recomputed with refactorize.
+func (cf *CanonicalForm) drop(p, row int) {
+	v := cf.remap[p]
+	n, m := cf.n, cf.m
+	if row >= 0 {
+		m--
+	} else {
+		n--
+	}
+	A := mat.NewDense(m, n+m, nil)
+	c := mat.NewDense(1, n+m, nil)
+	b := mat.NewDense(m, 1, nil)
+	xBStar := mat.NewDense(m, 1, nil)
+	remap := make([]int, 0, n+m)
+	col := 0
+	for k := 0; k < cf.n+cf.m; k++ {
+		if k == p {
+			continue
+		}
+		r := 0
+		for i := 0; i < cf.m; i++ {
+			if i == row {
+				continue
+			}
+			A.Set(r, col, cf.A.At(i, k))
+			r++
+		}
+		c.Set(0, col, cf.c.At(0, k))
+		w := cf.remap[k]
+		if w > v {
+			w--
+		}
+		remap = append(remap, w)
+		col++
+	}
+	r := 0
+	for i := 0; i < cf.m; i++ {
+		if i == row {
+			continue
+		}
+		b.Set(r, 0, cf.b.At(i, 0))
+		r++
+	}
+	r = 0
+	for i := 0; i < cf.m; i++ {
+		if cf.n+i == p {
+			continue
+		}
+		xBStar.Set(r, 0, cf.xBStar.At(i, 0))
+		r++
+	}
+	cf.slack = append(cf.slack[:v:v], cf.slack[v+1:]...)
+	if cf.upper != nil {
+		cf.upper = append(cf.upper[:v:v], cf.upper[v+1:]...)
+		cf.reflected = append(cf.reflected[:v:v], cf.reflected[v+1:]...)
+	}
+	cf.n, cf.m = n, m
+	cf.A = A
+	cf.c = c
+	cf.b = b
+	cf.xBStar = xBStar
+	cf.remap = remap
+	cf.x = mat.NewDense(cf.n+cf.m, 1, nil)
+	// The candidates of the multiple pricing are positions
+	cf.candidates = cf.candidates[:0]
+	cf.slice()
+}
@@ -0,0 +1,142 @@
+package goptimization
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+// withoutRow Copy of A without its row i
+func withoutRow(A *mat.Dense, i int) *mat.Dense {
+	m, n := A.Dims()
+	out := mat.NewDense(m-1, n, nil)
+	for r, k := 0, 0; r < m; r++ {
+		if r != i {
+			out.SetRow(k, mat.Row(nil, r, A))
+			k++
+		}
+	}
+	return out
+}
+
+// withoutColumn Copy of A without its column j
+func withoutColumn(A *mat.Dense, j int) *mat.Dense {
+	var t mat.Dense
+	t.CloneFrom(A.T())
+	var out mat.Dense
+	out.CloneFrom(withoutRow(&t, j).T())
+	return &out
+}
+
+func TestRemoveConstraint(t *testing.T) {
+	lp, err := GenerateLP(15, 12, 0.4, 3)
+	require.NoError(t, err)
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(mat.DenseCopyOf(lp.C), mat.DenseCopyOf(lp.A), lp.B))
+	cf.configure(newOptions([]Option{silent}))
+	_, err = cf.Reoptimize(0)
+	require.NoError(t, err)
+
+	A, b := mat.DenseCopyOf(lp.A), mat.DenseCopyOf(lp.B)
+	binding, loose := -1, -1
+	for i, slack := range cf.Solution().Slacks {
+		if slack < 1e-9 && binding == -1 {
+			binding = i
+		}
+		if slack > 1e-6 && loose == -1 {
+			loose = i
+		}
+	}
+	require.True(t, binding >= 0 && loose >= 0)
+	// Remove the last one first, the other keeps its index
+	for _, i := range []int{int(math.Max(float64(binding), float64(loose))), int(math.Min(float64(binding), float64(loose)))} {
+		_, err = cf.RemoveConstraint(i)
+		require.NoError(t, err)
+		A, b = withoutRow(A, i), withoutRow(b, i)
+		_, _, expected, err := Simplex(lp.C, mat.DenseCopyOf(A), b, silent)
+		require.NoError(t, err)
+		solution := cf.Solution()
+		assert.InDelta(t, expected, solution.Score, 1e-7, "constraint %d", i)
+		rows, _ := b.Dims()
+		assert.Len(t, solution.Slacks, rows)
+		assert.True(t, feasible(A, b, solution.X))
+	}
+
+	_, err = cf.RemoveConstraint(10)
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+}
+
+func TestRemoveVariable(t *testing.T) {
+	lp, err := GenerateLP(15, 12, 0.4, 8)
+	require.NoError(t, err)
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(mat.DenseCopyOf(lp.C), mat.DenseCopyOf(lp.A), lp.B))
+	cf.configure(newOptions([]Option{silent}))
+	_, err = cf.Reoptimize(0)
+	require.NoError(t, err)
+
+	basic, zero := -1, -1
+	for j, x := range cf.Solution().X {
+		if x > 1e-6 && basic == -1 {
+			basic = j
+		}
+		if x < 1e-9 && zero == -1 {
+			zero = j
+		}
+	}
+	require.True(t, basic >= 0 && zero >= 0)
+	c, A := mat.DenseCopyOf(lp.C), mat.DenseCopyOf(lp.A)
+	for _, j := range []int{int(math.Max(float64(basic), float64(zero))), int(math.Min(float64(basic), float64(zero)))} {
+		_, err = cf.RemoveVariable(j)
+		require.NoError(t, err)
+		c, A = withoutColumn(c, j), withoutColumn(A, j)
+		_, _, expected, err := Simplex(c, mat.DenseCopyOf(A), lp.B, silent)
+		require.NoError(t, err)
+		solution := cf.Solution()
+		assert.InDelta(t, expected, solution.Score, 1e-7, "variable %d", j)
+		_, n := A.Dims()
+		assert.Len(t, solution.X, n)
+		assert.True(t, feasible(A, lp.B, solution.X))
+	}
+
+	_, err = cf.RemoveVariable(13)
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+}
+
+func TestRemoveRanged(t *testing.T) {
+	lp, err := GenerateLP(12, 8, 0.5, 5)
+	require.NoError(t, err)
+	ranges := []float64{math.Inf(1), 2, math.Inf(1), 4, 1, math.Inf(1), 3, math.Inf(1)}
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(mat.DenseCopyOf(lp.C), mat.DenseCopyOf(lp.A), lp.B))
+	cf.configure(newOptions([]Option{silent}))
+	upper := make([]float64, 20)
+	for j := range upper {
+		upper[j] = math.Inf(1)
+		if j >= 12 {
+			upper[j] = ranges[j-12]
+		}
+	}
+	cf.setUpper(upper)
+	_, _, _, err = cf.run(newOptions([]Option{silent}))
+	require.NoError(t, err)
+
+	// Remove a constraint whose slack is at its upper bound when there is one
+	removed := 0
+	for i, slack := range cf.Solution().Slacks {
+		if math.Abs(slack-ranges[i]) < 1e-9 {
+			removed = i
+			break
+		}
+	}
+	_, err = cf.RemoveConstraint(removed)
+	require.NoError(t, err)
+	ranges = append(append([]float64(nil), ranges[:removed]...), ranges[removed+1:]...)
+	_, _, expected, err := SimplexRanged(lp.C, withoutRow(lp.A, removed), withoutRow(lp.B, removed), ranges, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, expected, cf.Solution().Score, 1e-7)
+}
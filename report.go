@@ -0,0 +1,40 @@
+package goptimization
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Report Write the current dictionary and its solution to w for people. Unlike the trace of the iterations the output
+// only depends on the dictionary:
+// - the objective
+// - the tableau B^-1*A, one row per basic variable with its value, and the reduced costs with the objective, see Tableau
+// - the value of each decision variable and of each slack
+// The variables are named x0, x1, ... in the order of Solution.X and s0, s1, ... in the order of Solution.Slacks.
+// The values within the tolerance of 0 are written as 0. The errors are those of the solves with B.
+func (cf *CanonicalForm) Report(w io.Writer) error {
+	t, err := cf.Tableau()
+	if err != nil {
+		return err
+	}
+	s := cf.Solution()
+	_, err = fmt.Fprintf(w, "Objective: %s\n\n", t.format(s.Score))
+	if err != nil {
+		return err
+	}
+	err = t.Write(w)
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "\nvariable\tvalue\n")
+	for j, x := range s.X {
+		fmt.Fprintf(writer, "x%d\t%s\n", j, t.format(x))
+	}
+	for i, slack := range s.Slacks {
+		fmt.Fprintf(writer, "s%d\t%s\n", i, t.format(slack))
+	}
+	return writer.Flush()
+}
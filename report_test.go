@@ -0,0 +1,77 @@
+package goptimization
+
+import (
+	"bytes"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestReport(t *testing.T) {
+	c := mat.NewDense(1, 3, []float64{5, 4, 3})
+	A := mat.NewDense(3, 3, []float64{
+		2, 3, 1,
+		4, 1, 2,
+		3, 4, 2,
+	})
+	b := mat.NewDense(3, 1, []float64{5, 11, 8})
+	var trace bytes.Buffer
+	o := newOptions([]Option{WithLogger(log.New(&trace, "", 0))})
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(c, A, b))
+	cf.configure(o)
+	_, _, _, err := cf.run(o)
+	require.NoError(t, err)
+	assert.Contains(t, trace.String(), "Score: 13")
+
+	// GetResults has no side effect
+	trace.Reset()
+	results, score := cf.GetResults()
+	assert.Empty(t, trace.String())
+	assert.InDelta(t, 13, score, 1e-9)
+	assert.InDeltaSlice(t, []float64{2, 0, 1, 0, 1, 0}, mat.Col(nil, 0, results), 1e-9)
+
+	var report bytes.Buffer
+	require.NoError(t, cf.Report(&report))
+	assert.Equal(t, `Objective: 13
+
+basis    x0  x1  x2  s0  s1  s2  value
+x0       1   2   0   2   0   -1  2
+s1       0   -5  0   -2  1   0   1
+x2       0   -1  1   -3  0   2   1
+reduced  0   -3  0   -1  0   -1  13
+
+variable  value
+x0        2
+x1        0
+x2        1
+s0        0
+s1        1
+s2        0
+`, report.String())
+	assert.Empty(t, trace.String())
+	var again bytes.Buffer
+	require.NoError(t, cf.Report(&again))
+	assert.Equal(t, report.String(), again.String())
+}
+
+func TestReportReflected(t *testing.T) {
+	// x1 at its upper bound 1 is substituted by 1 - x1
+	c := mat.NewDense(1, 2, []float64{3, 1})
+	A := mat.NewDense(1, 2, []float64{1, 1})
+	b := mat.NewDense(1, 1, []float64{4})
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(c, A, b))
+	cf.configure(newOptions([]Option{WithLogger(log.New(&bytes.Buffer{}, "", 0))}))
+	cf.setUpper([]float64{1, 10, 10})
+	_, err := cf.Reoptimize(0)
+	require.NoError(t, err)
+	var report bytes.Buffer
+	require.NoError(t, cf.Report(&report))
+	assert.Contains(t, report.String(), "x0'")
+	assert.Contains(t, report.String(), "Objective: 6\n")
+	assert.Contains(t, report.String(), "x0        1\nx1        3\n")
+}
@@ -0,0 +1,165 @@
+package goptimization
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// MaxMin Maximize the minimum of several scenario objectives
+// Maximize min(1<=k<=K) Σ(1<=j<=n) c_k_j*x_j
+// Constraints:
+// 1<=i<=m,  Σ(1<=j<=n) a_i_j*x_j <= b_i
+// 1<=j<=n x_j >= 0
+// Each row of C is the objective of one scenario. The epigraph variable t = t⁺ - t⁻ is introduced
+// and the problem solved with Simplex is:
+// Maximize t
+// Constraints:
+// 1<=i<=m,  Σ(1<=j<=n) a_i_j*x_j <= b_i
+// 1<=k<=K,  t - Σ(1<=j<=n) c_k_j*x_j <= 0
+// It returns a matrix (n+m,1) like Simplex, without the epigraph variable and the linking constraints,
+// and the worst scenario objective. The options are given to Simplex.
+func MaxMin(C, A, b *mat.Dense, maxIter int, opts ...Option) (int, *mat.Dense, float64, error) {
+	c, epigraphA, epigraphB, err := maxMinForm(C, A, b)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	totalIter, results, score, err := Simplex(c, epigraphA, epigraphB, append([]Option{WithMaxIter(maxIter)}, opts...)...)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+
+	_, n := C.Dims()
+	m, _ := A.Dims()
+	x := mat.NewDense(n+m, 1, nil)
+	x.Slice(0, n, 0, 1).(*mat.Dense).Copy(results.Slice(0, n, 0, 1))
+	x.Slice(n, n+m, 0, 1).(*mat.Dense).Copy(results.Slice(n+2, n+2+m, 0, 1))
+	return totalIter, x, score, nil
+}
+
+// maxMinForm Build the epigraph formulation of MaxMin, the variables are (x, t⁺, t⁻)
+func maxMinForm(C, A, b *mat.Dense) (*mat.Dense, *mat.Dense, *mat.Dense, error) {
+	k, n := C.Dims()
+	m, cols := A.Dims()
+	if k == 0 {
+		return nil, nil, nil, newError(ErrDimensionMismatch, "C dims.r == 0")
+	}
+	if cols != n {
+		return nil, nil, nil, newError(ErrDimensionMismatch, "A dims.c != C dims.c")
+	}
+
+	c := mat.NewDense(1, n+2, nil)
+	c.Set(0, n, 1)
+	c.Set(0, n+1, -1)
+
+	epigraphA := mat.NewDense(m+k, n+2, nil)
+	epigraphA.Slice(0, m, 0, n).(*mat.Dense).Copy(A)
+	for s := 0; s < k; s++ {
+		for j := 0; j < n; j++ {
+			epigraphA.Set(m+s, j, -C.At(s, j))
+		}
+		epigraphA.Set(m+s, n, 1)
+		epigraphA.Set(m+s, n+1, -1)
+	}
+
+	epigraphB := mat.NewDense(m+k, 1, nil)
+	epigraphB.Slice(0, m, 0, 1).(*mat.Dense).Copy(b)
+	return c, epigraphA, epigraphB, nil
+}
+
+// RobustCounterpart Build the robust counterpart of Simplex's standard form when each coefficient a_i_j takes any
+// value in [a_i_j - d_i_j, a_i_j + d_i_j] and at most budget[i] coefficients of the row i deviate (Bertsimas-Sim).
+// The worst case of the row, Σ a_i_j*x_j + max(|S|<=Γ_i) Σ(j in S) d_i_j*x_j <= b_i with a fractional part of Γ_i
+// on one more coefficient, is replaced by its dual
+// Σ a_i_j*x_j + Γ_i*z_i + Σ p_i_j <= b_i
+// d_i_j*x_j - z_i - p_i_j <= 0 for each d_i_j != 0
+// A nil budget is the box uncertainty where all the coefficients deviate, the row then becomes Σ (a_i_j + d_i_j)*x_j
+// without z_i and p_i_j, as do the rows whose budget covers all their uncertain coefficients.
+// The variables z_i and p_i_j follow the n original variables and have no cost.
+func RobustCounterpart(c, A, D, b *mat.Dense, budget []float64) (*mat.Dense, *mat.Dense, *mat.Dense, error) {
+	_, n := c.Dims()
+	m, cols := A.Dims()
+	if cols != n {
+		return nil, nil, nil, newError(ErrDimensionMismatch, "A dims.c != z dims.c")
+	}
+	if rows, dCols := D.Dims(); rows != m || dCols != n {
+		return nil, nil, nil, newError(ErrDimensionMismatch, "D dims != A dims")
+	}
+	if rows, _ := b.Dims(); rows != m {
+		return nil, nil, nil, newError(ErrDimensionMismatch, "b dims.r != A dims.r")
+	}
+	if budget != nil && len(budget) != m {
+		return nil, nil, nil, newError(ErrDimensionMismatch, "len(budget) != A dims.r")
+	}
+
+	// Uncertain coefficients of each row, and the rows which need z_i and p_i_j
+	uncertain := make([][]int, m)
+	dual := make([]bool, m)
+	vars, rows := n, m
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			if D.At(i, j) < 0 {
+				return nil, nil, nil, errors.Errorf("negative deviation of a_%d_%d", i, j)
+			}
+			if D.At(i, j) > 0 {
+				uncertain[i] = append(uncertain[i], j)
+			}
+		}
+		if budget == nil {
+			continue
+		}
+		if budget[i] < 0 || math.IsNaN(budget[i]) {
+			return nil, nil, nil, errors.Errorf("invalid budget %g for row %d", budget[i], i)
+		}
+		if budget[i] > 0 && budget[i] < float64(len(uncertain[i])) {
+			dual[i] = true
+			vars += 1 + len(uncertain[i])
+			rows += len(uncertain[i])
+		}
+	}
+
+	rc := mat.NewDense(1, vars, nil)
+	rc.Slice(0, 1, 0, n).(*mat.Dense).Copy(c)
+	rA := mat.NewDense(rows, vars, nil)
+	rA.Slice(0, m, 0, n).(*mat.Dense).Copy(A)
+	rb := mat.NewDense(rows, 1, nil)
+	rb.Slice(0, m, 0, 1).(*mat.Dense).Copy(b)
+	z, r := n, m
+	for i := 0; i < m; i++ {
+		switch {
+		case dual[i]:
+			rA.Set(i, z, budget[i])
+			for k, j := range uncertain[i] {
+				p := z + 1 + k
+				rA.Set(i, p, 1)
+				rA.Set(r, j, D.At(i, j))
+				rA.Set(r, z, -1)
+				rA.Set(r, p, -1)
+				r++
+			}
+			z += 1 + len(uncertain[i])
+		case budget == nil || budget[i] > 0:
+			for _, j := range uncertain[i] {
+				rA.Set(i, j, A.At(i, j)+D.At(i, j))
+			}
+		}
+	}
+	return rc, rA, rb, nil
+}
+
+// Robust Solve the robust counterpart of the problem with the simplex algorithm, see RobustCounterpart.
+// b can be negative, a phase one then finds a feasible basis. The options configure the dictionary, WithMaxIter aside.
+// It returns the number of iterations, the robust solution x (n,1) and its score.
+func Robust(c, A, D, b *mat.Dense, budget []float64, maxIter int, opts ...Option) (int, *mat.Dense, float64, error) {
+	rc, rA, rb, err := RobustCounterpart(c, A, D, b, budget)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	iter, results, score, err := twoPhaseSimplex(rc, rA, rb, maxIter, opts...)
+	if err != nil {
+		return iter, nil, 0, err
+	}
+	_, n := c.Dims()
+	return iter, mat.DenseCopyOf(results.Slice(0, n, 0, 1)), score, nil
+}
@@ -0,0 +1,80 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestMaxMin(t *testing.T) {
+	//Scenarios 3x_1 + x_2 and x_1 + 2x_2 with a budget x_1 + x_2 <= 4
+	C := mat.NewDense(2, 2, []float64{
+		3, 1,
+		1, 2,
+	})
+	A := mat.NewDense(2, 2, []float64{
+		1, 1,
+		1, 0,
+	})
+	b := mat.NewDense(2, 1, []float64{4, 3})
+
+	_, results, score, err := MaxMin(C, A, b, 20, silent)
+	require.NoError(t, err)
+	//3x_1 + x_2 = x_1 + 2x_2 on x_1 + x_2 = 4 gives x = (4/3, 8/3)
+	assert.True(t, mat.EqualApprox(mat.NewDense(4, 1, []float64{4.0 / 3, 8.0 / 3, 0, 3 - 4.0/3}), results, 0.000001))
+	assert.InEpsilon(t, 20.0/3, score, 0.000001)
+}
+
+func TestMaxMinDims(t *testing.T) {
+	C := mat.NewDense(2, 3, nil)
+	A := mat.NewDense(1, 2, nil)
+	b := mat.NewDense(1, 1, nil)
+
+	_, _, _, err := MaxMin(C, A, b, 20, silent)
+	assert.Error(t, err)
+}
+
+func TestRobust(t *testing.T) {
+	// Maximize 2x+y, x+y <= 4 with both coefficients in [0, 2], x <= 3, y <= 3
+	c := mat.NewDense(1, 2, []float64{2, 1})
+	A := mat.NewDense(3, 2, []float64{1, 1, 1, 0, 0, 1})
+	D := mat.NewDense(3, 2, []float64{1, 1, 0, 0, 0, 0})
+	b := mat.NewDense(3, 1, []float64{4, 3, 3})
+
+	for _, test := range []struct {
+		budget   []float64
+		expected float64
+	}{
+		{[]float64{0, 0, 0}, 7},
+		{[]float64{0.5, 0, 0}, 16.0 / 3},
+		{[]float64{1, 0, 0}, 4},
+		{[]float64{2, 0, 0}, 4},
+		{nil, 4},
+	} {
+		_, x, score, err := Robust(c, A, D, b, test.budget, 100, silent)
+		require.NoError(t, err)
+		assert.InDelta(t, test.expected, score, 0.000001, "budget %v", test.budget)
+		r, _ := x.Dims()
+		assert.Equal(t, 2, r)
+		assert.InDelta(t, score, 2*x.At(0, 0)+x.At(1, 0), 0.000001)
+	}
+
+	rc, rA, rb, err := RobustCounterpart(c, A, D, b, []float64{1, 0, 0})
+	require.NoError(t, err)
+	assert.True(t, mat.Equal(mat.NewDense(1, 5, []float64{2, 1, 0, 0, 0}), rc))
+	assert.True(t, mat.Equal(mat.NewDense(5, 5, []float64{
+		1, 1, 1, 1, 1,
+		1, 0, 0, 0, 0,
+		0, 1, 0, 0, 0,
+		1, 0, -1, -1, 0,
+		0, 1, -1, 0, -1,
+	}), rA))
+	assert.True(t, mat.Equal(mat.NewDense(5, 1, []float64{4, 3, 3, 0, 0}), rb))
+
+	_, _, _, err = RobustCounterpart(c, A, D, b, []float64{1})
+	assert.Error(t, err)
+	_, _, _, err = RobustCounterpart(c, A, mat.NewDense(3, 2, []float64{-1, 0, 0, 0, 0, 0}), b, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,128 @@
+package scheduling
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// Rule Dispatching rule choosing the next operation of a machine among the operations in conflict
+type Rule int
+
+const (
+	// ShortestProcessingTime Operation with the shortest duration
+	ShortestProcessingTime Rule = iota
+	// LongestProcessingTime Operation with the longest duration
+	LongestProcessingTime
+	// MostWorkRemaining Operation of the job with the longest remaining duration, including the operation
+	MostWorkRemaining
+	// EarliestStart Operation which can start first, the first job in case of a tie
+	EarliestStart
+)
+
+// Rules All the dispatching rules
+var Rules = []Rule{ShortestProcessingTime, LongestProcessingTime, MostWorkRemaining, EarliestStart}
+
+// priority Priority of the operation for the rule, the lowest is dispatched first
+func (r Rule) priority(p *Problem, id OperationID, start float64) float64 {
+	switch r {
+	case ShortestProcessingTime:
+		return p.operation(id).Duration
+	case LongestProcessingTime:
+		return -p.operation(id).Duration
+	case MostWorkRemaining:
+		remaining := 0.0
+		for _, op := range p.Jobs[id.Job].Operations[id.Index:] {
+			remaining += op.Duration
+		}
+		return -remaining
+	default:
+		return start
+	}
+}
+
+// Dispatch Build an active schedule with the algorithm of Giffler and Thompson:
+// among the operations whose predecessors are scheduled, the one completed first defines a machine and a date.
+// The operations of this machine which can start before the date are in conflict and the rule picks the one scheduled next.
+// It returns an error when the precedences have a cycle.
+func Dispatch(p *Problem, rule Rule) (*Schedule, error) {
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+	before := p.predecessors()
+	ids := p.operations()
+	done := map[OperationID]float64{}
+	free := make([]float64, p.Machines)
+	s := &Schedule{Start: make([][]float64, len(p.Jobs))}
+	for j, job := range p.Jobs {
+		s.Start[j] = make([]float64, len(job.Operations))
+	}
+
+	for len(done) < len(ids) {
+		ready := []OperationID{}
+		starts := []float64{}
+		for _, id := range ids {
+			if _, ok := done[id]; ok {
+				continue
+			}
+			start, isReady := free[p.operation(id).Machine], true
+			for _, predecessor := range before[id] {
+				end, ok := done[predecessor]
+				if !ok {
+					isReady = false
+					break
+				}
+				start = math.Max(start, end)
+			}
+			if isReady {
+				ready = append(ready, id)
+				starts = append(starts, start)
+			}
+		}
+		if len(ready) == 0 {
+			return nil, errors.New("the precedences have a cycle")
+		}
+
+		first := 0
+		for k := range ready {
+			if starts[k]+p.operation(ready[k]).Duration < starts[first]+p.operation(ready[first]).Duration {
+				first = k
+			}
+		}
+		machine := p.operation(ready[first]).Machine
+		date := starts[first] + p.operation(ready[first]).Duration
+		chosen := first
+		for k, id := range ready {
+			if p.operation(id).Machine != machine || (starts[k] >= date && k != first) {
+				continue
+			}
+			if rule.priority(p, id, starts[k]) < rule.priority(p, ready[chosen], starts[chosen]) {
+				chosen = k
+			}
+		}
+
+		id := ready[chosen]
+		end := starts[chosen] + p.operation(id).Duration
+		s.Start[id.Job][id.Index] = starts[chosen]
+		done[id] = end
+		free[machine] = end
+		s.Makespan = math.Max(s.Makespan, end)
+	}
+	return s, nil
+}
+
+// BestDispatch Schedule with the smallest makespan over all the rules
+func BestDispatch(p *Problem) (*Schedule, error) {
+	var best *Schedule
+	for _, rule := range Rules {
+		s, err := Dispatch(p, rule)
+		if err != nil {
+			return nil, err
+		}
+		if best == nil || s.Makespan < best.Makespan {
+			best = s
+		}
+	}
+	return best, nil
+}
@@ -0,0 +1,35 @@
+package scheduling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatch(t *testing.T) {
+	p := jobShop()
+	for _, rule := range Rules {
+		s, err := Dispatch(p, rule)
+		require.NoError(t, err)
+		assert.NoError(t, p.Check(s), rule)
+	}
+	best, err := BestDispatch(p)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, best.Makespan, bruteForce(p))
+
+	//A single machine processes the operations back to back
+	single := &Problem{Machines: 1, Jobs: []Job{{Operations: []Operation{{0, 2}}}, {Operations: []Operation{{0, 5}}}}}
+	s, err := Dispatch(single, ShortestProcessingTime)
+	require.NoError(t, err)
+	assert.Equal(t, [][]float64{{0}, {2}}, s.Start)
+	assert.Equal(t, 7.0, s.Makespan)
+
+	cycle := jobShop()
+	cycle.Precedences = []Precedence{
+		{Before: OperationID{0, 2}, After: OperationID{1, 0}},
+		{Before: OperationID{1, 2}, After: OperationID{0, 0}},
+	}
+	_, err = Dispatch(cycle, EarliestStart)
+	assert.Error(t, err)
+}
@@ -0,0 +1,140 @@
+package scheduling
+
+import (
+	"math"
+
+	"github.com/askiada/goptimization"
+)
+
+// dispatchHeuristic Solution of the branch and bound given by a schedule of the dispatching rules
+type dispatchHeuristic struct {
+	x []float64
+}
+
+// Name Name reported in the incumbents
+func (dispatchHeuristic) Name() string {
+	return "dispatch"
+}
+
+// Run Return the schedule
+func (h dispatchHeuristic) Run(*goptimization.HeuristicContext) ([]float64, bool, error) {
+	return h.x, true, nil
+}
+
+// pair Two operations of the same machine ordered by the binary variable y: y = 1 when First is processed before Second
+type pair struct {
+	first, second OperationID
+	y             goptimization.Var
+}
+
+// Solve Minimize the makespan with the disjunctive formulation, s_o is the start of the operation o:
+// Minimize Cmax
+// Constraints:
+// o before q in a job or a precedence, s_o + d_o <= s_q
+// o last operation of a job, s_o + d_o <= Cmax
+// o, q on the same machine, s_o + d_o <= s_q + M*(1-y_oq) and s_q + d_q <= s_o + M*y_oq
+// Cmax <= M
+// M is the makespan of BestDispatch, whose schedule is the first incumbent of the branch and bound.
+// It returns the schedule, with ErrIterationLimit and the best schedule found when the search stopped after maxNodes nodes.
+// The options are those of goptimization.MIP.
+func Solve(p *Problem, maxNodes int, opts ...goptimization.Option) (*Schedule, error) {
+	start, err := BestDispatch(p)
+	if err != nil {
+		return nil, err
+	}
+	bigM := start.Makespan
+	ids := p.operations()
+
+	m := &goptimization.Model{}
+	starts := map[OperationID]goptimization.Var{}
+	for _, id := range ids {
+		starts[id] = m.AddVariable("", false)
+	}
+	makespan := m.AddVariable("", false)
+	m.Maximize(goptimization.Expr{Terms: []goptimization.Term{{Var: makespan, Coef: -1}}})
+
+	// s_o + d_o - s_q <= 0
+	precede := func(o OperationID, q goptimization.Var) error {
+		return m.AddConstraint(goptimization.Expr{Terms: []goptimization.Term{{Var: starts[o], Coef: 1}, {Var: q, Coef: -1}}},
+			-p.operation(o).Duration)
+	}
+	before := p.predecessors()
+	for _, id := range ids {
+		for _, predecessor := range before[id] {
+			err := precede(predecessor, starts[id])
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	for j, job := range p.Jobs {
+		if len(job.Operations) == 0 {
+			continue
+		}
+		err := precede(OperationID{Job: j, Index: len(job.Operations) - 1}, makespan)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = m.AddConstraint(makespan.Expr(), bigM)
+	if err != nil {
+		return nil, err
+	}
+	pairs := []pair{}
+	for a, o := range ids {
+		for _, q := range ids[a+1:] {
+			if p.operation(o).Machine != p.operation(q).Machine || o.Job == q.Job {
+				continue
+			}
+			y := m.AddBinary("")
+			pairs = append(pairs, pair{first: o, second: q, y: y})
+			rows := []goptimization.Expr{
+				{Terms: []goptimization.Term{{Var: starts[o], Coef: 1}, {Var: starts[q], Coef: -1}, {Var: y, Coef: bigM}}},
+				{Terms: []goptimization.Term{{Var: starts[q], Coef: 1}, {Var: starts[o], Coef: -1}, {Var: y, Coef: -bigM}}},
+			}
+			rhs := []float64{bigM - p.operation(o).Duration, -p.operation(q).Duration}
+			for k, row := range rows {
+				err := m.AddConstraint(row, rhs[k])
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	c, A, b, integer := m.Standard()
+	x := make([]float64, len(integer))
+	for _, id := range ids {
+		x[starts[id]] = start.Start[id.Job][id.Index]
+	}
+	x[makespan] = start.Makespan
+	for _, pr := range pairs {
+		if start.Start[pr.first.Job][pr.first.Index]+p.operation(pr.first).Duration <= start.Start[pr.second.Job][pr.second.Index] {
+			x[pr.y] = 1
+		}
+	}
+
+	bb := &goptimization.BranchAndBound{}
+	err = bb.New(c, A, b, integer)
+	if err != nil {
+		return nil, err
+	}
+	bb.Configure(opts...)
+	bb.Heuristics = append(bb.Heuristics, dispatchHeuristic{x: x})
+	results, _, err := bb.Solve(maxNodes)
+	if err != nil {
+		return nil, err
+	}
+	s := &Schedule{Start: make([][]float64, len(p.Jobs))}
+	for j, job := range p.Jobs {
+		s.Start[j] = make([]float64, len(job.Operations))
+		for k, op := range job.Operations {
+			s.Start[j][k] = results.At(int(starts[OperationID{Job: j, Index: k}]), 0)
+			s.Makespan = math.Max(s.Makespan, s.Start[j][k]+op.Duration)
+		}
+	}
+	if bb.Stopped != "" {
+		return s, goptimization.ErrIterationLimit
+	}
+	return s, nil
+}
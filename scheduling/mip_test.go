@@ -0,0 +1,59 @@
+package scheduling
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"testing"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// silent Logger of the solves
+var silent = goptimization.WithLogger(log.New(ioutil.Discard, "", 0))
+
+func TestSolve(t *testing.T) {
+	//The dispatching rules are 1 time unit above the optimum 12
+	p := &Problem{
+		Machines: 3,
+		Jobs: []Job{
+			{Operations: []Operation{{1, 2}, {2, 1}, {0, 2}}},
+			{Operations: []Operation{{2, 4}, {1, 4}, {0, 3}}},
+			{Operations: []Operation{{2, 2}, {0, 2}, {1, 1}}},
+		},
+	}
+	dispatched, err := BestDispatch(p)
+	require.NoError(t, err)
+	assert.Equal(t, 13.0, dispatched.Makespan)
+	s, err := Solve(p, 10000, silent)
+	require.NoError(t, err)
+	require.NoError(t, p.Check(s))
+	assert.InDelta(t, 12, s.Makespan, 0.000001)
+	assert.InDelta(t, bruteForce(p), s.Makespan, 0.000001)
+
+	p = jobShop()
+
+	//Job c cannot start before job a is done
+	p.Precedences = []Precedence{{Before: OperationID{0, 2}, After: OperationID{2, 0}}}
+	s, err = Solve(p, 10000, silent)
+	require.NoError(t, err)
+	require.NoError(t, p.Check(s))
+	assert.InDelta(t, bruteForce(p), s.Makespan, 0.000001)
+	assert.GreaterOrEqual(t, s.Start[2][0], s.Start[0][2]+2-0.000001)
+}
+
+func TestSolveOptions(t *testing.T) {
+	//A cancelled context stops the search at the schedule of BestDispatch
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p := jobShop()
+	s, err := Solve(p, 10000, silent, goptimization.WithContext(ctx))
+	assert.Equal(t, goptimization.ErrIterationLimit, errors.Cause(err))
+	require.NoError(t, p.Check(s))
+	dispatched, err := BestDispatch(p)
+	require.NoError(t, err)
+	assert.InDelta(t, dispatched.Makespan, s.Makespan, 0.000001)
+}
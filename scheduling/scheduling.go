@@ -0,0 +1,157 @@
+// Package scheduling Job shop scheduling: jobs made of operations processed in order on machines,
+// with the makespan minimized by a MIP formulation solved with goptimization or by dispatching rules.
+package scheduling
+
+import (
+	"math"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+)
+
+// Operation Step of a job, processed on the machine for the duration without interruption
+type Operation struct {
+	Machine  int
+	Duration float64
+}
+
+// Job Operations processed one after the other, in order
+type Job struct {
+	Name       string
+	Operations []Operation
+}
+
+// OperationID Operation Index of the job Job
+type OperationID struct {
+	Job   int
+	Index int
+}
+
+// Precedence The operation After cannot start before the operation Before is done
+type Precedence struct {
+	Before OperationID
+	After  OperationID
+}
+
+// Problem Job shop: each machine processes one operation at a time
+type Problem struct {
+	Machines int
+	Jobs     []Job
+	// Precedences Constraints between the operations of different jobs, on top of the order of each job
+	Precedences []Precedence
+}
+
+// Schedule Start time of each operation, indexed like Problem.Jobs and Job.Operations
+type Schedule struct {
+	Start    [][]float64
+	Makespan float64
+}
+
+// Validate Check the machines, the durations and the precedences of the problem
+func (p *Problem) Validate() error {
+	if p.Machines <= 0 || len(p.Jobs) == 0 {
+		return errors.New("the problem needs machines and jobs")
+	}
+	for j, job := range p.Jobs {
+		for k, op := range job.Operations {
+			if op.Machine < 0 || op.Machine >= p.Machines {
+				return errors.Errorf("operation %d of job %d uses the unknown machine %d", k, j, op.Machine)
+			}
+			if op.Duration < 0 {
+				return errors.Errorf("operation %d of job %d has the negative duration %g", k, j, op.Duration)
+			}
+		}
+	}
+	for _, precedence := range p.Precedences {
+		for _, id := range []OperationID{precedence.Before, precedence.After} {
+			if !p.valid(id) {
+				return errors.Errorf("precedence on the unknown operation %d of job %d", id.Index, id.Job)
+			}
+		}
+	}
+	return nil
+}
+
+// valid Check if the operation is in the problem
+func (p *Problem) valid(id OperationID) bool {
+	return id.Job >= 0 && id.Job < len(p.Jobs) && id.Index >= 0 && id.Index < len(p.Jobs[id.Job].Operations)
+}
+
+// operation Operation of the id
+func (p *Problem) operation(id OperationID) Operation {
+	return p.Jobs[id.Job].Operations[id.Index]
+}
+
+// Check Check that the schedule satisfies the order of the jobs, the precedences and the capacity of the machines
+func (p *Problem) Check(s *Schedule) error {
+	const tolerance = 1e-6
+	if len(s.Start) != len(p.Jobs) {
+		return goptimization.ErrDimensionMismatch
+	}
+	end := func(id OperationID) float64 {
+		return s.Start[id.Job][id.Index] + p.operation(id).Duration
+	}
+	makespan := 0.0
+	for j, job := range p.Jobs {
+		if len(s.Start[j]) != len(job.Operations) {
+			return goptimization.ErrDimensionMismatch
+		}
+		for k := range job.Operations {
+			if s.Start[j][k] < -tolerance {
+				return errors.Errorf("operation %d of job %d starts before 0", k, j)
+			}
+			if k > 0 && s.Start[j][k] < end(OperationID{j, k - 1})-tolerance {
+				return errors.Errorf("operation %d of job %d starts before the previous operation is done", k, j)
+			}
+			makespan = math.Max(makespan, end(OperationID{j, k}))
+		}
+	}
+	for _, precedence := range p.Precedences {
+		if s.Start[precedence.After.Job][precedence.After.Index] < end(precedence.Before)-tolerance {
+			return errors.Errorf("operation %d of job %d starts before operation %d of job %d is done",
+				precedence.After.Index, precedence.After.Job, precedence.Before.Index, precedence.Before.Job)
+		}
+	}
+	ids := p.operations()
+	for a, first := range ids {
+		for _, second := range ids[a+1:] {
+			if p.operation(first).Machine != p.operation(second).Machine {
+				continue
+			}
+			startFirst, startSecond := s.Start[first.Job][first.Index], s.Start[second.Job][second.Index]
+			if startFirst < end(second)-tolerance && startSecond < end(first)-tolerance {
+				return errors.Errorf("operations of jobs %d and %d overlap on machine %d", first.Job, second.Job, p.operation(first).Machine)
+			}
+		}
+	}
+	if math.Abs(makespan-s.Makespan) > tolerance {
+		return errors.Errorf("the makespan is %g, not %g", makespan, s.Makespan)
+	}
+	return nil
+}
+
+// operations All the operations, job by job
+func (p *Problem) operations() []OperationID {
+	ids := []OperationID{}
+	for j, job := range p.Jobs {
+		for k := range job.Operations {
+			ids = append(ids, OperationID{Job: j, Index: k})
+		}
+	}
+	return ids
+}
+
+// predecessors Operations which must be done before each operation, including the previous operation of its job
+func (p *Problem) predecessors() map[OperationID][]OperationID {
+	before := map[OperationID][]OperationID{}
+	for j, job := range p.Jobs {
+		for k := 1; k < len(job.Operations); k++ {
+			id := OperationID{Job: j, Index: k}
+			before[id] = append(before[id], OperationID{Job: j, Index: k - 1})
+		}
+	}
+	for _, precedence := range p.Precedences {
+		before[precedence.After] = append(before[precedence.After], precedence.Before)
+	}
+	return before
+}
@@ -0,0 +1,102 @@
+package scheduling
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jobShop 3 jobs on 3 machines
+func jobShop() *Problem {
+	return &Problem{
+		Machines: 3,
+		Jobs: []Job{
+			{Name: "a", Operations: []Operation{{0, 3}, {1, 2}, {2, 2}}},
+			{Name: "b", Operations: []Operation{{0, 2}, {2, 1}, {1, 4}}},
+			{Name: "c", Operations: []Operation{{1, 4}, {2, 3}}},
+		},
+	}
+}
+
+// permutations All the orders of the items
+func permutations(items []OperationID) [][]OperationID {
+	if len(items) <= 1 {
+		return [][]OperationID{append([]OperationID(nil), items...)}
+	}
+	all := [][]OperationID{}
+	for k := range items {
+		rest := append(append([]OperationID(nil), items[:k]...), items[k+1:]...)
+		for _, permutation := range permutations(rest) {
+			all = append(all, append([]OperationID{items[k]}, permutation...))
+		}
+	}
+	return all
+}
+
+// bruteForce Smallest makespan over all the orders of the operations on each machine
+func bruteForce(p *Problem) float64 {
+	machines := make([][]OperationID, p.Machines)
+	for _, id := range p.operations() {
+		machines[p.operation(id).Machine] = append(machines[p.operation(id).Machine], id)
+	}
+	best := math.Inf(1)
+	orders := make([][]OperationID, p.Machines)
+	var choose func(machine int)
+	choose = func(machine int) {
+		if machine == p.Machines {
+			// Earliest starts for the orders, by repeated relaxation of the precedences
+			withOrders := &Problem{Machines: p.Machines, Jobs: p.Jobs, Precedences: append([]Precedence(nil), p.Precedences...)}
+			for _, order := range orders {
+				for k := 1; k < len(order); k++ {
+					withOrders.Precedences = append(withOrders.Precedences, Precedence{Before: order[k-1], After: order[k]})
+				}
+			}
+			before := withOrders.predecessors()
+			start := map[OperationID]float64{}
+			changed := true
+			for round := 0; round <= len(p.operations()) && changed; round++ {
+				changed = false
+				for _, id := range p.operations() {
+					for _, predecessor := range before[id] {
+						if end := start[predecessor] + p.operation(predecessor).Duration; end > start[id] {
+							start[id] = end
+							changed = true
+						}
+					}
+				}
+			}
+			// A cycle keeps increasing the starts
+			if changed {
+				return
+			}
+			makespan := 0.0
+			for _, id := range p.operations() {
+				makespan = math.Max(makespan, start[id]+p.operation(id).Duration)
+			}
+			best = math.Min(best, makespan)
+			return
+		}
+		for _, order := range permutations(machines[machine]) {
+			orders[machine] = order
+			choose(machine + 1)
+		}
+	}
+	choose(0)
+	return best
+}
+
+func TestValidate(t *testing.T) {
+	assert.Error(t, (&Problem{}).Validate())
+	assert.Error(t, (&Problem{Machines: 1, Jobs: []Job{{Operations: []Operation{{1, 1}}}}}).Validate())
+	assert.Error(t, (&Problem{Machines: 1, Jobs: []Job{{Operations: []Operation{{0, -1}}}}}).Validate())
+	assert.Error(t, (&Problem{Machines: 1, Jobs: []Job{{Operations: []Operation{{0, 1}}}},
+		Precedences: []Precedence{{Before: OperationID{0, 0}, After: OperationID{1, 0}}}}).Validate())
+
+	p := jobShop()
+	s, err := Dispatch(p, EarliestStart)
+	require.NoError(t, err)
+	s.Start[0][1] = 0
+	assert.Error(t, p.Check(s))
+}
@@ -0,0 +1,110 @@
+package goptimization
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// semiContinuous Variable equal to 0 or within [l, u]
+type semiContinuous struct {
+	j int
+	l float64
+	u float64
+}
+
+// AddSemiContinuous Restrict the original variable j to x_j = 0 or l <= x_j <= u, it must be called after New.
+// A semi-integer variable is also integer. The upper bound u can be +Inf,
+// otherwise the constraint x_j <= u is added to the relaxation. The lower bound is enforced by branching
+// on x_j <= 0 or x_j >= l, for example to model the minimum lot size of a production.
+func (bb *BranchAndBound) AddSemiContinuous(j int, l, u float64, integer bool) error {
+	if bb.root == nil {
+		return errors.New("branch and bound is not initialized")
+	}
+	if j < 0 || j >= bb.n {
+		return errors.Errorf("variable %d is not an original variable", j)
+	}
+	if l <= 0 || u < l {
+		return errors.New("bounds must satisfy 0 < l <= u")
+	}
+	for _, sc := range bb.semi {
+		if sc.j == j {
+			return errors.Errorf("variable %d is already semi-continuous", j)
+		}
+	}
+
+	root := bb.root
+	if integer {
+		bb.integer[j] = true
+		root.integer[j] = true
+	}
+	if !math.IsInf(u, 1) {
+		a := make([]float64, root.cf.n+root.cf.m)
+		a[j] = 1
+		err := root.cf.AddConstraint(a, u)
+		if err != nil {
+			return err
+		}
+		root.integer = append(root.integer, root.integer[j] && isIntegral(u))
+	}
+	bb.semi = append(bb.semi, semiContinuous{j: j, l: l, u: u})
+	return nil
+}
+
+// violation Distance of x_j to {0} ∪ [l, u], the upper bound is part of the relaxation
+func (sc semiContinuous) violation(x []float64) float64 {
+	if x[sc.j] <= integerTolerance || x[sc.j] >= sc.l-integerTolerance {
+		return 0
+	}
+	return math.Min(x[sc.j], sc.l-x[sc.j])
+}
+
+// satisfiesSemiContinuous Check if x satisfies the domains of all the semi-continuous variables
+func (bb *BranchAndBound) satisfiesSemiContinuous(x []float64) bool {
+	for _, sc := range bb.semi {
+		if sc.violation(x) > 0 || x[sc.j] > sc.u+feasibilityTolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// branchSemiContinuous Branch on x_j <= 0 or x_j >= l for the semi-continuous variable the farthest from its domain,
+// the child on the closest side is explored first.
+// It returns false if all the semi-continuous variables are within their domain.
+func (bb *BranchAndBound) branchSemiContinuous(nd *node, values []float64) ([]*node, bool, error) {
+	branch := -1
+	maxViolation := 0.0
+	for k, sc := range bb.semi {
+		violation := sc.violation(values)
+		if violation > maxViolation {
+			maxViolation = violation
+			branch = k
+		}
+	}
+	if branch == -1 {
+		return nil, false, nil
+	}
+
+	sc := bb.semi[branch]
+	off, err := bb.branch(nd, []bound{{j: sc.j, sign: 1, rhs: 0}})
+	if err != nil {
+		return nil, true, err
+	}
+	on, err := bb.branch(nd, []bound{{j: sc.j, sign: -1, rhs: -sc.l}})
+	if err != nil {
+		return nil, true, err
+	}
+
+	// The last child is explored first
+	children := []*node{}
+	for _, child := range []*node{on, off} {
+		if child != nil {
+			children = append(children, child)
+		}
+	}
+	if len(children) == 2 && values[sc.j] > sc.l/2 {
+		children[0], children[1] = children[1], children[0]
+	}
+	return children, true, nil
+}
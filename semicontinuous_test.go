@@ -0,0 +1,78 @@
+package goptimization
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSemiContinuous(t *testing.T) {
+	// The relaxation produces x_0 = 2 below the minimum lot size 3
+	c := mat.NewDense(1, 2, []float64{5, 3})
+	A := mat.NewDense(2, 2, []float64{
+		1, 1,
+		2, 1,
+	})
+	b := mat.NewDense(2, 1, []float64{5, 7})
+
+	bb := BranchAndBound{}
+	require.NoError(t, bb.New(c, A, b, []bool{false, false}))
+	require.NoError(t, bb.AddSemiContinuous(0, 3, 8, false))
+	results, score, err := bb.Solve(100)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 18.0, score, 0.000001)
+	assert.True(t, mat.EqualApprox(mat.NewDense(4, 1, []float64{3, 1, 1, 0}), results, 0.000001))
+}
+
+func TestSemiInteger(t *testing.T) {
+	c := mat.NewDense(1, 2, []float64{5, 3})
+	A := mat.NewDense(2, 2, []float64{
+		1, 1,
+		2, 1,
+	})
+	b := mat.NewDense(2, 1, []float64{5, 7.5})
+
+	bb := BranchAndBound{}
+	require.NoError(t, bb.New(c, A, b, []bool{false, false}))
+	require.NoError(t, bb.AddSemiContinuous(0, 3, math.Inf(1), true))
+	results, score, err := bb.Solve(100)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 19.5, score, 0.000001)
+	assert.True(t, mat.EqualApprox(mat.NewDense(4, 1, []float64{3, 1.5, 0.5, 0}), results, 0.000001))
+}
+
+func TestSemiContinuousOff(t *testing.T) {
+	// The minimum lot size cannot be produced, the variable is switched off
+	c := mat.NewDense(1, 2, []float64{5, 4})
+	A := mat.NewDense(2, 2, []float64{
+		1, 1,
+		2, 1,
+	})
+	b := mat.NewDense(2, 1, []float64{5, 7})
+
+	bb := BranchAndBound{}
+	require.NoError(t, bb.New(c, A, b, []bool{false, false}))
+	require.NoError(t, bb.AddSemiContinuous(0, 4, 8, false))
+	results, score, err := bb.Solve(100)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 20.0, score, 0.000001)
+	assert.True(t, mat.EqualApprox(mat.NewDense(4, 1, []float64{0, 5, 0, 2}), results, 0.000001))
+}
+
+func TestAddSemiContinuousErrors(t *testing.T) {
+	c := mat.NewDense(1, 2, []float64{1, 1})
+	A := mat.NewDense(1, 2, []float64{1, 1})
+	b := mat.NewDense(1, 1, []float64{1})
+
+	bb := BranchAndBound{}
+	assert.Error(t, bb.AddSemiContinuous(0, 1, 2, false))
+	require.NoError(t, bb.New(c, A, b, []bool{false, false}))
+	assert.Error(t, bb.AddSemiContinuous(2, 1, 2, false))
+	assert.Error(t, bb.AddSemiContinuous(0, 0, 2, false))
+	assert.Error(t, bb.AddSemiContinuous(0, 3, 2, false))
+	require.NoError(t, bb.AddSemiContinuous(0, 1, 2, false))
+	assert.Error(t, bb.AddSemiContinuous(0, 1, 2, true))
+}
@@ -0,0 +1,386 @@
+// Package server HTTP service solving linear and mixed integer problems with goptimization.
+// A problem is submitted in JSON or in the free MPS format, queued and solved by a pool of workers,
+// then its status is polled and its solution fetched:
+// POST /problems submits a problem and returns its job
+// GET /problems/{id} returns the job
+// GET /problems/{id}/solution returns the solution once the job is done
+// The finished jobs are kept JobTTL, and at most MaxJobs jobs are kept, the ids of the evicted jobs are unknown.
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+)
+
+// Status State of a job
+type Status string
+
+const (
+	// Queued The job waits for a worker
+	Queued Status = "queued"
+	// Running A worker solves the problem
+	Running Status = "running"
+	// Done The solution is available
+	Done Status = "done"
+	// Failed The problem could not be read or solved, see Job.Error
+	Failed Status = "failed"
+)
+
+// Request Problem in JSON: either an MPS file, or the standard form of Simplex
+// Maximize c*x
+// Constraints:
+// Ax <= b, x >= 0, x_j integer if integer[j]
+type Request struct {
+	MPS     string      `json:"mps,omitempty"`
+	C       []float64   `json:"c,omitempty"`
+	A       [][]float64 `json:"A,omitempty"`
+	B       []float64   `json:"b,omitempty"`
+	Integer []bool      `json:"integer,omitempty"`
+	// TimeLimit Time limit of the solve in seconds, the limit of the server applies when it is 0 or larger
+	TimeLimit float64 `json:"time_limit,omitempty"`
+	// MaxIter Maximum number of simplex iterations or of explored nodes, the default of the server when 0
+	MaxIter int `json:"max_iter,omitempty"`
+}
+
+// Solution Values of the variables, named after the columns of the MPS file or x0, x1, ... for the standard form
+type Solution struct {
+	Names  []string  `json:"names"`
+	Values []float64 `json:"values"`
+	Score  float64   `json:"score"`
+}
+
+// Job Submitted problem
+type Job struct {
+	ID        string    `json:"id"`
+	Status    Status    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Submitted time.Time `json:"submitted"`
+	// Started Start of the solve, nil while the job is queued
+	Started *time.Time `json:"started,omitempty"`
+	// Finished End of the solve, nil until the job is done or failed
+	Finished *time.Time `json:"finished,omitempty"`
+}
+
+// job Job with its problem and its solution
+type job struct {
+	Job
+	model     *goptimization.Model
+	timeLimit time.Duration
+	maxIter   int
+	solution  *Solution
+}
+
+// discard Logger dropping the trace of the solves
+type discard struct{}
+
+func (discard) Printf(string, ...interface{}) {}
+
+// Server Queue of jobs solved concurrently by Workers goroutines, it implements http.Handler
+type Server struct {
+	// Workers Number of problems solved concurrently
+	Workers int
+	// TimeLimit Largest time limit of a solve
+	TimeLimit time.Duration
+	// MaxIter Maximum number of simplex iterations or of explored nodes when the request gives none
+	MaxIter int
+	// QueueSize Number of jobs waiting for a worker beyond which the submissions are refused
+	QueueSize int
+	// JobTTL Time a finished job and its solution are kept
+	JobTTL time.Duration
+	// MaxJobs Number of jobs kept, the oldest finished jobs are evicted beyond it
+	MaxJobs int
+	// MaxBodyBytes Largest size of a submitted problem, the larger requests are refused with 413
+	MaxBodyBytes int64
+
+	mu       sync.Mutex
+	jobs     map[string]*job
+	finished []*job
+	queue    chan *job
+	closed   bool
+	next     int
+	wg       sync.WaitGroup
+}
+
+// New Initialize the server and start the workers, a solve takes at most timeLimit.
+// There is 1 worker when workers <= 0. MaxIter is 100000, QueueSize 1000, JobTTL 1 hour, MaxJobs 10000
+// and MaxBodyBytes 10 MiB by default.
+func (s *Server) New(workers int, timeLimit time.Duration) {
+	if workers <= 0 {
+		workers = 1
+	}
+	s.Workers = workers
+	s.TimeLimit = timeLimit
+	if s.MaxIter <= 0 {
+		s.MaxIter = 100000
+	}
+	if s.QueueSize <= 0 {
+		s.QueueSize = 1000
+	}
+	if s.JobTTL <= 0 {
+		s.JobTTL = time.Hour
+	}
+	if s.MaxJobs <= 0 {
+		s.MaxJobs = 10000
+	}
+	if s.MaxBodyBytes <= 0 {
+		s.MaxBodyBytes = 10 << 20
+	}
+	s.jobs = map[string]*job{}
+	s.finished = nil
+	s.queue = make(chan *job, s.QueueSize)
+	for w := 0; w < s.Workers; w++ {
+		s.wg.Add(1)
+		go s.work(s.queue)
+	}
+}
+
+// Close Stop accepting jobs and wait for the workers to solve the queued ones
+func (s *Server) Close() {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+// work Solve the jobs of the queue until it is closed
+func (s *Server) work(queue <-chan *job) {
+	defer s.wg.Done()
+	for j := range queue {
+		s.solve(j)
+	}
+}
+
+// solve Solve the problem of the job and store its solution or its error
+func (s *Server) solve(j *job) {
+	s.mu.Lock()
+	j.Status = Running
+	started := time.Now()
+	j.Started = &started
+	s.mu.Unlock()
+
+	solution, err := j.model.Solve(j.maxIter, goptimization.WithTimeLimit(j.timeLimit), goptimization.WithLogger(discard{}))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	finished := time.Now()
+	j.Finished = &finished
+	s.finished = append(s.finished, j)
+	if err != nil {
+		j.Status = Failed
+		j.Error = err.Error()
+		return
+	}
+	j.Status = Done
+	j.solution = &Solution{Names: make([]string, len(solution.Values)), Values: solution.Values, Score: solution.Score}
+	for v := range solution.Values {
+		j.solution.Names[v] = j.model.Name(goptimization.Var(v))
+	}
+}
+
+// Submit Queue the problem of the request and return its job
+func (s *Server) Submit(r *Request) (Job, error) {
+	m, err := r.model()
+	if err != nil {
+		return Job{}, err
+	}
+	limit := s.TimeLimit
+	if requested := time.Duration(r.TimeLimit * float64(time.Second)); requested > 0 && (limit <= 0 || requested < limit) {
+		limit = requested
+	}
+	maxIter := s.MaxIter
+	if r.MaxIter > 0 {
+		maxIter = r.MaxIter
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return Job{}, errors.New("the server is closed")
+	}
+	s.next++
+	j := &job{
+		Job:       Job{ID: strconv.Itoa(s.next), Status: Queued, Submitted: time.Now()},
+		model:     m,
+		timeLimit: limit,
+		maxIter:   maxIter,
+	}
+	select {
+	case s.queue <- j:
+	default:
+		return Job{}, errQueueFull
+	}
+	s.evict(j.Submitted)
+	s.jobs[j.ID] = j
+	return j.Job, nil
+}
+
+// evict Remove the finished jobs older than JobTTL, then the oldest finished jobs until there is room for a job.
+// The queued and running jobs are kept, there are at most QueueSize + Workers of them.
+func (s *Server) evict(now time.Time) {
+	k := 0
+	for ; k < len(s.finished); k++ {
+		j := s.finished[k]
+		if now.Sub(*j.Finished) < s.JobTTL && len(s.jobs) < s.MaxJobs {
+			break
+		}
+		delete(s.jobs, j.ID)
+	}
+	s.finished = s.finished[k:]
+}
+
+// errQueueFull The queue has QueueSize jobs
+var errQueueFull = errors.New("the queue is full")
+
+// Job Job of the id
+func (s *Server) Job(id string) (Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, ok := s.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return j.Job, true
+}
+
+// Solution Solution of the job of the id, nil while the job is not done
+func (s *Server) Solution(id string) (*Solution, Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, ok := s.jobs[id]
+	if !ok {
+		return nil, Job{}, false
+	}
+	return j.solution, j.Job, true
+}
+
+// model Model of the request
+func (r *Request) model() (*goptimization.Model, error) {
+	if r.MPS != "" {
+		return goptimization.ReadMPS(strings.NewReader(r.MPS))
+	}
+	n := len(r.C)
+	if n == 0 || len(r.A) == 0 {
+		return nil, errors.New("the request needs an MPS file or c, A and b")
+	}
+	if len(r.B) != len(r.A) {
+		return nil, errors.Wrapf(goptimization.ErrDimensionMismatch, "len(b) must be %d, got %d", len(r.A), len(r.B))
+	}
+	if r.Integer != nil && len(r.Integer) != n {
+		return nil, errors.Wrapf(goptimization.ErrDimensionMismatch, "len(integer) must be %d, got %d", n, len(r.Integer))
+	}
+	m := &goptimization.Model{}
+	vars := make([]goptimization.Var, n)
+	objective := goptimization.Expr{}
+	for j := range vars {
+		vars[j] = m.AddVariable("x"+strconv.Itoa(j), r.Integer != nil && r.Integer[j])
+		objective.Terms = append(objective.Terms, goptimization.Term{Var: vars[j], Coef: r.C[j]})
+	}
+	m.Maximize(objective)
+	for i, row := range r.A {
+		if len(row) != n {
+			return nil, errors.Wrapf(goptimization.ErrDimensionMismatch, "len(A[%d]) must be %d, got %d", i, n, len(row))
+		}
+		e := goptimization.Expr{}
+		for j, a := range row {
+			if a != 0 {
+				e.Terms = append(e.Terms, goptimization.Term{Var: vars[j], Coef: a})
+			}
+		}
+		err := m.AddConstraint(e, r.B[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// ServeHTTP Route the requests of the API. A problem is posted as a JSON Request,
+// or as an MPS file when the content type is text/plain.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	parts := strings.Split(path, "/")
+	switch {
+	case path == "problems" && r.Method == http.MethodPost:
+		s.submit(w, r)
+	case len(parts) == 2 && parts[0] == "problems" && r.Method == http.MethodGet:
+		j, ok := s.Job(parts[1])
+		if !ok {
+			http.Error(w, "unknown job "+parts[1], http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, j)
+	case len(parts) == 3 && parts[0] == "problems" && parts[2] == "solution" && r.Method == http.MethodGet:
+		solution, j, ok := s.Solution(parts[1])
+		switch {
+		case !ok:
+			http.Error(w, "unknown job "+parts[1], http.StatusNotFound)
+		case j.Status == Failed:
+			http.Error(w, j.Error, http.StatusUnprocessableEntity)
+		case solution == nil:
+			http.Error(w, "job "+j.ID+" is "+string(j.Status), http.StatusConflict)
+		default:
+			writeJSON(w, http.StatusOK, solution)
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// submit Read the problem of the request and queue it
+func (s *Server) submit(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, s.MaxBodyBytes))
+	if err != nil {
+		// The reader stops at the limit
+		if int64(len(body)) >= s.MaxBodyBytes {
+			http.Error(w, "the problem is larger than "+strconv.FormatInt(s.MaxBodyBytes, 10)+" bytes", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	request := &Request{}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "text/plain") {
+		request.MPS = string(body)
+		if limit := r.URL.Query().Get("time_limit"); limit != "" {
+			request.TimeLimit, err = strconv.ParseFloat(limit, 64)
+			if err != nil {
+				http.Error(w, "time_limit: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+	} else {
+		err := json.Unmarshal(body, request)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	j, err := s.Submit(request)
+	if err == errQueueFull {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, j)
+}
+
+// writeJSON Write the value in JSON with the status code
+func writeJSON(w http.ResponseWriter, code int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(value)
+}
@@ -0,0 +1,302 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMPS = `NAME CONTINUOUS
+OBJSENSE MAX
+ROWS
+ N OBJ
+ L C1
+ L C2
+COLUMNS
+ X OBJ 5 C1 6
+ X C2 1
+ Y OBJ 4 C1 4
+ Y C2 2
+RHS
+ RHS C1 24 C2 6
+ENDATA
+`
+
+// newServer Server with 2 workers behind an HTTP test server, close stops both
+func newServer() (*Server, *httptest.Server, func()) {
+	s := &Server{}
+	s.New(2, 10*time.Second)
+	h := httptest.NewServer(s)
+	return s, h, func() {
+		h.Close()
+		s.Close()
+	}
+}
+
+// wait Poll the job until it is done or failed
+func wait(t *testing.T, url, id string) Job {
+	for start := time.Now(); time.Since(start) < 10*time.Second; time.Sleep(10 * time.Millisecond) {
+		response, err := http.Get(url + "/problems/" + id)
+		require.NoError(t, err)
+		j := Job{}
+		require.NoError(t, json.NewDecoder(response.Body).Decode(&j))
+		response.Body.Close()
+		if j.Status == Done || j.Status == Failed {
+			return j
+		}
+	}
+	t.Fatalf("job %s is not done", id)
+	return Job{}
+}
+
+func submit(t *testing.T, url, contentType, body string) (*http.Response, Job) {
+	response, err := http.Post(url+"/problems", contentType, strings.NewReader(body))
+	require.NoError(t, err)
+	defer response.Body.Close()
+	j := Job{}
+	if response.StatusCode == http.StatusAccepted {
+		require.NoError(t, json.NewDecoder(response.Body).Decode(&j))
+	}
+	return response, j
+}
+
+func solution(t *testing.T, url, id string) (*http.Response, *Solution) {
+	response, err := http.Get(url + "/problems/" + id + "/solution")
+	require.NoError(t, err)
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return response, nil
+	}
+	s := &Solution{}
+	require.NoError(t, json.NewDecoder(response.Body).Decode(s))
+	return response, s
+}
+
+func TestJSON(t *testing.T) {
+	_, h, close := newServer()
+	defer close()
+	body, err := json.Marshal(Request{
+		C: []float64{3, 2},
+		A: [][]float64{{1, 1}, {1, 0}},
+		B: []float64{4, 2},
+	})
+	require.NoError(t, err)
+	response, j := submit(t, h.URL, "application/json", string(body))
+	require.Equal(t, http.StatusAccepted, response.StatusCode)
+	assert.Equal(t, Done, wait(t, h.URL, j.ID).Status)
+
+	response, s := solution(t, h.URL, j.ID)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, []string{"x0", "x1"}, s.Names)
+	assert.InDelta(t, 10.0, s.Score, 1e-6)
+	assert.InDelta(t, 2.0, s.Values[0], 1e-6)
+	assert.InDelta(t, 2.0, s.Values[1], 1e-6)
+}
+
+func TestMPS(t *testing.T) {
+	_, h, close := newServer()
+	defer close()
+	response, j := submit(t, h.URL, "text/plain", testMPS)
+	require.Equal(t, http.StatusAccepted, response.StatusCode)
+	assert.Equal(t, Done, wait(t, h.URL, j.ID).Status)
+
+	response, s := solution(t, h.URL, j.ID)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, []string{"X", "Y"}, s.Names)
+	assert.InDelta(t, 21.0, s.Score, 1e-6)
+	assert.InDelta(t, 3.0, s.Values[0], 1e-6)
+	assert.InDelta(t, 1.5, s.Values[1], 1e-6)
+}
+
+func TestConcurrent(t *testing.T) {
+	_, h, close := newServer()
+	defer close()
+	ids := make([]string, 10)
+	for k := range ids {
+		body, err := json.Marshal(Request{
+			C:       []float64{1, float64(k)},
+			A:       [][]float64{{1, 1}},
+			B:       []float64{1},
+			Integer: []bool{true, true},
+		})
+		require.NoError(t, err)
+		response, j := submit(t, h.URL, "application/json", string(body))
+		require.Equal(t, http.StatusAccepted, response.StatusCode)
+		ids[k] = j.ID
+	}
+	for k, id := range ids {
+		assert.Equal(t, Done, wait(t, h.URL, id).Status)
+		_, s := solution(t, h.URL, id)
+		assert.InDelta(t, math.Max(1, float64(k)), s.Score, 1e-6, "problem %d", k)
+	}
+}
+
+func TestFailed(t *testing.T) {
+	_, h, close := newServer()
+	defer close()
+	// x0 <= 1 and x0 >= 2
+	body, err := json.Marshal(Request{C: []float64{1}, A: [][]float64{{1}, {-1}}, B: []float64{1, -2}})
+	require.NoError(t, err)
+	response, j := submit(t, h.URL, "application/json", string(body))
+	require.Equal(t, http.StatusAccepted, response.StatusCode)
+	j = wait(t, h.URL, j.ID)
+	assert.Equal(t, Failed, j.Status)
+	assert.NotEmpty(t, j.Error)
+
+	response, _ = solution(t, h.URL, j.ID)
+	assert.Equal(t, http.StatusUnprocessableEntity, response.StatusCode)
+}
+
+func TestBadRequest(t *testing.T) {
+	_, h, close := newServer()
+	defer close()
+	for _, body := range []string{
+		"{",
+		"{}",
+		`{"c": [1, 2], "A": [[1]], "b": [1]}`,
+		`{"c": [1], "A": [[1]], "b": [1, 2]}`,
+	} {
+		response, _ := submit(t, h.URL, "application/json", body)
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode, body)
+	}
+	response, _ := submit(t, h.URL, "text/plain", "ROWS\n X Y\n")
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
+
+func TestNotFound(t *testing.T) {
+	_, h, close := newServer()
+	defer close()
+	response, err := http.Get(h.URL + "/problems/42")
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	response, _ = solution(t, h.URL, "42")
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	response, err = http.Get(h.URL + "/solutions")
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
+
+func TestNotDone(t *testing.T) {
+	s, h, close := newServer()
+	defer close()
+	s.mu.Lock()
+	s.jobs["queued"] = &job{Job: Job{ID: "queued", Status: Queued}}
+	s.mu.Unlock()
+	response, _ := solution(t, h.URL, "queued")
+	assert.Equal(t, http.StatusConflict, response.StatusCode)
+}
+
+func TestQueueFull(t *testing.T) {
+	s := &Server{QueueSize: 1}
+	s.New(1, time.Second)
+	// The queue is full while no worker reads it
+	s.Close()
+	s.closed = false
+	s.queue = make(chan *job, 1)
+	request := &Request{C: []float64{1}, A: [][]float64{{1}}, B: []float64{1}}
+	_, err := s.Submit(request)
+	require.NoError(t, err)
+	_, err = s.Submit(request)
+	assert.Equal(t, errQueueFull, err)
+
+	w := httptest.NewRecorder()
+	body, err := json.Marshal(request)
+	require.NoError(t, err)
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/problems", bytes.NewReader(body)))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestTimeLimit(t *testing.T) {
+	s := &Server{}
+	s.New(1, time.Minute)
+	defer s.Close()
+	request := &Request{C: []float64{1}, A: [][]float64{{1}}, B: []float64{1}, TimeLimit: 2}
+	j, err := s.Submit(request)
+	require.NoError(t, err)
+	s.mu.Lock()
+	assert.Equal(t, 2*time.Second, s.jobs[j.ID].timeLimit)
+	s.mu.Unlock()
+
+	request.TimeLimit = 3600
+	j, err = s.Submit(request)
+	require.NoError(t, err)
+	s.mu.Lock()
+	assert.Equal(t, time.Minute, s.jobs[j.ID].timeLimit)
+	s.mu.Unlock()
+}
+
+func TestEvict(t *testing.T) {
+	s := &Server{MaxJobs: 2, JobTTL: time.Minute}
+	s.New(1, time.Second)
+	defer s.Close()
+	request := &Request{C: []float64{1}, A: [][]float64{{1}}, B: []float64{1}}
+	ids := []string{}
+	for k := 0; k < 3; k++ {
+		j, err := s.Submit(request)
+		require.NoError(t, err)
+		ids = append(ids, j.ID)
+		for done := false; !done; time.Sleep(time.Millisecond) {
+			j, _ = s.Job(j.ID)
+			done = j.Status == Done
+		}
+	}
+	// The oldest finished job makes room for the third one
+	_, ok := s.Job(ids[0])
+	assert.False(t, ok)
+	_, ok = s.Job(ids[1])
+	assert.True(t, ok)
+
+	// The finished jobs older than JobTTL are evicted by the next submission
+	s.mu.Lock()
+	for _, j := range s.finished {
+		finished := j.Finished.Add(-time.Hour)
+		j.Finished = &finished
+	}
+	s.mu.Unlock()
+	j, err := s.Submit(request)
+	require.NoError(t, err)
+	s.mu.Lock()
+	assert.Len(t, s.jobs, 1)
+	assert.Contains(t, s.jobs, j.ID)
+	s.mu.Unlock()
+}
+
+func TestTooLarge(t *testing.T) {
+	s := &Server{MaxBodyBytes: 16}
+	s.New(1, time.Second)
+	defer s.Close()
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/problems", strings.NewReader(testMPS)))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	_, ok := s.Job("1")
+	assert.False(t, ok)
+}
+
+func TestJobTimes(t *testing.T) {
+	// A queued job has no start nor end
+	body, err := json.Marshal(Job{ID: "1", Status: Queued, Submitted: time.Now()})
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "started")
+	assert.NotContains(t, string(body), "finished")
+
+	_, h, close := newServer()
+	defer close()
+	response, j := submit(t, h.URL, "text/plain", testMPS)
+	require.Equal(t, http.StatusAccepted, response.StatusCode)
+	j = wait(t, h.URL, j.ID)
+	require.NotNil(t, j.Started)
+	require.NotNil(t, j.Finished)
+	assert.False(t, j.Finished.Before(*j.Started))
+}
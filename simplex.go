@@ -1,13 +1,22 @@
 package goptimization
 
 import (
-	"fmt"
+	"context"
 	"math"
+	"time"
 
-	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
 	"gonum.org/v1/gonum/mat"
 )
 
+const (
+	// epsilon Tolerance used to compare reduced costs, pivots and values to zero
+	epsilon = 1e-9
+	// blandThreshold Number of consecutive degenerate pivots before switching to Bland's rule
+	blandThreshold = 10
+)
+
 // Simplex Solve a linear problem wihtout strict inequality constraints.
 // Input follows standard form:
 // Maximize z = Σ(1<=j<=n) c_j*x_j
@@ -15,34 +24,163 @@ import (
 // 1<=i<=m,  Σ(1<=j<=n) a_i_j*x_j <= b_i
 // 1<=j<=n x_j >= 0
 // - Define the canonical form of the problem (add slack variables and transfrom inequality constraints to equality constraints)
-// - Check the basic solution is feasible, if not you need to run two phases simplex
+// - Check the basic solution is feasible, if not (some b_i < 0) run phaseOne to find a feasible basis
 // - Run iterations
 // - Stop when the optimal solution is found or after maxIter
 // Apply
 // - First Danzig critera: for entering variable, pick the nonbasic variable with the largest reduced cost.
 // - Bland's rule to avoid cycles : Choose the entering basic variable xj such that j is the smallest
 // index with c¯j < 0. Also choose the leaving basic variable i with the smallest index (in case of ties in the ratio test)
-func Simplex(c, A, b *mat.Dense, maxIter int) (int, *mat.Dense, float64, error) {
-	totalIter := 0
+// The errors wrap ErrDimensionMismatch, ErrSingularBasis, ErrUnbounded or ErrInfeasible, errors.Cause gives the kind.
+// The options configure the solve, see WithMaxIter, WithTolerance, WithPivotRule, WithLogger, WithTimeLimit and WithContext.
+// When the iteration or time limit is reached or the context is done, the basis reached is returned with ErrIterationLimit,
+// without results if no feasible basis was found yet.
+// c and b are vectors, a *mat.Dense (1,n) and (m,1), a *mat.VecDense or any matrix with one row or one column,
+// see SimplexSlices for slices.
+func Simplex(c mat.Matrix, A *mat.Dense, b mat.Matrix, opts ...Option) (int, *mat.Dense, float64, error) {
+	o := newOptions(opts)
+	row, err := asRow(c, "c")
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	column, err := asColumn(b, "b")
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	cf := CanonicalForm{}
+	err = cf.New(row, A, column)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	cf.configure(o)
+	return cf.run(o)
+}
+
+// run Run the iterations of Simplex from the slack basis of the dictionary configured by o
+func (cf *CanonicalForm) run(o options) (int, *mat.Dense, float64, error) {
+	cf.deadline = o.deadline()
+	maxIter := iterationLimit(o.maxIter, cf.n, cf.m)
+	//A negative b_i makes the slack basis infeasible, phaseOne does nothing otherwise
+	totalIter, err := cf.phaseOne(maxIter)
+	if err != nil {
+		return totalIter, nil, 0, err
+	}
+	for ; totalIter < maxIter && !expired(cf.deadline) && !o.cancelled() && o.proceed(cf, totalIter); totalIter++ {
+		end, err := cf.Iter(0)
+		if err != nil {
+			return totalIter, nil, 0, err
+		}
+		if end {
+			break
+		}
+		iter, err := cf.restoreFeasibility(maxIter - totalIter - 1)
+		if err != nil {
+			return totalIter, nil, 0, err
+		}
+		totalIter += iter
+	}
+	optimal, err := cf.optimal()
+	if err != nil {
+		return totalIter, nil, 0, err
+	}
+	results, score := cf.GetResults()
+	cf.traceResults(results, score)
+	if !optimal {
+		return totalIter, results, score, limitError(cf.deadline)
+	}
+	return totalIter, results, score, nil
+}
+
+// DualSimplex Solve the linear problem of Simplex with the dual simplex algorithm.
+// The slack basis, whose reduced costs are c, is dual feasible when c <= 0, think of the minimization of nonnegative
+// costs with >= constraints: the dual iterations then make the negative b_i nonnegative while the dictionary stays optimal.
+// When some c_j > 0 a phase one finds a feasible basis first, like Simplex, and the dual iterations have nothing to do.
+// Primal iterations, like the ones of Reoptimize, then remove the reduced costs left positive within the tolerance.
+// The options, results and errors are those of Simplex, except at a limit before the feasibility: there are no results
+// and the score is the upper bound of the optimum given by the dual feasible basis.
+func DualSimplex(c mat.Matrix, A *mat.Dense, b mat.Matrix, opts ...Option) (int, *mat.Dense, float64, error) {
+	o := newOptions(opts)
+	row, err := asRow(c, "c")
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	column, err := asColumn(b, "b")
+	if err != nil {
+		return 0, nil, 0, err
+	}
 	cf := CanonicalForm{}
-	err := cf.New(c, A, b)
+	err = cf.New(row, A, column)
 	if err != nil {
 		return 0, nil, 0, err
 	}
-	for i := 0; i < maxIter; i++ {
+	cf.configure(o)
+	cf.deadline = o.deadline()
+	maxIter := iterationLimit(o.maxIter, cf.n, cf.m)
+	totalIter := 0
+	for j := 0; j < cf.n; j++ {
+		if row.At(0, j) > cf.tolerance {
+			totalIter, err = cf.phaseOne(maxIter)
+			if err != nil {
+				return totalIter, nil, 0, err
+			}
+			break
+		}
+	}
+	running := func() bool {
+		return totalIter < maxIter && !expired(cf.deadline) && !o.cancelled() && o.proceed(&cf, totalIter)
+	}
+	for ; running(); totalIter++ {
+		end, err := cf.DualIter()
+		if err != nil {
+			return totalIter, nil, 0, err
+		}
+		if end {
+			break
+		}
+	}
+	if !cf.primalFeasible() {
+		// The basis stays dual feasible, the objective of its basic solution is an upper bound of the optimum
+		_, bound := cf.values()
+		return totalIter, nil, bound, limitError(cf.deadline)
+	}
+	for ; running(); totalIter++ {
 		end, err := cf.Iter(0)
 		if err != nil {
-			return 0, nil, 0, err
+			return totalIter, nil, 0, err
 		}
 		if end {
 			break
 		}
-		totalIter++
+		iter, err := cf.restoreFeasibility(maxIter - totalIter - 1)
+		if err != nil {
+			return totalIter, nil, 0, err
+		}
+		totalIter += iter
+	}
+	optimal, err := cf.optimal()
+	if err != nil {
+		return totalIter, nil, 0, err
 	}
 	results, score := cf.GetResults()
+	cf.traceResults(results, score)
+	if !optimal {
+		return totalIter, results, score, limitError(cf.deadline)
+	}
 	return totalIter, results, score, nil
 }
 
+// iterationLimit Number of iterations allowed for maxIter, maxIter <= 0 means unlimited up to a safeguard
+// of 100 iterations per variable and at least 1000, far above the 2m to 3m pivots of a typical problem
+func iterationLimit(maxIter, n, m int) int {
+	if maxIter > 0 {
+		return maxIter
+	}
+	if 100*(n+m) > 1000 {
+		return 100 * (n + m)
+	}
+	return 1000
+}
+
 // CanonicalForm Canonical form of a linear optimizattion problem
 type CanonicalForm struct {
 	// Positivity constraints
@@ -75,22 +213,64 @@ type CanonicalForm struct {
 	cN *mat.Dense
 
 	remap []int
+	//Kind of each variable, indexed by variable: true for the slack variable of a constraint
+	slack []bool
+	//Upper bound of each variable, indexed by variable, nil without bounds, see SimplexRanged.
+	//The reflected variables are substituted by upper - x, which adds offset to the objective
+	upper     []float64
+	reflected []bool
+	offset    float64
+
+	//Number of consecutive degenerate pivots, used to switch to Bland's rule
+	degenerate int
+
+	recordHistory bool
+	history       []Pivot
+
+	//Teaching mode, number of pivots confirmed by the gate
+	gate  PivotGate
+	gated int
+
+	//Check the dictionary after every pivot
+	checkInvariants bool
+
+	//Configuration set by the options of Simplex
+	tolerance float64
+	rule      PivotRule
+	logger    Logger
+	pricing   PricingRule
+	//Scheme of the solves with B, see BasisUpdate
+	basisUpdate BasisUpdate
+	//Deadline of Simplex and DualSimplex, phase one included, zero without time limit
+	deadline time.Time
+	//Context of WithContext, which stops phase one too, nil without context
+	ctx context.Context
+
+	//Partial pricing, position of the next segment, and candidates of the multiple pricing
+	priceStart int
+	candidates []int
+
+	//Pivots since the last estimate of the condition number of B, number of refactorizations of xBStar
+	//and of repairs of the basis, repaired is set until restoreFeasibility runs
+	sinceCondition   int
+	refactorizations int
+	repairs          int
+	repaired         bool
+
+	//Buffers of the iterations, see workspace
+	work *workspace
 }
 
 //New Initialize all the parameters in order to run the simplex algorithm
+// b can be negative, the slack basis is then infeasible and phaseOne must run before the primal iterations.
 func (cf *CanonicalForm) New(c, A, b *mat.Dense) error {
 
-	rows, cols := c.Dims()
-	if rows > 1 {
-		return errors.New("z dims.r > 1")
-	}
-	cf.n = cols
-
-	rows, cols = A.Dims()
-	if cols > cf.n {
-		return errors.New("A dims.c > z dims.r")
+	err := checkDims(c, A, b)
+	if err != nil {
+		return err
 	}
-	cf.m = rows
+	_, cf.n = c.Dims()
+	cf.m, _ = A.Dims()
 
 	cf.A = A
 	cf.A = cf.A.Grow(0, cf.m).(*mat.Dense)
@@ -107,19 +287,36 @@ func (cf *CanonicalForm) New(c, A, b *mat.Dense) error {
 
 	cf.xBStar = mat.DenseCopyOf(b)
 
-	cf.xN = cf.x.Slice(0, cf.n, 0, 1).(*mat.Dense)
-
-	cf.B = cf.A.Slice(0, cf.m, cf.n, cf.n+cf.m).(*mat.Dense)
-
-	cf.AN = cf.A.Slice(0, cf.m, 0, cf.n).(*mat.Dense)
-
-	cf.cB = cf.c.Slice(0, 1, cf.n, cf.n+cf.m).(*mat.Dense)
-	cf.cN = cf.c.Slice(0, 1, 0, cf.n).(*mat.Dense)
+	cf.slice()
 
 	//Store the entring and leaving pairs for each iteration
 	cf.remap = make([]int, cf.n+cf.m)
+	cf.slack = make([]bool, cf.n+cf.m)
 	for i := 0; i < cf.n+cf.m; i++ {
 		cf.remap[i] = i
+		cf.slack[i] = i >= cf.n
+	}
+	cf.checkInvariants = invariantsByDefault
+	cf.configure(newOptions(nil))
+	return nil
+}
+
+// checkDims Check that c is (1,n), A is (m,n) and b is (m,1)
+func checkDims(c, A, b *mat.Dense) error {
+	if c == nil || A == nil || b == nil {
+		return newError(ErrDimensionMismatch, "c, A and b must not be nil")
+	}
+	rows, n := c.Dims()
+	if rows != 1 {
+		return newError(ErrDimensionMismatch, "c dims must be (1,n), got (%d,%d)", rows, n)
+	}
+	m, cols := A.Dims()
+	if cols != n {
+		return newError(ErrDimensionMismatch, "A dims must be (m,%d) like c, got (%d,%d)", n, m, cols)
+	}
+	rows, cols = b.Dims()
+	if rows != m || cols != 1 {
+		return newError(ErrDimensionMismatch, "b dims must be (%d,1) like the rows of A, got (%d,%d)", m, rows, cols)
 	}
 	return nil
 }
@@ -129,132 +326,150 @@ func (cf *CanonicalForm) New(c, A, b *mat.Dense) error {
 // (1) xB = xBStar - B^-1*AN*xN
 // (2) z = zStar + (cN - cB*B^-1*AN)xN
 // Set y=cB*B^-1 and solve it
+// The iterations use the workspace of the dictionary, FindY returns a copy the caller can keep.
 func (cf *CanonicalForm) FindY() (*mat.Dense, error) {
-
-	var y, BInv mat.Dense
-	err := BInv.Inverse(cf.B)
+	//Solve B^T*y^T = cB^T instead of inverting B
+	y, err := cf.findY()
 	if err != nil {
 		return nil, err
 	}
-	y.Mul(cf.cB, &BInv)
-
-	fmt.Printf("y:\n %v\n\n", mat.Formatted(&y, mat.Prefix(" "), mat.Excerpt(8)))
-
-	return &y, nil
+	return mat.DenseCopyOf(y), nil
 }
 
 //FindEnteringVariable Define the best entering varialbe following Danzig criteria and Bland's rule
 // Find one column a^k of A not in B with y*a^k<c^k
 // If there is no entering column, the current solution is optimal
+// After blandThreshold consecutive degenerate pivots, pick the candidate with the smallest variable index to avoid cycles
+// Otherwise the pricing rule of the dictionary decides which variables are priced, see PricingRule
 func (cf *CanonicalForm) FindEnteringVariable(y *mat.Dense, forceEnteringVarIndex int) (int, error) {
-	var m mat.Dense
-	m.Mul(y, cf.AN)
-	m.Sub(cf.cN, &m)
-
-	_, c := m.Dims()
-
 	enteringVarIndex := -1
-	if forceEnteringVarIndex != 0 {
-		if m.At(0, forceEnteringVarIndex) > 0 {
+	switch {
+	case forceEnteringVarIndex != 0:
+		if cf.reducedCost(y, forceEnteringVarIndex) > cf.tolerance {
 			enteringVarIndex = forceEnteringVarIndex
 		}
-	} else {
-		max := 0.0
-		for j := 0; j < c; j++ {
-			//First Danzig criteria and Bland's rule
-			if m.At(0, j) > 0 && m.At(0, j) > max {
-				max = m.At(0, j)
+	case cf.bland():
+		m := cf.reducedCostsTo(&cf.workspace().reduced, y)
+		for j := 0; j < cf.n; j++ {
+			//Bland's rule
+			if m.At(0, j) > cf.tolerance && (enteringVarIndex == -1 || cf.remap[j] < cf.remap[enteringVarIndex]) {
 				enteringVarIndex = j
 			}
 		}
+	case cf.pricing == PartialPricing:
+		enteringVarIndex = cf.partialPricing(y)
+	case cf.pricing == MultiplePricing:
+		enteringVarIndex = cf.multiplePricing(y)
+	default:
+		reduced := rowVector(cf.reducedCostsTo(&cf.workspace().reduced, y), 0).Data
+		//First Danzig criteria
+		if j := floats.MaxIdx(reduced); reduced[j] > cf.tolerance {
+			enteringVarIndex = j
+		}
+	}
+	if cf.tracing() {
+		cf.logf("enteringVarIndex %v\n", enteringVarIndex)
 	}
-	fmt.Println("enteringVarIndex", enteringVarIndex)
 	return enteringVarIndex, nil
 }
 
 //SolveBd FindY describes the current dictionary.
 // To find the best leaving variable we start from (1) and set d=B^-1*a^k
 // When we solve it, we get the equation to maximize in order to find the leaving variable
+// Like FindY, it returns a copy of the workspace of the iterations.
 func (cf *CanonicalForm) SolveBd(enteringVarIndex int) (*mat.Dense, error) {
-	var d mat.Dense
-	err := d.Solve(cf.B, cf.AN.ColView(enteringVarIndex))
+	d, err := cf.solveBd(enteringVarIndex)
 	if err != nil {
 		return nil, err
 	}
-
-	fmt.Printf("d:\n %v\n\n", mat.Formatted(&d, mat.Prefix(" "), mat.Excerpt(8)))
-
-	return &d, nil
+	return mat.DenseCopyOf(d), nil
 }
 
 // FindLeavingVariable Define what is the best leaving variable following Bland's rule
 // Find the biggest x_kStar with x_BStar - x_kStar*d >= 0
 // If d<=0, the algorithm ends and the problem is unbounded.
 // Otherwise, the biggest x_kStar force one of the components of x_BStar - x_kStar*d to be equal to zero
-// and defines the leaving variable
+// and defines the leaving variable.
+// Ties are broken with the largest pivot d_i for numerical stability, or with the smallest variable index
+// once Bland's rule is active.
 func (cf *CanonicalForm) FindLeavingVariable(d *mat.Dense) (float64, int, error) {
-	r, _ := d.Dims()
+	column, xB := colVector(d, 0), colVector(cf.xBStar, 0)
 	x := math.Inf(1)
 	leavingVarIndex := -1
 
 	found := false
+	tracing := cf.tracing()
 
-	for i := 0; i < r; i++ {
-		if d.At(i, 0) <= 0 {
+	for i := 0; i < column.N; i++ {
+		di := column.Data[i*column.Inc]
+		if di <= cf.tolerance {
 			continue
 		}
 		found = true
-		tmp := cf.xBStar.At(i, 0) / d.At(i, 0)
-		fmt.Println("xLeaving:", i, tmp)
-		//Bland's rule
-		if tmp < x {
+		tmp := xB.Data[i*xB.Inc] / di
+		if tracing {
+			cf.logf("xLeaving: %v %v\n", i, tmp)
+		}
+		switch {
+		case tmp < x:
 			x = tmp
 			leavingVarIndex = i
+		case tmp > x:
+		case cf.bland():
+			//Bland's rule
+			if cf.remap[cf.n+i] < cf.remap[cf.n+leavingVarIndex] {
+				leavingVarIndex = i
+			}
+		case di > column.Data[leavingVarIndex*column.Inc]:
+			leavingVarIndex = i
 		}
 	}
 	if !found {
 		return -1.0, -1, nil
 	}
-	fmt.Println("x", x)
-	fmt.Println("leavingVarIndex", leavingVarIndex)
+	if tracing {
+		cf.logf("x %v\n", x)
+		cf.logf("leavingVarIndex %v\n", leavingVarIndex)
+	}
 	return x, leavingVarIndex, nil
 }
 
 // Update Update the dictionary in order to run anotheriteration
 // Replace the leaving variable with the entering variable in xBStar
 // Replace the leaving column in the base B with the entering column
+// The dictionary is updated in place, without allocating.
 func (cf *CanonicalForm) Update(d, y *mat.Dense, x float64, enteringVarIndex, leavingVarIndex int) error {
-	var tmp mat.Dense
-	tmp.Scale(x, d)
-	cf.xBStar.Sub(cf.xBStar, &tmp)
+	cf.recordEta(d, leavingVarIndex)
+	blas64.Axpy(-x, colVector(d, 0), colVector(cf.xBStar, 0))
 	cf.xBStar.Set(leavingVarIndex, 0, x)
 
-	fmt.Printf("xBStar:\n %v\n\n", mat.Formatted(cf.xBStar, mat.Prefix(" "), mat.Excerpt(8)))
-
-	r, _ := d.Dims()
+	tracing := cf.tracing()
+	if tracing {
+		cf.logf("xBStar:\n %v\n\n", mat.Formatted(cf.xBStar, mat.Prefix(" "), mat.Excerpt(8)))
+	}
 
-	leavingCol := mat.DenseCopyOf(cf.B.ColView(leavingVarIndex))
+	blas64.Swap(colVector(cf.B, leavingVarIndex), colVector(cf.AN, enteringVarIndex))
 	leavingC := cf.cB.At(0, leavingVarIndex)
-	for i := 0; i < r; i++ {
-		cf.B.Set(i, leavingVarIndex, cf.AN.At(i, enteringVarIndex))
-		cf.AN.Set(i, enteringVarIndex, leavingCol.At(i, 0))
-	}
 	cf.cB.Set(0, leavingVarIndex, cf.cN.At(0, enteringVarIndex))
 	cf.cN.Set(0, enteringVarIndex, leavingC)
 
-	fmt.Printf("A:\n %v\n\n", mat.Formatted(cf.A, mat.Prefix(" "), mat.Excerpt(8)))
+	if tracing {
+		cf.logf("A:\n %v\n\n", mat.Formatted(cf.A, mat.Prefix(" "), mat.Excerpt(8)))
 
-	fmt.Printf("cB:\n %v\n\n", mat.Formatted(cf.cB, mat.Prefix(" "), mat.Excerpt(8)))
+		cf.logf("cB:\n %v\n\n", mat.Formatted(cf.cB, mat.Prefix(" "), mat.Excerpt(8)))
+	}
 
 	return nil
 }
 
 //Iter Run one iteration of the simplex algorithm
+// It returns true once the dictionary is optimal, and ErrUnbounded when no constraint limits the entering variable.
+// When B is singular the iteration repairs the basis instead of pivoting, see recoverSingular.
 func (cf *CanonicalForm) Iter(forceEnteringVarIndex int) (bool, error) {
 	//Solve yB=c_B
-	y, err := cf.FindY()
+	y, err := cf.findY()
 	if err != nil {
-		return false, err
+		return false, cf.recoverSingular(err)
 	}
 	//Find a entering column/variable
 	enteringVarIndex, err := cf.FindEnteringVariable(y, forceEnteringVarIndex)
@@ -267,10 +482,10 @@ func (cf *CanonicalForm) Iter(forceEnteringVarIndex int) (bool, error) {
 		return true, nil
 	}
 
-	//Solve Bd=a^k
-	d, err := cf.SolveBd(enteringVarIndex)
+	//Solve Bd=a^k, B has not changed since findY
+	d, err := cf.solveD(enteringVarIndex)
 	if err != nil {
-		return false, err
+		return false, cf.recoverSingular(err)
 	}
 
 	// Find the leaving column/variable
@@ -278,44 +493,471 @@ func (cf *CanonicalForm) Iter(forceEnteringVarIndex int) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	// The algorithm ends when there is no candidates
+	// The upper bounds limit the step too, an entering variable stopped by its own bound flips without a pivot
+	flip, x, leavingVarIndex := cf.boundedRatio(d, enteringVarIndex, x, leavingVarIndex)
+	if flip {
+		return false, nil
+	}
+	// No basic variable limits the entering variable
 	if leavingVarIndex == -1 {
-		return true, nil
+		return false, ErrUnbounded
 	}
 
+	// Let the caller confirm or override the pivot
+	if cf.gate != nil {
+		// The gate solves with the workspace, e.g. in LeavingFor
+		y, d = mat.DenseCopyOf(y), mat.DenseCopyOf(d)
+		d, x, enteringVarIndex, leavingVarIndex, err = cf.askGate(y, d, x, enteringVarIndex, leavingVarIndex)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// Update the dictionary for the next iteration
+	err = cf.pivot(d, y, x, enteringVarIndex, leavingVarIndex, false)
+	if err != nil {
+		return false, err
+	}
+
+	return false, nil
+}
+
+// pivot Swap the entering and leaving variables and update the dictionary
+func (cf *CanonicalForm) pivot(d, y *mat.Dense, x float64, enteringVarIndex, leavingVarIndex int, dual bool) error {
 	//Store the new pair of entering/leaving variables
 	tmp := cf.remap[cf.n+leavingVarIndex]
-	cf.remap[cf.n+leavingVarIndex] = enteringVarIndex
+	cf.remap[cf.n+leavingVarIndex] = cf.remap[enteringVarIndex]
 	cf.remap[enteringVarIndex] = tmp
 
-	// Update the dictionary for the next iteration
-	err = cf.Update(d, y, x, enteringVarIndex, leavingVarIndex)
+	if math.Abs(x) <= cf.tolerance {
+		cf.degenerate++
+	} else {
+		cf.degenerate = 0
+	}
+
+	var before invariantState
+	if cf.checkInvariants {
+		before = cf.stateBeforePivot(y)
+	}
+	err := cf.Update(d, y, x, enteringVarIndex, leavingVarIndex)
+	if err != nil {
+		return err
+	}
+	cf.record(dual, cf.remap[cf.n+leavingVarIndex], tmp, x)
+	if cf.checkInvariants {
+		err = cf.checkAfterPivot(before, dual)
+		if err != nil {
+			return err
+		}
+	}
+	return cf.monitorCondition()
+}
+
+// reducedCosts Compute cN - y*AN for the current dictionary
+func (cf *CanonicalForm) reducedCosts(y *mat.Dense) *mat.Dense {
+	return cf.reducedCostsTo(&mat.Dense{}, y)
+}
+
+// tableauRow Compute the row r of B^-1*AN, i.e. the coefficients of the nonbasic variables
+// in the equation of the r-th basic variable of the current dictionary
+// The row is a view on the workspace, valid until the next call.
+func (cf *CanonicalForm) tableauRow(r int) (*mat.Dense, error) {
+	cf.factorize()
+	return cf.solveRow(r)
+}
+
+// DualIter Run one iteration of the dual simplex algorithm
+// The current dictionary must be dual feasible (no positive reduced cost), which is the case
+// once the primal simplex has reached an optimal solution.
+// - Pick the most negative basic variable as the leaving variable
+// - Pick the entering variable that keeps the reduced costs non-positive (dual ratio test)
+// The basic variables above their upper bound, see SimplexRanged, are substituted first and become negative.
+// The ratio test is the bound-flipping (long-step) one: while the variable of the first breakpoint is bounded and
+// its bound is not enough to make the leaving variable non-negative, it flips to its bound
+// and the dual step goes on to the next breakpoint. The flipped variables are substituted before the pivot.
+// A pivot is degenerate when the dual step, the ratio, is zero. After blandThreshold of them
+// the leaving and the entering variables are the ones with the smallest index, which avoids the cycles.
+// It returns ErrInfeasible when a basic variable is negative and cannot be increased.
+func (cf *CanonicalForm) DualIter() (bool, error) {
+	cf.reflectAbove()
+	leavingVarIndex := -1
+	min := -cf.tolerance
+	for i := 0; i < cf.m; i++ {
+		if cf.xBStar.At(i, 0) >= -cf.tolerance {
+			continue
+		}
+		if cf.bland() {
+			if leavingVarIndex == -1 || cf.remap[cf.n+i] < cf.remap[cf.n+leavingVarIndex] {
+				leavingVarIndex = i
+			}
+			continue
+		}
+		if cf.xBStar.At(i, 0) < min {
+			min = cf.xBStar.At(i, 0)
+			leavingVarIndex = i
+		}
+	}
+	// The dictionary is primal feasible
+	if leavingVarIndex == -1 {
+		return true, nil
+	}
+
+	y, err := cf.findY()
+	if err != nil {
+		return false, err
+	}
+	reduced := cf.reducedCostsTo(&cf.workspace().reduced, y)
+
+	row, err := cf.solveRow(leavingVarIndex)
+	if err != nil {
+		return false, err
+	}
+
+	alphas, costs := rowVector(row, 0).Data, rowVector(reduced, 0).Data
+	enteringVarIndex, ratio := cf.dualRatio(alphas, costs)
+	// A flip of x_j to its bound U_j adds -alpha_j*U_j to the leaving variable and changes the sign of its column
+	flipped := []int{}
+	xr := cf.xBStar.At(leavingVarIndex, 0)
+	for enteringVarIndex != -1 {
+		u := cf.upperOf(cf.remap[enteringVarIndex])
+		if math.IsInf(u, 1) || xr-alphas[enteringVarIndex]*u >= -cf.tolerance {
+			break
+		}
+		xr -= alphas[enteringVarIndex] * u
+		flipped = append(flipped, enteringVarIndex)
+		alphas[enteringVarIndex], costs[enteringVarIndex] = -alphas[enteringVarIndex], -costs[enteringVarIndex]
+		enteringVarIndex, ratio = cf.dualRatio(alphas, costs)
+	}
+	// The leaving variable cannot be increased
+	if enteringVarIndex == -1 {
+		return false, ErrInfeasible
+	}
+	for _, j := range flipped {
+		d, err := cf.solveD(j)
+		if err != nil {
+			return false, err
+		}
+		cf.reflect(j, d)
+	}
+
+	d, err := cf.solveD(enteringVarIndex)
 	if err != nil {
 		return false, err
 	}
+	x := cf.xBStar.At(leavingVarIndex, 0) / d.At(leavingVarIndex, 0)
 
+	degenerate := cf.degenerate
+	err = cf.pivot(d, y, x, enteringVarIndex, leavingVarIndex, true)
+	if err != nil {
+		return false, err
+	}
+	if math.Abs(ratio) <= cf.tolerance {
+		cf.degenerate = degenerate + 1
+	} else {
+		cf.degenerate = 0
+	}
 	return false, nil
 }
 
+// dualRatio Dual ratio test on the row alphas of the leaving variable and the reduced costs,
+// the nonbasic position with the smallest ratio min(c_j, 0)/alpha_j over alpha_j < 0 and the ratio, -1 if there is none.
+// The ties go to the largest |alpha_j|, or to the smallest variable with Bland's rule.
+func (cf *CanonicalForm) dualRatio(alphas, costs []float64) (int, float64) {
+	enteringVarIndex := -1
+	ratio := math.Inf(1)
+	for j, alpha := range alphas {
+		if alpha >= -cf.tolerance {
+			continue
+		}
+		tmp := math.Min(costs[j], 0) / alpha
+		switch {
+		case tmp < ratio-cf.tolerance:
+			ratio = tmp
+			enteringVarIndex = j
+		case tmp > ratio+cf.tolerance:
+		case cf.bland():
+			//Bland's rule
+			if cf.remap[j] < cf.remap[enteringVarIndex] {
+				enteringVarIndex = j
+			}
+		case alpha < alphas[enteringVarIndex]:
+			enteringVarIndex = j
+		}
+	}
+	return enteringVarIndex, ratio
+}
+
+// Reoptimize Restore an optimal dictionary after the problem has been modified (e.g. with AddConstraint)
+// Run the dual simplex until the dictionary is feasible, then the primal simplex until it is optimal.
+// It returns the number of iterations. Like phaseOne, the iterations stop at the deadline of the dictionary
+// or once its context is done.
+func (cf *CanonicalForm) Reoptimize(maxIter int) (int, error) {
+	maxIter = iterationLimit(maxIter, cf.n, cf.m)
+	totalIter := 0
+	running := func() bool {
+		return totalIter < maxIter && !expired(cf.deadline) && !cf.cancelled()
+	}
+	for ; running(); totalIter++ {
+		end, err := cf.DualIter()
+		if err != nil {
+			return totalIter, err
+		}
+		if end {
+			break
+		}
+	}
+	if !cf.primalFeasible() {
+		return totalIter, limitError(cf.deadline)
+	}
+	for ; running(); totalIter++ {
+		end, err := cf.Iter(0)
+		if err != nil {
+			return totalIter, err
+		}
+		if end {
+			break
+		}
+		iter, err := cf.restoreFeasibility(maxIter - totalIter - 1)
+		if err != nil {
+			return totalIter, err
+		}
+		totalIter += iter
+	}
+	optimal, err := cf.optimal()
+	if err != nil {
+		return totalIter, err
+	}
+	if !optimal {
+		return totalIter, limitError(cf.deadline)
+	}
+	return totalIter, nil
+}
+
+// primalFeasible Check if no basic variable is negative or above its upper bound
+func (cf *CanonicalForm) primalFeasible() bool {
+	for i := 0; i < cf.m; i++ {
+		if cf.xBStar.At(i, 0) < -cf.tolerance || cf.xBStar.At(i, 0) > cf.upperOf(cf.remap[cf.n+i])+cf.tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// optimal Check if no nonbasic variable has a positive reduced cost
+func (cf *CanonicalForm) optimal() (bool, error) {
+	y, err := cf.findY()
+	if err != nil {
+		return false, err
+	}
+	reduced := cf.reducedCostsTo(&cf.workspace().reduced, y)
+	for j := 0; j < cf.n; j++ {
+		if reduced.At(0, j) > cf.tolerance {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// AddConstraint Add the constraint Σ a_j*x_j <= rhs to the current dictionary
+// a is indexed by variable (the n original variables followed by the slack variables of each constraint).
+// The slack variable of the new constraint has index len(a) and enters the basis,
+// its value is negative if the current solution violates the constraint.
+// The coefficients of the variables substituted by their upper bound U - x are -a_j, and rhs loses a_j*U.
+func (cf *CanonicalForm) AddConstraint(a []float64, rhs float64) error {
+	if len(a) != cf.n+cf.m {
+		return newError(ErrDimensionMismatch, "len(a) != number of variables")
+	}
+
+	A := mat.NewDense(cf.m+1, cf.n+cf.m+1, nil)
+	A.Slice(0, cf.m, 0, cf.n+cf.m).(*mat.Dense).Copy(cf.A)
+	for j := 0; j < cf.n+cf.m; j++ {
+		v := cf.remap[j]
+		if cf.reflected != nil && cf.reflected[v] {
+			A.Set(cf.m, j, -a[v])
+			rhs -= a[v] * cf.upper[v]
+			continue
+		}
+		A.Set(cf.m, j, a[v])
+	}
+	A.Set(cf.m, cf.n+cf.m, 1)
+
+	//The new slack variable is basic, its value is rhs - a*x for the current solution
+	xBStar := mat.NewDense(cf.m+1, 1, nil)
+	xBStar.Slice(0, cf.m, 0, 1).(*mat.Dense).Copy(cf.xBStar)
+	slack := rhs
+	for i := 0; i < cf.m; i++ {
+		slack -= A.At(cf.m, cf.n+i) * cf.xBStar.At(i, 0)
+	}
+	xBStar.Set(cf.m, 0, slack)
+
+	b := mat.NewDense(cf.m+1, 1, nil)
+	b.Slice(0, cf.m, 0, 1).(*mat.Dense).Copy(cf.b)
+	b.Set(cf.m, 0, rhs)
+
+	c := mat.NewDense(1, cf.n+cf.m+1, nil)
+	c.Slice(0, 1, 0, cf.n+cf.m).(*mat.Dense).Copy(cf.c)
+
+	cf.remap = append(cf.remap, cf.n+cf.m)
+	cf.slack = append(cf.slack, true)
+	cf.growBounds()
+	cf.m++
+	cf.A = A
+	cf.b = b
+	cf.c = c
+	cf.xBStar = xBStar
+	cf.x = mat.NewDense(cf.n+cf.m, 1, nil)
+	cf.slice()
+	return nil
+}
+
+// AddColumn Add a nonbasic variable with the coefficient a_i in each constraint of the dictionary and the cost c
+// The new variable has index n+m, the dictionary stays primal feasible.
+func (cf *CanonicalForm) AddColumn(a []float64, cost float64) error {
+	if len(a) != cf.m {
+		return newError(ErrDimensionMismatch, "len(a) != number of constraints")
+	}
+
+	//The new column is the last nonbasic column, the basic columns are shifted
+	A := mat.NewDense(cf.m, cf.n+cf.m+1, nil)
+	A.Slice(0, cf.m, 0, cf.n).(*mat.Dense).Copy(cf.AN)
+	A.Slice(0, cf.m, cf.n+1, cf.n+cf.m+1).(*mat.Dense).Copy(cf.B)
+	for i := 0; i < cf.m; i++ {
+		A.Set(i, cf.n, a[i])
+	}
+
+	c := mat.NewDense(1, cf.n+cf.m+1, nil)
+	c.Slice(0, 1, 0, cf.n).(*mat.Dense).Copy(cf.cN)
+	c.Slice(0, 1, cf.n+1, cf.n+cf.m+1).(*mat.Dense).Copy(cf.cB)
+	c.Set(0, cf.n, cost)
+
+	remap := append(append(append([]int(nil), cf.remap[:cf.n]...), cf.n+cf.m), cf.remap[cf.n:]...)
+
+	cf.remap = remap
+	cf.slack = append(cf.slack, false)
+	cf.growBounds()
+	cf.n++
+	cf.A = A
+	cf.c = c
+	cf.x = mat.NewDense(cf.n+cf.m, 1, nil)
+	cf.slice()
+	return nil
+}
+
+// setCosts Replace the objective, costs is indexed by variable
+// The dictionary stays primal feasible, the primal simplex restores its optimality.
+func (cf *CanonicalForm) setCosts(costs []float64) {
+	cf.offset = 0
+	for j := 0; j < cf.n+cf.m; j++ {
+		v := cf.remap[j]
+		if cf.reflected != nil && cf.reflected[v] {
+			cf.c.Set(0, j, -costs[v])
+			cf.offset += costs[v] * cf.upper[v]
+			continue
+		}
+		cf.c.Set(0, j, costs[v])
+	}
+}
+
+// costs Objective of the dictionary indexed by variable, see setCosts
+func (cf *CanonicalForm) costs() []float64 {
+	costs := make([]float64, cf.n+cf.m)
+	for j := 0; j < cf.n+cf.m; j++ {
+		v := cf.remap[j]
+		costs[v] = cf.c.At(0, j)
+		if cf.reflected != nil && cf.reflected[v] {
+			costs[v] = -costs[v]
+		}
+	}
+	return costs
+}
+
+// Clone Deep copy of the current dictionary
+func (cf *CanonicalForm) Clone() *CanonicalForm {
+	clone := &CanonicalForm{
+		n:          cf.n,
+		m:          cf.m,
+		A:          mat.DenseCopyOf(cf.A),
+		x:          mat.DenseCopyOf(cf.x),
+		c:          mat.DenseCopyOf(cf.c),
+		b:          mat.DenseCopyOf(cf.b),
+		xBStar:     mat.DenseCopyOf(cf.xBStar),
+		remap:      append([]int(nil), cf.remap...),
+		slack:      append([]bool(nil), cf.slack...),
+		degenerate: cf.degenerate,
+
+		upper:     append([]float64(nil), cf.upper...),
+		reflected: append([]bool(nil), cf.reflected...),
+		offset:    cf.offset,
+
+		recordHistory: cf.recordHistory,
+		history:       append([]Pivot(nil), cf.history...),
+
+		gate:  cf.gate,
+		gated: cf.gated,
+
+		checkInvariants: cf.checkInvariants,
+
+		tolerance: cf.tolerance,
+		rule:      cf.rule,
+		logger:    cf.logger,
+		pricing:   cf.pricing,
+
+		basisUpdate: cf.basisUpdate,
+		ctx:         cf.ctx,
+
+		priceStart: cf.priceStart,
+		candidates: append([]int(nil), cf.candidates...),
+
+		sinceCondition:   cf.sinceCondition,
+		refactorizations: cf.refactorizations,
+		repairs:          cf.repairs,
+		repaired:         cf.repaired,
+	}
+	clone.slice()
+	return clone
+}
+
+// slice Define the views on A, c and x for the basic and nonbasic variables
+func (cf *CanonicalForm) slice() {
+	cf.invalidate()
+	cf.xN = cf.x.Slice(0, cf.n, 0, 1).(*mat.Dense)
+
+	cf.B = cf.A.Slice(0, cf.m, cf.n, cf.n+cf.m).(*mat.Dense)
+
+	cf.AN = cf.A.Slice(0, cf.m, 0, cf.n).(*mat.Dense)
+
+	cf.cB = cf.c.Slice(0, 1, cf.n, cf.n+cf.m).(*mat.Dense)
+	cf.cN = cf.c.Slice(0, 1, 0, cf.n).(*mat.Dense)
+}
+
 // GetResults Build the solution.
 // It returns a matrix (n+m,1), the first n components are the best value for the problem and the others are the "leftover" for each constraint.
-// Also returns the maximum score.
+// Also returns the maximum score. Solution splits the decision and the slack variables.
+// It only computes, the solvers write the results to their trace and Report prints the dictionary.
 func (cf *CanonicalForm) GetResults() (*mat.Dense, float64) {
-	total := float64(0)
-
-	rows, cols := cf.x.Dims()
+	values, total := cf.values()
+	return mat.NewDense(len(values), 1, values), total
+}
 
-	result := mat.NewDense(rows, cols, nil)
-	result.Zero()
+// traceResults Write the results of GetResults at the end of the trace of the iterations
+func (cf *CanonicalForm) traceResults(result *mat.Dense, score float64) {
+	cf.logf("result:\n %v\n\n", mat.Formatted(result, mat.Prefix(" "), mat.Excerpt(8)))
+	cf.logf("Score: %v\n", score)
+}
 
+// values Value of each variable, indexed like the columns of the canonical form, and the current score
+func (cf *CanonicalForm) values() ([]float64, float64) {
+	total := float64(0)
+	values := make([]float64, cf.n+cf.m)
 	for i := cf.n; i < cf.n+cf.m; i++ {
-		result.Set(cf.remap[i], 0, cf.xBStar.At(i-cf.n, 0))
-		if cf.remap[i] < cf.n {
-			total += cf.xBStar.At(i-cf.n, 0) * cf.c.At(0, i)
+		values[cf.remap[i]] = cf.xBStar.At(i-cf.n, 0)
+		total += cf.xBStar.At(i-cf.n, 0) * cf.c.At(0, i)
+	}
+	//A variable substituted by its upper bound U - x is U at 0
+	for v, reflected := range cf.reflected {
+		if reflected {
+			values[v] = cf.upper[v] - values[v]
 		}
 	}
-
-	fmt.Printf("result:\n %v\n\n", mat.Formatted(result, mat.Prefix(" "), mat.Excerpt(8)))
-	fmt.Println("Score:", total)
-	return result, total
+	return values, total + cf.offset
 }
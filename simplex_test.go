@@ -3,6 +3,7 @@ package goptimization
 import (
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gonum.org/v1/gonum/mat"
@@ -17,7 +18,7 @@ func TestSimplex(t *testing.T) {
 	})
 	b := mat.NewDense(3, 1, []float64{42, 17, 24})
 
-	totalIter, results, score, err := Simplex(c, A, b, 10)
+	totalIter, results, score, err := Simplex(c, A, b, WithMaxIter(10))
 	require.NoError(t, err)
 	assert.Equal(t, 2, totalIter)
 	assert.True(t, mat.EqualApprox(mat.NewDense(7, 1, []float64{3, 0, 7, 0, 1, 0, 0}), results, 0.000001))
@@ -33,7 +34,7 @@ func TestSimplex2(t *testing.T) {
 	})
 	b := mat.NewDense(3, 1, []float64{480, 180, 720})
 
-	totalIter, results, score, err := Simplex(c, A, b, 10)
+	totalIter, results, score, err := Simplex(c, A, b, WithMaxIter(10))
 	require.NoError(t, err)
 	assert.Equal(t, 2, totalIter)
 	assert.True(t, mat.EqualApprox(mat.NewDense(5, 1, []float64{15, 9, 84, 0, 0}), results, 0.000001))
@@ -49,7 +50,7 @@ func TestSimplex3(t *testing.T) {
 	})
 	b := mat.NewDense(3, 1, []float64{4800, 4000, 5600})
 
-	totalIter, results, score, err := Simplex(c, A, b, 10)
+	totalIter, results, score, err := Simplex(c, A, b, WithMaxIter(10))
 	require.NoError(t, err)
 	assert.Equal(t, 3, totalIter)
 	assert.True(t, mat.EqualApprox(mat.NewDense(6, 1, []float64{200, 100, 350, 0, 0, 0}), results, 0.000001))
@@ -65,7 +66,7 @@ func TestSimplex4(t *testing.T) {
 	})
 	b := mat.NewDense(3, 1, []float64{4, 5, 7})
 
-	totalIter, results, score, err := Simplex(c, A, b, 10)
+	totalIter, results, score, err := Simplex(c, A, b, WithMaxIter(10))
 	require.NoError(t, err)
 	assert.Equal(t, 3, totalIter)
 	assert.True(t, mat.EqualApprox(mat.NewDense(6, 1, []float64{2.5, 1.5, 0, 0, 0, 0.5}), results, 0.000001))
@@ -80,7 +81,7 @@ func TestSimplex5(t *testing.T) {
 	})
 	b := mat.NewDense(2, 1, []float64{5, 3})
 
-	totalIter, results, score, err := Simplex(c, A, b, 10)
+	totalIter, results, score, err := Simplex(c, A, b, WithMaxIter(10))
 	require.NoError(t, err)
 	assert.Equal(t, 4, totalIter)
 	assert.True(t, mat.EqualApprox(mat.NewDense(6, 1, []float64{1, 2, 0, 0, 0, 0}), results, 0.000001))
@@ -96,7 +97,7 @@ func TestSimplex6(t *testing.T) {
 	})
 	b := mat.NewDense(3, 1, []float64{0, 0, 1})
 
-	totalIter, results, score, err := Simplex(c, A, b, 10)
+	totalIter, results, score, err := Simplex(c, A, b, WithMaxIter(10))
 	require.NoError(t, err)
 	assert.Equal(t, 4, totalIter)
 	assert.True(t, mat.EqualApprox(mat.NewDense(7, 1, []float64{1, 0, 1, 0, 2, 0, 0}), results, 0.000001))
@@ -120,31 +121,31 @@ func TestIter(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, end)
 	assert.True(t, mat.Equal(mat.NewDense(3, 7, []float64{
-		2, 4, 5, 7, 1, 0, 5,
-		1, 1, 2, 2, 0, 1, 2,
-		1, 2, 3, 3, 0, 0, 3,
+		2, 4, 0, 7, 1, 0, 5,
+		1, 1, 0, 2, 0, 1, 2,
+		1, 2, 1, 3, 0, 0, 3,
 	}), cf.A))
 
 	assert.True(t, mat.Equal(b, cf.b))
-	assert.True(t, mat.Equal(mat.NewDense(1, 7, []float64{7, 9, 18, 17, 0, 0, 18}), cf.c))
+	assert.True(t, mat.Equal(mat.NewDense(1, 7, []float64{7, 9, 0, 17, 0, 0, 18}), cf.c))
 	assert.True(t, mat.Equal(mat.NewDense(3, 1, []float64{2, 1, 8}), cf.xBStar))
 	assert.True(t, mat.Equal(mat.NewDense(3, 3, []float64{1, 0, 5, 0, 1, 2, 0, 0, 3}), cf.B))
-	assert.True(t, mat.Equal(AConstraints, cf.AN))
+	assert.True(t, mat.Equal(mat.NewDense(3, 4, []float64{2, 4, 0, 7, 1, 1, 0, 2, 1, 2, 1, 3}), cf.AN))
 
 	end, err = cf.Iter(0)
 	require.NoError(t, err)
 	require.False(t, end)
 	assert.True(t, mat.Equal(mat.NewDense(3, 7, []float64{
-		2, 4, 5, 7, 1, 2, 5,
-		1, 1, 2, 2, 0, 1, 2,
-		1, 2, 3, 3, 0, 1, 3,
+		0, 4, 0, 7, 1, 2, 5,
+		1, 1, 0, 2, 0, 1, 2,
+		0, 2, 1, 3, 0, 1, 3,
 	}), cf.A))
 
 	assert.True(t, mat.Equal(b, cf.b))
-	assert.True(t, mat.Equal(mat.NewDense(1, 7, []float64{7, 9, 18, 17, 0, 7, 18}), cf.c))
+	assert.True(t, mat.Equal(mat.NewDense(1, 7, []float64{0, 9, 0, 17, 0, 7, 18}), cf.c))
 	assert.True(t, mat.EqualApprox(mat.NewDense(3, 1, []float64{1, 3, 7}), cf.xBStar, 0.000001))
 	assert.True(t, mat.Equal(mat.NewDense(3, 3, []float64{1, 2, 5, 0, 1, 2, 0, 1, 3}), cf.B))
-	assert.True(t, mat.Equal(AConstraints, cf.AN))
+	assert.True(t, mat.Equal(mat.NewDense(3, 4, []float64{0, 4, 0, 7, 1, 1, 0, 2, 0, 2, 1, 3}), cf.AN))
 
 	end, err = cf.Iter(0)
 	require.NoError(t, err)
@@ -225,3 +226,217 @@ func TestNew(t *testing.T) {
 
 	assert.True(t, mat.Equal(mat.NewDense(1, 3, []float64{0, 0, 18}), cf.cB))
 }
+
+func TestAddConstraint(t *testing.T) {
+	c := mat.NewDense(1, 4, []float64{7, 9, 18, 17})
+	A := mat.NewDense(3, 4, []float64{
+		2, 4, 5, 7,
+		1, 1, 2, 2,
+		1, 2, 3, 3,
+	})
+	b := mat.NewDense(3, 1, []float64{42, 17, 24})
+
+	cf := CanonicalForm{}
+	err := cf.New(mat.DenseCopyOf(c), mat.DenseCopyOf(A), mat.DenseCopyOf(b))
+	require.NoError(t, err)
+	_, err = cf.Reoptimize(10)
+	require.NoError(t, err)
+
+	clone := cf.Clone()
+
+	//x_3 <= 6 cuts the optimal solution (3, 0, 7, 0)
+	err = cf.AddConstraint([]float64{0, 0, 1, 0, 0, 0, 0}, 6)
+	require.NoError(t, err)
+	assert.InEpsilon(t, -1.0, cf.xBStar.At(3, 0), 0.000001)
+	_, err = cf.Reoptimize(10)
+	require.NoError(t, err)
+	results, score := cf.GetResults()
+
+	_, expected, expectedScore, err := Simplex(c, mat.NewDense(4, 4, []float64{
+		2, 4, 5, 7,
+		1, 1, 2, 2,
+		1, 2, 3, 3,
+		0, 0, 1, 0,
+	}), mat.NewDense(4, 1, []float64{42, 17, 24, 6}), WithMaxIter(10))
+	require.NoError(t, err)
+	assert.InEpsilon(t, expectedScore, score, 0.000001)
+	assert.True(t, mat.EqualApprox(expected, results, 0.000001))
+
+	//The clone still holds the previous optimal dictionary
+	results, score = clone.GetResults()
+	assert.True(t, mat.EqualApprox(mat.NewDense(7, 1, []float64{3, 0, 7, 0, 1, 0, 0}), results, 0.000001))
+	assert.Equal(t, 147.0, score)
+}
+
+func TestDualIterInfeasible(t *testing.T) {
+	c := mat.NewDense(1, 2, []float64{1, 1})
+	A := mat.NewDense(1, 2, []float64{1, 1})
+	b := mat.NewDense(1, 1, []float64{2})
+
+	cf := CanonicalForm{}
+	err := cf.New(c, A, b)
+	require.NoError(t, err)
+	_, err = cf.Reoptimize(10)
+	require.NoError(t, err)
+
+	//x_1 + x_2 >= 3 contradicts x_1 + x_2 <= 2
+	err = cf.AddConstraint([]float64{-1, -1, 0}, -3)
+	require.NoError(t, err)
+	_, err = cf.Reoptimize(10)
+	assert.Equal(t, ErrInfeasible, err)
+}
+
+func TestDualIterRatio(t *testing.T) {
+	c := mat.NewDense(1, 2, []float64{3, 1})
+	A := mat.NewDense(2, 2, []float64{1, 0, 0, 1})
+	b := mat.NewDense(2, 1, []float64{3, 3})
+
+	cf := CanonicalForm{}
+	err := cf.New(c, A, b)
+	require.NoError(t, err)
+	_, err = cf.Reoptimize(10)
+	require.NoError(t, err)
+
+	//2x_1 + x_2 <= 6 cuts (3, 3), the ratio test picks x_2 whose ratio d_j/alpha_j is the smallest:
+	//one dual pivot reaches the optimum (3, 0) and keeps the reduced costs non-positive
+	err = cf.AddConstraint([]float64{2, 1, 0, 0}, 6)
+	require.NoError(t, err)
+	end, err := cf.DualIter()
+	require.NoError(t, err)
+	assert.False(t, end)
+	y, err := cf.FindY()
+	require.NoError(t, err)
+	reduced := cf.reducedCosts(y)
+	for j := 0; j < cf.n; j++ {
+		assert.True(t, reduced.At(0, j) <= epsilon, "reduced cost %d is %v", j, reduced.At(0, j))
+	}
+	end, err = cf.DualIter()
+	require.NoError(t, err)
+	assert.True(t, end)
+	results, score := cf.GetResults()
+	assert.InDelta(t, 3, results.At(0, 0), 0.000001)
+	assert.InDelta(t, 0, results.At(1, 0), 0.000001)
+	assert.InDelta(t, 9, score, 0.000001)
+}
+
+func TestAddColumn(t *testing.T) {
+	c := mat.NewDense(1, 3, []float64{7, 9, 18})
+	A := mat.NewDense(3, 3, []float64{
+		2, 4, 5,
+		1, 1, 2,
+		1, 2, 3,
+	})
+	b := mat.NewDense(3, 1, []float64{42, 17, 24})
+
+	cf := CanonicalForm{}
+	err := cf.New(mat.DenseCopyOf(c), mat.DenseCopyOf(A), mat.DenseCopyOf(b))
+	require.NoError(t, err)
+	_, err = cf.Reoptimize(10)
+	require.NoError(t, err)
+	assert.Error(t, cf.AddColumn([]float64{7, 2}, 17))
+
+	//The fourth variable of TestAddConstraint is added after the slack variables
+	err = cf.AddColumn([]float64{7, 2, 3}, 17)
+	require.NoError(t, err)
+	_, err = cf.Reoptimize(10)
+	require.NoError(t, err)
+	values, score := cf.values()
+
+	_, expected, expectedScore, err := Simplex(mat.NewDense(1, 4, []float64{7, 9, 18, 17}), mat.NewDense(3, 4, []float64{
+		2, 4, 5, 7,
+		1, 1, 2, 2,
+		1, 2, 3, 3,
+	}), mat.DenseCopyOf(b), WithMaxIter(10))
+	require.NoError(t, err)
+	assert.InEpsilon(t, expectedScore, score, 0.000001)
+	assert.InDeltaSlice(t, []float64{expected.At(0, 0), expected.At(1, 0), expected.At(2, 0), expected.At(4, 0), expected.At(5, 0), expected.At(6, 0), expected.At(3, 0)}, values, 0.000001)
+}
+
+func TestIterationLimit(t *testing.T) {
+	//The optimum (3, 1.5) needs two pivots
+	c := mat.NewDense(1, 2, []float64{5, 4})
+	A := mat.NewDense(2, 2, []float64{6, 4, 1, 2})
+	b := mat.NewDense(2, 1, []float64{24, 6})
+
+	totalIter, results, score, err := Simplex(mat.DenseCopyOf(c), mat.DenseCopyOf(A), mat.DenseCopyOf(b), WithMaxIter(1))
+	assert.Equal(t, ErrIterationLimit, err)
+	assert.Equal(t, 1, totalIter)
+	require.NotNil(t, results)
+	assert.InEpsilon(t, 20.0, score, 0.000001)
+
+	//maxIter = 0 is unlimited
+	totalIter, _, score, err = Simplex(mat.DenseCopyOf(c), mat.DenseCopyOf(A), mat.DenseCopyOf(b), WithMaxIter(0))
+	require.NoError(t, err)
+	assert.Equal(t, 2, totalIter)
+	assert.InEpsilon(t, 21.0, score, 0.000001)
+
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(mat.DenseCopyOf(c), mat.DenseCopyOf(A), mat.DenseCopyOf(b)))
+	_, err = cf.Reoptimize(1)
+	assert.Equal(t, ErrIterationLimit, err)
+	_, err = cf.Reoptimize(1)
+	require.NoError(t, err)
+}
+
+func TestNewDims(t *testing.T) {
+	for _, tc := range []struct {
+		c, A, b *mat.Dense
+		message string
+	}{
+		{mat.NewDense(2, 2, nil), mat.NewDense(1, 2, nil), mat.NewDense(1, 1, nil), "c dims must be (1,n), got (2,2)"},
+		{mat.NewDense(1, 3, nil), mat.NewDense(1, 2, nil), mat.NewDense(1, 1, nil), "A dims must be (m,3) like c, got (1,2)"},
+		{mat.NewDense(1, 2, nil), mat.NewDense(2, 2, nil), mat.NewDense(3, 1, nil), "b dims must be (2,1) like the rows of A, got (3,1)"},
+		{mat.NewDense(1, 2, nil), mat.NewDense(2, 2, nil), mat.NewDense(2, 2, nil), "b dims must be (2,1) like the rows of A, got (2,2)"},
+		{mat.NewDense(1, 2, nil), nil, mat.NewDense(2, 1, nil), "c, A and b must not be nil"},
+	} {
+		cf := CanonicalForm{}
+		err := cf.New(tc.c, tc.A, tc.b)
+		assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+		assert.EqualError(t, err, "dimension mismatch: "+tc.message)
+	}
+}
+
+func TestSimplexNegativeRHS(t *testing.T) {
+	//Minimize x_1 + x_2 with x_1 + x_2 >= 2 and x_1 <= 3
+	c := mat.NewDense(1, 2, []float64{-1, -1})
+	A := mat.NewDense(2, 2, []float64{-1, -1, 1, 0})
+	b := mat.NewDense(2, 1, []float64{-2, 3})
+
+	_, results, score, err := Simplex(c, A, b, WithMaxIter(10))
+	require.NoError(t, err)
+	assert.InDelta(t, -2.0, score, 0.000001)
+	assert.InDelta(t, 2.0, results.At(0, 0)+results.At(1, 0), 0.000001)
+	assert.GreaterOrEqual(t, results.At(0, 0), -0.000001)
+	assert.GreaterOrEqual(t, results.At(1, 0), -0.000001)
+
+	//x_1 <= -1 has no nonnegative solution
+	_, _, _, err = Simplex(mat.NewDense(1, 1, []float64{1}), mat.NewDense(1, 1, []float64{1}), mat.NewDense(1, 1, []float64{-1}), WithMaxIter(10))
+	assert.Equal(t, ErrInfeasible, errors.Cause(err))
+}
+
+func TestDualSimplex(t *testing.T) {
+	// Minimize 2x + 3y with x + y >= 4 and x >= 1: the slack basis is dual feasible
+	c := mat.NewDense(1, 2, []float64{-2, -3})
+	A := mat.NewDense(2, 2, []float64{
+		-1, -1,
+		-1, 0,
+	})
+	b := mat.NewDense(2, 1, []float64{-4, -1})
+	totalIter, results, score, err := DualSimplex(c, A, b, WithMaxIter(10))
+	require.NoError(t, err)
+	assert.Equal(t, 1, totalIter)
+	assert.True(t, mat.EqualApprox(mat.NewDense(4, 1, []float64{4, 0, 0, 3}), results, 0.000001))
+	assert.Equal(t, -8.0, score)
+
+	// Positive costs need a feasible basis first
+	_, _, score, err = DualSimplex(mat.NewDense(1, 2, []float64{100, 85}), mat.NewDense(3, 2, []float64{
+		12, 24,
+		9, 5,
+		30, 30,
+	}), mat.NewDense(3, 1, []float64{480, 180, 720}))
+	require.NoError(t, err)
+	assert.InDelta(t, 2265, score, 0.000001)
+
+	_, _, _, err = DualSimplex(mat.NewDense(1, 1, []float64{-1}), mat.NewDense(2, 1, []float64{1, -1}), mat.NewDense(2, 1, []float64{1, -2}))
+	assert.Equal(t, ErrInfeasible, errors.Cause(err))
+}
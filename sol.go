@@ -0,0 +1,96 @@
+package goptimization
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Duals Reduced cost c_j - y*a_j of each decision variable and dual value y_i of each constraint of the current
+// dictionary, with y = cB*B^-1 and a_j the original column of x_j. They follow the order of Solution.
+// At the optimum of the maximization the reduced costs are <= 0 and the duals >= 0.
+func (cf *CanonicalForm) Duals() ([]float64, []float64, error) {
+	y, err := cf.FindY()
+	if err != nil {
+		return nil, nil, err
+	}
+	var yA mat.Dense
+	yA.Mul(y, cf.A)
+	reduced := []float64{}
+	duals := []float64{}
+	// Indexed by variable like values
+	costs := make([]float64, cf.n+cf.m)
+	for position, id := range cf.remap {
+		costs[id] = cf.c.At(0, position) - yA.At(0, position)
+		// The column and the cost of a variable substituted by its upper bound have changed sign
+		if cf.reflected != nil && cf.reflected[id] {
+			costs[id] = -costs[id]
+		}
+		// The reduced cost of a basic variable is 0 up to the rounding of the solve
+		if position >= cf.n {
+			costs[id] = 0
+		}
+	}
+	for id, cost := range costs {
+		if cf.slack[id] {
+			// The column of the slack of the constraint i is e_i, its reduced cost is -y_i
+			duals = append(duals, -cost)
+		} else {
+			reduced = append(reduced, cost)
+		}
+	}
+	return reduced, duals, nil
+}
+
+// WriteSol Write the current solution of the dictionary in the SOL text format:
+// # Objective value = <score>
+// # Columns <n>: name value reduced_cost
+// one line per decision variable
+// # Rows <m>: name dual slack
+// one line per constraint
+// The lines starting with # are comments, so tools reading "name value" lines, for a MIP start for example,
+// read the values of the columns. columns and rows name the variables and the constraints in the order of Solution,
+// when they are nil the names are x0, x1, ... and c0, c1, ...
+func (cf *CanonicalForm) WriteSol(w io.Writer, columns, rows []string) error {
+	s := cf.Solution()
+	reduced, duals, err := cf.Duals()
+	if err != nil {
+		return err
+	}
+	if columns != nil && len(columns) != len(s.X) {
+		return newError(ErrDimensionMismatch, "len(columns) must be %d, got %d", len(s.X), len(columns))
+	}
+	if rows != nil && len(rows) != len(s.Slacks) {
+		return newError(ErrDimensionMismatch, "len(rows) must be %d, got %d", len(s.Slacks), len(rows))
+	}
+	writer := bufio.NewWriter(w)
+	fmt.Fprintf(writer, "# Objective value = %s\n", formatSol(s.Score))
+	fmt.Fprintf(writer, "# Columns %d: name value reduced_cost\n", len(s.X))
+	for j, x := range s.X {
+		fmt.Fprintf(writer, "%s %s %s\n", solName(columns, "x", j), formatSol(x), formatSol(reduced[j]))
+	}
+	fmt.Fprintf(writer, "# Rows %d: name dual slack\n", len(s.Slacks))
+	for i, slack := range s.Slacks {
+		fmt.Fprintf(writer, "%s %s %s\n", solName(rows, "c", i), formatSol(duals[i]), formatSol(slack))
+	}
+	return writer.Flush()
+}
+
+// solName Name k of names, prefix followed by k when names is nil or the name is empty
+func solName(names []string, prefix string, k int) string {
+	if names == nil || names[k] == "" {
+		return prefix + strconv.Itoa(k)
+	}
+	return names[k]
+}
+
+// formatSol Value with 12 significant digits, which hides the rounding errors of the solves, and 0 for -0
+func formatSol(v float64) string {
+	if v == 0 {
+		v = 0
+	}
+	return strconv.FormatFloat(v, 'g', 12, 64)
+}
@@ -0,0 +1,63 @@
+package goptimization
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+// solExample Maximize 5x + 4y + z, 6x + 4y + z <= 24, x + 2y + z <= 6, x <= 10, solved at x = 3, y = 1.5
+func solExample(t *testing.T) *CanonicalForm {
+	cf := &CanonicalForm{}
+	require.NoError(t, cf.New(
+		mat.NewDense(1, 3, []float64{5, 4, 1}),
+		mat.NewDense(3, 3, []float64{6, 4, 1, 1, 2, 1, 1, 0, 0}),
+		mat.NewDense(3, 1, []float64{24, 6, 10}),
+	))
+	cf.logger = log.New(ioutil.Discard, "", 0)
+	_, err := cf.Reoptimize(100)
+	require.NoError(t, err)
+	return cf
+}
+
+func TestDuals(t *testing.T) {
+	reduced, duals, err := solExample(t).Duals()
+	require.NoError(t, err)
+	require.Len(t, reduced, 3)
+	require.Len(t, duals, 3)
+	assert.InDelta(t, 0.0, reduced[0], 0.000001)
+	assert.InDelta(t, 0.0, reduced[1], 0.000001)
+	assert.InDelta(t, 1-0.75-0.5, reduced[2], 0.000001)
+	assert.InDelta(t, 0.75, duals[0], 0.000001)
+	assert.InDelta(t, 0.5, duals[1], 0.000001)
+	assert.InDelta(t, 0.0, duals[2], 0.000001)
+}
+
+func TestWriteSol(t *testing.T) {
+	cf := solExample(t)
+	buf := &bytes.Buffer{}
+	require.NoError(t, cf.WriteSol(buf, []string{"x", "y", ""}, []string{"wood", "labor", "xmax"}))
+	assert.Equal(t, `# Objective value = 21
+# Columns 3: name value reduced_cost
+x 3 0
+y 1.5 0
+x2 0 -0.25
+# Rows 3: name dual slack
+wood 0.75 0
+labor 0.5 0
+xmax 0 7
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, cf.WriteSol(buf, nil, nil))
+	assert.Contains(t, buf.String(), "\nx0 3 0\n")
+	assert.Contains(t, buf.String(), "\nc2 0 7\n")
+
+	assert.Error(t, cf.WriteSol(buf, []string{"x"}, nil))
+	assert.Error(t, cf.WriteSol(buf, nil, []string{"wood"}))
+}
@@ -0,0 +1,44 @@
+package goptimization
+
+import (
+	"gonum.org/v1/gonum/mat"
+)
+
+// Solution Values of a dictionary split between the decision variables and the slack variables,
+// without the (n+m,1) layout of GetResults
+type Solution struct {
+	// X Value of each decision variable, in the order of the columns of c and then of AddColumn
+	X []float64
+	// Slacks Leftover b_i - Σ a_i_j*x_j of each constraint, in the order of the rows of A and then of AddConstraint
+	Slacks []float64
+	Score  float64
+}
+
+// Solution Current solution of the dictionary
+func (cf *CanonicalForm) Solution() *Solution {
+	values, score := cf.values()
+	s := &Solution{X: []float64{}, Slacks: []float64{}, Score: score}
+	for id, v := range values {
+		if cf.slack[id] {
+			s.Slacks = append(s.Slacks, v)
+		} else {
+			s.X = append(s.X, v)
+		}
+	}
+	return s
+}
+
+// NewSolution Split the matrix (n+m,1) returned by Simplex, and the solvers which follow its layout,
+// for a problem with n decision variables
+func NewSolution(results *mat.Dense, score float64, n int) *Solution {
+	rows, _ := results.Dims()
+	s := &Solution{X: make([]float64, n), Slacks: make([]float64, rows-n), Score: score}
+	for i := 0; i < rows; i++ {
+		if i < n {
+			s.X[i] = results.At(i, 0)
+		} else {
+			s.Slacks[i-n] = results.At(i, 0)
+		}
+	}
+	return s
+}
@@ -0,0 +1,34 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSolution(t *testing.T) {
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(mat.NewDense(1, 2, []float64{5, 4}), mat.NewDense(2, 2, []float64{6, 4, 1, 2}), mat.NewDense(2, 1, []float64{24, 6})))
+	_, err := cf.Reoptimize(10)
+	require.NoError(t, err)
+	s := cf.Solution()
+	assert.InDeltaSlice(t, []float64{3, 1.5}, s.X, 0.000001)
+	assert.InDeltaSlice(t, []float64{0, 0}, s.Slacks, 0.000001)
+	assert.InDelta(t, 21.0, s.Score, 0.000001)
+
+	// The added column and constraint keep their kind although their ids interleave
+	require.NoError(t, cf.AddConstraint([]float64{1, 0, 0, 0}, 2))
+	require.NoError(t, cf.AddColumn([]float64{1, 1, 1}, 1))
+	_, err = cf.Reoptimize(10)
+	require.NoError(t, err)
+	s = cf.Solution()
+	require.Len(t, s.X, 3)
+	require.Len(t, s.Slacks, 3)
+	assert.LessOrEqual(t, s.X[0], 2+0.000001)
+	assert.InDelta(t, 2-s.X[0]-s.X[2], s.Slacks[2], 0.000001)
+
+	results, score := mat.NewDense(4, 1, []float64{3, 1.5, 0, 0}), 21.0
+	assert.Equal(t, &Solution{X: []float64{3, 1.5}, Slacks: []float64{0, 0}, Score: 21}, NewSolution(results, score, 2))
+}
@@ -0,0 +1,129 @@
+package goptimization
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Problem Linear problem in the standard form of Simplex, Integer flags the variables which must be integral for MIP
+type Problem struct {
+	C, A, B *mat.Dense
+	Integer []bool
+}
+
+// Options Configuration shared by the solvers, the zero value keeps the defaults of the corresponding Option
+type Options struct {
+	// MaxIter Iterations of the LP solvers, iterations of each node for MIP, see WithMaxIter
+	MaxIter int
+	// MaxNodes Nodes explored by MIP, <= 0 means unlimited
+	MaxNodes  int
+	TimeLimit time.Duration
+	Tolerance float64
+	// Threads See WithThreads, the number of workers of SolveBatch
+	Threads int
+	MIPGap  float64
+	Logger  Logger
+}
+
+// options Option functions of the configuration and of the context
+func (o *Options) options(ctx context.Context) []Option {
+	opts := []Option{WithContext(ctx)}
+	if o == nil {
+		return opts
+	}
+	opts = append(opts, WithMaxIter(o.MaxIter), WithTimeLimit(o.TimeLimit), WithThreads(o.Threads), WithMIPGap(o.MIPGap), WithLogger(o.Logger))
+	if o.Tolerance > 0 {
+		opts = append(opts, WithTolerance(o.Tolerance))
+	}
+	return opts
+}
+
+// Result Solution of a Solver with the work it took
+type Result struct {
+	Solution
+	// Iterations Iterations of the LP solvers, explored nodes for MIP
+	Iterations int
+	// Status Outcome of the solve, StatusTimeout when TimeLimit or the deadline of the context stopped it
+	Status Status
+}
+
+// Solver Method solving a Problem, so that applications swap the methods, or external backends, without code changes.
+// The errors are those of the method. When a limit or the context stops it with a solution,
+// the result is returned with ErrIterationLimit, or with the error of the context.
+type Solver interface {
+	Solve(ctx context.Context, p *Problem, o *Options) (*Result, error)
+}
+
+// SimplexSolver Solver of Simplex, Integer is ignored
+type SimplexSolver struct{}
+
+// DualSimplexSolver Solver of DualSimplex, Integer is ignored
+type DualSimplexSolver struct{}
+
+// InteriorPointSolver Solver of InteriorPoint, Integer is ignored
+type InteriorPointSolver struct{}
+
+// MIPSolver Solver of MIP
+type MIPSolver struct{}
+
+// lpMethod Signature of Simplex, DualSimplex and InteriorPoint
+type lpMethod func(c mat.Matrix, A *mat.Dense, b mat.Matrix, opts ...Option) (int, *mat.Dense, float64, error)
+
+// Solve Solve p with Simplex
+func (SimplexSolver) Solve(ctx context.Context, p *Problem, o *Options) (*Result, error) {
+	return solveLP(ctx, Simplex, p, o)
+}
+
+// Solve Solve p with DualSimplex
+func (DualSimplexSolver) Solve(ctx context.Context, p *Problem, o *Options) (*Result, error) {
+	return solveLP(ctx, DualSimplex, p, o)
+}
+
+// Solve Solve p with InteriorPoint
+func (InteriorPointSolver) Solve(ctx context.Context, p *Problem, o *Options) (*Result, error) {
+	return solveLP(ctx, InteriorPoint, p, o)
+}
+
+// Solve Solve p with MIP, the result of a search stopped by a limit or the context holds the best integer solution found
+func (MIPSolver) Solve(ctx context.Context, p *Problem, o *Options) (*Result, error) {
+	if p == nil {
+		return nil, newError(ErrDimensionMismatch, "the problem must not be nil")
+	}
+	bb, err := newMIP(p.C, p.A, p.B, p.Integer, o.options(ctx))
+	if err != nil {
+		return nil, err
+	}
+	maxNodes := math.MaxInt32
+	if o != nil && o.MaxNodes > 0 {
+		maxNodes = o.MaxNodes
+	}
+	results, score, err := bb.Solve(maxNodes)
+	if err == nil && bb.Stopped != "" {
+		err = newError(ErrIterationLimit, "the search stopped at the %s limit", bb.Stopped)
+	}
+	return newResult(ctx, p, bb.Nodes, results, score, err)
+}
+
+// solveLP Solve p with the LP method
+func solveLP(ctx context.Context, method lpMethod, p *Problem, o *Options) (*Result, error) {
+	if p == nil || p.C == nil || p.A == nil || p.B == nil {
+		return nil, newError(ErrDimensionMismatch, "c, A and b must not be nil")
+	}
+	iter, results, score, err := method(p.C, p.A, p.B, o.options(ctx)...)
+	return newResult(ctx, p, iter, results, score, err)
+}
+
+// newResult Result of a method, the error of the context replaces the limit it caused
+func newResult(ctx context.Context, p *Problem, iter int, results *mat.Dense, score float64, err error) (*Result, error) {
+	if err != nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	if results == nil {
+		return nil, err
+	}
+	_, n := p.C.Dims()
+	return &Result{Solution: *NewSolution(results, score, n), Iterations: iter, Status: StatusOf(err)}, err
+}
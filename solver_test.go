@@ -0,0 +1,85 @@
+package goptimization
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSolvers(t *testing.T) {
+	p := &Problem{
+		C: mat.NewDense(1, 2, []float64{5, 4}),
+		A: mat.NewDense(2, 2, []float64{
+			6, 4,
+			1, 2,
+		}),
+		B:       mat.NewDense(2, 1, []float64{24, 6}),
+		Integer: []bool{true, true},
+	}
+	o := &Options{Logger: log.New(ioutil.Discard, "", 0)}
+	// The relaxation has the optimum x = 3, y = 1.5
+	for _, solver := range []Solver{SimplexSolver{}, DualSimplexSolver{}, InteriorPointSolver{}} {
+		r, err := solver.Solve(context.Background(), p, o)
+		require.NoError(t, err, "%T", solver)
+		assert.InDelta(t, 21, r.Score, 0.000001, "%T", solver)
+		assert.InDeltaSlice(t, []float64{3, 1.5}, r.X, 0.000001, "%T", solver)
+		assert.Len(t, r.Slacks, 2)
+		assert.Equal(t, StatusOptimal, r.Status)
+	}
+	r, err := MIPSolver{}.Solve(context.Background(), p, o)
+	require.NoError(t, err)
+	assert.InDelta(t, 20, r.Score, 0.000001)
+	assert.InDeltaSlice(t, []float64{4, 0}, r.X, 0.000001)
+
+	_, err = SimplexSolver{}.Solve(context.Background(), &Problem{}, o)
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+	_, err = MIPSolver{}.Solve(context.Background(), nil, o)
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+}
+
+func TestSolverContext(t *testing.T) {
+	c, A, b, integer := knapsack(12, 7)
+	p := &Problem{C: c, A: A, B: b, Integer: integer}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	o := &Options{Logger: log.New(ioutil.Discard, "", 0)}
+	_, err := SimplexSolver{}.Solve(ctx, p, o)
+	assert.Equal(t, context.Canceled, err)
+	_, err = InteriorPointSolver{}.Solve(ctx, p, o)
+	assert.Equal(t, context.Canceled, err)
+	// The best iterate is returned with the status of the time limit
+	r, err := InteriorPointSolver{}.Solve(context.Background(), p, &Options{Logger: o.Logger, TimeLimit: time.Nanosecond})
+	require.NotNil(t, r)
+	assert.Equal(t, StatusTimeout, r.Status)
+	assert.Equal(t, StatusTimeout, StatusOf(err))
+
+	// 2x + 2y + ... = 21 has no integer solution, the search stops after the root when the context is done
+	n := 21
+	c = mat.NewDense(1, n, nil)
+	c.Set(0, 0, 1)
+	A = mat.NewDense(2+n, n, nil)
+	b = mat.NewDense(2+n, 1, nil)
+	for j := 0; j < n; j++ {
+		A.Set(0, j, 2)
+		A.Set(1, j, -2)
+		A.Set(2+j, j, 1)
+		b.Set(2+j, 0, 1)
+	}
+	b.Set(0, 0, 21)
+	b.Set(1, 0, -21)
+	p = &Problem{C: c, A: A, B: b, Integer: make([]bool, n)}
+	for j := range p.Integer {
+		p.Integer[j] = true
+	}
+	_, err = MIPSolver{}.Solve(ctx, p, o)
+	assert.Equal(t, context.Canceled, err)
+	_, err = MIPSolver{}.Solve(context.Background(), p, o)
+	assert.Equal(t, ErrInfeasible, errors.Cause(err))
+}
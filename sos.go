@@ -0,0 +1,164 @@
+package goptimization
+
+import (
+	"github.com/pkg/errors"
+)
+
+// SOSType Type of a special ordered set
+type SOSType int
+
+const (
+	// SOS1 At most one variable of the set is nonzero
+	SOS1 SOSType = 1
+	// SOS2 At most two variables of the set are nonzero and they are consecutive in the order of the weights
+	SOS2 SOSType = 2
+)
+
+// SOS Special ordered set of original variables, ordered by increasing weights
+type SOS struct {
+	Type    SOSType
+	Vars    []int
+	Weights []float64
+}
+
+// AddSOS Add a special ordered set to the problem, it must be called after New.
+// The weights order the variables of the set and must be strictly increasing, nil weights follow the order of vars.
+// The variables do not need to be integer, the sets are enforced by branching.
+func (bb *BranchAndBound) AddSOS(t SOSType, vars []int, weights []float64) error {
+	if bb.root == nil {
+		return errors.New("branch and bound is not initialized")
+	}
+	if t != SOS1 && t != SOS2 {
+		return errors.Errorf("unknown special ordered set type %d", t)
+	}
+	if weights == nil {
+		weights = make([]float64, len(vars))
+		for k := range weights {
+			weights[k] = float64(k)
+		}
+	}
+	if len(weights) != len(vars) {
+		return newError(ErrDimensionMismatch, "len(weights) != len(vars)")
+	}
+	seen := map[int]bool{}
+	for k, j := range vars {
+		if j < 0 || j >= bb.n {
+			return errors.Errorf("variable %d is not an original variable", j)
+		}
+		if seen[j] {
+			return errors.Errorf("variable %d appears twice in the set", j)
+		}
+		seen[j] = true
+		if k > 0 && weights[k] <= weights[k-1] {
+			return errors.New("weights must be strictly increasing")
+		}
+	}
+	bb.sets = append(bb.sets, SOS{
+		Type:    t,
+		Vars:    append([]int(nil), vars...),
+		Weights: append([]float64(nil), weights...),
+	})
+	return nil
+}
+
+// nonZeros First and last positions of the set with a nonzero value, and the number of nonzero values
+func (s SOS) nonZeros(x []float64) (first, last, count int) {
+	first, last = -1, -1
+	for k, j := range s.Vars {
+		if x[j] > integerTolerance || x[j] < -integerTolerance {
+			if first == -1 {
+				first = k
+			}
+			last = k
+			count++
+		}
+	}
+	return first, last, count
+}
+
+// violated Check if x does not satisfy the set
+func (s SOS) violated(x []float64) bool {
+	first, last, count := s.nonZeros(x)
+	if s.Type == SOS1 {
+		return count > 1
+	}
+	return count > 2 || last-first > 1
+}
+
+// satisfiesSOS Check if x satisfies all the special ordered sets
+func (bb *BranchAndBound) satisfiesSOS(x []float64) bool {
+	for _, s := range bb.sets {
+		if s.violated(x) {
+			return false
+		}
+	}
+	return true
+}
+
+// branchSOS Branch on the first special ordered set violated by the relaxation.
+// The set is split at the position r of the weighted mean Σ w_k*x_k / Σ x_k:
+// - SOS1: the variables after r are fixed to zero in one child, the ones up to r in the other
+// - SOS2: the variables after r are fixed to zero in one child, the ones before r in the other
+// It returns false if all the sets are satisfied.
+func (bb *BranchAndBound) branchSOS(nd *node, values []float64) ([]*node, bool, error) {
+	for _, s := range bb.sets {
+		if !s.violated(values) {
+			continue
+		}
+		first, last, _ := s.nonZeros(values)
+		total := 0.0
+		mean := 0.0
+		for k, j := range s.Vars {
+			total += values[j]
+			mean += s.Weights[k] * values[j]
+		}
+		mean /= total
+		r := first
+		for r+1 < len(s.Vars) && s.Weights[r+1] <= mean {
+			r++
+		}
+
+		// Both children must exclude the relaxation
+		var left, right []bound
+		if s.Type == SOS1 {
+			if r >= last {
+				r = last - 1
+			}
+			for k, j := range s.Vars {
+				if k > r {
+					left = append(left, bound{j: j, sign: 1, rhs: 0})
+				} else {
+					right = append(right, bound{j: j, sign: 1, rhs: 0})
+				}
+			}
+		} else {
+			if r <= first {
+				r = first + 1
+			}
+			if r >= last {
+				r = last - 1
+			}
+			for k, j := range s.Vars {
+				if k > r {
+					left = append(left, bound{j: j, sign: 1, rhs: 0})
+				}
+				if k < r {
+					right = append(right, bound{j: j, sign: 1, rhs: 0})
+				}
+			}
+		}
+
+		children := []*node{}
+		for _, bounds := range [][]bound{right, left} {
+			child, err := bb.branch(nd, bounds)
+			if err != nil {
+				return nil, true, err
+			}
+			if child != nil {
+				children = append(children, child)
+			}
+		}
+		return children, true, nil
+	}
+	return nil, false, nil
+}
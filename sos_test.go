@@ -0,0 +1,66 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSOS1(t *testing.T) {
+	c := mat.NewDense(1, 3, []float64{1, 2, 3})
+	A := mat.NewDense(4, 3, []float64{
+		1, 1, 1,
+		1, 0, 0,
+		0, 1, 0,
+		0, 0, 1,
+	})
+	b := mat.NewDense(4, 1, []float64{2, 1, 1, 1})
+
+	bb := BranchAndBound{}
+	require.NoError(t, bb.New(c, A, b, []bool{false, false, false}))
+	require.NoError(t, bb.AddSOS(SOS1, []int{0, 1, 2}, nil))
+	results, score, err := bb.Solve(100)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 3.0, score, 0.000001)
+	assert.True(t, mat.EqualApprox(mat.NewDense(1, 3, []float64{0, 0, 1}), results.Slice(0, 3, 0, 1).T(), 0.000001))
+}
+
+func TestSOS2(t *testing.T) {
+	// Piecewise linear f through (0,3), (1,0), (2,7), (3,9) with x <= 1.5
+	c := mat.NewDense(1, 4, []float64{3, 0, 7, 9})
+	A := mat.NewDense(2, 4, []float64{
+		1, 1, 1, 1,
+		0, 1, 2, 3,
+	})
+	b := mat.NewDense(2, 1, []float64{1, 1.5})
+
+	_, _, score, err := Simplex(c, A, b, WithMaxIter(100))
+	require.NoError(t, err)
+	assert.InEpsilon(t, 6.0, score, 0.000001)
+
+	bb := BranchAndBound{}
+	require.NoError(t, bb.New(c, A, b, []bool{false, false, false, false}))
+	require.NoError(t, bb.AddSOS(SOS2, []int{0, 1, 2, 3}, []float64{0, 1, 2, 3}))
+	results, score, err := bb.Solve(100)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 5.25, score, 0.000001)
+	assert.True(t, mat.EqualApprox(mat.NewDense(1, 4, []float64{0, 0, 0.75, 0}), results.Slice(0, 4, 0, 1).T(), 0.000001))
+}
+
+func TestAddSOSErrors(t *testing.T) {
+	c := mat.NewDense(1, 2, []float64{1, 1})
+	A := mat.NewDense(1, 2, []float64{1, 1})
+	b := mat.NewDense(1, 1, []float64{1})
+
+	bb := BranchAndBound{}
+	assert.Error(t, bb.AddSOS(SOS1, []int{0, 1}, nil))
+	require.NoError(t, bb.New(c, A, b, []bool{false, false}))
+	assert.Error(t, bb.AddSOS(3, []int{0, 1}, nil))
+	assert.Error(t, bb.AddSOS(SOS1, []int{0, 2}, nil))
+	assert.Error(t, bb.AddSOS(SOS1, []int{0, 0}, nil))
+	assert.Error(t, bb.AddSOS(SOS2, []int{0, 1}, []float64{1, 1}))
+	assert.Error(t, bb.AddSOS(SOS2, []int{0, 1}, []float64{1}))
+	assert.NoError(t, bb.AddSOS(SOS2, []int{1, 0}, []float64{1, 2}))
+}
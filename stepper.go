@@ -0,0 +1,171 @@
+package goptimization
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Ratio Row of the ratio test of an entering variable
+type Ratio struct {
+	// Basic Basic variable of the row
+	Basic int
+	// Value Value xBStar_i of the basic variable
+	Value float64
+	// Coefficient Coefficient d_i of the entering variable in the row of B^-1*N
+	Coefficient float64
+	// Ratio Value/Coefficient, +Inf when the coefficient is not positive and the row does not limit the entering variable
+	Ratio float64
+}
+
+// Step Decisions of one iteration of the primal simplex, see Stepper
+// The variables are indexes like in Pivot, the n original variables followed by the slack variables.
+type Step struct {
+	// Iteration Number of steps before this one
+	Iteration int
+	// Tableau Dictionary before the step
+	Tableau *Tableau
+	// Entering Nonbasic variables with a positive reduced cost, the candidates to enter the basis
+	Entering []Candidate
+	// EnteringVar Variable chosen by the pricing rule, -1 once the dictionary is optimal
+	EnteringVar int
+	// RatioTest Ratio test of EnteringVar, a row per basic variable
+	RatioTest []Ratio
+	// LeavingVar Basic variable with the minimum ratio, -1 once the dictionary is optimal
+	LeavingVar int
+	// Increase Value of the entering variable after the pivot, the minimum ratio
+	Increase float64
+	// Objective Objective after the step
+	Objective float64
+	// Optimal No variable enters the basis, the dictionary is optimal and the step changes nothing
+	Optimal bool
+}
+
+// Stepper Run the primal simplex one pivot at a time and expose each decision, e.g. to write worked examples
+type Stepper struct {
+	cf    *CanonicalForm
+	steps int
+	// pivot Decisions of the current step, filled by the pivot gate
+	pivot *Step
+}
+
+// NewStepper Stepper for the linear problem of Simplex, with the same options
+// When a b_i is negative the phase one runs first, without steps, and its errors are returned.
+func NewStepper(c mat.Matrix, A *mat.Dense, b mat.Matrix, opts ...Option) (*Stepper, error) {
+	o := newOptions(opts)
+	row, err := asRow(c, "c")
+	if err != nil {
+		return nil, err
+	}
+	column, err := asColumn(b, "b")
+	if err != nil {
+		return nil, err
+	}
+	cf := &CanonicalForm{}
+	err = cf.New(row, A, column)
+	if err != nil {
+		return nil, err
+	}
+	cf.configure(o)
+	_, err = cf.phaseOne(iterationLimit(o.maxIter, cf.n, cf.m))
+	if err != nil {
+		return nil, err
+	}
+	s := &Stepper{cf: cf}
+	cf.SetPivotGate(s.record)
+	return s, nil
+}
+
+// Step Run one iteration, the errors are those of Iter, e.g. ErrUnbounded with the candidates of the step
+// An iteration which repairs a singular basis instead of pivoting gives a step with EnteringVar and LeavingVar -1
+// and Optimal false.
+func (s *Stepper) Step() (*Step, error) {
+	tableau, err := s.cf.Tableau()
+	if err != nil {
+		return nil, err
+	}
+	step := &Step{
+		Iteration:   s.steps,
+		Tableau:     tableau,
+		Entering:    []Candidate{},
+		EnteringVar: -1,
+		LeavingVar:  -1,
+	}
+	for k, d := range tableau.Reduced {
+		if d > s.cf.tolerance {
+			step.Entering = append(step.Entering, Candidate{Variable: tableau.Nonbasic[k], Value: d})
+		}
+	}
+	s.pivot = step
+	end, err := s.cf.Iter(0)
+	// The phase one after a repair pivots without steps
+	s.pivot = nil
+	if err != nil {
+		return step, err
+	}
+	_, err = s.cf.restoreFeasibility(iterationLimit(0, s.cf.n, s.cf.m))
+	if err != nil {
+		return step, err
+	}
+	step.Optimal = end
+	_, step.Objective = s.cf.values()
+	s.steps++
+	return step, nil
+}
+
+// Solve Run the steps up to the optimum, at most maxIter of them, maxIter <= 0 means the limit of Simplex.
+// The last step is the optimal one, and ErrIterationLimit is returned with the steps when the limit is reached first.
+func (s *Stepper) Solve(maxIter int) ([]*Step, error) {
+	maxIter = iterationLimit(maxIter, s.cf.n, s.cf.m)
+	steps := []*Step{}
+	for k := 0; k < maxIter; k++ {
+		step, err := s.Step()
+		if err != nil {
+			return steps, err
+		}
+		steps = append(steps, step)
+		if step.Optimal {
+			return steps, nil
+		}
+	}
+	return steps, ErrIterationLimit
+}
+
+// Dictionary Dictionary of the stepper, e.g. for GetResults or Report
+// Its pivot gate records the steps and must not be replaced.
+func (s *Stepper) Dictionary() *CanonicalForm {
+	return s.cf
+}
+
+// record Pivot gate of the stepper, it writes the ratio test of the proposed pivot and confirms it
+func (s *Stepper) record(p *PivotProposal) (int, int, error) {
+	if s.pivot == nil {
+		return p.EnteringVar, p.LeavingVar, nil
+	}
+	j, err := s.cf.enteringPosition(p.EnteringVar)
+	if err != nil {
+		return 0, 0, err
+	}
+	d, err := s.cf.SolveBd(j)
+	if err != nil {
+		return 0, 0, err
+	}
+	s.pivot.EnteringVar, s.pivot.LeavingVar = p.EnteringVar, p.LeavingVar
+	s.pivot.RatioTest = make([]Ratio, s.cf.m)
+	for i := range s.pivot.RatioTest {
+		ratio := Ratio{
+			Basic:       s.cf.remap[s.cf.n+i],
+			Value:       s.cf.xBStar.At(i, 0),
+			Coefficient: d.At(i, 0),
+			Ratio:       math.Inf(1),
+		}
+		if ratio.Coefficient > s.cf.tolerance {
+			ratio.Ratio = ratio.Value / ratio.Coefficient
+		}
+		if ratio.Basic == p.LeavingVar {
+			s.pivot.Increase = ratio.Ratio
+		}
+		s.pivot.RatioTest[i] = ratio
+	}
+	return p.EnteringVar, p.LeavingVar, nil
+}
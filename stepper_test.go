@@ -0,0 +1,70 @@
+package goptimization
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestStepper(t *testing.T) {
+	c := mat.NewDense(1, 3, []float64{5, 4, 3})
+	A := mat.NewDense(3, 3, []float64{
+		2, 3, 1,
+		4, 1, 2,
+		3, 4, 2,
+	})
+	b := mat.NewDense(3, 1, []float64{5, 11, 8})
+	stepper, err := NewStepper(c, A, b, silent)
+	require.NoError(t, err)
+
+	first, err := stepper.Step()
+	require.NoError(t, err)
+	assert.Equal(t, 0, first.Iteration)
+	assert.Equal(t, []Candidate{{Variable: 0, Value: 5}, {Variable: 1, Value: 4}, {Variable: 2, Value: 3}}, first.Entering)
+	assert.Equal(t, 0, first.EnteringVar)
+	assert.Equal(t, []Ratio{
+		{Basic: 3, Value: 5, Coefficient: 2, Ratio: 2.5},
+		{Basic: 4, Value: 11, Coefficient: 4, Ratio: 2.75},
+		{Basic: 5, Value: 8, Coefficient: 3, Ratio: 8.0 / 3},
+	}, first.RatioTest)
+	assert.Equal(t, 3, first.LeavingVar)
+	assert.Equal(t, 2.5, first.Increase)
+	assert.InDelta(t, 12.5, first.Objective, 1e-9)
+	assert.False(t, first.Optimal)
+	assert.Equal(t, []int{3, 4, 5}, first.Tableau.Basis)
+
+	steps, err := stepper.Solve(0)
+	require.NoError(t, err)
+	require.Len(t, steps, 2)
+	assert.Equal(t, 1, steps[0].Iteration)
+	assert.Equal(t, 2, steps[0].EnteringVar)
+	assert.Equal(t, 5, steps[0].LeavingVar)
+	assert.InDelta(t, 1, steps[0].Increase, 1e-9)
+	// x2 does not appear in the row of s1
+	assert.Equal(t, 4, steps[0].RatioTest[1].Basic)
+	assert.InDelta(t, 0, steps[0].RatioTest[1].Coefficient, 1e-9)
+	assert.True(t, math.IsInf(steps[0].RatioTest[1].Ratio, 1))
+	assert.InDelta(t, 5, steps[0].RatioTest[0].Ratio, 1e-9)
+	assert.InDelta(t, 13, steps[0].Objective, 1e-9)
+	last := steps[1]
+	assert.True(t, last.Optimal)
+	assert.Empty(t, last.Entering)
+	assert.Equal(t, -1, last.EnteringVar)
+	assert.Equal(t, -1, last.LeavingVar)
+	assert.Nil(t, last.RatioTest)
+
+	results, score := stepper.Dictionary().GetResults()
+	assert.InDelta(t, 13, score, 1e-9)
+	assert.InDeltaSlice(t, []float64{2, 0, 1, 0, 1, 0}, mat.Col(nil, 0, results), 1e-9)
+}
+
+func TestStepperUnbounded(t *testing.T) {
+	// Maximize x, x - y <= 1
+	stepper, err := NewStepper(mat.NewDense(1, 2, []float64{1, 0}), mat.NewDense(1, 2, []float64{1, -1}), mat.NewDense(1, 1, []float64{1}), silent)
+	require.NoError(t, err)
+	_, err = stepper.Solve(10)
+	assert.Equal(t, ErrUnbounded, err)
+}
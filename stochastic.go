@@ -0,0 +1,58 @@
+package goptimization
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Scenario Realization of the second stage of a two-stage stochastic linear problem, with its probability
+// The recourse max H*y, W*y <= R - T*x, y >= 0 is solved once the first stage decision x is known.
+type Scenario struct {
+	Probability float64
+	T           *mat.Dense
+	W           *mat.Dense
+	R           *mat.Dense
+	H           *mat.Dense
+}
+
+// NewStochastic Initialize the L-shaped method for the two-stage stochastic linear problem
+// Maximize z = c*x + Σ(k) p_k*H_k*y_k
+// Constraints:
+// A*x <= b
+// T_k*x + W_k*y_k <= R_k for each scenario k
+// x >= 0, y_k >= 0
+// It is the Benders decomposition of the deterministic equivalent, the objective of each scenario is weighted by
+// its probability and the optimality cuts are aggregated into the expected recourse. MultiCut can be set afterwards.
+// The scenario subproblems are solved concurrently, Lower and Upper bound the weighted value of each scenario.
+// The probabilities must be non-negative and sum to 1. The options are those of New.
+func (bd *Benders) NewStochastic(c, A, b *mat.Dense, scenarios []Scenario, opts ...Option) error {
+	if len(scenarios) == 0 {
+		return errors.New("no scenario")
+	}
+	total := 0.0
+	subproblems := make([]BendersSubproblem, len(scenarios))
+	for k, s := range scenarios {
+		if s.Probability < 0 {
+			return errors.Errorf("scenario %d has a negative probability", k)
+		}
+		total += s.Probability
+		if s.H == nil {
+			return errors.Errorf("scenario %d has no objective", k)
+		}
+		H := mat.DenseCopyOf(s.H)
+		H.Scale(s.Probability, H)
+		subproblems[k] = BendersSubproblem{T: s.T, W: s.W, R: s.R, H: H}
+	}
+	if math.Abs(total-1) > feasibilityTolerance {
+		return errors.Errorf("the probabilities sum to %g instead of 1", total)
+	}
+	_, n := c.Dims()
+	err := bd.New(c, A, b, make([]bool, n), subproblems, opts...)
+	if err != nil {
+		return err
+	}
+	bd.MultiCut = false
+	return nil
+}
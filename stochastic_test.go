@@ -0,0 +1,70 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+// newsvendor Order x <= 100 units at a cost of 1 and sell y_k <= min(x, d_k) at a price of 3 for the demands
+// d = 10, 20 and 30 with probabilities 0.3, 0.4 and 0.3
+func newsvendor() (*mat.Dense, *mat.Dense, *mat.Dense, []Scenario) {
+	c := mat.NewDense(1, 1, []float64{-1})
+	A := mat.NewDense(1, 1, []float64{1})
+	b := mat.NewDense(1, 1, []float64{100})
+	scenarios := []Scenario{}
+	for k, demand := range []float64{10, 20, 30} {
+		scenarios = append(scenarios, Scenario{
+			Probability: []float64{0.3, 0.4, 0.3}[k],
+			T:           mat.NewDense(2, 1, []float64{-1, 0}),
+			W:           mat.NewDense(2, 1, []float64{1, 1}),
+			R:           mat.NewDense(2, 1, []float64{0, demand}),
+			H:           mat.NewDense(1, 1, []float64{3}),
+		})
+	}
+	return c, A, b, scenarios
+}
+
+func TestStochastic(t *testing.T) {
+	c, A, b, scenarios := newsvendor()
+
+	// Deterministic equivalent over (x, y_1, y_2, y_3)
+	_, _, expected, err := twoPhaseSimplex(mat.NewDense(1, 4, []float64{-1, 0.9, 1.2, 0.9}), mat.NewDense(7, 4, []float64{
+		1, 0, 0, 0,
+		-1, 1, 0, 0,
+		0, 1, 0, 0,
+		-1, 0, 1, 0,
+		0, 0, 1, 0,
+		-1, 0, 0, 1,
+		0, 0, 0, 1,
+	}), mat.NewDense(7, 1, []float64{100, 0, 10, 0, 20, 0, 30}), 100, silent)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 31.0, expected, 0.000001)
+
+	for _, multiCut := range []bool{false, true} {
+		bd := Benders{}
+		require.NoError(t, bd.NewStochastic(c, A, b, scenarios, silent))
+		assert.False(t, bd.MultiCut)
+		bd.MultiCut = multiCut
+		bd.Upper = 1000
+		x, y, score, err := bd.Solve(50)
+		require.NoError(t, err)
+		assert.InEpsilon(t, expected, score, 0.000001)
+		assert.InDelta(t, 20.0, x.At(0, 0), 0.000001)
+		assert.InDelta(t, 10.0, y[0].At(0, 0), 0.000001)
+		assert.InDelta(t, 20.0, y[1].At(0, 0), 0.000001)
+		assert.InDelta(t, 20.0, y[2].At(0, 0), 0.000001)
+	}
+}
+
+func TestStochasticErrors(t *testing.T) {
+	c, A, b, scenarios := newsvendor()
+	bd := Benders{}
+	assert.Error(t, bd.NewStochastic(c, A, b, nil))
+	scenarios[0].Probability = 0.5
+	assert.Error(t, bd.NewStochastic(c, A, b, scenarios))
+	scenarios[0].Probability = -0.3
+	assert.Error(t, bd.NewStochastic(c, A, b, scenarios))
+}
@@ -0,0 +1,179 @@
+package goptimization
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/pkg/errors"
+)
+
+// Objective Continuous function to minimize
+type Objective func(x []float64) float64
+
+// SwarmVariant Velocity update of the particle swarm
+type SwarmVariant int
+
+const (
+	// InertiaWeight v = w*v + c1*r1*(p-x) + c2*r2*(g-x)
+	InertiaWeight SwarmVariant = iota
+	// Constriction v = χ*(v + c1*r1*(p-x) + c2*r2*(g-x)) with χ = 2/|2-φ-sqrt(φ²-4φ)| and φ = c1+c2 > 4
+	Constriction
+)
+
+// Swarm Particle swarm optimization of a black-box objective over the box Lower <= x <= Upper
+type Swarm struct {
+	Objective Objective
+	Lower     []float64
+	Upper     []float64
+
+	// Particles Size of the swarm, 30 by default
+	Particles int
+	// MaxIter Maximum number of moves of the swarm, 1000 by default
+	MaxIter int
+	// Variant InertiaWeight by default, with Inertia 0.729 and Cognitive = Social = 1.494.
+	// Constriction requires Cognitive + Social > 4, for example 2.05 each.
+	Variant   SwarmVariant
+	Inertia   float64
+	Cognitive float64
+	Social    float64
+	// Diversity Stop once the mean distance of the particles to their center, relative to the diagonal of the box,
+	// is below Diversity, 1e-6 by default
+	Diversity float64
+	// Seed Seed of the initial swarm and of the moves
+	Seed int64
+
+	// Iterations Number of moves done by the last Solve
+	Iterations int
+}
+
+// New Initialize the swarm over the box lower <= x <= upper
+func (s *Swarm) New(objective Objective, lower, upper []float64) error {
+	if objective == nil {
+		return errors.New("objective must be defined")
+	}
+	if len(lower) == 0 || len(lower) != len(upper) {
+		return newError(ErrDimensionMismatch, "len(lower) = %d and len(upper) = %d must be equal and positive", len(lower), len(upper))
+	}
+	for j := range lower {
+		if math.IsInf(lower[j], 0) || math.IsInf(upper[j], 0) || lower[j] > upper[j] {
+			return errors.Errorf("the box must be finite with lower <= upper, got [%g, %g] for x%d", lower[j], upper[j], j)
+		}
+	}
+	s.Objective = objective
+	s.Lower = append([]float64(nil), lower...)
+	s.Upper = append([]float64(nil), upper...)
+	s.Particles = 30
+	s.MaxIter = 1000
+	s.Variant = InertiaWeight
+	s.Inertia = 0.729
+	s.Cognitive = 1.494
+	s.Social = 1.494
+	s.Diversity = 1e-6
+	s.Seed = 0
+	s.Iterations = 0
+	return nil
+}
+
+// constriction Factor χ of the Constriction variant
+func (s *Swarm) constriction() (float64, error) {
+	phi := s.Cognitive + s.Social
+	if phi <= 4 {
+		return 0, errors.Errorf("constriction requires Cognitive + Social > 4, got %g", phi)
+	}
+	return 2 / math.Abs(2-phi-math.Sqrt(phi*phi-4*phi)), nil
+}
+
+// diversity Mean distance of the particles to their center, relative to the diagonal of the box
+func (s *Swarm) diversity(positions [][]float64) float64 {
+	n := len(s.Lower)
+	center := make([]float64, n)
+	for _, x := range positions {
+		for j := range x {
+			center[j] += x[j] / float64(len(positions))
+		}
+	}
+	diagonal := 0.0
+	for j := 0; j < n; j++ {
+		diagonal += (s.Upper[j] - s.Lower[j]) * (s.Upper[j] - s.Lower[j])
+	}
+	if diagonal == 0 {
+		return 0
+	}
+	mean := 0.0
+	for _, x := range positions {
+		distance := 0.0
+		for j := range x {
+			distance += (x[j] - center[j]) * (x[j] - center[j])
+		}
+		mean += math.Sqrt(distance) / float64(len(positions))
+	}
+	return mean / math.Sqrt(diagonal)
+}
+
+// Solve Move the particles towards their best position and the best position of the swarm until MaxIter moves
+// or the swarm has collapsed below Diversity. A particle leaving the box is put back on its border with a zero velocity.
+// It returns the best position found and its objective.
+func (s *Swarm) Solve() ([]float64, float64, error) {
+	if s.Objective == nil || len(s.Lower) == 0 {
+		return nil, 0, errors.New("swarm is not initialized")
+	}
+	if s.Particles < 2 {
+		return nil, 0, errors.Errorf("the swarm must have at least 2 particles, got %d", s.Particles)
+	}
+	inertia, factor := s.Inertia, 1.0
+	if s.Variant == Constriction {
+		chi, err := s.constriction()
+		if err != nil {
+			return nil, 0, err
+		}
+		inertia, factor = chi, chi
+	}
+	n := len(s.Lower)
+	rnd := rand.New(rand.NewSource(s.Seed))
+
+	positions := make([][]float64, s.Particles)
+	velocities := make([][]float64, s.Particles)
+	best := make([][]float64, s.Particles)
+	bestValues := make([]float64, s.Particles)
+	global := -1
+	for i := range positions {
+		positions[i] = make([]float64, n)
+		velocities[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			width := s.Upper[j] - s.Lower[j]
+			positions[i][j] = s.Lower[j] + rnd.Float64()*width
+			velocities[i][j] = (2*rnd.Float64() - 1) * width
+		}
+		best[i] = append([]float64(nil), positions[i]...)
+		bestValues[i] = s.Objective(positions[i])
+		if global == -1 || bestValues[i] < bestValues[global] {
+			global = i
+		}
+	}
+
+	s.Iterations = 0
+	for s.Iterations < s.MaxIter && s.diversity(positions) >= s.Diversity {
+		s.Iterations++
+		g := append([]float64(nil), best[global]...)
+		for i, x := range positions {
+			v := velocities[i]
+			for j := 0; j < n; j++ {
+				v[j] = inertia*v[j] + factor*(s.Cognitive*rnd.Float64()*(best[i][j]-x[j])+s.Social*rnd.Float64()*(g[j]-x[j]))
+				x[j] += v[j]
+				if x[j] < s.Lower[j] || x[j] > s.Upper[j] {
+					x[j] = math.Max(s.Lower[j], math.Min(s.Upper[j], x[j]))
+					v[j] = 0
+				}
+			}
+			value := s.Objective(x)
+			if value < bestValues[i] {
+				copy(best[i], x)
+				bestValues[i] = value
+				if value < bestValues[global] {
+					global = i
+				}
+			}
+		}
+	}
+	return append([]float64(nil), best[global]...), bestValues[global], nil
+}
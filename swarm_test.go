@@ -0,0 +1,46 @@
+package goptimization
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwarm(t *testing.T) {
+	//Rosenbrock function, minimum 0 at (1, 1)
+	rosenbrock := func(x []float64) float64 {
+		return 100*math.Pow(x[1]-x[0]*x[0], 2) + math.Pow(1-x[0], 2)
+	}
+	for _, variant := range []SwarmVariant{InertiaWeight, Constriction} {
+		s := &Swarm{}
+		require.NoError(t, s.New(rosenbrock, []float64{-2, -2}, []float64{2, 2}))
+		s.Variant = variant
+		if variant == Constriction {
+			s.Cognitive, s.Social = 2.05, 2.05
+		}
+		s.Seed = 1
+		x, value, err := s.Solve()
+		require.NoError(t, err)
+		assert.InDelta(t, 1, x[0], 0.001)
+		assert.InDelta(t, 1, x[1], 0.001)
+		assert.InDelta(t, 0, value, 0.000001)
+		//The swarm collapsed before the iteration limit
+		assert.Less(t, s.Iterations, s.MaxIter)
+	}
+
+	//The minimum of the box is on its border
+	s := &Swarm{}
+	require.NoError(t, s.New(func(x []float64) float64 { return x[0] + x[1] }, []float64{1, -1}, []float64{3, 4}))
+	x, value, err := s.Solve()
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1, -1}, x)
+	assert.Equal(t, 0.0, value)
+
+	s.Variant = Constriction
+	_, _, err = s.Solve()
+	assert.Error(t, err)
+	assert.Error(t, s.New(rosenbrock, []float64{0}, []float64{1, 1}))
+	assert.Error(t, s.New(rosenbrock, []float64{0, 0}, []float64{1, math.Inf(1)}))
+}
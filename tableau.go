@@ -0,0 +1,143 @@
+package goptimization
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Tableau Snapshot of the dictionary, x_B = B^-1*b - B^-1*N*x_N and z = Objective + Reduced*x_N.
+// The variables are indexes like in Pivot, the n original variables followed by the slack variables.
+// The columns of the variables substituted by their upper bound, see SimplexRanged, are those of U - x_j.
+type Tableau struct {
+	// Names Name of each variable, x0, x1, ... in the order of Solution.X and s0, s1, ... in the order of
+	// Solution.Slacks, with a final ' for the variables substituted by their upper bound
+	Names []string
+	// Basis Basic variable of each row
+	Basis []int
+	// Nonbasic Nonbasic variable of each column of BInvN and Reduced
+	Nonbasic []int
+	// BInvN B^-1*N, (m,n)
+	BInvN *mat.Dense
+	// RHS Value B^-1*b of each basic variable
+	RHS []float64
+	// Reduced Reduced cost c_N - c_B*B^-1*N of each nonbasic variable
+	Reduced   []float64
+	Objective float64
+
+	// tolerance Values written as 0 by Write
+	tolerance float64
+}
+
+// Tableau Snapshot of the current dictionary, the errors are those of the solves with B
+func (cf *CanonicalForm) Tableau() (*Tableau, error) {
+	// The solves with B write to the trace of the iterations
+	logger := cf.logger
+	cf.logger = log.New(ioutil.Discard, "", 0)
+	defer func() {
+		cf.logger = logger
+	}()
+	y, err := cf.FindY()
+	if err != nil {
+		return nil, err
+	}
+	t := &Tableau{
+		Names:     cf.variableNames(),
+		Basis:     append([]int(nil), cf.remap[cf.n:]...),
+		Nonbasic:  append([]int(nil), cf.remap[:cf.n]...),
+		BInvN:     mat.NewDense(cf.m, cf.n, nil),
+		RHS:       mat.Col(nil, 0, cf.xBStar),
+		Reduced:   mat.Row(nil, 0, cf.reducedCosts(y)),
+		tolerance: cf.tolerance,
+	}
+	_, t.Objective = cf.values()
+	for r := 0; r < cf.m; r++ {
+		row, err := cf.tableauRow(r)
+		if err != nil {
+			return nil, err
+		}
+		t.BInvN.Slice(r, r+1, 0, cf.n).(*mat.Dense).Copy(row)
+	}
+	return t, nil
+}
+
+// Write Write the tableau B^-1*A to w as a table: a row per basic variable with its value, a column per variable,
+// the basic ones with their unit column, then the reduced costs and the objective. The values within the
+// tolerance of the dictionary of 0 are written as 0.
+func (t *Tableau) Write(w io.Writer) error {
+	// column Position of each variable in BInvN, -1 for the basic variables
+	column := make([]int, len(t.Names))
+	for v := range column {
+		column[v] = -1
+	}
+	for k, v := range t.Nonbasic {
+		column[v] = k
+	}
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "basis\t%s\tvalue\n", strings.Join(t.Names, "\t"))
+	for r, basic := range t.Basis {
+		fmt.Fprintf(writer, "%s", t.Names[basic])
+		for v, k := range column {
+			value := 0.0
+			switch {
+			case k >= 0:
+				value = t.BInvN.At(r, k)
+			case v == basic:
+				value = 1
+			}
+			fmt.Fprintf(writer, "\t%s", t.format(value))
+		}
+		fmt.Fprintf(writer, "\t%s\n", t.format(t.RHS[r]))
+	}
+	fmt.Fprintf(writer, "reduced")
+	for _, k := range column {
+		value := 0.0
+		if k >= 0 {
+			value = t.Reduced[k]
+		}
+		fmt.Fprintf(writer, "\t%s", t.format(value))
+	}
+	fmt.Fprintf(writer, "\t%s\n", t.format(t.Objective))
+	return writer.Flush()
+}
+
+// String Tableau written by Write
+func (t *Tableau) String() string {
+	var b strings.Builder
+	_ = t.Write(&b)
+	return b.String()
+}
+
+// format Value written by Write
+func (t *Tableau) format(v float64) string {
+	if math.Abs(v) <= t.tolerance {
+		return "0"
+	}
+	return strconv.FormatFloat(v, 'g', 6, 64)
+}
+
+// variableNames Name of each variable in Tableau, indexed by variable
+func (cf *CanonicalForm) variableNames() []string {
+	names := make([]string, len(cf.slack))
+	decisions, slacks := 0, 0
+	for v, slack := range cf.slack {
+		if slack {
+			names[v] = "s" + strconv.Itoa(slacks)
+			slacks++
+		} else {
+			names[v] = "x" + strconv.Itoa(decisions)
+			decisions++
+		}
+		if cf.reflected != nil && cf.reflected[v] {
+			names[v] += "'"
+		}
+	}
+	return names
+}
@@ -0,0 +1,60 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestTableau(t *testing.T) {
+	c := mat.NewDense(1, 3, []float64{5, 4, 3})
+	A := mat.NewDense(3, 3, []float64{
+		2, 3, 1,
+		4, 1, 2,
+		3, 4, 2,
+	})
+	b := mat.NewDense(3, 1, []float64{5, 11, 8})
+	o := newOptions([]Option{silent})
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(c, A, b))
+	cf.configure(o)
+
+	// The slack basis is the problem itself
+	tableau, err := cf.Tableau()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x0", "x1", "x2", "s0", "s1", "s2"}, tableau.Names)
+	assert.Equal(t, []int{3, 4, 5}, tableau.Basis)
+	assert.Equal(t, []int{0, 1, 2}, tableau.Nonbasic)
+	assert.True(t, mat.Equal(A, tableau.BInvN))
+	assert.Equal(t, []float64{5, 11, 8}, tableau.RHS)
+	assert.Equal(t, []float64{5, 4, 3}, tableau.Reduced)
+	assert.Equal(t, 0.0, tableau.Objective)
+
+	_, _, _, err = cf.run(o)
+	require.NoError(t, err)
+	tableau, err = cf.Tableau()
+	require.NoError(t, err)
+	assert.Equal(t, `basis    x0  x1  x2  s0  s1  s2  value
+x0       1   2   0   2   0   -1  2
+s1       0   -5  0   -2  1   0   1
+x2       0   -1  1   -3  0   2   1
+reduced  0   -3  0   -1  0   -1  13
+`, tableau.String())
+	assert.InDelta(t, 13, tableau.Objective, 1e-9)
+	// x_B = RHS - BInvN*x_N
+	expected := map[int]float64{0: 2, 4: 1, 2: 1}
+	for r, v := range tableau.Basis {
+		assert.InDelta(t, expected[v], tableau.RHS[r], 1e-9)
+	}
+	for _, d := range tableau.Reduced {
+		assert.True(t, d <= 1e-9)
+	}
+
+	// The snapshot does not follow the dictionary
+	tableau.RHS[0] = 42
+	snapshot, err := cf.Tableau()
+	require.NoError(t, err)
+	assert.NotEqual(t, 42.0, snapshot.RHS[0])
+}
@@ -0,0 +1,141 @@
+package goptimization
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Candidate Variable which can enter or leave the basis
+type Candidate struct {
+	// Variable Index of the variable (the n original variables followed by the slack variables)
+	Variable int
+	// Value Reduced cost of an entering candidate, ratio xBStar_i/d_i of a leaving candidate
+	Value float64
+}
+
+// PivotProposal Pivot chosen by the simplex algorithm, submitted to the PivotGate before it is applied
+type PivotProposal struct {
+	// Iteration Number of pivots already applied by the gate
+	Iteration int
+	// Entering Nonbasic variables with a positive reduced cost
+	Entering []Candidate
+	// Leaving Basic variables limiting the increase of the proposed entering variable
+	Leaving []Candidate
+	// EnteringVar, LeavingVar Pivot chosen by the pivot rules
+	EnteringVar int
+	LeavingVar  int
+
+	cf *CanonicalForm
+}
+
+// LeavingFor Ratio test for another entering variable
+func (p *PivotProposal) LeavingFor(entering int) ([]Candidate, error) {
+	j, err := p.cf.enteringPosition(entering)
+	if err != nil {
+		return nil, err
+	}
+	d, err := p.cf.SolveBd(j)
+	if err != nil {
+		return nil, err
+	}
+	return p.cf.leavingCandidates(d), nil
+}
+
+// PivotGate Decide the pivot before each iteration of the primal simplex
+// Return the proposed variables to confirm the pivot or other candidates to override it,
+// an error stops the algorithm.
+type PivotGate func(p *PivotProposal) (entering, leaving int, err error)
+
+// SetPivotGate Pause the primal simplex before each pivot and let gate confirm or override it
+// The override must be a valid pivot: the entering variable has a positive reduced cost and the leaving
+// variable has the minimum ratio, so the dictionary stays feasible.
+func (cf *CanonicalForm) SetPivotGate(gate PivotGate) {
+	cf.gate = gate
+}
+
+// askGate Submit the proposed pivot to the gate and check its decision
+func (cf *CanonicalForm) askGate(y, d *mat.Dense, x float64, enteringVarIndex, leavingVarIndex int) (*mat.Dense, float64, int, int, error) {
+	reduced := cf.reducedCosts(y)
+	proposal := &PivotProposal{
+		Iteration:   cf.gated,
+		Entering:    []Candidate{},
+		Leaving:     cf.leavingCandidates(d),
+		EnteringVar: cf.remap[enteringVarIndex],
+		LeavingVar:  cf.remap[cf.n+leavingVarIndex],
+		cf:          cf,
+	}
+	for j := 0; j < cf.n; j++ {
+		if reduced.At(0, j) > cf.tolerance {
+			proposal.Entering = append(proposal.Entering, Candidate{Variable: cf.remap[j], Value: reduced.At(0, j)})
+		}
+	}
+
+	entering, leaving, err := cf.gate(proposal)
+	if err != nil {
+		return nil, 0, 0, 0, err
+	}
+
+	if entering != proposal.EnteringVar {
+		enteringVarIndex, err = cf.enteringPosition(entering)
+		if err != nil {
+			return nil, 0, 0, 0, err
+		}
+		if reduced.At(0, enteringVarIndex) <= cf.tolerance {
+			return nil, 0, 0, 0, errors.Errorf("variable %d has a non-positive reduced cost and cannot enter the basis", entering)
+		}
+		d, err = cf.SolveBd(enteringVarIndex)
+		if err != nil {
+			return nil, 0, 0, 0, err
+		}
+	}
+
+	candidates := cf.leavingCandidates(d)
+	min := math.Inf(1)
+	for _, candidate := range candidates {
+		min = math.Min(min, candidate.Value)
+	}
+	leavingVarIndex = -1
+	for _, candidate := range candidates {
+		if candidate.Variable != leaving {
+			continue
+		}
+		if candidate.Value > min+cf.tolerance {
+			return nil, 0, 0, 0, errors.Errorf("variable %d does not have the minimum ratio, the dictionary would be infeasible", leaving)
+		}
+		x = candidate.Value
+		for i := 0; i < cf.m; i++ {
+			if cf.remap[cf.n+i] == leaving {
+				leavingVarIndex = i
+			}
+		}
+	}
+	if leavingVarIndex == -1 {
+		return nil, 0, 0, 0, errors.Errorf("variable %d cannot leave the basis", leaving)
+	}
+
+	cf.gated++
+	return d, x, enteringVarIndex, leavingVarIndex, nil
+}
+
+// enteringPosition Column of the nonbasic variable in the dictionary
+func (cf *CanonicalForm) enteringPosition(variable int) (int, error) {
+	for j := 0; j < cf.n; j++ {
+		if cf.remap[j] == variable {
+			return j, nil
+		}
+	}
+	return -1, errors.Errorf("variable %d is not a nonbasic variable", variable)
+}
+
+// leavingCandidates Basic variables with d_i > 0 and their ratio xBStar_i/d_i
+func (cf *CanonicalForm) leavingCandidates(d *mat.Dense) []Candidate {
+	candidates := []Candidate{}
+	for i := 0; i < cf.m; i++ {
+		if d.At(i, 0) > cf.tolerance {
+			candidates = append(candidates, Candidate{Variable: cf.remap[cf.n+i], Value: cf.xBStar.At(i, 0) / d.At(i, 0)})
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,86 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestPivotGate(t *testing.T) {
+	c := mat.NewDense(1, 4, []float64{7, 9, 18, 17})
+	A := mat.NewDense(3, 4, []float64{
+		2, 4, 5, 7,
+		1, 1, 2, 2,
+		1, 2, 3, 3,
+	})
+	b := mat.NewDense(3, 1, []float64{42, 17, 24})
+
+	cf := CanonicalForm{}
+	err := cf.New(c, A, b)
+	require.NoError(t, err)
+
+	proposals := []PivotProposal{}
+	cf.SetPivotGate(func(p *PivotProposal) (int, int, error) {
+		proposals = append(proposals, *p)
+		if p.Iteration > 0 {
+			return p.EnteringVar, p.LeavingVar, nil
+		}
+		//Override the first pivot: x_1 enters instead of x_3
+		leaving, err := p.LeavingFor(0)
+		if err != nil {
+			return 0, 0, err
+		}
+		assert.Equal(t, []Candidate{{Variable: 4, Value: 21}, {Variable: 5, Value: 17}, {Variable: 6, Value: 24}}, leaving)
+		return 0, 5, nil
+	})
+	iter, err := cf.Reoptimize(10)
+	require.NoError(t, err)
+
+	require.True(t, len(proposals) > 0)
+	assert.Equal(t, []Candidate{{Variable: 0, Value: 7}, {Variable: 1, Value: 9}, {Variable: 2, Value: 18}, {Variable: 3, Value: 17}}, proposals[0].Entering)
+	assert.Equal(t, 2, proposals[0].EnteringVar)
+	assert.Equal(t, 6, proposals[0].LeavingVar)
+	assert.Equal(t, len(proposals), iter)
+
+	results, score := cf.GetResults()
+	assert.True(t, mat.EqualApprox(mat.NewDense(7, 1, []float64{3, 0, 7, 0, 1, 0, 0}), results, 0.000001))
+	assert.InEpsilon(t, 147.0, score, 0.000001)
+}
+
+func TestPivotGateAssertions(t *testing.T) {
+	c := mat.NewDense(1, 4, []float64{7, 9, 18, 17})
+	A := mat.NewDense(3, 4, []float64{
+		2, 4, 5, 7,
+		1, 1, 2, 2,
+		1, 2, 3, 3,
+	})
+	b := mat.NewDense(3, 1, []float64{42, 17, 24})
+
+	cf := CanonicalForm{}
+	err := cf.New(c, A, b)
+	require.NoError(t, err)
+
+	//x_5 is basic
+	cf.SetPivotGate(func(p *PivotProposal) (int, int, error) {
+		return 5, p.LeavingVar, nil
+	})
+	_, err = cf.Iter(0)
+	assert.EqualError(t, err, "variable 5 is not a nonbasic variable")
+
+	//The ratio of x_4 is 42/5 > 24/3
+	cf.SetPivotGate(func(p *PivotProposal) (int, int, error) {
+		return p.EnteringVar, 4, nil
+	})
+	_, err = cf.Iter(0)
+	assert.EqualError(t, err, "variable 4 does not have the minimum ratio, the dictionary would be infeasible")
+
+	stop := errors.New("stop")
+	cf.SetPivotGate(func(p *PivotProposal) (int, int, error) {
+		return 0, 0, stop
+	})
+	_, err = cf.Iter(0)
+	assert.Equal(t, stop, err)
+}
@@ -0,0 +1,159 @@
+package goptimization
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SearchNode Node of the search tree of BranchAndBound, recorded when RecordTree is set
+type SearchNode struct {
+	// Branch Bounds added to the parent, e.g. x2 <= 1, empty at the root
+	Branch string
+	// Bound Score of the relaxation when the node was created, -Inf when it is infeasible
+	Bound float64
+	Depth int
+	// Explored Number of the node in the exploration, like Nodes, 0 when it was not explored
+	Explored int
+	// Status What happened to the node:
+	// "branched" it has children, "integral" its relaxation is a new incumbent,
+	// "infeasible" or "bound" it was pruned because its relaxation is infeasible or cannot improve the incumbent,
+	// "open" it was not explored when the search stopped
+	Status   string
+	Children []*SearchNode
+}
+
+// Tree Root of the search tree recorded by Solve when RecordTree is set, nil otherwise.
+// The nodes restored from a checkpoint are not recorded.
+func (bb *BranchAndBound) Tree() *SearchNode {
+	return bb.tree
+}
+
+// recordRoot Start the search tree with the root, nil when the root is infeasible
+func (bb *BranchAndBound) recordRoot(root *node) {
+	if !bb.RecordTree {
+		return
+	}
+	bb.tree = &SearchNode{Bound: math.Inf(-1), Status: "infeasible"}
+	if root != nil {
+		bb.tree.Bound = root.bound
+		bb.tree.Status = "open"
+		root.entry = bb.tree
+	}
+}
+
+// recordChild Add the child of nd created with the bounds, nil when it was pruned, to the search tree
+func (bb *BranchAndBound) recordChild(nd *node, bounds []bound, child *node, status string, score float64) {
+	if nd.entry == nil {
+		return
+	}
+	branch := make([]string, len(bounds))
+	for k, bd := range bounds {
+		if bd.sign > 0 {
+			branch[k] = fmt.Sprintf("x%d <= %s", bd.j, formatBound(bd.rhs))
+		} else {
+			branch[k] = fmt.Sprintf("x%d >= %s", bd.j, formatBound(-bd.rhs))
+		}
+	}
+	entry := &SearchNode{Branch: strings.Join(branch, ", "), Bound: score, Depth: nd.depth + 1, Status: status}
+	nd.entry.Children = append(nd.entry.Children, entry)
+	if child != nil {
+		child.entry = entry
+	}
+}
+
+// mark Record the status of the node in the search tree, if any
+func (nd *node) mark(status string) {
+	if nd.entry != nil {
+		nd.entry.Status = status
+	}
+}
+
+// WriteDOT Write the tree in the DOT language of Graphviz, e.g. for dot -Tsvg
+// Each node shows its number in the exploration, its bound and its status, each edge the branching bounds.
+func (t *SearchNode) WriteDOT(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	fmt.Fprintf(writer, "digraph search {\n\tnode [shape=box, style=filled];\n")
+	id := 0
+	var walk func(n *SearchNode) int
+	walk = func(n *SearchNode) int {
+		k := id
+		id++
+		fmt.Fprintf(writer, "\tn%d [label=%s, fillcolor=%q];\n", k, strconv.Quote(n.label("\n")), treeColors[n.Status])
+		for _, child := range n.Children {
+			c := walk(child)
+			fmt.Fprintf(writer, "\tn%d -> n%d [label=%s];\n", k, c, strconv.Quote(child.Branch))
+		}
+		return k
+	}
+	walk(t)
+	fmt.Fprintf(writer, "}\n")
+	return writer.Flush()
+}
+
+// WriteHTML Write the tree as a standalone HTML page, the subtrees can be folded
+func (t *SearchNode) WriteHTML(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	fmt.Fprintf(writer, `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Search tree</title>
+<style>
+ul { list-style: none; padding-left: 1.5em; border-left: 1px dotted #999; }
+summary, .leaf { font-family: monospace; padding: 0.1em 0.3em; }
+`)
+	for _, status := range []string{"branched", "integral", "infeasible", "bound", "open"} {
+		fmt.Fprintf(writer, ".%s { background: %s; }\n", status, treeColors[status])
+	}
+	fmt.Fprintf(writer, "</style>\n</head>\n<body>\n<ul>\n")
+	var walk func(n *SearchNode)
+	walk = func(n *SearchNode) {
+		label := html.EscapeString(n.label(", "))
+		if n.Branch != "" {
+			label = html.EscapeString(n.Branch) + ": " + label
+		}
+		if len(n.Children) == 0 {
+			fmt.Fprintf(writer, "<li><span class=\"leaf %s\">%s</span></li>\n", n.Status, label)
+			return
+		}
+		fmt.Fprintf(writer, "<li><details open><summary class=\"%s\">%s</summary>\n<ul>\n", n.Status, label)
+		for _, child := range n.Children {
+			walk(child)
+		}
+		fmt.Fprintf(writer, "</ul>\n</details></li>\n")
+	}
+	walk(t)
+	fmt.Fprintf(writer, "</ul>\n</body>\n</html>\n")
+	return writer.Flush()
+}
+
+// treeColors Color of the nodes of each status in WriteDOT and WriteHTML
+var treeColors = map[string]string{
+	"branched":   "#ffffff",
+	"integral":   "#b8e6b8",
+	"infeasible": "#f4b6b6",
+	"bound":      "#dddddd",
+	"open":       "#fff2a8",
+}
+
+// label Number, bound and status of the node, separated by sep
+func (t *SearchNode) label(sep string) string {
+	parts := []string{}
+	if t.Explored > 0 {
+		parts = append(parts, "#"+strconv.Itoa(t.Explored))
+	}
+	if !math.IsInf(t.Bound, -1) {
+		parts = append(parts, "bound "+formatBound(t.Bound))
+	}
+	return strings.Join(append(parts, t.Status), sep)
+}
+
+// formatBound Number written in the tree
+func formatBound(v float64) string {
+	return strconv.FormatFloat(v, 'g', 6, 64)
+}
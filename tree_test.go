@@ -0,0 +1,94 @@
+package goptimization
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSearchTree(t *testing.T) {
+	newSearch := func() *BranchAndBound {
+		bb := &BranchAndBound{}
+		require.NoError(t, bb.New(mat.NewDense(1, 2, []float64{5, 4}), mat.NewDense(2, 2, []float64{6, 4, 1, 2}), mat.NewDense(2, 1, []float64{24, 6}), []bool{true, true}))
+		bb.CutRounds = 0
+		bb.Heuristics = nil
+		bb.Probing = false
+		bb.root.cf.logger = log.New(ioutil.Discard, "", 0)
+		return bb
+	}
+	bb := newSearch()
+	_, _, err := bb.Solve(100)
+	require.NoError(t, err)
+	assert.Nil(t, bb.Tree())
+
+	bb = newSearch()
+	bb.RecordTree = true
+	_, score, err := bb.Solve(100)
+	require.NoError(t, err)
+	assert.InDelta(t, 20, score, 1e-9)
+	root := bb.Tree()
+	require.NotNil(t, root)
+	assert.Equal(t, "", root.Branch)
+	assert.Equal(t, 1, root.Explored)
+	assert.InDelta(t, 21, root.Bound, 1e-9)
+	assert.Equal(t, "branched", root.Status)
+	// The root branches on x1 = 1.5, the most fractional variable
+	require.Len(t, root.Children, 2)
+	assert.Equal(t, "x1 <= 1", root.Children[0].Branch)
+	assert.Equal(t, "x1 >= 2", root.Children[1].Branch)
+	// The incumbent 20 prunes the node of x1 >= 2
+	assert.Equal(t, "bound", root.Children[1].Status)
+	assert.Equal(t, 0, root.Children[1].Explored)
+
+	explored, nodes := map[int]bool{}, 0
+	var walk func(n *SearchNode, depth int)
+	walk = func(n *SearchNode, depth int) {
+		nodes++
+		assert.Equal(t, depth, n.Depth)
+		if n.Explored > 0 {
+			assert.False(t, explored[n.Explored])
+			explored[n.Explored] = true
+		}
+		assert.Equal(t, n.Status == "branched", len(n.Children) > 0, "%v", n)
+		assert.NotEqual(t, "open", n.Status)
+		if n.Status == "infeasible" {
+			assert.True(t, math.IsInf(n.Bound, -1))
+		}
+		for _, child := range n.Children {
+			assert.True(t, child.Bound <= n.Bound+1e-9)
+			walk(child, depth+1)
+		}
+	}
+	walk(root, 0)
+	assert.Equal(t, bb.Nodes, len(explored))
+
+	var dot bytes.Buffer
+	require.NoError(t, root.WriteDOT(&dot))
+	assert.True(t, strings.HasPrefix(dot.String(), "digraph search {\n"))
+	assert.Contains(t, dot.String(), "\tn0 [label=\"#1\\nbound 21\\nbranched\", fillcolor=\"#ffffff\"];\n")
+	assert.Contains(t, dot.String(), "\tn0 -> n1 [label=\"x1 <= 1\"];\n")
+	assert.Equal(t, nodes-1, strings.Count(dot.String(), "->"))
+
+	var page bytes.Buffer
+	require.NoError(t, root.WriteHTML(&page))
+	assert.Contains(t, page.String(), "<summary class=\"branched\">#1, bound 21, branched</summary>")
+	assert.Contains(t, page.String(), "x1 &lt;= 1: ")
+	assert.Equal(t, nodes, strings.Count(page.String(), "<li>"))
+
+	// The nodes left by a limit stay open
+	bb = newSearch()
+	bb.RecordTree = true
+	_, _, _ = bb.Solve(1)
+	assert.Equal(t, "branched", bb.Tree().Status)
+	for _, child := range bb.Tree().Children {
+		assert.Equal(t, "open", child.Status)
+		assert.Equal(t, 0, child.Explored)
+	}
+}
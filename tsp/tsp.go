@@ -0,0 +1,496 @@
+// Package tsp Traveling salesman problem: exact formulations solved by the branch and bound of goptimization
+// for small instances, nearest neighbor, 2-opt, Lin-Kernighan and Or-opt heuristics for large ones.
+// A tour is the order of the visited cities, it starts at the city 0 and returns to it.
+package tsp
+
+import (
+	"math"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// orOptSegment Longest segment of cities moved by Or-opt
+const orOptSegment = 3
+
+// check Number of cities of the distance matrix d, which must be square
+func check(d *mat.Dense) (int, error) {
+	if d == nil {
+		return 0, errors.Wrap(goptimization.ErrDimensionMismatch, "d must not be nil")
+	}
+	r, c := d.Dims()
+	if r != c {
+		return 0, errors.Wrapf(goptimization.ErrDimensionMismatch, "d dims must be (n,n), got (%d,%d)", r, c)
+	}
+	return r, nil
+}
+
+// symmetric Check if d(i,j) = d(j,i)
+func symmetric(d *mat.Dense, n int) bool {
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if d.At(i, j) != d.At(j, i) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Length Length of the closed tour
+func Length(d *mat.Dense, tour []int) float64 {
+	length := 0.0
+	for k := range tour {
+		length += d.At(tour[k], tour[(k+1)%len(tour)])
+	}
+	return length
+}
+
+// NearestNeighbor Tour going from each city to the closest city not visited yet, starting at the city 0
+func NearestNeighbor(d *mat.Dense) ([]int, error) {
+	n, err := check(d)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, errors.New("there is no city")
+	}
+	visited := make([]bool, n)
+	tour := []int{0}
+	visited[0] = true
+	for len(tour) < n {
+		last := tour[len(tour)-1]
+		next := -1
+		for j := 0; j < n; j++ {
+			if !visited[j] && (next == -1 || d.At(last, j) < d.At(last, next)) {
+				next = j
+			}
+		}
+		visited[next] = true
+		tour = append(tour, next)
+	}
+	return tour, nil
+}
+
+// TwoOpt Improve the tour by reversing the segment between two edges while it shortens the tour.
+// The reversal keeps the length of the segment only when d is symmetric.
+func TwoOpt(d *mat.Dense, tour []int) []int {
+	tour = append([]int(nil), tour...)
+	n := len(tour)
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < n-1; i++ {
+			for j := i + 2; j < n; j++ {
+				a, b := tour[i], tour[i+1]
+				c, e := tour[j], tour[(j+1)%n]
+				if a == e {
+					continue
+				}
+				// Replace the edges (a,b) and (c,e) by (a,c) and (b,e)
+				if d.At(a, c)+d.At(b, e) < d.At(a, b)+d.At(c, e)-1e-12 {
+					for l, r := i+1, j; l < r; l, r = l+1, r-1 {
+						tour[l], tour[r] = tour[r], tour[l]
+					}
+					improved = true
+				}
+			}
+		}
+	}
+	return tour
+}
+
+// LinKernighan Improve the tour by sequential 3-opt moves, a Lin-Kernighan step of depth 3, while it shortens the tour.
+// From t1 = tour[i] and t2 = tour[i+1], the move removes x1 = (t1,t2), adds y1 = (t2,t3), removes x2 = (t3,t4),
+// adds y2 = (t4,t5), removes x3 = (t5,t6) and closes the tour with (t6,t1). The gain criterion prunes the search:
+// the partial gains G1 = |x1| - |y1| and G2 = G1 + |x2| - |y2| must be positive, the move is applied when
+// G2 + |x3| - |(t6,t1)| is. The segments between the removed edges are swapped, they keep their direction.
+func LinKernighan(d *mat.Dense, tour []int) []int {
+	tour = append([]int(nil), tour...)
+	n := len(tour)
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < n-2 && !improved; i++ {
+			t1, t2 := tour[i], tour[i+1]
+			for k := i + 2; k < n && !improved; k++ {
+				t3, t4 := tour[k], tour[(k+1)%n]
+				g1 := d.At(t1, t2) - d.At(t2, t3)
+				if g1 <= 1e-12 {
+					continue
+				}
+				for j := i + 1; j < k; j++ {
+					t5, t6 := tour[j], tour[j+1]
+					g2 := g1 + d.At(t3, t4) - d.At(t4, t5)
+					if g2 <= 1e-12 {
+						continue
+					}
+					if g2+d.At(t5, t6)-d.At(t6, t1) > 1e-12 {
+						// t1 is followed by tour[j+1:k+1], from t6 to t3, then by tour[i+1:j+1], from t2 to t5
+						swapped := append(append([]int(nil), tour[:i+1]...), tour[j+1:k+1]...)
+						swapped = append(swapped, tour[i+1:j+1]...)
+						tour = append(swapped, tour[k+1:]...)
+						improved = true
+						break
+					}
+				}
+			}
+		}
+	}
+	return tour
+}
+
+// OrOpt Improve the tour by moving segments of 1 to 3 cities between two other cities while it shortens the tour.
+// The segments keep their direction.
+func OrOpt(d *mat.Dense, tour []int) []int {
+	tour = append([]int(nil), tour...)
+	n := len(tour)
+	for improved := true; improved; {
+		improved = false
+		for size := 1; size <= orOptSegment && size < n-2; size++ {
+			for i := 1; i+size <= n && !improved; i++ {
+				// The segment tour[i:i+size] between prev and next
+				prev, first, last, next := tour[i-1], tour[i], tour[i+size-1], tour[(i+size)%n]
+				removed := d.At(prev, first) + d.At(last, next) - d.At(prev, next)
+				rest := append(append([]int(nil), tour[:i]...), tour[i+size:]...)
+				for k := 0; k < len(rest); k++ {
+					a, b := rest[k], rest[(k+1)%len(rest)]
+					if d.At(a, first)+d.At(last, b)-d.At(a, b) < removed-1e-12 {
+						moved := append(append([]int(nil), rest[:k+1]...), tour[i:i+size]...)
+						tour = append(moved, rest[k+1:]...)
+						improved = true
+						break
+					}
+				}
+			}
+		}
+	}
+	return rotate(tour)
+}
+
+// rotate Rotate the tour so that it starts at the city 0
+func rotate(tour []int) []int {
+	for k, city := range tour {
+		if city == 0 {
+			return append(append([]int(nil), tour[k:]...), tour[:k]...)
+		}
+	}
+	return tour
+}
+
+// Heuristic Nearest neighbor tour improved by 2-opt, Lin-Kernighan and Or-opt until none shortens it,
+// d must be symmetric.
+// It returns the tour and its length.
+func Heuristic(d *mat.Dense) ([]int, float64, error) {
+	tour, err := NearestNeighbor(d)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !symmetric(d, len(tour)) {
+		return nil, 0, errors.New("the heuristic needs a symmetric distance matrix")
+	}
+	length := Length(d, tour)
+	for {
+		tour = OrOpt(d, LinKernighan(d, TwoOpt(d, tour)))
+		improved := Length(d, tour)
+		if improved >= length-1e-12 {
+			return tour, improved, nil
+		}
+		length = improved
+	}
+}
+
+// tourHeuristic Solution of the branch and bound given by a tour found beforehand
+type tourHeuristic struct {
+	x []float64
+}
+
+// Name Name reported in the incumbents
+func (tourHeuristic) Name() string {
+	return "tour"
+}
+
+// Run Return the tour
+func (t tourHeuristic) Run(h *goptimization.HeuristicContext) ([]float64, bool, error) {
+	return t.x, true, nil
+}
+
+// edges Index of the variable x_ij, i < j, of the symmetric formulation
+type edges struct {
+	n     int
+	index [][]int
+}
+
+func newEdges(n int) edges {
+	e := edges{n: n, index: make([][]int, n)}
+	k := 0
+	for i := 0; i < n; i++ {
+		e.index[i] = make([]int, n)
+		for j := i + 1; j < n; j++ {
+			e.index[i][j] = k
+			k++
+		}
+	}
+	return e
+}
+
+// count Number of edges
+func (e edges) count() int {
+	return e.n * (e.n - 1) / 2
+}
+
+// at Index of the edge between i and j
+func (e edges) at(i, j int) int {
+	if i > j {
+		i, j = j, i
+	}
+	return e.index[i][j]
+}
+
+// components Connected components of the graph of the edges with x_e = 1
+func (e edges) components(x []float64) [][]int {
+	seen := make([]bool, e.n)
+	components := [][]int{}
+	for start := 0; start < e.n; start++ {
+		if seen[start] {
+			continue
+		}
+		component := []int{}
+		stack := []int{start}
+		seen[start] = true
+		for len(stack) > 0 {
+			i := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			component = append(component, i)
+			for j := 0; j < e.n; j++ {
+				if j != i && !seen[j] && x[e.at(i, j)] > 0.5 {
+					seen[j] = true
+					stack = append(stack, j)
+				}
+			}
+		}
+		components = append(components, component)
+	}
+	return components
+}
+
+// subtours Subtour elimination constraints violated by x: Σ(i, j in S) x_ij <= |S| - 1 for each component S
+func (e edges) subtours(x []float64) ([]goptimization.Cut, error) {
+	components := e.components(x)
+	if len(components) == 1 {
+		return nil, nil
+	}
+	cuts := []goptimization.Cut{}
+	for _, component := range components {
+		a := make([]float64, e.count())
+		for k, i := range component {
+			for _, j := range component[k+1:] {
+				a[e.at(i, j)] = 1
+			}
+		}
+		cuts = append(cuts, goptimization.Cut{A: a, RHS: float64(len(component) - 1)})
+	}
+	return cuts, nil
+}
+
+// tour Tour followed by the edges with x_e = 1
+func (e edges) tour(x []float64) []int {
+	tour := []int{0}
+	for previous, city := -1, 0; len(tour) < e.n; {
+		for j := 0; j < e.n; j++ {
+			if j != city && j != previous && x[e.at(city, j)] > 0.5 {
+				previous, city = city, j
+				break
+			}
+		}
+		tour = append(tour, city)
+	}
+	return tour
+}
+
+// Solve Shortest tour of the symmetric distance matrix d (n,n)
+// Binary variables x_ij, i < j, select the edges of the tour:
+// Minimize Σ d_ij*x_ij
+// Constraints:
+// 1<=i<=n, Σ(j) x_ij = 2
+// S subset of the cities, Σ(i, j in S) x_ij <= |S| - 1
+// The subtour elimination constraints are lazy: they are added when an integer solution has a subtour.
+// The tour of Heuristic is the first incumbent. It returns the tour and its length,
+// with ErrIterationLimit and the best tour found when the search stopped after maxNodes nodes.
+// The options are those of goptimization.MIP.
+func Solve(d *mat.Dense, maxNodes int, opts ...goptimization.Option) ([]int, float64, error) {
+	n, err := check(d)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !symmetric(d, n) {
+		return nil, 0, errors.New("Solve needs a symmetric distance matrix, see SolveMTZ")
+	}
+	if n <= 3 {
+		tour, err := NearestNeighbor(d)
+		if err != nil {
+			return nil, 0, err
+		}
+		return tour, Length(d, tour), nil
+	}
+
+	e := newEdges(n)
+	m := &goptimization.Model{}
+	objective := goptimization.Expr{}
+	vars := make([]goptimization.Var, e.count())
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			k := e.at(i, j)
+			vars[k] = m.AddBinary("")
+			objective.Terms = append(objective.Terms, goptimization.Term{Var: vars[k], Coef: -d.At(i, j)})
+		}
+	}
+	m.Maximize(objective)
+	for i := 0; i < n; i++ {
+		degree := goptimization.Expr{}
+		for j := 0; j < n; j++ {
+			if j != i {
+				degree.Terms = append(degree.Terms, goptimization.Term{Var: vars[e.at(i, j)], Coef: 1})
+			}
+		}
+		err := m.AddRow(degree, goptimization.Equal, 2)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	start, _, err := Heuristic(d)
+	if err != nil {
+		return nil, 0, err
+	}
+	x := make([]float64, e.count())
+	for k := range start {
+		x[e.at(start[k], start[(k+1)%n])] = 1
+	}
+
+	c, A, b, integer := m.Standard()
+	bb := &goptimization.BranchAndBound{}
+	err = bb.New(c, A, b, integer)
+	if err != nil {
+		return nil, 0, err
+	}
+	bb.Configure(opts...)
+	bb.Lazy = e.subtours
+	bb.Heuristics = append(bb.Heuristics, tourHeuristic{x: x})
+	results, _, err := bb.Solve(maxNodes)
+	if err != nil {
+		return nil, 0, err
+	}
+	solution := make([]float64, e.count())
+	for k, v := range vars {
+		solution[k] = results.At(int(v), 0)
+	}
+	tour := e.tour(solution)
+	if bb.Stopped != "" {
+		return tour, Length(d, tour), goptimization.ErrIterationLimit
+	}
+	return tour, Length(d, tour), nil
+}
+
+// SolveMTZ Shortest tour of the distance matrix d (n,n), which can be asymmetric, with the formulation of
+// Miller, Tucker and Zemlin. Binary variables x_ij, i != j, select the arcs and the position u_i of the city i
+// in the tour eliminates the subtours:
+// Minimize Σ d_ij*x_ij
+// Constraints:
+// 1<=i<=n, Σ(j) x_ij = 1 and Σ(j) x_ji = 1
+// 2<=i,j<=n, i != j, u_i - u_j + (n-1)*x_ij <= n-2
+// 2<=i<=n, 1 <= u_i <= n-1
+// The formulation is compact but its relaxation is weak, Solve is faster on symmetric instances.
+// The options are those of goptimization.MIP.
+func SolveMTZ(d *mat.Dense, maxNodes int, opts ...goptimization.Option) ([]int, float64, error) {
+	n, err := check(d)
+	if err != nil {
+		return nil, 0, err
+	}
+	if n <= 2 {
+		tour, err := NearestNeighbor(d)
+		if err != nil {
+			return nil, 0, err
+		}
+		return tour, Length(d, tour), nil
+	}
+
+	m := &goptimization.Model{}
+	arcs := make([][]goptimization.Var, n)
+	objective := goptimization.Expr{}
+	for i := range arcs {
+		arcs[i] = make([]goptimization.Var, n)
+		for j := 0; j < n; j++ {
+			if j != i {
+				arcs[i][j] = m.AddBinary("")
+				objective.Terms = append(objective.Terms, goptimization.Term{Var: arcs[i][j], Coef: -d.At(i, j)})
+			}
+		}
+	}
+	m.Maximize(objective)
+	position := make([]goptimization.Var, n)
+	rows := []goptimization.Expr{}
+	senses := []goptimization.Sense{}
+	rhs := []float64{}
+	for i := 0; i < n; i++ {
+		out, in := goptimization.Expr{}, goptimization.Expr{}
+		for j := 0; j < n; j++ {
+			if j != i {
+				out.Terms = append(out.Terms, goptimization.Term{Var: arcs[i][j], Coef: 1})
+				in.Terms = append(in.Terms, goptimization.Term{Var: arcs[j][i], Coef: 1})
+			}
+		}
+		rows = append(rows, out, in)
+		senses = append(senses, goptimization.Equal, goptimization.Equal)
+		rhs = append(rhs, 1, 1)
+		if i > 0 {
+			position[i] = m.AddVariable("", false)
+			rows = append(rows, position[i].Expr(), position[i].Expr())
+			senses = append(senses, goptimization.GreaterEq, goptimization.LessEq)
+			rhs = append(rhs, 1, float64(n-1))
+		}
+	}
+	for i := 1; i < n; i++ {
+		for j := 1; j < n; j++ {
+			if i == j {
+				continue
+			}
+			rows = append(rows, goptimization.Expr{Terms: []goptimization.Term{
+				{Var: position[i], Coef: 1}, {Var: position[j], Coef: -1}, {Var: arcs[i][j], Coef: float64(n - 1)},
+			}})
+			senses = append(senses, goptimization.LessEq)
+			rhs = append(rhs, float64(n-2))
+		}
+	}
+	for k, row := range rows {
+		err := m.AddRow(row, senses[k], rhs[k])
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	c, A, b, integer := m.Standard()
+	bb := &goptimization.BranchAndBound{}
+	err = bb.New(c, A, b, integer)
+	if err != nil {
+		return nil, 0, err
+	}
+	bb.Configure(opts...)
+	results, _, err := bb.Solve(maxNodes)
+	if err != nil {
+		return nil, 0, err
+	}
+	tour := []int{0}
+	for city := 0; len(tour) < n; {
+		for j := 0; j < n; j++ {
+			if j != city && math.Round(results.At(int(arcs[city][j]), 0)) == 1 {
+				city = j
+				break
+			}
+		}
+		tour = append(tour, city)
+	}
+	if bb.Stopped != "" {
+		return tour, Length(d, tour), goptimization.ErrIterationLimit
+	}
+	return tour, Length(d, tour), nil
+}
@@ -0,0 +1,163 @@
+package tsp
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+// silent Logger of the solves
+var silent = goptimization.WithLogger(log.New(ioutil.Discard, "", 0))
+
+// euclidean Distances between n random points of the unit square
+func euclidean(n int, seed int64) *mat.Dense {
+	rnd := rand.New(rand.NewSource(seed))
+	x, y := make([]float64, n), make([]float64, n)
+	for i := range x {
+		x[i], y[i] = rnd.Float64(), rnd.Float64()
+	}
+	d := mat.NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			d.Set(i, j, math.Hypot(x[i]-x[j], y[i]-y[j]))
+		}
+	}
+	return d
+}
+
+// bruteForce Length of the shortest tour by enumeration of all the tours starting at 0
+func bruteForce(d *mat.Dense) float64 {
+	n, _ := d.Dims()
+	best := math.Inf(1)
+	var visit func(tour []int, used []bool)
+	visit = func(tour []int, used []bool) {
+		if len(tour) == n {
+			best = math.Min(best, Length(d, tour))
+			return
+		}
+		for j := 1; j < n; j++ {
+			if !used[j] {
+				used[j] = true
+				visit(append(tour, j), used)
+				used[j] = false
+			}
+		}
+	}
+	visit([]int{0}, make([]bool, n))
+	return best
+}
+
+// isTour Check that the tour visits each city once, starting at 0
+func isTour(t *testing.T, tour []int, n int) {
+	require.Len(t, tour, n)
+	assert.Equal(t, 0, tour[0])
+	seen := make([]bool, n)
+	for _, city := range tour {
+		assert.False(t, seen[city])
+		seen[city] = true
+	}
+}
+
+func TestSolve(t *testing.T) {
+	for seed := int64(1); seed <= 3; seed++ {
+		d := euclidean(8, seed)
+		tour, length, err := Solve(d, 10000, silent)
+		require.NoError(t, err)
+		isTour(t, tour, 8)
+		assert.InDelta(t, bruteForce(d), length, 0.000001)
+		assert.InDelta(t, Length(d, tour), length, 0.000001)
+	}
+
+	tour, length, err := Solve(mat.NewDense(2, 2, []float64{0, 1, 1, 0}), 10, silent)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, tour)
+	assert.Equal(t, 2.0, length)
+
+	_, _, err = Solve(mat.NewDense(2, 3, nil), 10, silent)
+	assert.Error(t, err)
+	_, _, err = Solve(mat.NewDense(2, 2, []float64{0, 1, 2, 0}), 10, silent)
+	assert.Error(t, err)
+}
+
+func TestSolveMTZ(t *testing.T) {
+	//Asymmetric distances
+	rnd := rand.New(rand.NewSource(4))
+	d := mat.NewDense(5, 5, nil)
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			if i != j {
+				d.Set(i, j, float64(1+rnd.Intn(20)))
+			}
+		}
+	}
+	tour, length, err := SolveMTZ(d, 10000, silent)
+	require.NoError(t, err)
+	isTour(t, tour, 5)
+	assert.InDelta(t, bruteForce(d), length, 0.000001)
+
+	symmetric := euclidean(5, 5)
+	_, exact, err := Solve(symmetric, 10000, silent)
+	require.NoError(t, err)
+	_, mtz, err := SolveMTZ(symmetric, 10000, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, exact, mtz, 0.000001)
+}
+
+func TestSolveOptions(t *testing.T) {
+	//The options reach the branch and bound: a cancelled context stops both searches with a tour
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tour, length, err := Solve(euclidean(8, 1), 10000, silent, goptimization.WithContext(ctx))
+	assert.Equal(t, goptimization.ErrIterationLimit, errors.Cause(err))
+	isTour(t, tour, 8)
+	assert.InDelta(t, Length(euclidean(8, 1), tour), length, 0.000001)
+	tour, _, err = SolveMTZ(euclidean(6, 1), 10000, silent, goptimization.WithContext(ctx))
+	assert.Equal(t, goptimization.ErrIterationLimit, errors.Cause(err))
+	isTour(t, tour, 6)
+}
+
+func TestHeuristic(t *testing.T) {
+	d := euclidean(60, 6)
+	start, err := NearestNeighbor(d)
+	require.NoError(t, err)
+	isTour(t, start, 60)
+
+	tour, length, err := Heuristic(d)
+	require.NoError(t, err)
+	isTour(t, tour, 60)
+	assert.Less(t, length, Length(d, start))
+	assert.InDelta(t, Length(d, tour), length, 0.000001)
+	//No 2-opt, Lin-Kernighan or Or-opt move improves the tour
+	assert.InDelta(t, length, Length(d, TwoOpt(d, tour)), 0.000001)
+	assert.InDelta(t, length, Length(d, LinKernighan(d, tour)), 0.000001)
+	assert.InDelta(t, length, Length(d, OrOpt(d, tour)), 0.000001)
+
+	d = euclidean(8, 7)
+	_, length, err = Heuristic(d)
+	require.NoError(t, err)
+	_, optimum, err := Solve(d, 10000, silent)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, length, optimum-0.000001)
+
+	//The 3-opt move of Lin-Kernighan shortens a tour 2-opt cannot improve
+	d = euclidean(6, 23)
+	start, err = NearestNeighbor(d)
+	require.NoError(t, err)
+	stuck := TwoOpt(d, start)
+	assert.InDelta(t, Length(d, stuck), Length(d, TwoOpt(d, stuck)), 0.000001)
+	swapped := LinKernighan(d, stuck)
+	isTour(t, swapped, 6)
+	assert.Less(t, Length(d, swapped), Length(d, stuck)-0.000001)
+
+	_, err = NearestNeighbor(mat.NewDense(1, 2, nil))
+	assert.Equal(t, goptimization.ErrDimensionMismatch, errors.Cause(err))
+}
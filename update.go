@@ -0,0 +1,78 @@
+package goptimization
+
+import (
+	"gonum.org/v1/gonum/mat"
+)
+
+// SetRHS Replace the right-hand side of the constraint i by value and re-optimize from the current basis,
+// for the problems solved again with fresh data. The reduced costs do not depend on b: an optimal dictionary
+// stays dual feasible and the dual simplex of Reoptimize restores the feasibility of its basic solution,
+// often in a few pivots. It returns the number of iterations, the errors are those of Reoptimize.
+// The dictionary should be optimal, e.g. after Reoptimize, otherwise the primal iterations finish the solve.
+func (cf *CanonicalForm) SetRHS(i int, value float64) (int, error) {
+	if i < 0 || i >= cf.m {
+		return 0, newError(ErrDimensionMismatch, "constraint %d not in [0, %d)", i, cf.m)
+	}
+	rhs := cf.rhs(i)
+	// b can be the matrix given to New
+	cf.b = mat.DenseCopyOf(cf.b)
+	cf.b.Set(i, 0, cf.b.At(i, 0)+value-rhs)
+	err := cf.refactorize()
+	if err != nil {
+		return 0, err
+	}
+	return cf.Reoptimize(0)
+}
+
+// rhs Right-hand side of the constraint i, b_i without the substitutions of the upper bounds, see reflect
+func (cf *CanonicalForm) rhs(i int) float64 {
+	rhs := cf.b.At(i, 0)
+	for p, v := range cf.remap {
+		if cf.reflected != nil && cf.reflected[v] {
+			rhs -= cf.upper[v] * cf.A.At(i, p)
+		}
+	}
+	return rhs
+}
+
+// SetObjective Replace the cost of the decision variable j, in the order of Solution.X, and re-optimize
+// from the current basis, for the iterative methods which adjust the costs, like a Lagrangian relaxation
+// or the column generation. The basic solution does not depend on c: the dictionary stays primal feasible and
+// the primal simplex of Reoptimize restores its optimality. It returns the number of iterations, the errors are
+// those of Reoptimize.
+func (cf *CanonicalForm) SetObjective(j int, value float64) (int, error) {
+	decisions := cf.decisions()
+	if j < 0 || j >= len(decisions) {
+		return 0, newError(ErrDimensionMismatch, "decision variable %d not in [0, %d)", j, len(decisions))
+	}
+	costs := cf.costs()
+	costs[decisions[j]] = value
+	cf.setCosts(costs)
+	return cf.Reoptimize(0)
+}
+
+// SetObjectiveVector Replace the costs of all the decision variables, in the order of Solution.X, and re-optimize
+// like SetObjective, with a single solve for all the changes
+func (cf *CanonicalForm) SetObjectiveVector(c []float64) (int, error) {
+	decisions := cf.decisions()
+	if len(c) != len(decisions) {
+		return 0, newError(ErrDimensionMismatch, "len(c) must be %d, got %d", len(decisions), len(c))
+	}
+	costs := cf.costs()
+	for k, v := range decisions {
+		costs[v] = c[k]
+	}
+	cf.setCosts(costs)
+	return cf.Reoptimize(0)
+}
+
+// decisions Index of each decision variable, in the order of Solution.X
+func (cf *CanonicalForm) decisions() []int {
+	decisions := make([]int, 0, cf.n+cf.m)
+	for v, slack := range cf.slack {
+		if !slack {
+			decisions = append(decisions, v)
+		}
+	}
+	return decisions
+}
@@ -0,0 +1,144 @@
+package goptimization
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSetRHS(t *testing.T) {
+	lp, err := GenerateLP(20, 15, 0.4, 2)
+	require.NoError(t, err)
+	b := mat.DenseCopyOf(lp.B)
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(mat.DenseCopyOf(lp.C), mat.DenseCopyOf(lp.A), b))
+	cf.configure(newOptions([]Option{silent}))
+	_, err = cf.Reoptimize(0)
+	require.NoError(t, err)
+
+	// A rolling horizon: each row moves by a fraction of its value, the others keep the changes
+	expectedB := mat.DenseCopyOf(lp.B)
+	for step, i := range []int{3, 7, 0, 11} {
+		value := expectedB.At(i, 0) * (0.6 + 0.2*float64(step))
+		expectedB.Set(i, 0, value)
+		_, err = cf.SetRHS(i, value)
+		require.NoError(t, err)
+		_, _, expected, err := Simplex(lp.C, mat.DenseCopyOf(lp.A), expectedB, silent)
+		require.NoError(t, err)
+		_, score := cf.values()
+		assert.InDelta(t, expected, score, 1e-7, "step %d", step)
+	}
+	assert.True(t, mat.Equal(lp.B, b), "b given to New is not modified")
+
+	_, err = cf.SetRHS(15, 1)
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+	// x >= 0 cannot satisfy Σ_j a_0_j*x_j <= -1e6
+	_, err = cf.SetRHS(0, -1e6)
+	assert.Equal(t, ErrInfeasible, errors.Cause(err))
+}
+
+func TestSetRHSRanged(t *testing.T) {
+	lp, err := GenerateLP(12, 8, 0.5, 3)
+	require.NoError(t, err)
+	ranges := []float64{1, math.Inf(1), 2, math.Inf(1), 3, 1, math.Inf(1), 2}
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(mat.DenseCopyOf(lp.C), mat.DenseCopyOf(lp.A), lp.B))
+	cf.configure(newOptions([]Option{silent}))
+	upper := make([]float64, 20)
+	for j := range upper {
+		upper[j] = math.Inf(1)
+		if j >= 12 {
+			upper[j] = ranges[j-12]
+		}
+	}
+	cf.setUpper(upper)
+	_, _, _, err = cf.run(newOptions([]Option{silent}))
+	require.NoError(t, err)
+
+	b := mat.DenseCopyOf(lp.B)
+	for _, i := range []int{0, 2, 5} {
+		assert.InDelta(t, b.At(i, 0), cf.rhs(i), 1e-9)
+		b.Set(i, 0, b.At(i, 0)+0.5)
+		_, err = cf.SetRHS(i, b.At(i, 0))
+		require.NoError(t, err)
+	}
+	_, _, expected, err := SimplexRanged(lp.C, mat.DenseCopyOf(lp.A), b, ranges, silent)
+	require.NoError(t, err)
+	_, score := cf.values()
+	assert.InDelta(t, expected, score, 1e-7)
+}
+
+func TestSetObjective(t *testing.T) {
+	lp, err := GenerateLP(20, 15, 0.4, 4)
+	require.NoError(t, err)
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(mat.DenseCopyOf(lp.C), mat.DenseCopyOf(lp.A), lp.B))
+	cf.configure(newOptions([]Option{silent}))
+	_, err = cf.Reoptimize(0)
+	require.NoError(t, err)
+
+	c := mat.DenseCopyOf(lp.C)
+	for step, j := range []int{2, 9, 14} {
+		value := c.At(0, j) + 3 - float64(step)*4
+		c.Set(0, j, value)
+		_, err = cf.SetObjective(j, value)
+		require.NoError(t, err)
+		_, _, expected, err := Simplex(c, mat.DenseCopyOf(lp.A), lp.B, silent)
+		require.NoError(t, err)
+		assert.InDelta(t, expected, cf.Solution().Score, 1e-7, "step %d", step)
+	}
+
+	// Subgradient-like steps on all the costs
+	costs := mat.Row(nil, 0, lp.C)
+	for step := 0; step < 3; step++ {
+		for j := range costs {
+			costs[j] *= 0.9
+			if j%3 == step {
+				costs[j] -= 1
+			}
+		}
+		_, err = cf.SetObjectiveVector(costs)
+		require.NoError(t, err)
+		_, _, expected, err := Simplex(mat.NewDense(1, 20, costs), mat.DenseCopyOf(lp.A), lp.B, silent)
+		require.NoError(t, err)
+		assert.InDelta(t, expected, cf.Solution().Score, 1e-7, "step %d", step)
+	}
+
+	_, err = cf.SetObjective(20, 1)
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+	_, err = cf.SetObjectiveVector(costs[:19])
+	assert.Equal(t, ErrDimensionMismatch, errors.Cause(err))
+}
+
+func TestSetObjectiveRanged(t *testing.T) {
+	lp, err := GenerateLP(12, 8, 0.5, 6)
+	require.NoError(t, err)
+	ranges := []float64{2, math.Inf(1), 1, 3, math.Inf(1), 2, 1, math.Inf(1)}
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(mat.DenseCopyOf(lp.C), mat.DenseCopyOf(lp.A), lp.B))
+	cf.configure(newOptions([]Option{silent}))
+	upper := make([]float64, 20)
+	for j := range upper {
+		upper[j] = math.Inf(1)
+		if j >= 12 {
+			upper[j] = ranges[j-12]
+		}
+	}
+	cf.setUpper(upper)
+	_, _, _, err = cf.run(newOptions([]Option{silent}))
+	require.NoError(t, err)
+
+	costs := mat.Row(nil, 0, lp.C)
+	for j := range costs {
+		costs[j] = -costs[j] + float64(j%4)
+	}
+	_, err = cf.SetObjectiveVector(costs)
+	require.NoError(t, err)
+	_, _, expected, err := SimplexRanged(mat.NewDense(1, 12, costs), mat.DenseCopyOf(lp.A), lp.B, ranges, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, expected, cf.Solution().Score, 1e-7)
+}
@@ -0,0 +1,139 @@
+package goptimization
+
+import (
+	"fmt"
+	"strings"
+)
+
+// All Wildcard index of VarArray.Sum, every value of the dimension
+const All = -1
+
+// VarArray Family of variables indexed by a tuple, e.g. x[i][j] for the transportation and assignment models,
+// see Model.Vars. The variables are added to the model in row-major order, the last index varies fastest.
+type VarArray struct {
+	name string
+	dims []int
+	vars []Var
+}
+
+// Vars Add a family of non-negative variables with the dimensions, named like name[i][j]
+func (m *Model) Vars(name string, dims ...int) *VarArray {
+	return m.varArray(name, dims, func(name string) Var {
+		return m.AddVariable(name, false)
+	})
+}
+
+// IntegerVars Add a family of non-negative integer variables, see Vars
+func (m *Model) IntegerVars(name string, dims ...int) *VarArray {
+	return m.varArray(name, dims, func(name string) Var {
+		return m.AddVariable(name, true)
+	})
+}
+
+// BinaryVars Add a family of binary variables, see Vars and AddBinary
+func (m *Model) BinaryVars(name string, dims ...int) *VarArray {
+	return m.varArray(name, dims, m.AddBinary)
+}
+
+// varArray Family of the variables created by add with their names
+func (m *Model) varArray(name string, dims []int, add func(name string) Var) *VarArray {
+	a := &VarArray{name: name, dims: make([]int, len(dims))}
+	size := 1
+	for d, n := range dims {
+		// A negative dimension is empty
+		if n > 0 {
+			a.dims[d] = n
+		}
+		size *= a.dims[d]
+	}
+	a.vars = make([]Var, size)
+	index := make([]int, len(dims))
+	for k := range a.vars {
+		var b strings.Builder
+		b.WriteString(name)
+		for _, i := range index {
+			fmt.Fprintf(&b, "[%d]", i)
+		}
+		a.vars[k] = add(b.String())
+		nextIndex(index, a.dims)
+	}
+	return a
+}
+
+// nextIndex Move the index to the next one in row-major order, back to 0 after the last one
+func nextIndex(index, dims []int) {
+	for d := len(index) - 1; d >= 0; d-- {
+		index[d]++
+		if index[d] < dims[d] {
+			return
+		}
+		index[d] = 0
+	}
+}
+
+// Dims Dimensions of the family
+func (a *VarArray) Dims() []int {
+	return append([]int(nil), a.dims...)
+}
+
+// Vars Variables of the family in row-major order
+func (a *VarArray) Vars() []Var {
+	return append([]Var(nil), a.vars...)
+}
+
+// At Variable at the index, one value per dimension.
+// An index out of range, or with the wrong number of values, gives the variable -1 which the constraints reject.
+func (a *VarArray) At(index ...int) Var {
+	if !a.valid(index, false) {
+		return -1
+	}
+	k := 0
+	for d, i := range index {
+		k = k*a.dims[d] + i
+	}
+	return a.vars[k]
+}
+
+// valid Check if the index has a value in range per dimension, or All when all is set
+func (a *VarArray) valid(index []int, all bool) bool {
+	if len(index) != len(a.dims) {
+		return false
+	}
+	for d, i := range index {
+		if (i < 0 || i >= a.dims[d]) && !(all && i == All) {
+			return false
+		}
+	}
+	return true
+}
+
+// Sum Sum of the variables matching the index, All matches every value of its dimension:
+// x.Sum(All, j) is Σ_i x[i][j], x.Sum(All, All) sums the whole family.
+// An invalid index gives a term of the variable -1, like At.
+func (a *VarArray) Sum(index ...int) Expr {
+	return a.WeightedSum(func([]int) float64 { return 1 }, index...)
+}
+
+// WeightedSum Sum of weight(i)*x[i] over the variables matching the index like Sum, weight gets the full index of each
+// variable, e.g. x.WeightedSum(func(k []int) float64 { return cost[k[0]][k[1]] }, All, All) for a total cost.
+// weight must not keep the index, which is reused. The null weights are skipped.
+func (a *VarArray) WeightedSum(weight func(index []int) float64, index ...int) Expr {
+	if !a.valid(index, true) {
+		return Expr{Terms: []Term{{Var: -1, Coef: 1}}}
+	}
+	e := Expr{}
+	current := make([]int, len(a.dims))
+	for _, v := range a.vars {
+		match := true
+		for d, i := range index {
+			match = match && (i == All || i == current[d])
+		}
+		if match {
+			if w := weight(current); w != 0 {
+				e.Terms = append(e.Terms, Term{Var: v, Coef: w})
+			}
+		}
+		nextIndex(current, a.dims)
+	}
+	return e
+}
@@ -0,0 +1,54 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarArray(t *testing.T) {
+	m := &Model{}
+	y := m.AddVariable("y", false)
+	x := m.Vars("x", 2, 3)
+	assert.Equal(t, []int{2, 3}, x.Dims())
+	assert.Equal(t, []string{"y", "x[0][0]", "x[0][1]", "x[0][2]", "x[1][0]", "x[1][1]", "x[1][2]"}, m.Names())
+	assert.Equal(t, Var(6), x.At(1, 2))
+	assert.Equal(t, Var(3), x.At(0, 2))
+	assert.Equal(t, []Term{{Var: 2, Coef: 1}, {Var: 5, Coef: 1}}, x.Sum(All, 1).Terms)
+	assert.Equal(t, []Term{{Var: 4, Coef: 1}, {Var: 5, Coef: 1}, {Var: 6, Coef: 1}}, x.Sum(1, All).Terms)
+	assert.Len(t, x.Sum(All, All).Terms, 6)
+	assert.Equal(t, []Term{{Var: 5, Coef: 1}, {Var: 6, Coef: 2}}, x.WeightedSum(func(k []int) float64 {
+		return float64(k[0] * k[1])
+	}, All, All).Terms)
+
+	assert.Equal(t, Var(-1), x.At(2, 0))
+	assert.Equal(t, Var(-1), x.At(0))
+	assert.EqualError(t, m.AddConstraint(x.Sum(All, 3), 1), "variable -1 is not in the model")
+	assert.Empty(t, m.Vars("z", 3, -1).Vars())
+	assert.Equal(t, y, Var(0))
+}
+
+func TestVarArrayAssignment(t *testing.T) {
+	// Assign each worker to a task at the minimum cost
+	cost := [][]float64{
+		{4, 1, 3},
+		{2, 0, 5},
+		{3, 2, 2},
+	}
+	m := &Model{}
+	x := m.BinaryVars("x", 3, 3)
+	m.Maximize(x.WeightedSum(func(k []int) float64 { return -cost[k[0]][k[1]] }, All, All))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, m.AddRow(x.Sum(i, All), Equal, 1))
+		require.NoError(t, m.AddRow(x.Sum(All, i), Equal, 1))
+	}
+	solution, err := m.Solve(100, silent)
+	require.NoError(t, err)
+	assert.InDelta(t, -5, solution.Score, 1e-9)
+	for i, j := range []int{1, 0, 2} {
+		assert.InDelta(t, 1, solution.Value(x.At(i, j)), 1e-9)
+	}
+	assert.True(t, m.IsBinary(x.At(2, 2)))
+	assert.False(t, m.IsBinary(m.IntegerVars("n", 1).At(0)))
+}
@@ -0,0 +1,228 @@
+package vrp
+
+import (
+	"math"
+
+	"github.com/askiada/goptimization"
+)
+
+// routesHeuristic Solution of the branch and bound given by routes found beforehand
+type routesHeuristic struct {
+	x []float64
+}
+
+// Name Name reported in the incumbents
+func (routesHeuristic) Name() string {
+	return "routes"
+}
+
+// Run Return the routes
+func (h routesHeuristic) Run(*goptimization.HeuristicContext) ([]float64, bool, error) {
+	return h.x, true, nil
+}
+
+// edges Index of the variable x_ij, i < j, of the two-index formulation
+type edges struct {
+	n     int
+	index [][]int
+}
+
+func newEdges(n int) edges {
+	e := edges{n: n, index: make([][]int, n)}
+	k := 0
+	for i := 0; i < n; i++ {
+		e.index[i] = make([]int, n)
+		for j := i + 1; j < n; j++ {
+			e.index[i][j] = k
+			k++
+		}
+	}
+	return e
+}
+
+// count Number of edges
+func (e edges) count() int {
+	return e.n * (e.n - 1) / 2
+}
+
+// at Index of the edge between i and j
+func (e edges) at(i, j int) int {
+	if i > j {
+		i, j = j, i
+	}
+	return e.index[i][j]
+}
+
+// x Values of the edges used by the routes, x_0i = 2 for a route serving only i
+func (e edges) x(routes [][]int) []float64 {
+	x := make([]float64, e.count())
+	for _, route := range routes {
+		previous := 0
+		for _, customer := range route {
+			x[e.at(previous, customer)]++
+			previous = customer
+		}
+		x[e.at(previous, 0)]++
+	}
+	return x
+}
+
+// routes Routes followed by the edges of x
+func (e edges) routes(x []float64) [][]int {
+	visited := make([]bool, e.n)
+	routes := [][]int{}
+	for start := 1; start < e.n; start++ {
+		if visited[start] || math.Round(x[e.at(0, start)]) == 0 {
+			continue
+		}
+		route := []int{}
+		for previous, customer := 0, start; customer != 0; {
+			route = append(route, customer)
+			visited[customer] = true
+			next := 0
+			for j := 1; j < e.n; j++ {
+				if j != customer && j != previous && !visited[j] && math.Round(x[e.at(customer, j)]) == 1 {
+					next = j
+					break
+				}
+			}
+			previous, customer = customer, next
+		}
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// capacityCuts Rounded capacity inequalities violated by x: each component S of the customers, linked by the edges
+// between customers, needs Σ(i in S, j not in S) x_ij >= 2*⌈Σ(i in S) d_i / Q⌉ to be served by enough vehicles.
+// It cuts the subtours, whose component is not linked to the depot, and the routes which exceed the capacity.
+func (e edges) capacityCuts(p *Problem) goptimization.LazyConstraints {
+	return func(x []float64) ([]goptimization.Cut, error) {
+		seen := make([]bool, e.n)
+		cuts := []goptimization.Cut{}
+		for start := 1; start < e.n; start++ {
+			if seen[start] {
+				continue
+			}
+			component := []int{}
+			inside := make([]bool, e.n)
+			stack := []int{start}
+			seen[start] = true
+			for len(stack) > 0 {
+				i := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				component = append(component, i)
+				inside[i] = true
+				for j := 1; j < e.n; j++ {
+					if j != i && !seen[j] && x[e.at(i, j)] > 0.5 {
+						seen[j] = true
+						stack = append(stack, j)
+					}
+				}
+			}
+
+			a := make([]float64, e.count())
+			boundary := 0.0
+			for _, i := range component {
+				for j := 0; j < e.n; j++ {
+					if !inside[j] {
+						a[e.at(i, j)] = -1
+						boundary += x[e.at(i, j)]
+					}
+				}
+			}
+			vehicles := float64(p.vehicles(component))
+			if boundary < 2*vehicles-0.5 {
+				cuts = append(cuts, goptimization.Cut{A: a, RHS: -2 * vehicles})
+			}
+		}
+		return cuts, nil
+	}
+}
+
+// Solve Shortest routes with the two-index vehicle flow formulation, x_ij, i < j, is the number of times
+// the edge between i and j is used, in {0, 1, 2} for the edges of the depot and in {0, 1} otherwise:
+// Minimize Σ d_ij*x_ij
+// Constraints:
+// 1<=i<=n, Σ(j) x_ij = 2
+// Σ(j) x_0j >= 2*⌈Σ d_i / Q⌉
+// S subset of the customers, Σ(i in S, j not in S) x_ij >= 2*⌈Σ(i in S) d_i / Q⌉
+// The capacity constraints are lazy: they are added when an integer solution has a subtour or an overloaded route.
+// The routes of Heuristic are the first incumbent, the formulation is only practical for small instances.
+// It returns the routes, with ErrIterationLimit and the best routes found when the search stopped after maxNodes nodes.
+// The options are those of goptimization.MIP.
+func Solve(p *Problem, maxNodes int, opts ...goptimization.Option) (*Solution, error) {
+	start, err := Heuristic(p)
+	if err != nil {
+		return nil, err
+	}
+	n := len(p.Demands)
+	e := newEdges(n)
+	m := &goptimization.Model{}
+	objective := goptimization.Expr{}
+	vars := make([]goptimization.Var, e.count())
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			k := e.at(i, j)
+			if i == 0 {
+				vars[k] = m.AddVariable("", true)
+				err := m.AddConstraint(vars[k].Expr(), 2)
+				if err != nil {
+					return nil, err
+				}
+			} else {
+				vars[k] = m.AddBinary("")
+			}
+			objective.Terms = append(objective.Terms, goptimization.Term{Var: vars[k], Coef: -p.Distances.At(i, j)})
+		}
+	}
+	m.Maximize(objective)
+	depot := goptimization.Expr{}
+	customers := []int{}
+	for i := 1; i < n; i++ {
+		degree := goptimization.Expr{}
+		for j := 0; j < n; j++ {
+			if j != i {
+				degree.Terms = append(degree.Terms, goptimization.Term{Var: vars[e.at(i, j)], Coef: 1})
+			}
+		}
+		err := m.AddRow(degree, goptimization.Equal, 2)
+		if err != nil {
+			return nil, err
+		}
+		depot.Terms = append(depot.Terms, goptimization.Term{Var: vars[e.at(0, i)], Coef: 1})
+		customers = append(customers, i)
+	}
+	err = m.AddRow(depot, goptimization.GreaterEq, float64(2*p.vehicles(customers)))
+	if err != nil {
+		return nil, err
+	}
+
+	c, A, b, integer := m.Standard()
+	x := make([]float64, len(integer))
+	for k, value := range e.x(start.Routes) {
+		x[vars[k]] = value
+	}
+	bb := &goptimization.BranchAndBound{}
+	err = bb.New(c, A, b, integer)
+	if err != nil {
+		return nil, err
+	}
+	bb.Configure(opts...)
+	bb.Lazy = e.capacityCuts(p)
+	bb.Heuristics = append(bb.Heuristics, routesHeuristic{x: x})
+	results, _, err := bb.Solve(maxNodes)
+	if err != nil {
+		return nil, err
+	}
+	solution := make([]float64, e.count())
+	for k, v := range vars {
+		solution[k] = results.At(int(v), 0)
+	}
+	s := &Solution{Routes: e.routes(solution)}
+	s.Length = p.Length(s.Routes)
+	if bb.Stopped != "" {
+		return s, goptimization.ErrIterationLimit
+	}
+	return s, nil
+}
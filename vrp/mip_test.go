@@ -0,0 +1,47 @@
+package vrp
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"testing"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// silent Logger of the solves
+var silent = goptimization.WithLogger(log.New(ioutil.Discard, "", 0))
+
+func TestSolve(t *testing.T) {
+	for _, seed := range []int64{0, 2, 3, 13, 14, 24} {
+		p := random(6, 5, seed)
+		s, err := Solve(p, 2000, silent)
+		require.NoError(t, err)
+		require.NoError(t, p.Check(s))
+		assert.InDelta(t, bruteForce(p), s.Length, 0.000001)
+	}
+
+	// The heuristic misses the optimum of this instance
+	p := random(6, 5, 13)
+	heuristic, err := Heuristic(p)
+	require.NoError(t, err)
+	s, err := Solve(p, 2000, silent)
+	require.NoError(t, err)
+	assert.Less(t, s.Length, heuristic.Length-0.1)
+}
+
+func TestSolveOptions(t *testing.T) {
+	//A cancelled context stops the search at the routes of Heuristic
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p := random(6, 5, 13)
+	heuristic, err := Heuristic(p)
+	require.NoError(t, err)
+	s, err := Solve(p, 2000, silent, goptimization.WithContext(ctx))
+	assert.Equal(t, goptimization.ErrIterationLimit, errors.Cause(err))
+	require.NoError(t, p.Check(s))
+	assert.InDelta(t, heuristic.Length, s.Length, 0.000001)
+}
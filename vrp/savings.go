@@ -0,0 +1,215 @@
+package vrp
+
+import (
+	"sort"
+
+	"github.com/askiada/goptimization/tsp"
+	"gonum.org/v1/gonum/mat"
+)
+
+// saving Distance saved by serving the customers i and j one after the other instead of in two routes
+type saving struct {
+	i, j  int
+	value float64
+}
+
+// Savings Routes of the parallel savings algorithm of Clarke and Wright: each customer starts in its own route,
+// then the pairs of customers are considered by decreasing saving d_0i + d_0j - d_ij and two routes are joined
+// when i and j are at an end of different routes and the joined route fits in a vehicle.
+func Savings(p *Problem) (*Solution, error) {
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+	n := len(p.Demands)
+	routes := make([][]int, n)
+	routeOf := make([]int, n)
+	savings := []saving{}
+	for i := 1; i < n; i++ {
+		routes[i] = []int{i}
+		routeOf[i] = i
+		for j := i + 1; j < n; j++ {
+			value := p.Distances.At(0, i) + p.Distances.At(0, j) - p.Distances.At(i, j)
+			if value > tolerance {
+				savings = append(savings, saving{i: i, j: j, value: value})
+			}
+		}
+	}
+	sort.SliceStable(savings, func(a, b int) bool {
+		return savings[a].value > savings[b].value
+	})
+
+	for _, s := range savings {
+		a, b := routeOf[s.i], routeOf[s.j]
+		if a == b || p.Load(routes[a])+p.Load(routes[b]) > p.Capacity+tolerance {
+			continue
+		}
+		first, second := routes[a], routes[b]
+		// The route a ends with i and the route b starts with j
+		if first[len(first)-1] != s.i {
+			if first[0] != s.i {
+				continue
+			}
+			reverse(first)
+		}
+		if second[0] != s.j {
+			if second[len(second)-1] != s.j {
+				continue
+			}
+			reverse(second)
+		}
+		routes[a] = append(first, second...)
+		for _, customer := range second {
+			routeOf[customer] = a
+		}
+		routes[b] = nil
+	}
+
+	solution := &Solution{Routes: [][]int{}}
+	for _, route := range routes {
+		if len(route) > 0 {
+			solution.Routes = append(solution.Routes, route)
+		}
+	}
+	solution.Length = p.Length(solution.Routes)
+	return solution, nil
+}
+
+// reverse Reverse the route in place, its length does not change because the distances are symmetric
+func reverse(route []int) {
+	for l, r := 0, len(route)-1; l < r; l, r = l+1, r-1 {
+		route[l], route[r] = route[r], route[l]
+	}
+}
+
+// LocalSearch Improve the routes until no move shortens them:
+// each route is improved by the 2-opt and Or-opt of tsp, a customer is relocated to another route
+// and two customers of different routes are exchanged when the loads allow it.
+// The empty routes are removed.
+func LocalSearch(p *Problem, s *Solution) (*Solution, error) {
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+	routes := make([][]int, len(s.Routes))
+	for r, route := range s.Routes {
+		routes[r] = append([]int(nil), route...)
+	}
+	for improved := true; improved; {
+		for r := range routes {
+			routes[r] = p.improveRoute(routes[r])
+		}
+		improved = p.relocate(routes) || p.exchange(routes)
+	}
+
+	solution := &Solution{Routes: [][]int{}}
+	for _, route := range routes {
+		if len(route) > 0 {
+			solution.Routes = append(solution.Routes, route)
+		}
+	}
+	solution.Length = p.Length(solution.Routes)
+	return solution, nil
+}
+
+// improveRoute Route shortened by 2-opt and Or-opt on the tour of the depot and its customers
+func (p *Problem) improveRoute(route []int) []int {
+	if len(route) < 3 {
+		return route
+	}
+	nodes := append([]int{0}, route...)
+	d := mat.NewDense(len(nodes), len(nodes), nil)
+	for a, i := range nodes {
+		for b, j := range nodes {
+			d.Set(a, b, p.Distances.At(i, j))
+		}
+	}
+	tour := tsp.OrOpt(d, tsp.TwoOpt(d, identity(len(nodes))))
+	improved := make([]int, len(route))
+	for k, node := range tour[1:] {
+		improved[k] = nodes[node]
+	}
+	if p.RouteLength(improved) < p.RouteLength(route)-tolerance {
+		return improved
+	}
+	return route
+}
+
+// identity Tour 0, 1, ..., n-1
+func identity(n int) []int {
+	tour := make([]int, n)
+	for k := range tour {
+		tour[k] = k
+	}
+	return tour
+}
+
+// insert Route with the customer inserted at the position
+func insert(route []int, position, customer int) []int {
+	inserted := append(append([]int(nil), route[:position]...), customer)
+	return append(inserted, route[position:]...)
+}
+
+// remove Route without the customer at the position
+func remove(route []int, position int) []int {
+	return append(append([]int(nil), route[:position]...), route[position+1:]...)
+}
+
+// relocate Move the first customer found whose removal and cheapest insertion in another route shortens the routes.
+// It returns false if no move shortens them.
+func (p *Problem) relocate(routes [][]int) bool {
+	for r, route := range routes {
+		for k, customer := range route {
+			without := remove(route, k)
+			removed := p.RouteLength(route) - p.RouteLength(without)
+			for t, target := range routes {
+				if t == r || p.Load(target)+p.Demands[customer] > p.Capacity+tolerance {
+					continue
+				}
+				for position := 0; position <= len(target); position++ {
+					with := insert(target, position, customer)
+					if p.RouteLength(with)-p.RouteLength(target) < removed-tolerance {
+						routes[r], routes[t] = without, with
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// exchange Swap the first pair of customers of different routes found whose exchange shortens the routes,
+// each customer takes the position of the other. It returns false if no exchange shortens them.
+func (p *Problem) exchange(routes [][]int) bool {
+	for r := range routes {
+		for t := r + 1; t < len(routes); t++ {
+			first, second := routes[r], routes[t]
+			before := p.RouteLength(first) + p.RouteLength(second)
+			for a, i := range first {
+				for b, j := range second {
+					delta := p.Demands[j] - p.Demands[i]
+					if p.Load(first)+delta > p.Capacity+tolerance || p.Load(second)-delta > p.Capacity+tolerance {
+						continue
+					}
+					newFirst, newSecond := append([]int(nil), first...), append([]int(nil), second...)
+					newFirst[a], newSecond[b] = j, i
+					if p.RouteLength(newFirst)+p.RouteLength(newSecond) < before-tolerance {
+						routes[r], routes[t] = newFirst, newSecond
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Heuristic Routes of Savings improved by LocalSearch
+func Heuristic(p *Problem) (*Solution, error) {
+	s, err := Savings(p)
+	if err != nil {
+		return nil, err
+	}
+	return LocalSearch(p, s)
+}
@@ -0,0 +1,74 @@
+package vrp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSavings(t *testing.T) {
+	// Two customers on each side of the depot, a vehicle serves one side
+	p := &Problem{
+		Distances: mat.NewDense(5, 5, []float64{
+			0, 1, 2, 1, 2,
+			1, 0, 1, 2, 3,
+			2, 1, 0, 3, 4,
+			1, 2, 3, 0, 1,
+			2, 3, 4, 1, 0,
+		}),
+		Demands:  []float64{0, 1, 1, 1, 1},
+		Capacity: 2,
+	}
+	s, err := Savings(p)
+	require.NoError(t, err)
+	require.NoError(t, p.Check(s))
+	assert.Len(t, s.Routes, 2)
+	assert.Equal(t, 8.0, s.Length)
+
+	// Joining the two sides saves nothing
+	p.Capacity = 4
+	s, err = Savings(p)
+	require.NoError(t, err)
+	require.NoError(t, p.Check(s))
+	assert.Len(t, s.Routes, 2)
+
+	p.Distances.Set(1, 3, 1)
+	p.Distances.Set(3, 1, 1)
+	s, err = Savings(p)
+	require.NoError(t, err)
+	require.NoError(t, p.Check(s))
+	assert.Equal(t, [][]int{{2, 1, 3, 4}}, s.Routes)
+}
+
+func TestLocalSearch(t *testing.T) {
+	for seed := int64(0); seed < 5; seed++ {
+		p := random(30, 10, seed)
+		s, err := Savings(p)
+		require.NoError(t, err)
+		improved, err := LocalSearch(p, s)
+		require.NoError(t, err)
+		require.NoError(t, p.Check(improved))
+		assert.LessOrEqual(t, improved.Length, s.Length+0.000001)
+	}
+
+	// One route per customer is improved by the relocations
+	p := random(6, 8, 1)
+	s := &Solution{Routes: [][]int{{1}, {2}, {3}, {4}, {5}, {6}}}
+	s.Length = p.Length(s.Routes)
+	improved, err := LocalSearch(p, s)
+	require.NoError(t, err)
+	require.NoError(t, p.Check(improved))
+	assert.Less(t, improved.Length, s.Length)
+}
+
+func TestHeuristic(t *testing.T) {
+	for seed := int64(0); seed < 5; seed++ {
+		p := random(7, 6, seed)
+		s, err := Heuristic(p)
+		require.NoError(t, err)
+		require.NoError(t, p.Check(s))
+		assert.GreaterOrEqual(t, s.Length, bruteForce(p)-0.000001)
+	}
+}
@@ -0,0 +1,132 @@
+// Package vrp Capacitated vehicle routing: vehicles of the same capacity leave the depot, serve the customers
+// and return to the depot, the total length of the routes is minimized.
+// The savings of Clarke and Wright and a local search give good routes for large instances,
+// the branch and bound of goptimization polishes them to optimality on small instances.
+package vrp
+
+import (
+	"math"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+)
+
+// tolerance Tolerance on the loads and the lengths of the routes
+const tolerance = 1e-9
+
+// Problem The node 0 is the depot and the nodes 1 to n-1 are the customers
+type Problem struct {
+	// Distances Symmetric distances between the nodes (n,n)
+	Distances *mat.Dense
+	// Demands Demand of each node, the demand of the depot is ignored
+	Demands  []float64
+	Capacity float64
+}
+
+// Solution Routes of the vehicles, each route is the order of its customers between two visits of the depot
+type Solution struct {
+	Routes [][]int
+	Length float64
+}
+
+// Validate Check the dimensions of the problem, the symmetry of the distances and that each demand fits in a vehicle
+func (p *Problem) Validate() error {
+	if p.Distances == nil {
+		return errors.Wrap(goptimization.ErrDimensionMismatch, "Distances must not be nil")
+	}
+	r, c := p.Distances.Dims()
+	if r != c || r != len(p.Demands) {
+		return errors.Wrapf(goptimization.ErrDimensionMismatch, "Distances dims must be (%d,%d), got (%d,%d)", len(p.Demands), len(p.Demands), r, c)
+	}
+	if r < 2 {
+		return errors.New("the problem needs a depot and customers")
+	}
+	for i := 0; i < r; i++ {
+		for j := i + 1; j < r; j++ {
+			if p.Distances.At(i, j) != p.Distances.At(j, i) {
+				return errors.Errorf("the distances between %d and %d are not symmetric", i, j)
+			}
+		}
+	}
+	for i, d := range p.Demands[1:] {
+		if d < 0 {
+			return errors.Errorf("customer %d has the negative demand %g", i+1, d)
+		}
+		if d > p.Capacity+tolerance {
+			return errors.Wrapf(goptimization.ErrInfeasible, "the demand %g of customer %d is larger than the capacity %g", d, i+1, p.Capacity)
+		}
+	}
+	return nil
+}
+
+// customers Number of customers
+func (p *Problem) customers() int {
+	return len(p.Demands) - 1
+}
+
+// Load Total demand of the customers of the route
+func (p *Problem) Load(route []int) float64 {
+	load := 0.0
+	for _, customer := range route {
+		load += p.Demands[customer]
+	}
+	return load
+}
+
+// RouteLength Length of the route from the depot back to the depot
+func (p *Problem) RouteLength(route []int) float64 {
+	if len(route) == 0 {
+		return 0
+	}
+	length := p.Distances.At(0, route[0]) + p.Distances.At(route[len(route)-1], 0)
+	for k := 1; k < len(route); k++ {
+		length += p.Distances.At(route[k-1], route[k])
+	}
+	return length
+}
+
+// Length Total length of the routes
+func (p *Problem) Length(routes [][]int) float64 {
+	length := 0.0
+	for _, route := range routes {
+		length += p.RouteLength(route)
+	}
+	return length
+}
+
+// Check Check that each customer is visited once, the loads of the routes and the length of the solution
+func (p *Problem) Check(s *Solution) error {
+	visited := make([]bool, len(p.Demands))
+	for r, route := range s.Routes {
+		for _, customer := range route {
+			if customer < 1 || customer >= len(p.Demands) {
+				return errors.Errorf("route %d visits the unknown customer %d", r, customer)
+			}
+			if visited[customer] {
+				return errors.Errorf("customer %d is visited twice", customer)
+			}
+			visited[customer] = true
+		}
+		if load := p.Load(route); load > p.Capacity+tolerance {
+			return errors.Errorf("route %d has the load %g, more than the capacity %g", r, load, p.Capacity)
+		}
+	}
+	for customer := 1; customer < len(p.Demands); customer++ {
+		if !visited[customer] {
+			return errors.Errorf("customer %d is not visited", customer)
+		}
+	}
+	if length := p.Length(s.Routes); math.Abs(length-s.Length) > 1e-6*math.Max(1, length) {
+		return errors.Errorf("the length is %g, not %g", length, s.Length)
+	}
+	return nil
+}
+
+// vehicles Smallest number of vehicles needed by the demand of the customers, ⌈Σ d_i / Q⌉
+func (p *Problem) vehicles(customers []int) int {
+	if p.Capacity <= 0 {
+		return 1
+	}
+	return int(math.Max(1, math.Ceil(p.Load(customers)/p.Capacity-tolerance)))
+}
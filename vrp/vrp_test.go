@@ -0,0 +1,108 @@
+package vrp
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/askiada/goptimization"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+// random Depot and customers at random points of the unit square, with demands between 1 and 4
+func random(customers int, capacity float64, seed int64) *Problem {
+	rnd := rand.New(rand.NewSource(seed))
+	n := customers + 1
+	x, y := make([]float64, n), make([]float64, n)
+	p := &Problem{Distances: mat.NewDense(n, n, nil), Demands: make([]float64, n), Capacity: capacity}
+	for i := range x {
+		x[i], y[i] = rnd.Float64(), rnd.Float64()
+		if i > 0 {
+			p.Demands[i] = float64(1 + rnd.Intn(4))
+		}
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			p.Distances.Set(i, j, math.Hypot(x[i]-x[j], y[i]-y[j]))
+		}
+	}
+	return p
+}
+
+// shortestRoute Length of the shortest route serving the customers, by enumeration of their orders
+func shortestRoute(p *Problem, customers []int) float64 {
+	best := math.Inf(1)
+	var visit func(route []int, used []bool)
+	visit = func(route []int, used []bool) {
+		if len(route) == len(customers) {
+			best = math.Min(best, p.RouteLength(route))
+			return
+		}
+		for k, customer := range customers {
+			if !used[k] {
+				used[k] = true
+				visit(append(route, customer), used)
+				used[k] = false
+			}
+		}
+	}
+	visit([]int{}, make([]bool, len(customers)))
+	return best
+}
+
+// bruteForce Length of the shortest routes by enumeration of the partitions of the customers that fit in the vehicles
+func bruteForce(p *Problem) float64 {
+	best := math.Inf(1)
+	var partition func(customer int, blocks [][]int)
+	partition = func(customer int, blocks [][]int) {
+		if customer == len(p.Demands) {
+			length := 0.0
+			for _, block := range blocks {
+				length += shortestRoute(p, block)
+			}
+			best = math.Min(best, length)
+			return
+		}
+		for k := range blocks {
+			if p.Load(blocks[k])+p.Demands[customer] <= p.Capacity {
+				blocks[k] = append(blocks[k], customer)
+				partition(customer+1, blocks)
+				blocks[k] = blocks[k][:len(blocks[k])-1]
+			}
+		}
+		partition(customer+1, append(blocks, []int{customer}))
+	}
+	partition(1, [][]int{})
+	return best
+}
+
+func TestValidate(t *testing.T) {
+	p := random(5, 6, 1)
+	assert.NoError(t, p.Validate())
+
+	p.Demands[2] = 7
+	assert.Equal(t, goptimization.ErrInfeasible, errors.Cause(p.Validate()))
+	p = random(5, 6, 1)
+	p.Distances.Set(1, 2, 10)
+	assert.Error(t, p.Validate())
+	p.Distances = mat.NewDense(3, 3, nil)
+	assert.Equal(t, goptimization.ErrDimensionMismatch, errors.Cause(p.Validate()))
+}
+
+func TestCheck(t *testing.T) {
+	p := random(4, 6, 2)
+	s := &Solution{Routes: [][]int{{1, 2}, {3, 4}}}
+	s.Length = p.Length(s.Routes)
+	p.Demands[1], p.Demands[2], p.Demands[3], p.Demands[4] = 3, 3, 2, 2
+	require.NoError(t, p.Check(s))
+
+	p.Demands[1] = 4
+	assert.Error(t, p.Check(s))
+	p.Demands[1] = 3
+	assert.Error(t, p.Check(&Solution{Routes: [][]int{{1, 2}, {3}}, Length: s.Length}))
+	assert.Error(t, p.Check(&Solution{Routes: [][]int{{1, 2}, {3, 4, 1}}, Length: s.Length}))
+	assert.Error(t, p.Check(&Solution{Routes: s.Routes, Length: s.Length + 1}))
+}
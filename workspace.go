@@ -0,0 +1,162 @@
+package goptimization
+
+import (
+	"io/ioutil"
+	"log"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/mat"
+)
+
+// workspace Buffers of the iterations of a CanonicalForm, so that an iteration factorizes B once
+// and solves without allocating. They are resized when the dimensions of the dictionary change, e.g. with AddConstraint.
+// The matrices returned by the unexported solves are views on the buffers, valid until the next call.
+type workspace struct {
+	// LU factorization of B, shared by the solves of an iteration, or of B0 with the eta file of ProductForm
+	lu  mat.LU
+	eta productForm
+	// LU factorization of B for its condition number
+	cond mat.LU
+	// Right-hand side of a solve (m,1): cB^T, the entering column or e_r
+	rhs mat.Dense
+	// Solution of a solve (m,1): y^T, d or rho
+	yT, d, rho mat.Dense
+	// Row views (1,m) of y^T and rho
+	y, rhoT mat.Dense
+	// Reduced costs (1,n) and row of the tableau (1,n)
+	reduced, row mat.Dense
+}
+
+// workspace Buffers of the dictionary, created on first use
+func (cf *CanonicalForm) workspace() *workspace {
+	if cf.work == nil {
+		cf.work = &workspace{}
+	}
+	return cf.work
+}
+
+// resize Size of dst for a result (r,c), reusing its storage
+func resize(dst *mat.Dense, r, c int) *mat.Dense {
+	if rows, cols := dst.Dims(); rows != r || cols != c {
+		dst.Reset()
+		dst.ReuseAs(r, c)
+	}
+	return dst
+}
+
+// rowView View (1,m) of the column vector v (m,1)
+func rowView(dst, v *mat.Dense) *mat.Dense {
+	raw := v.RawMatrix()
+	dst.SetRawMatrix(blas64.General{Rows: 1, Cols: raw.Rows, Stride: raw.Rows, Data: raw.Data[:raw.Rows]})
+	return dst
+}
+
+// factorize Factorize the current B in the workspace, for the solves which follow until B changes.
+// With ProductForm the factorization is kept while the eta file describes B.
+func (cf *CanonicalForm) factorize() {
+	w := cf.workspace()
+	if cf.basisUpdate == ProductForm && w.eta.valid {
+		return
+	}
+	w.lu.Factorize(cf.B)
+	w.eta.reset()
+	w.eta.valid = cf.basisUpdate == ProductForm
+}
+
+// solve Solve B*x = rhs, or B^T*x = rhs, with the factorization of the workspace and its eta file
+func (cf *CanonicalForm) solve(dst *mat.Dense, trans bool) error {
+	w := cf.workspace()
+	if trans {
+		w.eta.btran(w.rhs.RawMatrix().Data[:cf.m])
+	}
+	dst.Reset()
+	err := w.lu.SolveTo(dst, trans, &w.rhs)
+	if err != nil {
+		return newError(ErrSingularBasis, "%v", err)
+	}
+	if !trans {
+		w.eta.ftran(dst.RawMatrix().Data[:cf.m])
+	}
+	return nil
+}
+
+// findY Solve B^T*y^T = cB^T like FindY, y is a view on the workspace
+func (cf *CanonicalForm) findY() (*mat.Dense, error) {
+	cf.factorize()
+	return cf.solveY()
+}
+
+// solveY Solve B^T*y^T = cB^T with the factorization of the current B
+func (cf *CanonicalForm) solveY() (*mat.Dense, error) {
+	w := cf.workspace()
+	rhs := resize(&w.rhs, cf.m, 1)
+	for i := 0; i < cf.m; i++ {
+		rhs.Set(i, 0, cf.cB.At(0, i))
+	}
+	err := cf.solve(&w.yT, true)
+	if err != nil {
+		return nil, err
+	}
+	y := rowView(&w.y, &w.yT)
+
+	if cf.tracing() {
+		cf.logf("y:\n %v\n\n", mat.Formatted(y, mat.Prefix(" "), mat.Excerpt(8)))
+	}
+	return y, nil
+}
+
+// solveBd Solve B*d = a^k like SolveBd, d is a view on the workspace
+func (cf *CanonicalForm) solveBd(enteringVarIndex int) (*mat.Dense, error) {
+	cf.factorize()
+	return cf.solveD(enteringVarIndex)
+}
+
+// solveD Solve B*d = a^k with the factorization of the current B
+func (cf *CanonicalForm) solveD(enteringVarIndex int) (*mat.Dense, error) {
+	w := cf.workspace()
+	rhs := resize(&w.rhs, cf.m, 1)
+	for i := 0; i < cf.m; i++ {
+		rhs.Set(i, 0, cf.AN.At(i, enteringVarIndex))
+	}
+	err := cf.solve(&w.d, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if cf.tracing() {
+		cf.logf("d:\n %v\n\n", mat.Formatted(&w.d, mat.Prefix(" "), mat.Excerpt(8)))
+	}
+	return &w.d, nil
+}
+
+// solveRow Row r of B^-1*AN, see tableauRow, with the factorization of the current B
+func (cf *CanonicalForm) solveRow(r int) (*mat.Dense, error) {
+	w := cf.workspace()
+	rhs := resize(&w.rhs, cf.m, 1)
+	rhs.Zero()
+	rhs.Set(r, 0, 1)
+	err := cf.solve(&w.rho, true)
+	if err != nil {
+		return nil, err
+	}
+	row := resize(&w.row, 1, cf.n)
+	row.Mul(rowView(&w.rhoT, &w.rho), cf.AN)
+	return row, nil
+}
+
+// reducedCostsTo Compute cN - y*AN into dst with one matrix-vector product
+func (cf *CanonicalForm) reducedCostsTo(dst *mat.Dense, y *mat.Dense) *mat.Dense {
+	reduced := rowVector(resize(dst, 1, cf.n), 0)
+	blas64.Copy(rowVector(cf.cN, 0), reduced)
+	gemvT(reduced, -1, rowVector(y, 0), cf.AN, 1)
+	return dst
+}
+
+// tracing Check if the trace of the iterations is written somewhere.
+// Formatting the arguments of logf allocates even when the logger discards them, the hot paths skip it.
+func (cf *CanonicalForm) tracing() bool {
+	if l, ok := cf.logger.(*log.Logger); ok {
+		return l.Writer() != ioutil.Discard
+	}
+	return true
+}
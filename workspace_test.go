@@ -0,0 +1,51 @@
+package goptimization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestWorkspaceAllocations(t *testing.T) {
+	if invariantsByDefault {
+		t.Skip("the invariant checks of the invariants build copy the dictionary after every pivot")
+	}
+	if raceEnabled {
+		t.Skip("the race detector allocates in the instrumented code")
+	}
+	c, A, b, _ := knapsack(40, 3)
+	iter := 0
+	allocs := testing.AllocsPerRun(3, func() {
+		var err error
+		iter, _, _, err = Simplex(c, A, b, silent)
+		require.NoError(t, err)
+	})
+	require.True(t, iter > 10)
+	// The iterations only allocate in the pools of the LU factorization, the setup and the results do the rest
+	assert.True(t, allocs/float64(iter) < 10, "%v allocations for %d iterations", allocs, iter)
+}
+
+func TestWorkspaceCopies(t *testing.T) {
+	c, A, b, _ := knapsack(12, 7)
+	cf := CanonicalForm{}
+	require.NoError(t, cf.New(c, mat.DenseCopyOf(A), b))
+	cf.configure(newOptions([]Option{silent}))
+
+	y, err := cf.FindY()
+	require.NoError(t, err)
+	d, err := cf.SolveBd(0)
+	require.NoError(t, err)
+	before, beforeD := mat.DenseCopyOf(y), mat.DenseCopyOf(d)
+	for k := 0; k < 3; k++ {
+		end, err := cf.Iter(0)
+		require.NoError(t, err)
+		if end {
+			break
+		}
+	}
+	// The iterations reuse the workspace, not the matrices returned by FindY and SolveBd
+	assert.True(t, mat.Equal(before, y))
+	assert.True(t, mat.Equal(beforeD, d))
+}
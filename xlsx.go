@@ -0,0 +1,428 @@
+package goptimization
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ReadXLSX Read a linear problem from an Excel workbook into a Model, r and size are those of zip.NewReader, e.g. an
+// *os.File and the size given by its Stat. The workbook has two sheets, each with a header row, named case-insensitively:
+//
+// Variables: a row per variable with the columns
+// - name
+// - max or min, the coefficient in the objective and its sense, objective is a synonym of max
+// - type, optional: continuous (or empty), integer or binary
+// - lower and upper, optional bounds: 0 and +Inf when empty, -inf gives a free variable, see AddFreeVariable
+//
+// Constraints: a row per constraint with the columns
+// - name
+// - sense: <=, >= or = (also written L, G, E, ≤ or ≥)
+// - rhs
+// - one column per variable named like in the Variables sheet with its coefficient, empty cells are 0
+//
+// Like ReadMPS, a minimization is read as the maximization of the opposite objective, and the bounds other than x >= 0
+// become rows of the model named after the variable with ".bound".
+func ReadXLSX(r io.ReaderAt, size int64) (*Model, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, errors.Wrap(err, "xlsx")
+	}
+	sheets, err := readWorkbook(archive)
+	if err != nil {
+		return nil, err
+	}
+	variables, ok := sheets["variables"]
+	if !ok {
+		return nil, errors.New("xlsx: no Variables sheet")
+	}
+	constraints, ok := sheets["constraints"]
+	if !ok {
+		return nil, errors.New("xlsx: no Constraints sheet")
+	}
+
+	m := &Model{}
+	index := map[string]Var{}
+	bounds, err := xlsxVariables(m, variables, index)
+	if err != nil {
+		return nil, err
+	}
+	err = xlsxConstraints(m, constraints, index)
+	if err != nil {
+		return nil, err
+	}
+	// The rows of the bounds follow the constraints like in ReadMPS
+	for _, bound := range bounds {
+		err = m.addInterval(m.Name(bound.v)+".bound", bound.v.Expr(), bound.lower, bound.upper)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Problem Standard form of the model for the solvers, see Standard
+func (m *Model) Problem() *Problem {
+	c, A, b, integer := m.Standard()
+	return &Problem{C: c, A: A, B: b, Integer: integer}
+}
+
+// xlsxBound Bounds of a variable other than x >= 0, the lower bound is -Inf when there is none
+type xlsxBound struct {
+	v            Var
+	lower, upper float64
+}
+
+// xlsxVariables Add the variables of the Variables sheet and the objective to the model, index maps their names.
+// It returns the bounds other than x >= 0.
+func xlsxVariables(m *Model, rows [][]string, index map[string]Var) ([]xlsxBound, error) {
+	columns, err := xlsxHeader("Variables", rows, "name")
+	if err != nil {
+		return nil, err
+	}
+	sign := 1.0
+	cost, ok := columns["max"]
+	if !ok {
+		cost, ok = columns["objective"]
+	}
+	if !ok {
+		cost, ok = columns["min"]
+		sign = -1
+	}
+	if !ok {
+		return nil, errors.New("xlsx: Variables: no max, min or objective column")
+	}
+
+	objective := Expr{}
+	bounds := []xlsxBound{}
+	for k, row := range rows[1:] {
+		line := k + 2
+		name := xlsxCell(row, columns["name"])
+		if name == "" {
+			continue
+		}
+		if _, ok := index[name]; ok {
+			return nil, errors.Errorf("xlsx: Variables, row %d: variable %s is defined twice", line, name)
+		}
+		c, err := xlsxNumber(row, cost, 0)
+		if err != nil {
+			return nil, errors.Errorf("xlsx: Variables, row %d: %v", line, err)
+		}
+		lower, err := xlsxNumber(row, xlsxColumn(columns, "lower"), 0)
+		if err != nil {
+			return nil, errors.Errorf("xlsx: Variables, row %d: %v", line, err)
+		}
+		upper, err := xlsxNumber(row, xlsxColumn(columns, "upper"), math.Inf(1))
+		if err != nil {
+			return nil, errors.Errorf("xlsx: Variables, row %d: %v", line, err)
+		}
+		if lower > upper {
+			return nil, errors.Errorf("xlsx: Variables, row %d: variable %s has a lower bound above its upper bound", line, name)
+		}
+
+		var v Var
+		switch kind := strings.ToLower(xlsxCell(row, xlsxColumn(columns, "type"))); kind {
+		case "", "continuous", "integer":
+			if lower < 0 {
+				v = m.AddFreeVariable(name, kind == "integer")
+			} else {
+				v = m.AddVariable(name, kind == "integer")
+			}
+		case "binary":
+			// x <= 1 is the row of AddBinary
+			v = m.AddBinary(name)
+			if upper >= 1 {
+				upper = math.Inf(1)
+			}
+		default:
+			return nil, errors.Errorf("xlsx: Variables, row %d: unknown type %s", line, kind)
+		}
+		index[name] = v
+		if c != 0 {
+			objective.Terms = append(objective.Terms, Term{Var: v, Coef: sign * c})
+		}
+		// x >= 0 is implicit, a negative bound only limits a free variable
+		if lower == 0 || (lower < 0 && !m.IsFree(v)) {
+			lower = math.Inf(-1)
+		}
+		if !math.IsInf(lower, -1) || !math.IsInf(upper, 1) {
+			bounds = append(bounds, xlsxBound{v: v, lower: lower, upper: upper})
+		}
+	}
+	if len(index) == 0 {
+		return nil, errors.New("xlsx: Variables: no variable")
+	}
+	m.Maximize(objective)
+	return bounds, nil
+}
+
+// xlsxConstraints Add the constraints of the Constraints sheet to the model
+func xlsxConstraints(m *Model, rows [][]string, index map[string]Var) error {
+	columns, err := xlsxHeader("Constraints", rows, "name", "sense", "rhs")
+	if err != nil {
+		return err
+	}
+	// coefficients Column of each variable
+	coefficients := map[Var]int{}
+	for k, cell := range rows[0] {
+		name := strings.TrimSpace(cell)
+		switch strings.ToLower(name) {
+		case "", "name", "sense", "rhs":
+			continue
+		}
+		v, ok := index[name]
+		if !ok {
+			return errors.Errorf("xlsx: Constraints: column %s is not a variable", name)
+		}
+		coefficients[v] = k
+	}
+
+	for k, row := range rows[1:] {
+		line := k + 2
+		name := xlsxCell(row, columns["name"])
+		sense := strings.ToLower(xlsxCell(row, columns["sense"]))
+		if name == "" && sense == "" {
+			continue
+		}
+		e := Expr{}
+		for v := Var(0); int(v) < len(m.names); v++ {
+			column, ok := coefficients[v]
+			if !ok {
+				continue
+			}
+			a, err := xlsxNumber(row, column, 0)
+			if err != nil {
+				return errors.Errorf("xlsx: Constraints, row %d: %v", line, err)
+			}
+			if a != 0 {
+				e.Terms = append(e.Terms, Term{Var: v, Coef: a})
+			}
+		}
+		rhs, err := xlsxNumber(row, columns["rhs"], 0)
+		if err != nil {
+			return errors.Errorf("xlsx: Constraints, row %d: %v", line, err)
+		}
+		var s Sense
+		switch sense {
+		case "<=", "≤", "l", "le":
+			s = LessEq
+		case ">=", "≥", "g", "ge":
+			s = GreaterEq
+		case "=", "==", "e", "eq":
+			s = Equal
+		default:
+			return errors.Errorf("xlsx: Constraints, row %d: unknown sense %s", line, xlsxCell(row, columns["sense"]))
+		}
+		err = m.AddNamedRow(name, e, s, rhs)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// xlsxHeader Column of each lower case name of the header row, the required columns must be present
+func xlsxHeader(sheet string, rows [][]string, required ...string) (map[string]int, error) {
+	if len(rows) == 0 {
+		return nil, errors.Errorf("xlsx: %s: no header row", sheet)
+	}
+	columns := map[string]int{}
+	for k, cell := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(cell))] = k
+	}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, errors.Errorf("xlsx: %s: no %s column", sheet, name)
+		}
+	}
+	return columns, nil
+}
+
+// xlsxColumn Column of the optional header, -1 when it is missing
+func xlsxColumn(columns map[string]int, name string) int {
+	if k, ok := columns[name]; ok {
+		return k
+	}
+	return -1
+}
+
+// xlsxCell Trimmed cell of the column, empty beyond the end of the row or for a missing column
+func xlsxCell(row []string, column int) string {
+	if column < 0 || column >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[column])
+}
+
+// xlsxNumber Value of the cell, empty when the cell is empty. inf and -inf are infinite.
+func xlsxNumber(row []string, column int, empty float64) (float64, error) {
+	cell := xlsxCell(row, column)
+	if cell == "" {
+		return empty, nil
+	}
+	return strconv.ParseFloat(cell, 64)
+}
+
+// xlsxWorkbook Sheets of xl/workbook.xml
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// xlsxRelationships Targets of xl/_rels/workbook.xml.rels
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxText Rich text of a shared string or of an inline string, the runs are concatenated
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+// String Text without formatting
+func (t xlsxText) String() string {
+	s := t.T
+	for _, r := range t.Runs {
+		s += r.T
+	}
+	return s
+}
+
+// xlsxSheet Cells of a worksheet
+type xlsxSheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readWorkbook Cells of each worksheet as text, by lower case sheet name
+func readWorkbook(archive *zip.Reader) (map[string][][]string, error) {
+	files := map[string]*zip.File{}
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+	var workbook xlsxWorkbook
+	err := xlsxDecode(files, "xl/workbook.xml", &workbook)
+	if err != nil {
+		return nil, err
+	}
+	var relationships xlsxRelationships
+	err = xlsxDecode(files, "xl/_rels/workbook.xml.rels", &relationships)
+	if err != nil {
+		return nil, err
+	}
+	targets := map[string]string{}
+	for _, r := range relationships.Relationships {
+		target := r.Target
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join("xl", target)
+		}
+		targets[r.ID] = target
+	}
+	shared := []string{}
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		var table struct {
+			Items []xlsxText `xml:"si"`
+		}
+		err = xlsxDecode(files, "xl/sharedStrings.xml", &table)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range table.Items {
+			shared = append(shared, item.String())
+		}
+	}
+
+	sheets := map[string][][]string{}
+	for _, s := range workbook.Sheets {
+		var sheet xlsxSheet
+		err = xlsxDecode(files, targets[s.ID], &sheet)
+		if err != nil {
+			return nil, err
+		}
+		rows := [][]string{}
+		for _, row := range sheet.Rows {
+			cells := []string{}
+			for k, c := range row.Cells {
+				r, column := len(rows), k
+				if c.Ref != "" {
+					r, column, err = xlsxReference(c.Ref)
+					if err != nil {
+						return nil, errors.Errorf("xlsx: %s: %v", s.Name, err)
+					}
+				}
+				value := c.Value
+				switch c.Type {
+				case "s":
+					i, err := strconv.Atoi(c.Value)
+					if err != nil || i < 0 || i >= len(shared) {
+						return nil, errors.Errorf("xlsx: %s: cell %s refers to an unknown shared string", s.Name, c.Ref)
+					}
+					value = shared[i]
+				case "inlineStr":
+					value = c.Inline.String()
+				}
+				// The rows and the cells without value are not written
+				for len(rows) < r {
+					rows = append(rows, nil)
+				}
+				for len(cells) <= column {
+					cells = append(cells, "")
+				}
+				cells[column] = value
+			}
+			rows = append(rows, cells)
+		}
+		sheets[strings.ToLower(s.Name)] = rows
+	}
+	return sheets, nil
+}
+
+// xlsxDecode Decode the XML file of the archive
+func xlsxDecode(files map[string]*zip.File, name string, v interface{}) error {
+	f, ok := files[name]
+	if !ok {
+		return errors.Errorf("xlsx: no %s in the workbook", name)
+	}
+	r, err := f.Open()
+	if err != nil {
+		return errors.Wrap(err, "xlsx")
+	}
+	defer r.Close()
+	return errors.Wrapf(xml.NewDecoder(r).Decode(v), "xlsx: %s", name)
+}
+
+// xlsxReference Row and column, from 0, of a cell reference like B3
+func xlsxReference(ref string) (int, int, error) {
+	column := 0
+	k := 0
+	for ; k < len(ref) && ref[k] >= 'A' && ref[k] <= 'Z'; k++ {
+		column = 26*column + int(ref[k]-'A') + 1
+	}
+	row, err := strconv.Atoi(ref[k:])
+	if k == 0 || err != nil || row < 1 {
+		return 0, 0, errors.Errorf("invalid cell reference %s", ref)
+	}
+	return row - 1, column - 1, nil
+}
@@ -0,0 +1,148 @@
+package goptimization
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// workbook Excel workbook with the sheets in order, the text cells are shared strings except the ones starting
+// with "inline:" which are inline strings
+func workbook(t *testing.T, names []string, sheets ...[][]string) *bytes.Reader {
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	write := func(name, content string) {
+		w, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(xml.Header + content))
+		require.NoError(t, err)
+	}
+
+	sheetList, rels := "", ""
+	shared := []string{}
+	for k, name := range names {
+		sheetList += fmt.Sprintf(`<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, name, k+1, k+1)
+		rels += fmt.Sprintf(`<Relationship Id="rId%d" Type="worksheet" Target="worksheets/sheet%d.xml"/>`, k+1, k+1)
+		rows := ""
+		for r, row := range sheets[k] {
+			cells := ""
+			for c, value := range row {
+				ref := string(rune('A'+c)) + strconv.Itoa(r+1)
+				_, err := strconv.ParseFloat(value, 64)
+				switch {
+				case value == "":
+				case strings.HasPrefix(value, "inline:"):
+					cells += fmt.Sprintf(`<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`, ref, escape(strings.TrimPrefix(value, "inline:")))
+				case err == nil:
+					cells += fmt.Sprintf(`<c r="%s"><v>%s</v></c>`, ref, value)
+				default:
+					cells += fmt.Sprintf(`<c r="%s" t="s"><v>%d</v></c>`, ref, len(shared))
+					shared = append(shared, value)
+				}
+			}
+			rows += fmt.Sprintf(`<row r="%d">%s</row>`, r+1, cells)
+		}
+		write(fmt.Sprintf("xl/worksheets/sheet%d.xml", k+1), `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`+rows+`</sheetData></worksheet>`)
+	}
+	write("xl/workbook.xml", `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`+sheetList+`</sheets></workbook>`)
+	write("xl/_rels/workbook.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`+rels+`</Relationships>`)
+	items := ""
+	for k, s := range shared {
+		// Rich text runs are concatenated
+		if k == 0 {
+			items += fmt.Sprintf(`<si><r><t>%s</t></r><r><t>%s</t></r></si>`, escape(s[:1]), escape(s[1:]))
+			continue
+		}
+		items += fmt.Sprintf(`<si><t>%s</t></si>`, escape(s))
+	}
+	write("xl/sharedStrings.xml", `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`+items+`</sst>`)
+	require.NoError(t, archive.Close())
+	return bytes.NewReader(buffer.Bytes())
+}
+
+// escape Text escaped for XML
+func escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func TestReadXLSX(t *testing.T) {
+	r := workbook(t, []string{"Variables", "Constraints"},
+		[][]string{
+			{"Name", "Max", "Type", "Upper"},
+			{"x", "5", "integer", ""},
+			{"y", "4", "", ""},
+			{"inline:w", "3", "continuous", "1.5"},
+		},
+		[][]string{
+			{"name", "sense", "rhs", "x", "w", "y"},
+			{"c1", "<=", "24", "6", "", "4"},
+			{},
+			{"c2", "≤", "6", "1", "", "2"},
+		})
+	m, err := ReadXLSX(r, r.Size())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x", "y", "w"}, m.Names())
+	assert.Equal(t, []string{"c1", "c2", "w.bound"}, m.RowNames())
+	p := m.Problem()
+	assert.Equal(t, []bool{true, false, false}, p.Integer)
+	assert.Equal(t, []float64{5, 4, 3}, p.C.RawRowView(0))
+	assert.Equal(t, []float64{6, 4, 0}, p.A.RawRowView(0))
+	solution, err := m.Solve(100)
+	require.NoError(t, err)
+	assert.InDelta(t, 25.5, solution.Score, 1e-9)
+	assert.InDelta(t, 3, solution.Value(0), 1e-9)
+
+	// Minimize x + 2y with x + y >= 3, y >= 1 and a free x >= -2
+	r = workbook(t, []string{"constraints", "VARIABLES"},
+		[][]string{
+			{"name", "sense", "rhs", "x", "y"},
+			{"sum", ">=", "3", "1", "1"},
+		},
+		[][]string{
+			{"name", "min", "lower"},
+			{"x", "1", "-2"},
+			{"y", "2", "1"},
+		})
+	m, err = ReadXLSX(r, r.Size())
+	require.NoError(t, err)
+	assert.True(t, m.IsFree(0))
+	assert.Equal(t, []string{"sum", "x.bound", "y.bound"}, m.RowNames())
+	solution, err = m.Solve(100)
+	require.NoError(t, err)
+	assert.InDelta(t, -4, solution.Score, 1e-9)
+	assert.InDelta(t, 2, solution.Value(0), 1e-9)
+	assert.InDelta(t, 1, solution.Value(1), 1e-9)
+}
+
+func TestReadXLSXErrors(t *testing.T) {
+	variables := [][]string{{"name", "max"}, {"x", "1"}}
+	constraints := [][]string{{"name", "sense", "rhs", "x"}, {"c", "<=", "1", "1"}}
+	for message, sheets := range map[string][][][]string{
+		"xlsx: no Constraints sheet":                          {variables, nil},
+		"xlsx: Variables: no max, min or objective column":    {{{"name", "cost"}, {"x", "1"}}, constraints},
+		"xlsx: Variables, row 3: variable x is defined twice": {{{"name", "max"}, {"x", "1"}, {"x", "2"}}, constraints},
+		"xlsx: Variables, row 2: unknown type real":           {{{"name", "max", "type"}, {"x", "1", "real"}}, constraints},
+		"xlsx: Constraints: no rhs column":                    {variables, {{"name", "sense", "x"}}},
+		"xlsx: Constraints: column y is not a variable":       {variables, {{"name", "sense", "rhs", "y"}}},
+		"xlsx: Constraints, row 2: unknown sense <":           {variables, {{"name", "sense", "rhs", "x"}, {"c", "<", "1", "1"}}},
+	} {
+		names := []string{"Variables", "Constraints"}
+		if sheets[1] == nil {
+			names, sheets = names[:1], sheets[:1]
+		}
+		r := workbook(t, names, sheets...)
+		_, err := ReadXLSX(r, r.Size())
+		assert.EqualError(t, err, message)
+	}
+	_, err := ReadXLSX(strings.NewReader("not a zip"), 9)
+	assert.Error(t, err)
+}